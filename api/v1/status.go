@@ -16,6 +16,14 @@ type Status struct {
 	Stopped bool      `json:"stopped" yaml:"stopped"`
 	Running bool      `json:"running" yaml:"running"`
 	Tainted bool      `json:"tainted" yaml:"tainted"`
+
+	Scenarios map[string]ScenarioStatus `json:"scenarios,omitempty" yaml:"scenarios,omitempty"`
+}
+
+// ScenarioStatus represents the status of the thresholds of a single scenario.
+type ScenarioStatus struct {
+	Stopped bool `json:"stopped" yaml:"stopped"`
+	Tainted bool `json:"tainted" yaml:"tainted"`
 }
 
 func newStatus(cs *ControlSurface) Status {
@@ -26,6 +34,18 @@ func newStatus(cs *ControlSurface) Status {
 		isStopped = true
 	default:
 	}
+	var scenarios map[string]ScenarioStatus
+	if outcomes := cs.MetricsEngine.GetScenarioOutcomes(); len(outcomes) > 0 {
+		scenarios = make(map[string]ScenarioStatus, len(outcomes))
+		for name, outcome := range outcomes {
+			status := ScenarioStatus{Stopped: outcome.Stopped}
+			for _, ok := range outcome.Thresholds {
+				status.Tainted = status.Tainted || !ok
+			}
+			scenarios[name] = status
+		}
+	}
+
 	return Status{
 		Status:  executionState.GetCurrentExecutionStatus(),
 		Running: executionState.HasStarted() && !executionState.HasEnded(),
@@ -34,5 +54,7 @@ func newStatus(cs *ControlSurface) Status {
 		VUs:     null.IntFrom(executionState.GetCurrentlyActiveVUsCount()),
 		VUsMax:  null.IntFrom(executionState.GetInitializedVUsCount()),
 		Tainted: cs.MetricsEngine.GetMetricsWithBreachedThresholdsCount() > 0,
+
+		Scenarios: scenarios,
 	}
 }
@@ -440,6 +440,24 @@ func getConfigConsolidationTestCases() []configConsolidationTestCase {
 				}, c.Options.DNS)
 			},
 		},
+		{opts{env: []string{"K6_DNS=servers=1.1.1.1;tls://dns.google;https://dns.google/dns-query"}}, exp{}, func(t *testing.T, c Config) {
+			assert.Equal(t, []types.DNSServer{
+				{Transport: types.DNSTransportUDP, Address: "1.1.1.1:53"},
+				{Transport: types.DNSTransportTLS, Address: "dns.google:853"},
+				{Transport: types.DNSTransportHTTPS, Address: "https://dns.google/dns-query"},
+			}, c.Options.DNS.Servers)
+		}},
+		{opts{env: []string{"K6_DNS=servers=ftp://1.1.1.1"}}, exp{consolidationError: true}, nil},
+		{
+			opts{fs: defaultConfig(`{"dns": {"servers": ["tcp://[::1]"], "overrides": {"*.internal": ["10.0.0.53:5353"]}}}`)},
+			exp{},
+			func(t *testing.T, c Config) {
+				assert.Equal(t, []types.DNSServer{{Transport: types.DNSTransportTCP, Address: "[::1]:53"}}, c.Options.DNS.Servers)
+				assert.Equal(t, map[string][]types.DNSServer{
+					"*.internal": {{Transport: types.DNSTransportUDP, Address: "10.0.0.53:5353"}},
+				}, c.Options.DNS.Overrides)
+			},
+		},
 		{
 			opts{
 				fs:  defaultConfig(`{"dns": {"ttl": "0"}}`),
@@ -470,6 +488,19 @@ func getConfigConsolidationTestCases() []configConsolidationTestCase {
 				}, c.Options.DNS)
 			},
 		},
+		{
+			opts{
+				fs:  defaultConfig(`{"hostLimits": {"*.internal": {"rps": 5}}}`),
+				env: []string{`K6_HOST_LIMITS={"api.k6.io": {"rps": 10, "maxConcurrent": 2}}`},
+			},
+			exp{},
+			func(t *testing.T, c Config) {
+				assert.Equal(t, map[string]types.HostLimit{
+					"api.k6.io": {RPS: null.IntFrom(10), MaxConcurrent: null.IntFrom(2)},
+				}, c.Options.HostLimits.Limits)
+			},
+		},
+		{opts{env: []string{`K6_HOST_LIMITS={"api.k6.io": {"rps": 0}}`}}, exp{consolidationError: true}, nil},
 		{
 			opts{env: []string{"K6_NO_SETUP=true", "K6_NO_TEARDOWN=false"}},
 			exp{},
@@ -48,6 +48,9 @@ func optionFlagSet() *pflag.FlagSet {
 	flags.StringSlice("blacklist-ip", nil, "blacklist an `ip range` from being called")
 	flags.StringSlice("block-hostnames", nil, "block a case-insensitive hostname `pattern`,"+
 		" with optional leading wildcard, from being called")
+	flags.String("proxy", "", "send the requests through the proxy with the given `url`, e.g. http://host:3128 or socks5://host:1080")
+	flags.StringSlice("no-proxy", nil, "connect directly to the hosts matching a case-insensitive hostname `pattern`,"+
+		" with optional leading wildcard, instead of through the proxy")
 
 	// The comment about system-tags also applies for summary-trend-stats. The default values
 	// are set in applyDefault().
@@ -177,6 +180,21 @@ func getOptions(flags *pflag.FlagSet) (lib.Options, error) {
 		}
 	}
 
+	proxyURL, err := flags.GetString("proxy")
+	if err != nil {
+		return opts, err
+	}
+	noProxy, err := flags.GetStringSlice("no-proxy")
+	if err != nil {
+		return opts, err
+	}
+	if flags.Changed("proxy") || flags.Changed("no-proxy") {
+		opts.Proxy, err = types.NewNullProxy(proxyURL, noProxy)
+		if err != nil {
+			return opts, err
+		}
+	}
+
 	localIpsString, err := flags.GetString("local-ips")
 	if err != nil {
 		return opts, err
@@ -104,6 +104,9 @@ func (c *cmdRun) run(cmd *cobra.Command, args []string) (err error) {
 	if err != nil {
 		return err
 	}
+	if err = test.openTLSKeyLog(logger); err != nil {
+		return err
+	}
 	if test.keyLogger != nil {
 		defer func() {
 			if klErr := test.keyLogger.Close(); klErr != nil {
@@ -201,6 +204,7 @@ func (c *cmdRun) run(cmd *cobra.Command, args []string) (err error) {
 					IsStdOutTTY: c.gs.Stdout.IsTTY,
 					IsStdErrTTY: c.gs.Stderr.IsTTY,
 				},
+				Scenarios: metricsEngine.GetScenarioOutcomes(),
 			})
 			if hsErr == nil {
 				hsErr = handleSummaryResult(c.gs.FS, c.gs.Stdout, c.gs.Stderr, summaryResult)
@@ -239,8 +243,13 @@ func (c *cmdRun) run(cmd *cobra.Command, args []string) (err error) {
 	}()
 
 	if !testRunState.RuntimeOptions.NoThresholds.Bool {
+		stopScenario := func(name string, reason error) {
+			if sErr := executionState.StopScenario(name, reason); sErr != nil {
+				logger.WithError(sErr).Error("Could not stop the scenario")
+			}
+		}
 		finalizeThresholds := metricsEngine.StartThresholdCalculations(
-			metricsIngester, runAbort, executionState.GetCurrentTestRunDuration,
+			metricsIngester, runAbort, stopScenario, executionState.GetCurrentTestRunDuration,
 		)
 		handleFinalThresholdCalculation := func() {
 			// This gets called after the Samples channel has been closed and
@@ -439,6 +448,9 @@ func (c *cmdRun) flagSet() *pflag.FlagSet {
 	flags.SortFlags = false
 	flags.AddFlagSet(optionFlagSet())
 	flags.AddFlagSet(runtimeOptionFlagSet(true))
+	flags.String("tls-key-log", "",
+		"log the TLS connection keys to this file, in the NSS key log format, so captured traffic can be decrypted")
+	flags.String("fs-output-dir", "", "local directory where k6/experimental/fs can write files")
 	flags.AddFlagSet(configFlagSet())
 	return flags
 }
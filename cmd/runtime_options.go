@@ -107,6 +107,22 @@ func getRuntimeOptions(flags *pflag.FlagSet, environment map[string]string) (lib
 		}
 	}
 
+	// the --tls-key-log flag is only defined by the run command
+	if flags.Lookup("tls-key-log") != nil {
+		opts.TLSKeyLog = getNullString(flags, "tls-key-log")
+	}
+	if envVar, ok := environment["K6_TLS_KEY_LOG"]; ok && !opts.TLSKeyLog.Valid {
+		opts.TLSKeyLog = null.StringFrom(envVar)
+	}
+
+	// the --fs-output-dir flag is only defined by the run command too
+	if flags.Lookup("fs-output-dir") != nil {
+		opts.FSOutputDir = getNullString(flags, "fs-output-dir")
+	}
+	if envVar, ok := environment["K6_FS_OUTPUT_DIR"]; ok && !opts.FSOutputDir.Valid {
+		opts.FSOutputDir = null.StringFrom(envVar)
+	}
+
 	if envVar, ok := environment["K6_TRACES_OUTPUT"]; ok {
 		if !opts.TracesOutput.Valid {
 			opts.TracesOutput = null.StringFrom(envVar)
@@ -111,19 +111,9 @@ func (lt *loadedTest) initializeFirstRunner(gs *state.GlobalState) error {
 	if lt.preInitState.RuntimeOptions.KeyWriter.Valid {
 		logger.Warnf("SSLKEYLOGFILE was specified, logging TLS connection keys to '%s'...",
 			lt.preInitState.RuntimeOptions.KeyWriter.String)
-		keylogFilename := lt.preInitState.RuntimeOptions.KeyWriter.String
-		// if path is absolute - no point doing anything
-		if !filepath.IsAbs(keylogFilename) {
-			// filepath.Abs could be used but it will get the pwd from `os` package instead of what is in lt.pwd
-			// this is against our general approach of not using `os` directly and makes testing harder
-			keylogFilename = filepath.Join(lt.pwd, keylogFilename)
+		if err := lt.openKeyLog(lt.preInitState.RuntimeOptions.KeyWriter.String); err != nil {
+			return err
 		}
-		f, err := lt.fs.OpenFile(keylogFilename, syscall.O_WRONLY|syscall.O_CREAT|syscall.O_APPEND, 0o600)
-		if err != nil {
-			return fmt.Errorf("couldn't get absolute path for keylog file: %w", err)
-		}
-		lt.keyLogger = f
-		lt.preInitState.KeyLogger = &syncWriter{w: f}
 	}
 	switch testType {
 	case testTypeJS:
@@ -185,6 +175,36 @@ func detectTestType(data []byte) string {
 	return testTypeJS
 }
 
+// openKeyLog opens the file where the TLS connection keys are logged, in the
+// NSS key log format, and sets it as the key logger of the test.
+func (lt *loadedTest) openKeyLog(keylogFilename string) error {
+	// if path is absolute - no point doing anything
+	if !filepath.IsAbs(keylogFilename) {
+		// filepath.Abs could be used but it will get the pwd from `os` package instead of what is in lt.pwd
+		// this is against our general approach of not using `os` directly and makes testing harder
+		keylogFilename = filepath.Join(lt.pwd, keylogFilename)
+	}
+	f, err := lt.fs.OpenFile(keylogFilename, syscall.O_WRONLY|syscall.O_CREAT|syscall.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("couldn't get absolute path for keylog file: %w", err)
+	}
+	lt.keyLogger = f
+	lt.preInitState.KeyLogger = &syncWriter{w: f}
+	return nil
+}
+
+// openTLSKeyLog opens the file set with --tls-key-log or K6_TLS_KEY_LOG. It's
+// only called by the run command, so the other ones never create the file.
+// SSLKEYLOGFILE takes precedence, since its key logger is already open.
+func (lt *loadedTest) openTLSKeyLog(logger logrus.FieldLogger) error {
+	keyLog := lt.preInitState.RuntimeOptions.TLSKeyLog
+	if !keyLog.Valid || keyLog.String == "" || lt.keyLogger != nil {
+		return nil
+	}
+	logger.Warnf("--tls-key-log was specified, logging TLS connection keys to '%s'...", keyLog.String)
+	return lt.openKeyLog(keyLog.String)
+}
+
 func (lt *loadedTest) consolidateDeriveAndValidateConfig(
 	gs *state.GlobalState, cmd *cobra.Command,
 	cliConfGetter func(flags *pflag.FlagSet) (Config, error), // TODO: obviate
@@ -221,6 +241,18 @@ func (lt *loadedTest) consolidateDeriveAndValidateConfig(
 				return nil, errext.WithExitCodeIfNone(err, exitcodes.InvalidConfig)
 			}
 		}
+		for scenarioName, scenarioConfig := range consolidatedConfig.Options.Scenarios {
+			for metricName, thresholdsDefinition := range lib.GetScenarioThresholds(scenarioConfig) {
+				err = thresholdsDefinition.Parse()
+				if err == nil {
+					err = thresholdsDefinition.Validate(metricName, lt.preInitState.Registry)
+				}
+				if err != nil {
+					err = fmt.Errorf("invalid thresholds in scenario '%s': %w", scenarioName, err)
+					return nil, errext.WithExitCodeIfNone(err, exitcodes.InvalidConfig)
+				}
+			}
+		}
 	}
 
 	derivedConfig, err := deriveAndValidateConfig(consolidatedConfig, lt.initRunner.IsExecutable, gs.Logger)
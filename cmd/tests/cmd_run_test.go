@@ -385,6 +385,98 @@ func testSSLKEYLOGFILE(t *testing.T, ts *GlobalTestState, filePath string) {
 	assert.Regexp(t, "^CLIENT_[A-Z_]+ [0-9a-f]+ [0-9a-f]+\n", string(sslloglines))
 }
 
+func TestTLSKeyLogFlag(t *testing.T) {
+	t.Parallel()
+	ts := NewGlobalTestState(t)
+
+	tb := httpmultibin.NewHTTPMultiBin(t)
+	ts.CmdArgs = []string{"k6", "run", "--tls-key-log", "./keys.log", "-"}
+	ts.Stdin = bytes.NewReader([]byte(tb.Replacer.Replace(`
+    import http from "k6/http"
+    export const options = {
+      hosts: {
+        "HTTPSBIN_DOMAIN": "HTTPSBIN_IP",
+      },
+      insecureSkipTLSVerify: true,
+    }
+
+    export default () => {
+      http.get("HTTPSBIN_URL/get");
+    }
+  `)))
+
+	cmd.ExecuteWithGlobalState(ts.GlobalState)
+
+	assert.True(t,
+		testutils.LogContains(ts.LoggerHook.Drain(), logrus.WarnLevel, "--tls-key-log was specified"))
+	keyLogLines, err := fsext.ReadFile(ts.FS, filepath.Join(ts.Cwd, "keys.log"))
+	require.NoError(t, err)
+	assert.Regexp(t, "^CLIENT_[A-Z_]+ [0-9a-f]+ [0-9a-f]+\n", string(keyLogLines))
+}
+
+func TestTLSKeyLogNotFromScriptOrInspect(t *testing.T) {
+	t.Parallel()
+	ts := NewGlobalTestState(t)
+	ts.Env["K6_TLS_KEY_LOG"] = "./keys.log"
+	ts.CmdArgs = []string{"k6", "inspect", "--execution-requirements", "-"}
+	ts.Stdin = bytes.NewReader([]byte(`
+    export const options = { tlsKeyLog: "./script-keys.log" }
+    export default () => {}
+  `))
+
+	cmd.ExecuteWithGlobalState(ts.GlobalState)
+
+	for _, name := range []string{"keys.log", "script-keys.log"} {
+		exists, err := fsext.Exists(ts.FS, filepath.Join(ts.Cwd, name))
+		require.NoError(t, err)
+		assert.False(t, exists, name)
+	}
+	assert.True(t,
+		testutils.LogContains(ts.LoggerHook.Drain(), logrus.WarnLevel, "There were unknown fields"))
+}
+
+func TestFSOutputDirIsNotArchived(t *testing.T) {
+	t.Parallel()
+	outputDir := t.TempDir()
+
+	ts := NewGlobalTestState(t)
+	require.NoError(t, fsext.WriteFile(ts.FS, filepath.Join(ts.Cwd, "script.js"), []byte(`
+		import { write } from "k6/experimental/fs";
+		export default async function () {
+			try {
+				await write("out.txt", "data");
+			} catch (e) {
+				console.log("write failed: " + e.name);
+			}
+		}
+	`), 0o644))
+	ts.Env["K6_FS_OUTPUT_DIR"] = outputDir
+	ts.CmdArgs = []string{"k6", "archive", "-O", filepath.Join(ts.Cwd, "archive.tar"), "script.js"}
+	cmd.ExecuteWithGlobalState(ts.GlobalState)
+	archive, err := fsext.ReadFile(ts.FS, filepath.Join(ts.Cwd, "archive.tar"))
+	require.NoError(t, err)
+
+	runArchive := func(args ...string) *GlobalTestState {
+		ts := NewGlobalTestState(t)
+		require.NoError(t, fsext.WriteFile(ts.FS, filepath.Join(ts.Cwd, "archive.tar"), archive, 0o644))
+		ts.CmdArgs = append(append([]string{"k6", "run"}, args...), "archive.tar")
+		cmd.ExecuteWithGlobalState(ts.GlobalState)
+		return ts
+	}
+
+	// the output directory is a runtime option of the machine running the
+	// test, so it needs to be set again when an archive is run
+	ts = runArchive()
+	assert.True(t, testutils.LogContains(ts.LoggerHook.Drain(), logrus.InfoLevel, "write failed: ForbiddenError"))
+	assert.NoFileExists(t, filepath.Join(outputDir, "out.txt"))
+
+	ts = runArchive("--fs-output-dir", outputDir)
+	assert.False(t, testutils.LogContains(ts.LoggerHook.Drain(), logrus.InfoLevel, "write failed"))
+	data, err := os.ReadFile(filepath.Join(outputDir, "out.txt")) //nolint:forbidigo // the output directory is on the OS fs
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+}
+
 func TestThresholdDeprecationWarnings(t *testing.T) {
 	t.Parallel()
 
@@ -441,7 +533,7 @@ func TestExecutionTestOptionsDefaultValues(t *testing.T) {
 	loglines := ts.LoggerHook.Drain()
 	require.Len(t, loglines, 1)
 
-	expected := `{"paused":null,"executionSegment":null,"executionSegmentSequence":null,"noSetup":null,"setupTimeout":null,"noTeardown":null,"teardownTimeout":null,"rps":null,"dns":{"ttl":null,"select":null,"policy":null},"maxRedirects":null,"userAgent":null,"batch":null,"batchPerHost":null,"httpDebug":null,"insecureSkipTLSVerify":null,"tlsCipherSuites":null,"tlsVersion":null,"tlsAuth":null,"throw":null,"thresholds":null,"blacklistIPs":null,"blockHostnames":null,"hosts":null,"noConnectionReuse":null,"noVUConnectionReuse":null,"minIterationDuration":null,"ext":null,"summaryTrendStats":["avg", "min", "med", "max", "p(90)", "p(95)"],"summaryTimeUnit":null,"systemTags":["check","error","error_code","expected_response","group","method","name","proto","scenario","service","status","subproto","tls_version","url"],"tags":null,"metricSamplesBufferSize":null,"noCookiesReset":null,"discardResponseBodies":null,"consoleOutput":null,"scenarios":{"default":{"vus":null,"iterations":1,"executor":"shared-iterations","maxDuration":null,"startTime":null,"env":null,"tags":null,"gracefulStop":null,"exec":null}},"localIPs":null}`
+	expected := `{"paused":null,"executionSegment":null,"executionSegmentSequence":null,"noSetup":null,"setupTimeout":null,"noTeardown":null,"teardownTimeout":null,"rps":null,"hostLimits":null,"dns":{"ttl":null,"select":null,"policy":null,"servers":null,"overrides":null},"maxRedirects":null,"userAgent":null,"batch":null,"batchPerHost":null,"httpDebug":null,"insecureSkipTLSVerify":null,"tlsCipherSuites":null,"tlsVersion":null,"tlsAuth":null,"tlsSessionResumption":null,"tlsFullHandshakePerIteration":null,"throw":null,"thresholds":null,"blacklistIPs":null,"blockHostnames":null,"hosts":null,"proxy":null,"noConnectionReuse":null,"noVUConnectionReuse":null,"minIterationDuration":null,"ext":null,"summaryTrendStats":["avg", "min", "med", "max", "p(90)", "p(95)"],"summaryTimeUnit":null,"systemTags":["cause","check","error","error_code","expected_response","group","host","method","name","proto","scenario","service","status","subproto","tls_version","url"],"tags":null,"metricSamplesBufferSize":null,"noCookiesReset":null,"discardResponseBodies":null,"consoleOutput":null,"scenarios":{"default":{"vus":null,"iterations":1,"executor":"shared-iterations","maxDuration":null,"startTime":null,"env":null,"tags":null,"gracefulStop":null,"exec":null,"maxIterationDuration":null,"recycleVUOnTimeout":null}},"localIPs":null}`
 	assert.JSONEq(t, expected, loglines[0].Message)
 }
 
@@ -2,6 +2,9 @@ package execution
 
 // Controller implementations are used to control the k6 execution of a test or
 // test suite, either locally or in a distributed environment.
+//
+// Distributed controllers can also implement lib.SharedStore, so the mutable
+// shared state of scripts (e.g. SharedMap in k6/data) spans all instances.
 type Controller interface {
 	// GetOrCreateData requests the data chunk with the given ID, if it already
 	// exists. If it doesn't (i.e. this was the first time this function was
@@ -46,6 +46,9 @@ func NewScheduler(trs *lib.TestRunState, controller Controller) (*Scheduler, err
 	maxPossibleVUs := lib.GetMaxPossibleVUs(executionPlan)
 
 	executionState := lib.NewExecutionState(trs, et, maxPlannedVUs, maxPossibleVUs)
+	if store, ok := controller.(lib.SharedStore); ok {
+		executionState.SharedStore = store
+	}
 	maxDuration, _ := lib.GetEndOffset(executionPlan) // we don't care if the end offset is final
 
 	executorConfigs := options.Scenarios.GetSortedConfigs()
@@ -356,6 +359,12 @@ func (e *Scheduler) runExecutor(
 		case <-runCtx.Done():
 			runResults <- nil // no error since executor hasn't started yet
 			return
+		case <-e.state.ScenarioStopNotify(executorConfig.GetName()):
+			executorLogger.Debugf("Executor was stopped before its start time")
+			executorProgress.Modify(pb.WithStatus(pb.Interrupted), pb.WithConstProgress(0, "stopped"))
+			e.state.MarkScenarioEnded(executorConfig.GetName())
+			runResults <- nil
+			return
 		case <-time.After(executorStartTime):
 			// continue
 		}
@@ -366,7 +375,9 @@ func (e *Scheduler) runExecutor(
 		pb.WithConstProgress(0, "started"),
 	)
 	executorLogger.Debugf("Starting executor")
+	e.state.MarkScenarioStarted(executorConfig.GetName())
 	err := executor.Run(runCtx, engineOut) // executor should handle context cancel itself
+	e.state.MarkScenarioEnded(executorConfig.GetName())
 	if err == nil {
 		executorLogger.Debugf("Executor finished successfully")
 	} else {
@@ -9,6 +9,7 @@ require (
 	github.com/PuerkitoBio/goquery v1.9.2
 	github.com/Soontao/goHttpDigestClient v0.0.0-20170320082612-6d28bb1415c5
 	github.com/andybalholm/brotli v1.1.1
+	github.com/antchfx/xpath v1.3.2
 	github.com/evanw/esbuild v0.21.2
 	github.com/fatih/color v1.17.0
 	github.com/go-sourcemap/sourcemap v2.1.4+incompatible
@@ -33,6 +34,7 @@ require (
 	github.com/mstoykov/envconfig v1.5.0
 	github.com/mstoykov/k6-taskqueue-lib v0.1.0
 	github.com/nu7hatch/gouuid v0.0.0-20131221200532-179d4d0c4d8d
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
 	github.com/serenize/snaker v0.0.0-20201027110005-a7ad2135616e
 	github.com/sirupsen/logrus v1.9.3
 	github.com/spf13/afero v1.1.2
@@ -9,6 +9,8 @@ import (
 	"net/url"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
 
 	"github.com/grafana/sobek"
 	"github.com/sirupsen/logrus"
@@ -21,9 +23,11 @@ import (
 	"go.k6.io/k6/js/compiler"
 	"go.k6.io/k6/js/eventloop"
 	"go.k6.io/k6/js/modules"
+	"go.k6.io/k6/js/modules/k6/http"
 	"go.k6.io/k6/lib"
 	"go.k6.io/k6/lib/consts"
 	"go.k6.io/k6/lib/fsext"
+	"go.k6.io/k6/lib/types"
 	"go.k6.io/k6/loader"
 )
 
@@ -179,10 +183,31 @@ func (b *Bundle) makeArchive() *lib.Archive {
 	for k, v := range b.preInitState.RuntimeOptions.Env {
 		arc.Env[k] = v
 	}
+	b.warnAboutProxyCredentials()
 
 	return arc
 }
 
+// warnAboutProxyCredentials warns that the proxy credentials aren't included
+// in archives, since they are left out when the options are marshaled.
+func (b *Bundle) warnAboutProxyCredentials() {
+	var scenarios []string
+	for name, sc := range b.Options.Scenarios {
+		if pc, ok := sc.(interface{ GetProxy() *types.Proxy }); ok && pc.GetProxy().HasCredentials() {
+			scenarios = append(scenarios, name)
+		}
+	}
+	if b.Options.Proxy.Valid && b.Options.Proxy.Proxy.HasCredentials() {
+		b.preInitState.Logger.Warn("The credentials of the proxy aren't included in the archive, " +
+			"they need to be provided again with the K6_PROXY environment variable or the --proxy flag")
+	}
+	if len(scenarios) > 0 {
+		sort.Strings(scenarios)
+		b.preInitState.Logger.Warnf("The credentials of the proxies of the scenarios %s aren't included "+
+			"in the archive, so their requests will be sent without them", strings.Join(scenarios, ", "))
+	}
+}
+
 // populateExports validates and extracts exported objects
 func (b *Bundle) populateExports(updateOptions bool, bi *BundleInstance) error {
 	var err error
@@ -432,6 +457,12 @@ func (b *Bundle) setInitGlobals(rt *sobek.Runtime, vu *moduleVUImpl, modSys *mod
 
 	mustSet("require", impl.require)
 
+	// The Fetch API is only available as globals, there's no module to import it from.
+	fetch := rt.ToValue(http.NewFetch().NewModuleInstance(vu).Exports().Default).ToObject(rt)
+	for _, key := range fetch.Keys() {
+		mustSet(key, fetch.Get(key))
+	}
+
 	mustSet("open", func(filename string, args ...string) (sobek.Value, error) {
 		// TODO fix in stack traces
 		if vu.state != nil {
@@ -49,6 +49,8 @@ var methodNameExceptions = map[string]string{
 	"HTML": "html",
 	"URL":  "url",
 	"OCSP": "ocsp",
+	// xpath() of the k6/html selections, since names starting with an X are constructors
+	"EvaluateXPath": "xpath",
 }
 
 // MethodName Returns the JS name for an exported method. The first letter of the method's name is
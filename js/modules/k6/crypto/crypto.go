@@ -69,6 +69,9 @@ func (c *Crypto) Exports() modules.Exports {
 			"sha512_224":  c.sha512_224,
 			"sha512_256":  c.sha512_256,
 			"hexEncode":   c.hexEncode,
+			"sign":        c.sign,
+			"verify":      c.verify,
+			"jwt":         c.jwt(),
 		},
 	}
 }
@@ -236,26 +239,51 @@ func (hasher *Hasher) Update(input interface{}) error {
 
 // Digest returns the hash value in the given encoding.
 func (hasher *Hasher) Digest(outputEncoding string) (interface{}, error) {
-	sum := hasher.hash.Sum(nil)
+	return encodeOutput(hasher.runtime, hasher.hash.Sum(nil), outputEncoding)
+}
 
+// encodeOutput returns the data in the given encoding, as a string or an
+// ArrayBuffer for the binary one.
+func encodeOutput(rt *sobek.Runtime, data []byte, outputEncoding string) (interface{}, error) {
 	switch outputEncoding {
 	case "base64":
-		return base64.StdEncoding.EncodeToString(sum), nil
+		return base64.StdEncoding.EncodeToString(data), nil
 
 	case "base64url":
-		return base64.URLEncoding.EncodeToString(sum), nil
+		return base64.URLEncoding.EncodeToString(data), nil
 
 	case "base64rawurl":
-		return base64.URLEncoding.WithPadding(base64.NoPadding).EncodeToString(sum), nil
+		return base64.URLEncoding.WithPadding(base64.NoPadding).EncodeToString(data), nil
 
 	case "hex":
-		return hex.EncodeToString(sum), nil
+		return hex.EncodeToString(data), nil
 
 	case "binary":
-		ab := hasher.runtime.NewArrayBuffer(sum)
+		ab := rt.NewArrayBuffer(data)
 		return &ab, nil
 
 	default:
 		return nil, fmt.Errorf("invalid output encoding: %s", outputEncoding)
 	}
 }
+
+// decodeInput returns the data of a string in the given encoding, which is
+// one of the output encodings except binary.
+func decodeInput(data string, inputEncoding string) ([]byte, error) {
+	switch inputEncoding {
+	case "base64":
+		return base64.StdEncoding.DecodeString(data)
+
+	case "base64url":
+		return base64.URLEncoding.DecodeString(data)
+
+	case "base64rawurl":
+		return base64.RawURLEncoding.DecodeString(data)
+
+	case "hex":
+		return hex.DecodeString(data)
+
+	default:
+		return nil, fmt.Errorf("invalid input encoding: %s", inputEncoding)
+	}
+}
@@ -0,0 +1,320 @@
+package crypto
+
+import (
+	"bytes"
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/grafana/sobek"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/js/modules/k6/crypto/x509"
+	"go.k6.io/k6/lib/types"
+)
+
+// jwtAlgorithm is a JSON Web Signature algorithm.
+type jwtAlgorithm struct {
+	hash gocrypto.Hash
+	hmac bool
+	pss  bool
+	// curveBits is the size of the curve of the ECDSA algorithms.
+	curveBits int
+	ed        bool
+}
+
+// jwtAlgorithms are the supported JWS algorithms, by name.
+var jwtAlgorithms = map[string]jwtAlgorithm{ //nolint:gochecknoglobals
+	"HS256": {hash: gocrypto.SHA256, hmac: true},
+	"HS384": {hash: gocrypto.SHA384, hmac: true},
+	"HS512": {hash: gocrypto.SHA512, hmac: true},
+	"RS256": {hash: gocrypto.SHA256},
+	"RS384": {hash: gocrypto.SHA384},
+	"RS512": {hash: gocrypto.SHA512},
+	"PS256": {hash: gocrypto.SHA256, pss: true},
+	"PS384": {hash: gocrypto.SHA384, pss: true},
+	"PS512": {hash: gocrypto.SHA512, pss: true},
+	"ES256": {hash: gocrypto.SHA256, curveBits: 256},
+	"ES384": {hash: gocrypto.SHA384, curveBits: 384},
+	"ES512": {hash: gocrypto.SHA512, curveBits: 521},
+	"EdDSA": {ed: true},
+}
+
+var jwtEncoding = base64.RawURLEncoding //nolint:gochecknoglobals
+
+func (c *Crypto) jwt() map[string]interface{} {
+	return map[string]interface{}{
+		"sign":   c.jwtSign,
+		"verify": c.jwtVerify,
+	}
+}
+
+// jwtSign returns a JWT of the payload signed with the key, which is the
+// secret of the HS* algorithms or a PEM-encoded private key. The options are
+// the algorithm, HS256 by default, extra header fields, and expiresIn, which
+// sets the exp claim.
+func (c *Crypto) jwtSign(payload sobek.Value, key interface{}, options sobek.Value) (string, error) {
+	rt := c.vu.Runtime()
+	if common.IsNullish(payload) {
+		return "", errors.New("a payload is required")
+	}
+	claims, ok := payload.Export().(map[string]interface{})
+	if !ok {
+		return "", errors.New("the payload must be an object")
+	}
+	header := map[string]interface{}{"typ": "JWT"}
+	alg := "HS256"
+
+	if !common.IsNullish(options) {
+		obj := options.ToObject(rt)
+		for _, k := range obj.Keys() {
+			v := obj.Get(k)
+			switch k {
+			case "algorithm":
+				alg = v.String()
+			case "header":
+				extra, ok := v.Export().(map[string]interface{})
+				if !ok {
+					return "", errors.New("the header option must be an object")
+				}
+				for name, value := range extra {
+					header[name] = value
+				}
+			case "expiresIn":
+				d, err := types.GetDurationValue(v.Export())
+				if err != nil {
+					return "", fmt.Errorf("invalid expiresIn value: %w", err)
+				}
+				claims["exp"] = time.Now().Add(d).Unix()
+			default:
+				return "", fmt.Errorf("unknown option: %q", k)
+			}
+		}
+	}
+	algorithm, ok := jwtAlgorithms[alg]
+	if !ok {
+		return "", fmt.Errorf("unsupported algorithm: %s", alg)
+	}
+	header["alg"] = alg
+
+	h, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	p, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := jwtEncoding.EncodeToString(h) + "." + jwtEncoding.EncodeToString(p)
+
+	kb, err := common.ToBytes(key)
+	if err != nil {
+		return "", err
+	}
+	signature, err := algorithm.sign(kb, []byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + jwtEncoding.EncodeToString(signature), nil
+}
+
+// jwtVerify verifies the signature of the JWT with the key, which is the
+// secret of the HS* algorithms or a PEM-encoded public key or certificate,
+// and its exp and nbf claims. It returns the payload. The options are the
+// allowed algorithms, all of them for the kind of the key by default, the
+// clockTolerance for the claims, and ignoreExpiration.
+func (c *Crypto) jwtVerify(token string, key interface{}, options sobek.Value) (map[string]interface{}, error) {
+	var (
+		allowed          []string
+		clockTolerance   time.Duration
+		ignoreExpiration bool
+	)
+	if !common.IsNullish(options) {
+		obj := options.ToObject(c.vu.Runtime())
+		for _, k := range obj.Keys() {
+			v := obj.Get(k)
+			switch k {
+			case "algorithms":
+				if err := c.vu.Runtime().ExportTo(v, &allowed); err != nil {
+					return nil, fmt.Errorf("invalid algorithms value: %w", err)
+				}
+			case "clockTolerance":
+				var err error
+				if clockTolerance, err = types.GetDurationValue(v.Export()); err != nil {
+					return nil, fmt.Errorf("invalid clockTolerance value: %w", err)
+				}
+			case "ignoreExpiration":
+				ignoreExpiration = v.ToBoolean()
+			default:
+				return nil, fmt.Errorf("unknown option: %q", k)
+			}
+		}
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("invalid token: it must have three parts")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("invalid token header: %w", err)
+	}
+	algorithm, ok := jwtAlgorithms[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported algorithm: %q", header.Alg)
+	}
+	if allowed != nil && !contains(allowed, header.Alg) {
+		return nil, fmt.Errorf("the %s algorithm is not allowed", header.Alg)
+	}
+
+	signature, err := jwtEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid token signature: %w", err)
+	}
+	kb, err := common.ToBytes(key)
+	if err != nil {
+		return nil, err
+	}
+	valid, err := algorithm.verify(kb, []byte(parts[0]+"."+parts[1]), signature)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, errors.New("invalid token signature")
+	}
+
+	var claims map[string]interface{}
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("invalid token payload: %w", err)
+	}
+	now := time.Now()
+	if exp, ok := claims["exp"].(float64); ok && !ignoreExpiration &&
+		now.After(time.Unix(int64(exp), 0).Add(clockTolerance)) {
+		return nil, errors.New("the token is expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(clockTolerance).Before(time.Unix(int64(nbf), 0)) {
+		return nil, errors.New("the token is not valid yet")
+	}
+	return claims, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	b, err := jwtEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (a jwtAlgorithm) options() signatureOptions {
+	return signatureOptions{hash: a.hash, pss: a.pss, saltLength: rsa.PSSSaltLengthEqualsHash}
+}
+
+func (a jwtAlgorithm) sign(key, signingInput []byte) ([]byte, error) {
+	if a.hmac {
+		mac := hmac.New(a.hash.New, key)
+		_, _ = mac.Write(signingInput)
+		return mac.Sum(nil), nil
+	}
+
+	signer, err := x509.ParsePrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.checkKey(signer.Public()); err != nil {
+		return nil, err
+	}
+	if a.curveBits == 0 {
+		return signData(signer, signingInput, a.options())
+	}
+
+	// the ECDSA signatures of JWS are the fixed-size r and s values
+	hashed, err := digest(signingInput, a.options(), false)
+	if err != nil {
+		return nil, err
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, signer.(*ecdsa.PrivateKey), hashed) //nolint:forcetypeassert
+	if err != nil {
+		return nil, err
+	}
+	size := (a.curveBits + 7) / 8
+	signature := make([]byte, 2*size)
+	r.FillBytes(signature[:size])
+	s.FillBytes(signature[size:])
+	return signature, nil
+}
+
+func (a jwtAlgorithm) verify(key, signingInput, signature []byte) (bool, error) {
+	if a.hmac {
+		if bytes.HasPrefix(bytes.TrimSpace(key), []byte("-----BEGIN")) {
+			return false, errors.New("the HS* algorithms can't be verified with a PEM key")
+		}
+		expected, err := a.sign(key, signingInput)
+		if err != nil {
+			return false, err
+		}
+		return hmac.Equal(expected, signature), nil
+	}
+
+	publicKey, err := x509.ParsePublicKey(key)
+	if err != nil {
+		return false, err
+	}
+	if err := a.checkKey(publicKey); err != nil {
+		return false, err
+	}
+	if a.curveBits == 0 {
+		return verifyData(publicKey, signingInput, signature, a.options())
+	}
+
+	size := (a.curveBits + 7) / 8
+	if len(signature) != 2*size {
+		return false, nil
+	}
+	hashed, err := digest(signingInput, a.options(), false)
+	if err != nil {
+		return false, err
+	}
+	r := new(big.Int).SetBytes(signature[:size])
+	s := new(big.Int).SetBytes(signature[size:])
+	return ecdsa.Verify(publicKey.(*ecdsa.PublicKey), hashed, r, s), nil //nolint:forcetypeassert
+}
+
+// checkKey checks that the key is of the kind of the algorithm, so a token
+// can't choose how its signature is verified.
+func (a jwtAlgorithm) checkKey(publicKey gocrypto.PublicKey) error {
+	var ok bool
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		ok = !a.ed && a.curveBits == 0
+	case *ecdsa.PublicKey:
+		ok = a.curveBits == key.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		ok = a.ed
+	}
+	if !ok {
+		return errors.New("the key doesn't match the algorithm of the token")
+	}
+	return nil
+}
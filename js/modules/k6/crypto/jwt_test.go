@@ -0,0 +1,117 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWT(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeyPairs(t)
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	keys["ecdsa384"] = newTestKeyPair(t, p384)
+
+	t.Run("HS256 vector", func(t *testing.T) {
+		t.Parallel()
+		rt := makeRuntime(t)
+
+		v, err := rt.RunString(`
+		var token = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.` +
+			`eyJzdWIiOiIxMjM0NTY3ODkwIiwibmFtZSI6IkpvaG4gRG9lIiwiaWF0IjoxNTE2MjM5MDIyfQ.` +
+			`SflKxwRJSMeKKF2QT4fwpMeJf36POk6yJV_adQssw5c";
+		crypto.jwt.verify(token, "your-256-bit-secret").name`)
+		require.NoError(t, err)
+		assert.Equal(t, "John Doe", v.String())
+
+		_, err = rt.RunString(`crypto.jwt.verify(token, "another secret")`)
+		assert.ErrorContains(t, err, "invalid token signature")
+		_, err = rt.RunString(`crypto.jwt.verify(token, "your-256-bit-secret", { algorithms: ["RS256"] })`)
+		assert.ErrorContains(t, err, "the HS256 algorithm is not allowed")
+	})
+
+	testCases := []struct {
+		algorithm, key string
+	}{
+		{algorithm: "HS384"},
+		{algorithm: "RS256", key: "rsa"},
+		{algorithm: "PS512", key: "rsa"},
+		{algorithm: "ES256", key: "ecdsa"},
+		{algorithm: "ES384", key: "ecdsa384"},
+		{algorithm: "EdDSA", key: "ed"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.algorithm, func(t *testing.T) {
+			t.Parallel()
+			rt := makeRuntime(t)
+			privateKey, publicKey := "a secret", "a secret"
+			if tc.key != "" {
+				privateKey, publicKey = keys[tc.key].private, keys[tc.key].public
+			}
+			require.NoError(t, rt.Set("privateKey", privateKey))
+			require.NoError(t, rt.Set("publicKey", publicKey))
+			require.NoError(t, rt.Set("algorithm", tc.algorithm))
+
+			v, err := rt.RunString(`
+			var token = crypto.jwt.sign({ sub: "k6", n: 1 }, privateKey, {
+				algorithm: algorithm,
+				header: { kid: "key-1" },
+				expiresIn: "1m",
+			});
+			var payload = crypto.jwt.verify(token, publicKey, { algorithms: [algorithm] });
+			payload.sub + " " + payload.n + " " + (payload.exp > Date.now() / 1000)`)
+			require.NoError(t, err)
+			token := rt.Get("token").String()
+			header, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[0])
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"alg":"`+tc.algorithm+`","kid":"key-1","typ":"JWT"}`, string(header))
+			assert.Equal(t, "k6 1 true", v.String())
+		})
+	}
+
+	t.Run("Claims", func(t *testing.T) {
+		t.Parallel()
+		rt := makeRuntime(t)
+
+		_, err := rt.RunString(`
+		var expired = crypto.jwt.sign({ exp: Date.now() / 1000 - 10 }, "secret");
+		var notYet = crypto.jwt.sign({ nbf: Date.now() / 1000 + 60 }, "secret");`)
+		require.NoError(t, err)
+
+		_, err = rt.RunString(`crypto.jwt.verify(expired, "secret")`)
+		assert.ErrorContains(t, err, "the token is expired")
+		_, err = rt.RunString(`crypto.jwt.verify(expired, "secret", { clockTolerance: "1m" })`)
+		assert.NoError(t, err)
+		_, err = rt.RunString(`crypto.jwt.verify(expired, "secret", { ignoreExpiration: true })`)
+		assert.NoError(t, err)
+		_, err = rt.RunString(`crypto.jwt.verify(notYet, "secret")`)
+		assert.ErrorContains(t, err, "the token is not valid yet")
+	})
+
+	t.Run("Key confusion", func(t *testing.T) {
+		t.Parallel()
+		rt := makeRuntime(t)
+		require.NoError(t, rt.Set("rsaPublicKey", keys["rsa"].public))
+		require.NoError(t, rt.Set("ecPrivateKey", keys["ecdsa"].private))
+		require.NoError(t, rt.Set("ecPublicKey", keys["ecdsa"].public))
+
+		_, err := rt.RunString(`crypto.jwt.verify(crypto.jwt.sign({}, rsaPublicKey), rsaPublicKey)`)
+		assert.ErrorContains(t, err, "the HS* algorithms can't be verified with a PEM key")
+		_, err = rt.RunString(`crypto.jwt.sign({}, ecPrivateKey, { algorithm: "ES384" })`)
+		assert.ErrorContains(t, err, "the key doesn't match the algorithm of the token")
+		_, err = rt.RunString(`
+		crypto.jwt.verify(crypto.jwt.sign({}, ecPrivateKey, { algorithm: "ES256" }), rsaPublicKey)`)
+		assert.ErrorContains(t, err, "the key doesn't match the algorithm of the token")
+		_, err = rt.RunString(`crypto.jwt.verify("eyJhbGciOiJub25lIn0.e30.", ecPublicKey)`)
+		assert.ErrorContains(t, err, `unsupported algorithm: "none"`)
+	})
+}
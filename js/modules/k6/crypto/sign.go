@@ -0,0 +1,203 @@
+package crypto
+
+import (
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"github.com/grafana/sobek"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/js/modules/k6/crypto/x509"
+)
+
+// signatureHashes are the hash algorithms of the signatures, by the names of
+// the hash functions of the module.
+var signatureHashes = map[string]gocrypto.Hash{ //nolint:gochecknoglobals
+	"md5":        gocrypto.MD5,
+	"sha1":       gocrypto.SHA1,
+	"sha256":     gocrypto.SHA256,
+	"sha384":     gocrypto.SHA384,
+	"sha512":     gocrypto.SHA512,
+	"sha512_224": gocrypto.SHA512_224,
+	"sha512_256": gocrypto.SHA512_256,
+}
+
+// signatureOptions are the options of sign and verify.
+type signatureOptions struct {
+	hash gocrypto.Hash
+	// pss is whether RSA keys use the PSS padding instead of the PKCS #1 v1.5 one.
+	pss        bool
+	saltLength int
+	// encoding is the one of the signatures given to verify as strings.
+	encoding string
+}
+
+func (c *Crypto) parseSignatureOptions(algorithm string, options sobek.Value) (signatureOptions, error) {
+	opts := signatureOptions{saltLength: rsa.PSSSaltLengthEqualsHash, encoding: "hex"}
+	if algorithm != "" {
+		var ok bool
+		if opts.hash, ok = signatureHashes[algorithm]; !ok {
+			return opts, fmt.Errorf("invalid algorithm: %s", algorithm)
+		}
+	}
+	if common.IsNullish(options) {
+		return opts, nil
+	}
+
+	obj := options.ToObject(c.vu.Runtime())
+	for _, k := range obj.Keys() {
+		v := obj.Get(k)
+		switch k {
+		case "padding":
+			switch padding := v.String(); padding {
+			case "pkcs1":
+				opts.pss = false
+			case "pss":
+				opts.pss = true
+			default:
+				return opts, fmt.Errorf("invalid padding: %s, it needs to be pkcs1 or pss", padding)
+			}
+		case "saltLength":
+			opts.saltLength = int(v.ToInteger())
+		case "encoding":
+			opts.encoding = v.String()
+		default:
+			return opts, fmt.Errorf("unknown option: %q", k)
+		}
+	}
+	return opts, nil
+}
+
+// sign returns the signature of the data with the PEM-encoded private key in
+// the given encoding. The data is hashed with the algorithm, which must be
+// null for Ed25519 keys. RSA keys use the PKCS #1 v1.5 padding, unless the
+// pss padding is set in the options, and ECDSA signatures are ASN.1 encoded.
+func (c *Crypto) sign(
+	algorithm string, key, data interface{}, outputEncoding string, options sobek.Value,
+) (interface{}, error) {
+	opts, err := c.parseSignatureOptions(algorithm, options)
+	if err != nil {
+		return nil, err
+	}
+	kb, err := common.ToBytes(key)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := x509.ParsePrivateKey(kb)
+	if err != nil {
+		return nil, err
+	}
+	d, err := common.ToBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := signData(signer, d, opts)
+	if err != nil {
+		return nil, err
+	}
+	return encodeOutput(c.vu.Runtime(), signature, outputEncoding)
+}
+
+// verify returns whether the signature of the data is valid for the
+// PEM-encoded public key or certificate, with the same algorithm and options
+// as the signing. Signatures given as strings are decoded with the encoding
+// option, which is hex by default.
+func (c *Crypto) verify(
+	algorithm string, key, data, signature interface{}, options sobek.Value,
+) (bool, error) {
+	opts, err := c.parseSignatureOptions(algorithm, options)
+	if err != nil {
+		return false, err
+	}
+	kb, err := common.ToBytes(key)
+	if err != nil {
+		return false, err
+	}
+	publicKey, err := x509.ParsePublicKey(kb)
+	if err != nil {
+		return false, err
+	}
+	d, err := common.ToBytes(data)
+	if err != nil {
+		return false, err
+	}
+
+	var sig []byte
+	if s, ok := signature.(string); ok {
+		sig, err = decodeInput(s, opts.encoding)
+	} else {
+		sig, err = common.ToBytes(signature)
+	}
+	if err != nil {
+		return false, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	return verifyData(publicKey, d, sig, opts)
+}
+
+// digest returns the hash of the data to sign, or the data itself for
+// Ed25519, which hashes it while signing.
+func digest(data []byte, opts signatureOptions, ed bool) ([]byte, error) {
+	if ed {
+		if opts.hash != 0 {
+			return nil, errors.New("the algorithm must be null for Ed25519 keys")
+		}
+		return data, nil
+	}
+	if opts.hash == 0 {
+		return nil, errors.New("an algorithm is required")
+	}
+	if !opts.hash.Available() {
+		return nil, fmt.Errorf("the %s algorithm is not available", opts.hash)
+	}
+	h := opts.hash.New()
+	_, _ = h.Write(data)
+	return h.Sum(nil), nil
+}
+
+func signData(signer gocrypto.Signer, data []byte, opts signatureOptions) ([]byte, error) {
+	_, ed := signer.(ed25519.PrivateKey)
+	hashed, err := digest(data, opts, ed)
+	if err != nil {
+		return nil, err
+	}
+
+	var signerOpts gocrypto.SignerOpts = opts.hash
+	if _, ok := signer.(*rsa.PrivateKey); ok && opts.pss {
+		signerOpts = &rsa.PSSOptions{SaltLength: opts.saltLength, Hash: opts.hash}
+	}
+	return signer.Sign(rand.Reader, hashed, signerOpts)
+}
+
+func verifyData(publicKey gocrypto.PublicKey, data, signature []byte, opts signatureOptions) (bool, error) {
+	_, ed := publicKey.(ed25519.PublicKey)
+	hashed, err := digest(data, opts, ed)
+	if err != nil {
+		return false, err
+	}
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if opts.pss {
+			saltLength := opts.saltLength
+			if saltLength == rsa.PSSSaltLengthEqualsHash {
+				saltLength = rsa.PSSSaltLengthAuto
+			}
+			return rsa.VerifyPSS(key, opts.hash, hashed, signature,
+				&rsa.PSSOptions{SaltLength: saltLength, Hash: opts.hash}) == nil, nil
+		}
+		return rsa.VerifyPKCS1v15(key, opts.hash, hashed, signature) == nil, nil
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, hashed, signature), nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, hashed, signature), nil
+	default:
+		return false, errors.New("unsupported public key algorithm")
+	}
+}
@@ -0,0 +1,126 @@
+package crypto
+
+import (
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKeyPair is a PEM-encoded key pair.
+type testKeyPair struct {
+	private, public string
+}
+
+func newTestKeyPair(t *testing.T, key gocrypto.Signer) testKeyPair {
+	t.Helper()
+	priv, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	pub, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+	return testKeyPair{
+		private: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: priv})),
+		public:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub})),
+	}
+}
+
+func newTestKeyPairs(t *testing.T) map[string]testKeyPair {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return map[string]testKeyPair{
+		"rsa":   newTestKeyPair(t, rsaKey),
+		"ecdsa": newTestKeyPair(t, ecKey),
+		"ed":    newTestKeyPair(t, edKey),
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeyPairs(t)
+	testCases := []struct {
+		name, key, algorithm, options string
+	}{
+		{name: "RSA PKCS1", key: "rsa", algorithm: `"sha256"`, options: `{}`},
+		{name: "RSA PSS", key: "rsa", algorithm: `"sha512"`, options: `{ padding: "pss" }`},
+		{name: "RSA PSS salt", key: "rsa", algorithm: `"sha256"`, options: `{ padding: "pss", saltLength: 8 }`},
+		{name: "ECDSA", key: "ecdsa", algorithm: `"sha256"`, options: `{}`},
+		{name: "Ed25519", key: "ed", algorithm: `null`, options: `{}`},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rt := makeRuntime(t)
+			require.NoError(t, rt.Set("privateKey", keys[tc.key].private))
+			require.NoError(t, rt.Set("publicKey", keys[tc.key].public))
+
+			_, err := rt.RunString(`
+			var options = ` + tc.options + `;
+			var sig = crypto.sign(` + tc.algorithm + `, privateKey, "some data", "base64", options);
+			options.encoding = "base64";
+			if (!crypto.verify(` + tc.algorithm + `, publicKey, "some data", sig, options)) {
+				throw new Error("the signature is invalid");
+			}
+			if (crypto.verify(` + tc.algorithm + `, publicKey, "other data", sig, options)) {
+				throw new Error("the signature of other data is valid");
+			}
+			var bin = crypto.sign(` + tc.algorithm + `, privateKey, "some data", "binary", options);
+			if (!crypto.verify(` + tc.algorithm + `, publicKey, "some data", bin, options)) {
+				throw new Error("the binary signature is invalid");
+			}`)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("Go PKCS1 verification", func(t *testing.T) {
+		t.Parallel()
+		rt := makeRuntime(t)
+		require.NoError(t, rt.Set("privateKey", keys["rsa"].private))
+
+		v, err := rt.RunString(`crypto.sign("sha256", privateKey, "some data", "hex")`)
+		require.NoError(t, err)
+		sig, err := hex.DecodeString(v.String())
+		require.NoError(t, err)
+
+		block, _ := pem.Decode([]byte(keys["rsa"].public))
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		require.NoError(t, err)
+		hashed := sha256.Sum256([]byte("some data"))
+		assert.NoError(t, rsa.VerifyPKCS1v15(pub.(*rsa.PublicKey), gocrypto.SHA256, hashed[:], sig)) //nolint:forcetypeassert
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		rt := makeRuntime(t)
+		require.NoError(t, rt.Set("rsaKey", keys["rsa"].private))
+		require.NoError(t, rt.Set("edKey", keys["ed"].private))
+
+		_, err := rt.RunString(`crypto.sign("sha256", "not a key", "data", "hex")`)
+		assert.ErrorContains(t, err, "failed to decode private key PEM file")
+		_, err = rt.RunString(`crypto.sign("whirlpool", rsaKey, "data", "hex")`)
+		assert.ErrorContains(t, err, "invalid algorithm: whirlpool")
+		_, err = rt.RunString(`crypto.sign(null, rsaKey, "data", "hex")`)
+		assert.ErrorContains(t, err, "an algorithm is required")
+		_, err = rt.RunString(`crypto.sign("sha256", edKey, "data", "hex")`)
+		assert.ErrorContains(t, err, "the algorithm must be null for Ed25519 keys")
+		_, err = rt.RunString(`crypto.sign("sha256", rsaKey, "data", "hex", { padding: "oaep" })`)
+		assert.ErrorContains(t, err, "invalid padding: oaep")
+	})
+}
@@ -0,0 +1,75 @@
+package x509
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// ParsePublicKey parses a PEM-encoded public key, in the PKIX or the PKCS #1
+// format, or the public key of a PEM-encoded certificate.
+func ParsePublicKey(encoded []byte) (crypto.PublicKey, error) {
+	decoded, _ := pem.Decode(encoded)
+	if decoded == nil {
+		return nil, errors.New("failed to decode public key PEM file")
+	}
+	switch decoded.Type {
+	case "CERTIFICATE":
+		parsed, err := x509.ParseCertificate(decoded.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		return parsed.PublicKey, nil
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(decoded.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		return key, nil
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(decoded.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key PEM block type %q", decoded.Type)
+	}
+}
+
+// ParsePrivateKey parses a PEM-encoded private key, in the PKCS #8, PKCS #1
+// or SEC 1 format. Encrypted keys aren't supported.
+func ParsePrivateKey(encoded []byte) (crypto.Signer, error) {
+	decoded, _ := pem.Decode(encoded)
+	if decoded == nil {
+		return nil, errors.New("failed to decode private key PEM file")
+	}
+
+	var (
+		key interface{}
+		err error
+	)
+	switch decoded.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(decoded.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(decoded.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(decoded.Bytes)
+	case "ENCRYPTED PRIVATE KEY":
+		return nil, errors.New("encrypted private keys are not supported")
+	default:
+		return nil, fmt.Errorf("unsupported private key PEM block type %q", decoded.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key algorithm")
+	}
+	return signer, nil
+}
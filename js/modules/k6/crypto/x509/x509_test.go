@@ -2,7 +2,12 @@ package x509
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	gox509 "crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"testing"
 
@@ -826,3 +831,44 @@ func TestMakeCertificateUnsupportedKey(t *testing.T) {
 	_, err := makeCertificate(&gox509.Certificate{})
 	assert.EqualError(t, err, "unsupported public key algorithm")
 }
+
+func TestParseKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("PublicKey", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := ParsePublicKey([]byte(material.publicKey))
+		require.NoError(t, err)
+		assert.IsType(t, &rsa.PublicKey{}, key)
+
+		key, err = ParsePublicKey([]byte(material.ecdsaCertificate))
+		require.NoError(t, err)
+		assert.IsType(t, &ecdsa.PublicKey{}, key)
+
+		_, err = ParsePublicKey([]byte("not a key"))
+		assert.EqualError(t, err, "failed to decode public key PEM file")
+	})
+
+	t.Run("PrivateKey", func(t *testing.T) {
+		t.Parallel()
+
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		encoded := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: gox509.MarshalPKCS1PrivateKey(rsaKey)})
+		key, err := ParsePrivateKey(encoded)
+		require.NoError(t, err)
+		assert.True(t, rsaKey.Equal(key))
+
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := gox509.MarshalECPrivateKey(ecKey)
+		require.NoError(t, err)
+		key, err = ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+		require.NoError(t, err)
+		assert.True(t, ecKey.Equal(key))
+
+		_, err = ParsePrivateKey([]byte(material.rsaCertificate))
+		assert.EqualError(t, err, `unsupported private key PEM block type "CERTIFICATE"`)
+	})
+}
@@ -20,12 +20,14 @@ type (
 	// instances for each VU.
 	RootModule struct {
 		shared sharedArrays
+		store  *localStore
 	}
 
 	// Data represents an instance of the data module.
 	Data struct {
 		vu     modules.VU
 		shared *sharedArrays
+		store  *localStore
 	}
 
 	sharedArrays struct {
@@ -45,6 +47,7 @@ func New() *RootModule {
 		shared: sharedArrays{
 			data: make(map[string]sharedArray),
 		},
+		store: newLocalStore(),
 	}
 }
 
@@ -54,6 +57,7 @@ func (rm *RootModule) NewModuleInstance(vu modules.VU) modules.Instance {
 	return &Data{
 		vu:     vu,
 		shared: &rm.shared,
+		store:  rm.store,
 	}
 }
 
@@ -61,7 +65,9 @@ func (rm *RootModule) NewModuleInstance(vu modules.VU) modules.Instance {
 func (d *Data) Exports() modules.Exports {
 	return modules.Exports{
 		Named: map[string]interface{}{
-			"SharedArray": d.sharedArray,
+			"SharedArray":   d.sharedArray,
+			"SharedMap":     d.sharedMap,
+			"AtomicCounter": d.atomicCounter,
 		},
 	}
 }
@@ -93,7 +99,7 @@ func (d *Data) sharedArray(call sobek.ConstructorCall) *sobek.Object {
 	}
 
 	array := d.shared.get(rt, name, fn)
-	return array.wrap(rt).ToObject(rt)
+	return array.wrap(d, rt)
 }
 
 // RecordReader is the interface that wraps the action of reading records from a resource.
@@ -104,6 +110,15 @@ type RecordReader interface {
 	Read() ([]string, error)
 }
 
+// ValueReader is the interface that wraps the action of reading records of any type from a resource.
+//
+// It is the counterpart of [RecordReader] for the records that aren't plain lists of strings, e.g.
+// the typed CSV rows. The records are stored in the shared array as JSON, so they can be of any
+// type that can be marshaled to it.
+type ValueReader interface {
+	Read() (any, error)
+}
+
 // NewSharedArrayFrom creates a new shared array from the provided data.
 //
 // This function is not exposed to the JS runtime. It is used internally to instantiate
@@ -113,9 +128,39 @@ type RecordReader interface {
 // This function takes an explicit runtime argument to retain control over which VU runtime it is
 // executed in. This is important because the shared array underlying implementation relies on maintaining
 // a single instance of arrays for the whole test setup and VUs.
+//
+// As it operates on the runtime, it must be called from the VU's event loop. Callers that want to
+// read the records asynchronously, or records of other types, should use [Data.CreateSharedArrayFrom]
+// and [Data.WrapSharedArray] instead.
 func (d *Data) NewSharedArrayFrom(rt *sobek.Runtime, name string, r RecordReader) *sobek.Object {
+	if err := d.CreateSharedArrayFrom(name, recordValueReader{r}); err != nil {
+		common.Throw(rt, err)
+	}
+
+	array, err := d.WrapSharedArray(rt, name)
+	if err != nil {
+		common.Throw(rt, err)
+	}
+
+	return array
+}
+
+// recordValueReader adapts a [RecordReader] to the [ValueReader] interface.
+type recordValueReader struct {
+	r RecordReader
+}
+
+func (r recordValueReader) Read() (any, error) {
+	return r.r.Read()
+}
+
+// CreateSharedArrayFrom reads all the records from the provided reader, and stores them
+// in a new shared array with the given name.
+//
+// It doesn't touch any JS runtime, so it is safe to call it from any goroutine.
+func (d *Data) CreateSharedArrayFrom(name string, r ValueReader) error {
 	if name == "" {
-		common.Throw(rt, errors.New("empty name provided to SharedArray's constructor"))
+		return errors.New("empty name provided to SharedArray's constructor")
 	}
 
 	var arr []string
@@ -125,25 +170,42 @@ func (d *Data) NewSharedArrayFrom(rt *sobek.Runtime, name string, r RecordReader
 			break
 		}
 		if err != nil {
-			common.Throw(rt, fmt.Errorf("failed to read record; reason: %w", err))
+			return fmt.Errorf("failed to read record; reason: %w", err)
 		}
 
 		marshaled, err := json.Marshal(record)
 		if err != nil {
-			common.Throw(rt, fmt.Errorf("failed to marshal record; reason: %w", err))
+			return fmt.Errorf("failed to marshal record; reason: %w", err)
 		}
 
 		arr = append(arr, string(marshaled))
 	}
 
-	return d.shared.set(name, arr).wrap(rt).ToObject(rt)
+	d.shared.set(name, arr)
+
+	return nil
+}
+
+// WrapSharedArray returns the JS object exposing the shared array with the given name,
+// previously created with [Data.CreateSharedArrayFrom].
+//
+// It must be called from the event loop of the VU owning the provided runtime.
+func (d *Data) WrapSharedArray(rt *sobek.Runtime, name string) (*sobek.Object, error) {
+	d.shared.mu.RLock()
+	array, ok := d.shared.data[name]
+	d.shared.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no shared array named %q", name)
+	}
+
+	return array.wrap(d, rt), nil
 }
 
 // set is a helper method to set a shared array in the underlying shared arrays map.
 func (s *sharedArrays) set(name string, arr []string) sharedArray {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	array := sharedArray{arr: arr}
+	array := newSharedArray(arr)
 	s.data[name] = array
 
 	return array
@@ -187,5 +249,5 @@ func getShareArrayFromCall(rt *sobek.Runtime, call sobek.Callable) sharedArray {
 		arr[i] = val.String()
 	}
 
-	return sharedArray{arr: arr}
+	return newSharedArray(arr)
 }
@@ -0,0 +1,65 @@
+package data
+
+import (
+	"bytes"
+	"sync"
+
+	"go.k6.io/k6/lib"
+)
+
+// localStore is the in-memory lib.SharedStore that's used when the test run
+// doesn't provide one, i.e. when all of the VUs are in the same instance.
+type localStore struct {
+	mu       sync.Mutex
+	values   map[string][]byte
+	counters map[string]int64
+}
+
+var _ lib.SharedStore = &localStore{}
+
+func newLocalStore() *localStore {
+	return &localStore{
+		values:   make(map[string][]byte),
+		counters: make(map[string]int64),
+	}
+}
+
+func (s *localStore) GetValue(key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, found := s.values[key]
+	return value, found, nil
+}
+
+func (s *localStore) SetValue(key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+	return nil
+}
+
+func (s *localStore) CompareAndSwapValue(key string, oldValue, newValue []byte) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, found := s.values[key]
+	if found != (oldValue != nil) || !bytes.Equal(current, oldValue) {
+		return false, nil
+	}
+	s.values[key] = newValue
+	return true, nil
+}
+
+func (s *localStore) DeleteValue(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, found := s.values[key]
+	delete(s.values, key)
+	return found, nil
+}
+
+func (s *localStore) AddToCounter(key string, delta int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counters[key] += delta
+	return s.counters[key], nil
+}
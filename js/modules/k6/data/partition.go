@@ -0,0 +1,157 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/grafana/sobek"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/lib"
+)
+
+// The supported partitioning modes, i.e. what gets a unique record:
+const (
+	// every VU gets its own record, based on its globally unique ID
+	partitionPerVU = "per-vu"
+	// every scenario iteration gets its own record, based on its globally
+	// unique number in the scenario
+	partitionPerIteration = "per-iteration"
+	// every call to next() gets its own record, from the share of the
+	// records that belongs to the current instance's execution segment
+	partitionPerInstance = "per-instance"
+)
+
+// The supported behaviors when all of the records have been handed out:
+const (
+	// start again from the first record
+	exhaustedWrap = "wrap"
+	// stop the current scenario and return undefined
+	exhaustedStop = "stop"
+)
+
+// partitionCounters contains the state shared by all partitions of the same
+// SharedArray across all VUs of the instance.
+type partitionCounters struct {
+	mu       sync.Mutex
+	instance *lib.SegmentedIndex
+}
+
+// nextInstanceIndex returns the next index that belongs to the instance's
+// execution segment, striped in the same way the VUs and the iterations are.
+func (pc *partitionCounters) nextInstanceIndex(et *lib.ExecutionTuple) int64 {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if pc.instance == nil {
+		pc.instance = lib.NewSegmentedIndex(et)
+	}
+	_, unscaled := pc.instance.Next()
+	return unscaled - 1
+}
+
+// partition is a view of a SharedArray that hands out each of its records
+// exactly once across all VUs and all k6 instances.
+type partition struct {
+	array     wrappedSharedArray
+	mode      string
+	exhausted string
+	data      *Data
+}
+
+// partitionOptions are the options of SharedArray.partition().
+type partitionOptions struct {
+	Exhausted string `js:"exhausted"`
+}
+
+// partition returns a partition of the SharedArray with the given mode.
+func (s wrappedSharedArray) partition(d *Data, mode string, opts sobek.Value) *sobek.Object {
+	rt := s.rt
+	switch mode {
+	case partitionPerVU, partitionPerIteration, partitionPerInstance:
+	default:
+		common.Throw(rt, fmt.Errorf(
+			"unknown SharedArray partition mode '%s', it should be one of '%s', '%s' or '%s'",
+			mode, partitionPerVU, partitionPerIteration, partitionPerInstance,
+		))
+	}
+
+	options := partitionOptions{Exhausted: exhaustedWrap}
+	if !common.IsNullish(opts) {
+		if err := rt.ExportTo(opts, &options); err != nil {
+			common.Throw(rt, fmt.Errorf("invalid SharedArray partition options: %w", err))
+		}
+	}
+	if options.Exhausted != exhaustedWrap && options.Exhausted != exhaustedStop {
+		common.Throw(rt, fmt.Errorf(
+			"invalid SharedArray partition option exhausted '%s', it should be either '%s' or '%s'",
+			options.Exhausted, exhaustedWrap, exhaustedStop,
+		))
+	}
+
+	p := &partition{array: s, mode: mode, exhausted: options.Exhausted, data: d}
+	obj := rt.NewObject()
+	mustSet := func(name string, val interface{}) {
+		if err := obj.Set(name, val); err != nil {
+			common.Throw(rt, err)
+		}
+	}
+	mustSet("mode", mode)
+	mustSet("next", p.next)
+	return obj
+}
+
+// next returns the record that belongs to the current VU, iteration or call,
+// depending on the partition mode.
+func (p *partition) next() sobek.Value {
+	rt := p.array.rt
+	state := p.data.vu.State()
+	if state == nil {
+		common.Throw(rt, errors.New("getting records from a SharedArray partition in the init context is not supported"))
+	}
+	if len(p.array.arr) == 0 {
+		return sobek.Undefined()
+	}
+
+	var index int64
+	switch p.mode {
+	case partitionPerVU:
+		index = int64(state.VUIDGlobal) - 1 //nolint:gosec
+	case partitionPerIteration:
+		if state.GetScenarioGlobalVUIter == nil {
+			common.Throw(rt, errors.New("per-iteration SharedArray partitions can only be used in a scenario"))
+		}
+		index = int64(state.GetScenarioGlobalVUIter()) //nolint:gosec
+	case partitionPerInstance:
+		es := lib.GetExecutionState(p.data.vu.Context())
+		if es == nil {
+			common.Throw(rt, errors.New("per-instance SharedArray partitions can only be used in a test run"))
+		}
+		index = p.array.partitions.nextInstanceIndex(es.ExecutionTuple)
+	}
+
+	length := int64(len(p.array.arr))
+	if index < length {
+		return p.array.Get(int(index))
+	}
+	if p.exhausted == exhaustedWrap {
+		return p.array.Get(int(index % length))
+	}
+
+	p.stopScenario()
+	return sobek.Undefined()
+}
+
+// stopScenario stops the scenario of the current VU, since all of the records
+// of the partition have already been handed out.
+func (p *partition) stopScenario() {
+	ctx := p.data.vu.Context()
+	es, ss := lib.GetExecutionState(ctx), lib.GetScenarioState(ctx)
+	if es == nil || ss == nil {
+		return
+	}
+	err := fmt.Errorf("all %d records of the SharedArray %s partition were used", len(p.array.arr), p.mode)
+	if sErr := es.StopScenario(ss.Name, err); sErr != nil {
+		p.data.vu.State().Logger.WithError(sErr).Warn("Could not stop the scenario")
+	}
+}
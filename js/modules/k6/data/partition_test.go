@@ -0,0 +1,146 @@
+package data
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.k6.io/k6/js/modulestest"
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/lib/testutils"
+)
+
+// newPartitionTestRuntime returns a runtime with the 50 element shared array
+// from makeArrayScript, running in the given execution segment.
+func newPartitionTestRuntime(t *testing.T, segment, sequence string) (*modulestest.Runtime, *lib.ExecutionState) {
+	t.Helper()
+	runtime, err := newConfiguredRuntime(t)
+	require.NoError(t, err)
+	_, err = runtime.VU.Runtime().RunString(makeArrayScript)
+	require.NoError(t, err)
+
+	seg, err := lib.NewExecutionSegmentFromString(segment)
+	require.NoError(t, err)
+	seq, err := lib.NewExecutionSegmentSequenceFromString(sequence)
+	require.NoError(t, err)
+	et, err := lib.NewExecutionTuple(seg, &seq)
+	require.NoError(t, err)
+
+	es := lib.NewExecutionState(nil, et, 1, 1)
+	ctx := lib.WithExecutionState(context.Background(), es)
+	runtime.VU.CtxField = lib.WithScenarioState(ctx, &lib.ScenarioState{Name: "default"})
+	return runtime, es
+}
+
+func TestSharedArrayPartitionPerVUAndIteration(t *testing.T) {
+	t.Parallel()
+
+	runtime, es := newPartitionTestRuntime(t, "0:1", "")
+	rt := runtime.VU.Runtime()
+	_, err := rt.RunString(`
+		var perVU = array.partition("per-vu", { exhausted: "stop" });
+		var perIter = array.partition("per-iteration");
+	`)
+	require.NoError(t, err)
+
+	var iter uint64 = 73
+	runtime.MoveToVUContext(&lib.State{
+		Logger:                  testutils.NewLogger(t),
+		VUIDGlobal:              7,
+		GetScenarioGlobalVUIter: func() uint64 { return iter },
+	})
+
+	v, err := rt.RunString(`perVU.next().value + "," + perIter.next().value`)
+	require.NoError(t, err)
+	assert.Equal(t, "something6,something23", v.String())
+
+	iter = 10
+	v, err = rt.RunString(`perIter.next().value`)
+	require.NoError(t, err)
+	assert.Equal(t, "something10", v.String())
+
+	runtime.VU.StateField.VUIDGlobal = 51
+	v, err = rt.RunString(`perVU.next()`)
+	require.NoError(t, err)
+	assert.Nil(t, v.Export())
+
+	stopped, reason := es.GetScenarioStopReason("default")
+	assert.True(t, stopped)
+	assert.ErrorContains(t, reason, "all 50 records of the SharedArray per-vu partition were used")
+}
+
+func TestSharedArrayPartitionPerInstance(t *testing.T) {
+	t.Parallel()
+
+	runtime, es := newPartitionTestRuntime(t, "1/2:1", "0,1/2,1")
+	rt := runtime.VU.Runtime()
+	_, err := rt.RunString(`
+		var first = array.partition("per-instance");
+		var second = array.partition("per-instance", { exhausted: "stop" });
+	`)
+	require.NoError(t, err)
+	runtime.MoveToVUContext(&lib.State{Logger: testutils.NewLogger(t)})
+
+	// Both partitions hand out records from the same sequence and this
+	// instance only gets the odd ones, the even ones belong to the other one.
+	v, err := rt.RunString(`
+		var values = [];
+		for (var i = 0; i < 25; i++) {
+			values.push((i % 2 == 0 ? first : second).next().value);
+		}
+		values
+	`)
+	require.NoError(t, err)
+	values := v.Export().([]interface{})
+	require.Len(t, values, 25)
+	for i, val := range values {
+		assert.Equal(t, "something"+strconv.Itoa(2*i+1), val)
+	}
+	stopped, _ := es.GetScenarioStopReason("default")
+	assert.False(t, stopped)
+
+	v, err = rt.RunString(`first.next().value`)
+	require.NoError(t, err)
+	assert.Equal(t, "something1", v.String(), "the first partition should wrap")
+
+	v, err = rt.RunString(`second.next()`)
+	require.NoError(t, err)
+	assert.Nil(t, v.Export())
+	stopped, _ = es.GetScenarioStopReason("default")
+	assert.True(t, stopped)
+}
+
+func TestSharedArrayPartitionExceptions(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		code, err string
+	}{
+		"unknown mode": {
+			code: `array.partition("per-something")`,
+			err:  "unknown SharedArray partition mode 'per-something'",
+		},
+		"unknown exhausted option": {
+			code: `array.partition("per-vu", { exhausted: "explode" })`,
+			err:  "invalid SharedArray partition option exhausted 'explode'",
+		},
+		"next in the init context": {
+			code: `array.partition("per-vu").next()`,
+			err:  "in the init context is not supported",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			runtime, _ := newPartitionTestRuntime(t, "0:1", "")
+			_, err := runtime.VU.Runtime().RunString(tc.code)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -8,7 +8,12 @@ import (
 // TODO fix it not working really well with setupData or just make it more broken
 // TODO fix it working with console.log
 type sharedArray struct {
-	arr []string
+	arr        []string
+	partitions *partitionCounters
+}
+
+func newSharedArray(arr []string) sharedArray {
+	return sharedArray{arr: arr, partitions: &partitionCounters{}}
 }
 
 type wrappedSharedArray struct {
@@ -20,17 +25,36 @@ type wrappedSharedArray struct {
 	parse    sobek.Callable
 }
 
-func (s sharedArray) wrap(rt *sobek.Runtime) sobek.Value {
+func (s sharedArray) wrap(d *Data, rt *sobek.Runtime) *sobek.Object {
 	freeze, _ := sobek.AssertFunction(rt.GlobalObject().Get("Object").ToObject(rt).Get("freeze"))
 	isFrozen, _ := sobek.AssertFunction(rt.GlobalObject().Get("Object").ToObject(rt).Get("isFrozen"))
 	parse, _ := sobek.AssertFunction(rt.GlobalObject().Get("JSON").ToObject(rt).Get("parse"))
-	return rt.NewDynamicArray(wrappedSharedArray{
+	wrapped := wrappedSharedArray{
 		sharedArray: s,
 		rt:          rt,
 		freeze:      freeze,
 		isFrozen:    isFrozen,
 		parse:       parse,
+	}
+
+	// The dynamic array can't have any other own properties, so the extra
+	// methods are defined on its prototype instead.
+	proto := rt.NewObject()
+	if err := proto.SetPrototype(rt.GlobalObject().Get("Array").ToObject(rt).Get("prototype").ToObject(rt)); err != nil {
+		common.Throw(rt, err)
+	}
+	err := proto.Set("partition", func(mode string, opts sobek.Value) *sobek.Object {
+		return wrapped.partition(d, mode, opts)
 	})
+	if err != nil {
+		common.Throw(rt, err)
+	}
+
+	obj := rt.NewDynamicArray(wrapped)
+	if err = obj.SetPrototype(proto); err != nil {
+		common.Throw(rt, err)
+	}
+	return obj
 }
 
 func (s wrappedSharedArray) Set(_ int, _ sobek.Value) bool {
@@ -3,6 +3,7 @@ package data
 import (
 	"context"
 	"errors"
+	"io"
 	"sync"
 	"testing"
 	"time"
@@ -254,3 +255,64 @@ func TestSharedArrayRaceInInitialization(t *testing.T) {
 		}
 	}
 }
+
+type sliceRecordReader [][]string
+
+func (r *sliceRecordReader) Read() ([]string, error) {
+	if len(*r) == 0 {
+		return nil, io.EOF
+	}
+	record := (*r)[0]
+	*r = (*r)[1:]
+	return record, nil
+}
+
+type failingValueReader struct{}
+
+func (failingValueReader) Read() (any, error) {
+	return nil, errors.New("oops")
+}
+
+func TestNewSharedArrayFrom(t *testing.T) {
+	t.Parallel()
+
+	rt := sobek.New()
+	vu := &modulestest.VU{
+		RuntimeField: rt,
+		InitEnvField: &common.InitEnvironment{},
+		CtxField:     context.Background(),
+	}
+	m, ok := New().NewModuleInstance(vu).(*Data)
+	require.True(t, ok)
+
+	require.NoError(t, rt.Set("newSharedArray", func(name string) *sobek.Object {
+		return m.NewSharedArrayFrom(rt, name, &sliceRecordReader{{"a", "1"}, {"b", "2"}})
+	}))
+	require.NoError(t, rt.Set("newFailingSharedArray", func(name string) *sobek.Object {
+		if err := m.CreateSharedArrayFrom(name, failingValueReader{}); err != nil {
+			common.Throw(rt, err)
+		}
+		array, err := m.WrapSharedArray(rt, name)
+		if err != nil {
+			common.Throw(rt, err)
+		}
+		return array
+	}))
+
+	_, err := rt.RunString(`
+	var array = newSharedArray("records");
+	if (array.length !== 2 || array[1][0] !== "b" || array[1][1] !== "2") {
+		throw new Error("bad array " + JSON.stringify(array));
+	}
+	`)
+	require.NoError(t, err)
+
+	_, err = rt.RunString(`newSharedArray("")`)
+	require.ErrorContains(t, err, "empty name provided to SharedArray's constructor")
+
+	_, err = rt.RunString(`newFailingSharedArray("failing")`)
+	require.ErrorContains(t, err, "failed to read record; reason: oops")
+
+	_, err = m.WrapSharedArray(rt, "missing")
+	require.ErrorContains(t, err, `no shared array named "missing"`)
+}
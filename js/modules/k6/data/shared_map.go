@@ -0,0 +1,199 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/grafana/sobek"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/lib"
+)
+
+// The prefixes of the shared store keys, so different maps and counters
+// with the same name don't clash with each other.
+const (
+	sharedMapKeyPrefix     = "k6/data/SharedMap/"
+	atomicCounterKeyPrefix = "k6/data/AtomicCounter/"
+)
+
+var errSharedStateInInitContext = errors.New(
+	"using SharedMap and AtomicCounter in the init context is not supported")
+
+// getStore returns the shared store of the test run, if there is one, or the
+// local one, if all of the VUs are in the current instance.
+func (d *Data) getStore() lib.SharedStore {
+	if d.vu.State() == nil {
+		common.Throw(d.vu.Runtime(), errSharedStateInInitContext)
+	}
+	if es := lib.GetExecutionState(d.vu.Context()); es != nil && es.SharedStore != nil {
+		return es.SharedStore
+	}
+	return d.store
+}
+
+func getSharedStateName(rt *sobek.Runtime, call sobek.ConstructorCall, typeName string) string {
+	name := call.Argument(0).String()
+	if common.IsNullish(call.Argument(0)) || name == "" {
+		common.Throw(rt, fmt.Errorf("empty name provided to %s's constructor", typeName))
+	}
+	return name
+}
+
+// sharedMap is a constructor returning a mutable map identified by its name,
+// whose JSON-serializable values are shared between all VUs.
+func (d *Data) sharedMap(call sobek.ConstructorCall) *sobek.Object {
+	rt := d.vu.Runtime()
+	name := getSharedStateName(rt, call, "SharedMap")
+	prefix := sharedMapKeyPrefix + name + "/"
+
+	stringify, _ := sobek.AssertFunction(rt.GlobalObject().Get("JSON").ToObject(rt).Get("stringify"))
+	parse, _ := sobek.AssertFunction(rt.GlobalObject().Get("JSON").ToObject(rt).Get("parse"))
+	marshal := func(val sobek.Value) []byte {
+		if sobek.IsUndefined(val) {
+			return nil
+		}
+		res, err := stringify(sobek.Undefined(), val)
+		if err != nil {
+			common.Throw(rt, err)
+		}
+		if sobek.IsUndefined(res) {
+			common.Throw(rt, fmt.Errorf("the SharedMap '%s' values need to be JSON-serializable", name))
+		}
+		return []byte(res.String())
+	}
+	unmarshal := func(data []byte) sobek.Value {
+		val, err := parse(sobek.Undefined(), rt.ToValue(string(data)))
+		if err != nil {
+			common.Throw(rt, err)
+		}
+		return val
+	}
+	must := func(err error) {
+		if err != nil {
+			common.Throw(rt, fmt.Errorf("SharedMap '%s' operation failed: %w", name, err))
+		}
+	}
+
+	obj := rt.NewObject()
+	mustSet := func(prop string, val interface{}) {
+		if err := obj.Set(prop, val); err != nil {
+			common.Throw(rt, err)
+		}
+	}
+	mustSet("name", name)
+	mustSet("get", func(key string) sobek.Value {
+		value, found, err := d.getStore().GetValue(prefix + key)
+		must(err)
+		if !found {
+			return sobek.Undefined()
+		}
+		return unmarshal(value)
+	})
+	mustSet("has", func(key string) bool {
+		_, found, err := d.getStore().GetValue(prefix + key)
+		must(err)
+		return found
+	})
+	mustSet("set", func(key string, val sobek.Value) {
+		value := marshal(val)
+		if value == nil {
+			common.Throw(rt, fmt.Errorf("can't set an undefined value in the SharedMap '%s'", name))
+		}
+		must(d.getStore().SetValue(prefix+key, value))
+	})
+	// compareAndSwap sets the new value only if the current one is equal to
+	// the expected one, or if the key doesn't exist and the expected value is
+	// undefined. Objects are equal if they have the same properties and values,
+	// regardless of the order of their keys.
+	mustSet("compareAndSwap", func(key string, expected, val sobek.Value) bool {
+		value := marshal(val)
+		if value == nil {
+			common.Throw(rt, fmt.Errorf("can't set an undefined value in the SharedMap '%s'", name))
+		}
+		expectedValue := marshal(expected)
+		for {
+			current, found, err := d.getStore().GetValue(prefix + key)
+			must(err)
+			if found != (expectedValue != nil) {
+				return false
+			}
+			if !found {
+				current = nil
+			} else if equal, err := jsonEqual(current, expectedValue); err != nil || !equal {
+				must(err)
+				return false
+			}
+
+			// The store compares the exact bytes, so the current value is
+			// swapped, and it's tried again if it has changed in the meantime.
+			swapped, err := d.getStore().CompareAndSwapValue(prefix+key, current, value)
+			must(err)
+			if swapped {
+				return true
+			}
+		}
+	})
+	mustSet("delete", func(key string) bool {
+		deleted, err := d.getStore().DeleteValue(prefix + key)
+		must(err)
+		return deleted
+	})
+
+	return obj
+}
+
+// atomicCounter is a constructor returning an integer counter identified by
+// its name, which is shared between all VUs.
+func (d *Data) atomicCounter(call sobek.ConstructorCall) *sobek.Object {
+	rt := d.vu.Runtime()
+	name := getSharedStateName(rt, call, "AtomicCounter")
+	key := atomicCounterKeyPrefix + name
+
+	add := func(delta int64) int64 {
+		val, err := d.getStore().AddToCounter(key, delta)
+		if err != nil {
+			common.Throw(rt, fmt.Errorf("AtomicCounter '%s' operation failed: %w", name, err))
+		}
+		return val
+	}
+
+	obj := rt.NewObject()
+	mustSet := func(prop string, val interface{}) {
+		if err := obj.Set(prop, val); err != nil {
+			common.Throw(rt, err)
+		}
+	}
+	mustSet("name", name)
+	mustSet("add", add)
+	mustSet("increment", func() int64 { return add(1) })
+	mustSet("decrement", func() int64 { return add(-1) })
+	mustSet("get", func() int64 { return add(0) })
+
+	return obj
+}
+
+// jsonEqual reports whether the given JSON texts decode to the same values.
+func jsonEqual(a, b []byte) (bool, error) {
+	if bytes.Equal(a, b) {
+		return true, nil
+	}
+	var aVal, bVal any
+	if err := decodeJSON(a, &aVal); err != nil {
+		return false, err
+	}
+	if err := decodeJSON(b, &bVal); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(aVal, bVal), nil
+}
+
+func decodeJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// the numbers are compared by their text, as JSON.stringify() formats them
+	dec.UseNumber()
+	return dec.Decode(v)
+}
@@ -0,0 +1,141 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.k6.io/k6/lib"
+)
+
+func TestSharedMapAcrossVUs(t *testing.T) {
+	t.Parallel()
+
+	first, err := newConfiguredRuntime(t)
+	require.NoError(t, err)
+	second, err := configuredRuntimeFromAnother(t, first)
+	require.NoError(t, err)
+
+	const initCode = `
+		var tokens = new data.SharedMap("tokens");
+		var other = new data.SharedMap("other");
+		var orders = new data.AtomicCounter("orders");
+	`
+	_, err = first.VU.Runtime().RunString(initCode)
+	require.NoError(t, err)
+	_, err = second.VU.Runtime().RunString(initCode)
+	require.NoError(t, err)
+	first.MoveToVUContext(&lib.State{})
+	second.MoveToVUContext(&lib.State{})
+
+	_, err = first.VU.Runtime().RunString(`
+		tokens.set("a", { token: "secret", uses: 1 });
+		if (other.has("a")) {
+			throw new Error("different maps shouldn't share keys");
+		}
+		if (orders.increment() !== 1 || orders.add(5) !== 6) {
+			throw new Error("unexpected counter value");
+		}
+	`)
+	require.NoError(t, err)
+
+	_, err = second.VU.Runtime().RunString(`
+		var a = tokens.get("a");
+		if (a.token !== "secret" || a.uses !== 1) {
+			throw new Error("unexpected value " + JSON.stringify(a));
+		}
+		if (tokens.compareAndSwap("a", { token: "secret", uses: 2 }, { token: "other" })) {
+			throw new Error("the swap should have failed");
+		}
+		if (!tokens.compareAndSwap("a", { uses: 1, token: "secret" }, { token: "secret", uses: 2 })) {
+			throw new Error("the swap should have succeeded regardless of the key order");
+		}
+		if (!tokens.compareAndSwap("b", undefined, "new")) {
+			throw new Error("the swap of a missing key should have succeeded");
+		}
+		if (orders.decrement() !== 5 || orders.get() !== 5) {
+			throw new Error("unexpected counter value");
+		}
+	`)
+	require.NoError(t, err)
+
+	v, err := first.VU.Runtime().RunString(`
+		JSON.stringify([tokens.get("a"), tokens.get("b"), tokens.delete("b"), tokens.delete("b"), tokens.get("b")])
+	`)
+	require.NoError(t, err)
+	assert.Equal(t, `[{"token":"secret","uses":2},"new",true,false,null]`, v.String())
+}
+
+func TestSharedMapUsesTheExecutionStateStore(t *testing.T) {
+	t.Parallel()
+
+	runtime, err := newConfiguredRuntime(t)
+	require.NoError(t, err)
+	_, err = runtime.VU.Runtime().RunString(`
+		var m = new data.SharedMap("m");
+		var c = new data.AtomicCounter("c");
+	`)
+	require.NoError(t, err)
+
+	et, err := lib.NewExecutionTuple(nil, nil)
+	require.NoError(t, err)
+	store := newLocalStore()
+	es := lib.NewExecutionState(nil, et, 0, 0)
+	es.SharedStore = store
+	runtime.VU.CtxField = lib.WithExecutionState(context.Background(), es)
+	runtime.MoveToVUContext(&lib.State{})
+
+	_, err = runtime.VU.Runtime().RunString(`m.set("key", [1, 2]); c.add(3);`)
+	require.NoError(t, err)
+
+	value, found, err := store.GetValue(sharedMapKeyPrefix + "m/key")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "[1,2]", string(value))
+	count, err := store.AddToCounter(atomicCounterKeyPrefix+"c", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestSharedMapExceptions(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		code, err string
+		vuCtx     bool
+	}{
+		"empty map name":     {code: `new data.SharedMap("")`, err: "empty name provided to SharedMap's constructor"},
+		"empty counter name": {code: `new data.AtomicCounter()`, err: "empty name provided to AtomicCounter's constructor"},
+		"init context": {
+			code: `new data.SharedMap("m").get("key")`,
+			err:  "using SharedMap and AtomicCounter in the init context is not supported",
+		},
+		"undefined value": {
+			code:  `new data.SharedMap("m").set("key", undefined)`,
+			err:   "can't set an undefined value in the SharedMap 'm'",
+			vuCtx: true,
+		},
+		"function value": {
+			code:  `new data.SharedMap("m").set("key", function() {})`,
+			err:   "the SharedMap 'm' values need to be JSON-serializable",
+			vuCtx: true,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			runtime, err := newConfiguredRuntime(t)
+			require.NoError(t, err)
+			if tc.vuCtx {
+				runtime.MoveToVUContext(&lib.State{})
+			}
+			_, err = runtime.VU.Runtime().RunString(tc.code)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
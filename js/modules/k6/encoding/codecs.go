@@ -0,0 +1,217 @@
+package encoding
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/lib/netext/httpext"
+)
+
+// base32Encoding returns the base32 encoding with the given name, which is
+// std by default.
+func base32Encoding(encoding string) *base32.Encoding {
+	switch encoding {
+	case "rawstd":
+		return base32.StdEncoding.WithPadding(base32.NoPadding)
+	case "hex":
+		return base32.HexEncoding
+	case "rawhex":
+		return base32.HexEncoding.WithPadding(base32.NoPadding)
+	default:
+		return base32.StdEncoding
+	}
+}
+
+// b32Encode returns the base32 encoding of input as a string, with the std,
+// rawstd, hex or rawhex encoding. The data type of input can be a string,
+// []byte or ArrayBuffer.
+func (e *Encoding) b32Encode(input interface{}, encoding string) (string, error) {
+	data, err := common.ToBytes(input)
+	if err != nil {
+		return "", err
+	}
+	return base32Encoding(encoding).EncodeToString(data), nil
+}
+
+// b32Decode returns the decoded data of the base32 encoded input string using
+// the given encoding, as a string if format is "s" or as an ArrayBuffer.
+func (e *Encoding) b32Decode(input, encoding, format string) (interface{}, error) {
+	output, err := base32Encoding(encoding).DecodeString(input)
+	if err != nil {
+		return nil, err
+	}
+	return e.output(output, format), nil
+}
+
+// base58Alphabet is the alphabet of the Bitcoin base58 encoding.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// b58Encode returns the base58 encoding of input as a string, with the
+// Bitcoin alphabet. The data type of input can be a string, []byte or
+// ArrayBuffer.
+func (e *Encoding) b58Encode(input interface{}) (string, error) {
+	data, err := common.ToBytes(input)
+	if err != nil {
+		return "", err
+	}
+
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+	// the little-endian base58 digits of the data
+	digits := make([]byte, 0, len(data)*138/100+1)
+	for _, b := range data[zeros:] {
+		carry := int(b)
+		for i := range digits {
+			carry += int(digits[i]) << 8
+			digits[i] = byte(carry % 58)
+			carry /= 58
+		}
+		for carry > 0 {
+			digits = append(digits, byte(carry%58))
+			carry /= 58
+		}
+	}
+
+	var sb strings.Builder
+	sb.Grow(zeros + len(digits))
+	sb.WriteString(strings.Repeat("1", zeros))
+	for i := len(digits) - 1; i >= 0; i-- {
+		sb.WriteByte(base58Alphabet[digits[i]])
+	}
+	return sb.String(), nil
+}
+
+// b58Decode returns the decoded data of the base58 encoded input string, as a
+// string if format is "s" or as an ArrayBuffer.
+func (e *Encoding) b58Decode(input, format string) (interface{}, error) {
+	zeros := 0
+	for zeros < len(input) && input[zeros] == '1' {
+		zeros++
+	}
+	// the little-endian bytes of the data
+	var data []byte
+	for i := zeros; i < len(input); i++ {
+		carry := strings.IndexByte(base58Alphabet, input[i])
+		if carry < 0 {
+			return nil, fmt.Errorf("illegal base58 data at input byte %d", i)
+		}
+		for j := range data {
+			carry += int(data[j]) * 58
+			data[j] = byte(carry)
+			carry >>= 8
+		}
+		for carry > 0 {
+			data = append(data, byte(carry))
+			carry >>= 8
+		}
+	}
+
+	output := make([]byte, zeros+len(data))
+	for i, b := range data {
+		output[len(output)-1-i] = b
+	}
+	return e.output(output, format), nil
+}
+
+// hexEncode returns the hex encoding of input as a string. The data type of
+// input can be a string, []byte or ArrayBuffer.
+func (e *Encoding) hexEncode(input interface{}) (string, error) {
+	data, err := common.ToBytes(input)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// hexDecode returns the decoded data of the hex encoded input string, as a
+// string if format is "s" or as an ArrayBuffer.
+func (e *Encoding) hexDecode(input, format string) (interface{}, error) {
+	output, err := hex.DecodeString(input)
+	if err != nil {
+		return nil, err
+	}
+	return e.output(output, format), nil
+}
+
+// urlEncode returns the percent-encoding of input, where all the bytes but
+// the unreserved characters of RFC 3986 are encoded, so binary data can be
+// used in any part of an URL. The data type of input can be a string, []byte
+// or ArrayBuffer.
+func (e *Encoding) urlEncode(input interface{}) (string, error) {
+	data, err := common.ToBytes(input)
+	if err != nil {
+		return "", err
+	}
+
+	const upperhex = "0123456789ABCDEF"
+	var sb strings.Builder
+	sb.Grow(len(data))
+	for _, b := range data {
+		if isUnreserved(b) {
+			sb.WriteByte(b)
+			continue
+		}
+		sb.WriteByte('%')
+		sb.WriteByte(upperhex[b>>4])
+		sb.WriteByte(upperhex[b&15])
+	}
+	return sb.String(), nil
+}
+
+func isUnreserved(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9' ||
+		b == '-' || b == '.' || b == '_' || b == '~'
+}
+
+// urlDecode returns the decoded data of the percent-encoded input string, as
+// a string if format is "s" or as an ArrayBuffer.
+func (e *Encoding) urlDecode(input, format string) (interface{}, error) {
+	output, err := url.PathUnescape(input)
+	if err != nil {
+		return nil, err
+	}
+	return e.output([]byte(output), format), nil
+}
+
+// compress returns the input compressed with the gzip, deflate, zstd or br
+// algorithm, as an ArrayBuffer. The data type of input can be a string,
+// []byte or ArrayBuffer.
+func (e *Encoding) compress(input interface{}, algorithm string) (interface{}, error) {
+	data, err := common.ToBytes(input)
+	if err != nil {
+		return nil, err
+	}
+	algo, err := httpext.CompressionTypeString(algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported compression algorithm %q", algorithm)
+	}
+	output, err := httpext.Compress(data, algo)
+	if err != nil {
+		return nil, err
+	}
+	return e.output(output, ""), nil
+}
+
+// decompress returns the input decompressed with the algorithm, as a string
+// if format is "s" or as an ArrayBuffer.
+func (e *Encoding) decompress(input interface{}, algorithm, format string) (interface{}, error) {
+	data, err := common.ToBytes(input)
+	if err != nil {
+		return nil, err
+	}
+	algo, err := httpext.CompressionTypeString(algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported compression algorithm %q", algorithm)
+	}
+	output, err := httpext.Decompress(data, algo)
+	if err != nil {
+		return nil, fmt.Errorf("%s decompression failed: %w", algorithm, err)
+	}
+	return e.output(output, format), nil
+}
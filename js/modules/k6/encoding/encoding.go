@@ -39,8 +39,20 @@ func (*RootModule) NewModuleInstance(vu modules.VU) modules.Instance {
 func (e *Encoding) Exports() modules.Exports {
 	return modules.Exports{
 		Named: map[string]interface{}{
-			"b64encode": e.b64Encode,
-			"b64decode": e.b64Decode,
+			"b64encode":   e.b64Encode,
+			"b64decode":   e.b64Decode,
+			"b32encode":   e.b32Encode,
+			"b32decode":   e.b32Decode,
+			"b58encode":   e.b58Encode,
+			"b58decode":   e.b58Decode,
+			"hexEncode":   e.hexEncode,
+			"hexDecode":   e.hexDecode,
+			"urlEncode":   e.urlEncode,
+			"urlDecode":   e.urlDecode,
+			"compress":    e.compress,
+			"decompress":  e.decompress,
+			"TextEncoder": e.newTextEncoder,
+			"TextDecoder": e.newTextDecoder,
 		},
 	}
 }
@@ -92,13 +104,15 @@ func (e *Encoding) b64Decode(input, encoding, format string) interface{} {
 		common.Throw(e.vu.Runtime(), err)
 	}
 
-	var out interface{}
+	return e.output(output, format)
+}
+
+// output returns the data as a string if format is "s", otherwise as an
+// ArrayBuffer.
+func (e *Encoding) output(data []byte, format string) interface{} {
 	if format == "s" {
-		out = string(output)
-	} else {
-		ab := e.vu.Runtime().NewArrayBuffer(output)
-		out = &ab
+		return string(data)
 	}
-
-	return out
+	ab := e.vu.Runtime().NewArrayBuffer(data)
+	return &ab
 }
@@ -217,3 +217,179 @@ func TestEncodingAlgorithms(t *testing.T) {
 		})
 	})
 }
+
+func TestExtendedEncodings(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name, script string
+	}{
+		{
+			name: "Hex",
+			script: `
+			var encoded = encoding.hexEncode("hello world");
+			if (encoded !== "68656c6c6f20776f726c64") {
+				throw new Error("Encoding mismatch: " + encoded);
+			}
+			var decoded = encoding.hexDecode(encoded, "s");
+			if (decoded !== "hello world") {
+				throw new Error("Decoding mismatch: " + decoded);
+			}`,
+		},
+		{
+			name: "Base32",
+			script: `
+			var cases = { std: "NBSWY3DP", rawstd: "NBSWY3DP", hex: "D1IMOR3F", rawhex: "D1IMOR3F" };
+			for (var enc in cases) {
+				var encoded = encoding.b32encode("hello", enc);
+				if (encoded !== cases[enc]) {
+					throw new Error(enc + " encoding mismatch: " + encoded);
+				}
+				var decoded = encoding.b32decode(encoded, enc, "s");
+				if (decoded !== "hello") {
+					throw new Error(enc + " decoding mismatch: " + decoded);
+				}
+			}
+			if (encoding.b32encode("hi", "std") !== "NBUQ====" || encoding.b32encode("hi", "rawstd") !== "NBUQ") {
+				throw new Error("padding mismatch");
+			}`,
+		},
+		{
+			name: "Base58",
+			script: `
+			var encoded = encoding.b58encode("Hello World!");
+			if (encoded !== "2NEpo7TZRRrLZSi2U") {
+				throw new Error("Encoding mismatch: " + encoded);
+			}
+			var zeros = encoding.b58encode(encoding.hexDecode("0000287fb4cd"));
+			if (zeros !== "11233QC4") {
+				throw new Error("Leading zeros encoding mismatch: " + zeros);
+			}
+			if (encoding.hexEncode(encoding.b58decode("11233QC4")) !== "0000287fb4cd") {
+				throw new Error("Leading zeros decoding mismatch");
+			}
+			var decoded = encoding.b58decode(encoded, "s");
+			if (decoded !== "Hello World!") {
+				throw new Error("Decoding mismatch: " + decoded);
+			}
+			if (encoding.b58encode("") !== "" || encoding.b58decode("", "s") !== "") {
+				throw new Error("Empty mismatch");
+			}`,
+		},
+		{
+			name: "URL",
+			script: `
+			var encoded = encoding.urlEncode(encoding.hexDecode("00ff2f41"));
+			if (encoded !== "%00%FF%2FA") {
+				throw new Error("Encoding mismatch: " + encoded);
+			}
+			if (encoding.urlEncode("a b&c=d~") !== "a%20b%26c%3Dd~") {
+				throw new Error("String encoding mismatch");
+			}
+			if (encoding.hexEncode(encoding.urlDecode(encoded)) !== "00ff2f41") {
+				throw new Error("Decoding mismatch");
+			}`,
+		},
+		{
+			name: "Compression",
+			script: `
+			var data = "hello hello hello hello hello hello";
+			["gzip", "deflate", "zstd", "br"].forEach(function(alg) {
+				var compressed = encoding.compress(data, alg);
+				if (!(compressed instanceof ArrayBuffer)) {
+					throw new Error(alg + " didn't return an ArrayBuffer");
+				}
+				var decompressed = encoding.decompress(compressed, alg, "s");
+				if (decompressed !== data) {
+					throw new Error(alg + " mismatch: " + decompressed);
+				}
+			});`,
+		},
+		{
+			name: "TextEncoder",
+			script: `
+			var cases = {
+				"utf-8": "68c3a9",
+				"utf-16le": "68000e01e9003dd800de",
+				"utf-16be": "0068010e00e9d83dde00",
+			};
+			for (var enc in cases) {
+				var encoder = new encoding.TextEncoder(enc);
+				var encoded = encoder.encode("hĎé😀");
+				if (!(encoded instanceof Uint8Array)) {
+					throw new Error(enc + " didn't return an Uint8Array");
+				}
+				if (enc === "utf-8") {
+					encoded = encoder.encode("hé");
+				}
+				if (encoding.hexEncode(encoded.buffer) !== cases[enc]) {
+					throw new Error(enc + " mismatch: " + encoding.hexEncode(encoded.buffer));
+				}
+				var decoded = new encoding.TextDecoder(enc).decode(encoded);
+				var expected = enc === "utf-8" ? "hé" : "hĎé😀";
+				if (decoded !== expected) {
+					throw new Error(enc + " decoding mismatch: " + decoded);
+				}
+			}
+			var latin1 = new encoding.TextEncoder("latin1");
+			if (latin1.encoding !== "iso-8859-1" || encoding.hexEncode(latin1.encode("hé").buffer) !== "68e9") {
+				throw new Error("latin1 mismatch");
+			}
+			if (new encoding.TextEncoder().encoding !== "utf-8") {
+				throw new Error("the default encoding isn't utf-8");
+			}`,
+		},
+		{
+			name: "TextDecoder",
+			script: `
+			var bom = new encoding.TextDecoder("utf-16").decode(encoding.hexDecode("fffe6800"));
+			if (bom !== "h") {
+				throw new Error("BOM mismatch: " + bom);
+			}
+			var kept = new encoding.TextDecoder("utf-8", { ignoreBOM: true }).decode(encoding.hexDecode("efbbbf68"));
+			if (kept !== "\ufeffh") {
+				throw new Error("ignoreBOM mismatch: " + kept);
+			}
+			var replaced = new encoding.TextDecoder().decode(encoding.hexDecode("68ff69"));
+			if (replaced !== "h\ufffdi") {
+				throw new Error("replacement mismatch: " + replaced);
+			}
+			var latin1 = new encoding.TextDecoder("ISO-8859-1").decode(encoding.hexDecode("68e9"));
+			if (latin1 !== "hé") {
+				throw new Error("latin1 mismatch: " + latin1);
+			}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rt := makeRuntime(t)
+			_, err := rt.RunString(tc.script)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+		rt := makeRuntime(t)
+
+		_, err := rt.RunString(`encoding.b58decode("0OIl")`)
+		assert.ErrorContains(t, err, "illegal base58 data at input byte 0")
+		_, err = rt.RunString(`encoding.hexDecode("xyz")`)
+		assert.ErrorContains(t, err, "invalid byte")
+		_, err = rt.RunString(`encoding.compress("data", "lzw")`)
+		assert.ErrorContains(t, err, `unsupported compression algorithm "lzw"`)
+		_, err = rt.RunString(`encoding.decompress("not gzip", "gzip")`)
+		assert.ErrorContains(t, err, "gzip decompression failed")
+		_, err = rt.RunString(`new encoding.TextDecoder("koi8-r")`)
+		assert.ErrorContains(t, err, `unsupported text encoding "koi8-r"`)
+		_, err = rt.RunString(`new encoding.TextEncoder("latin1").encode("Ď")`)
+		assert.ErrorContains(t, err, "can't be encoded in iso-8859-1")
+		_, err = rt.RunString(`new encoding.TextDecoder("utf-8", { fatal: true }).decode(encoding.hexDecode("ff"))`)
+		assert.ErrorContains(t, err, "the encoded data is not valid utf-8")
+		_, err = rt.RunString(`new encoding.TextDecoder("utf-16le", { fatal: true }).decode(encoding.hexDecode("3dd8"))`)
+		assert.ErrorContains(t, err, "the encoded data is not valid utf-16le")
+	})
+}
@@ -0,0 +1,210 @@
+package encoding
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/grafana/sobek"
+
+	"go.k6.io/k6/js/common"
+)
+
+// The supported text encodings.
+const (
+	textUTF8    = "utf-8"
+	textUTF16LE = "utf-16le"
+	textUTF16BE = "utf-16be"
+	textLatin1  = "iso-8859-1"
+)
+
+// textEncodingLabels are the text encodings by their labels. Unlike in the
+// browsers, latin1 is ISO-8859-1 rather than windows-1252, so all the bytes
+// map to the same code points.
+var textEncodingLabels = map[string]string{ //nolint:gochecknoglobals
+	"utf-8":             textUTF8,
+	"utf8":              textUTF8,
+	"unicode-1-1-utf-8": textUTF8,
+	"utf-16":            textUTF16LE,
+	"utf-16le":          textUTF16LE,
+	"utf-16be":          textUTF16BE,
+	"iso-8859-1":        textLatin1,
+	"iso8859-1":         textLatin1,
+	"latin1":            textLatin1,
+	"l1":                textLatin1,
+}
+
+func textEncoding(label sobek.Value) (string, error) {
+	if common.IsNullish(label) {
+		return textUTF8, nil
+	}
+	encoding, ok := textEncodingLabels[strings.ToLower(strings.TrimSpace(label.String()))]
+	if !ok {
+		return "", fmt.Errorf("unsupported text encoding %q", label.String())
+	}
+	return encoding, nil
+}
+
+// TextEncoder encodes strings to bytes, like the one of the browsers, but
+// with the UTF-16 and Latin-1 encodings too.
+type TextEncoder struct {
+	Encoding string `js:"encoding"`
+
+	rt *sobek.Runtime
+}
+
+func (e *Encoding) newTextEncoder(call sobek.ConstructorCall) *sobek.Object {
+	rt := e.vu.Runtime()
+	encoding, err := textEncoding(call.Argument(0))
+	if err != nil {
+		common.Throw(rt, err)
+	}
+	return rt.ToValue(&TextEncoder{Encoding: encoding, rt: rt}).ToObject(rt)
+}
+
+// Encode returns the encoded input as an Uint8Array.
+func (te *TextEncoder) Encode(input sobek.Value) (sobek.Value, error) {
+	var s string
+	if !common.IsNullish(input) {
+		s = input.String()
+	}
+
+	var data []byte
+	switch te.Encoding {
+	case textUTF16LE, textUTF16BE:
+		units := utf16.Encode([]rune(s))
+		data = make([]byte, 0, 2*len(units))
+		for _, u := range units {
+			if te.Encoding == textUTF16LE {
+				data = append(data, byte(u), byte(u>>8))
+			} else {
+				data = append(data, byte(u>>8), byte(u))
+			}
+		}
+	case textLatin1:
+		data = make([]byte, 0, len(s))
+		for _, r := range s {
+			if r > 0xFF {
+				return nil, fmt.Errorf("the character %q can't be encoded in %s", r, te.Encoding)
+			}
+			data = append(data, byte(r))
+		}
+	default:
+		data = []byte(s)
+	}
+
+	ctor, _ := sobek.AssertConstructor(te.rt.Get("Uint8Array"))
+	return ctor(nil, te.rt.ToValue(te.rt.NewArrayBuffer(data)))
+}
+
+// TextDecoder decodes bytes to strings, like the one of the browsers. Invalid
+// data is replaced with U+FFFD, unless it's fatal.
+type TextDecoder struct {
+	Encoding  string `js:"encoding"`
+	Fatal     bool   `js:"fatal"`
+	IgnoreBOM bool   `js:"ignoreBOM"`
+}
+
+func (e *Encoding) newTextDecoder(call sobek.ConstructorCall) *sobek.Object {
+	rt := e.vu.Runtime()
+	encoding, err := textEncoding(call.Argument(0))
+	if err != nil {
+		common.Throw(rt, err)
+	}
+	td := &TextDecoder{Encoding: encoding}
+	if options := call.Argument(1); !common.IsNullish(options) {
+		obj := options.ToObject(rt)
+		td.Fatal = obj.Get("fatal") != nil && obj.Get("fatal").ToBoolean()
+		td.IgnoreBOM = obj.Get("ignoreBOM") != nil && obj.Get("ignoreBOM").ToBoolean()
+	}
+	return rt.ToValue(td).ToObject(rt)
+}
+
+// Decode returns the decoded input, which can be an ArrayBuffer or a typed
+// array.
+func (td *TextDecoder) Decode(input interface{}) (string, error) {
+	if input == nil {
+		return "", nil
+	}
+	data, err := common.ToBytes(input)
+	if err != nil {
+		return "", err
+	}
+
+	switch td.Encoding {
+	case textUTF16LE, textUTF16BE:
+		return td.decodeUTF16(data)
+	case textLatin1:
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return string(runes), nil
+	default:
+		return td.decodeUTF8(data)
+	}
+}
+
+var errInvalidText = errors.New("the encoded data is not valid")
+
+func (td *TextDecoder) decodeUTF8(data []byte) (string, error) {
+	if !td.IgnoreBOM {
+		data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+	}
+	if utf8.Valid(data) {
+		return string(data), nil
+	}
+	if td.Fatal {
+		return "", fmt.Errorf("%w %s", errInvalidText, td.Encoding)
+	}
+
+	var sb strings.Builder
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		sb.WriteRune(r) // utf8.RuneError is U+FFFD
+		data = data[size:]
+	}
+	return sb.String(), nil
+}
+
+func (td *TextDecoder) decodeUTF16(data []byte) (string, error) {
+	unit := func(i int) uint16 {
+		if td.Encoding == textUTF16LE {
+			return uint16(data[i]) | uint16(data[i+1])<<8
+		}
+		return uint16(data[i])<<8 | uint16(data[i+1])
+	}
+
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		units = append(units, unit(i))
+	}
+	if !td.IgnoreBOM && len(units) > 0 && units[0] == 0xFEFF {
+		units = units[1:]
+	}
+
+	runes := utf16.Decode(units)
+	if td.Fatal {
+		// utf16.Decode replaces the unpaired surrogates with U+FFFD
+		invalid := len(data)%2 != 0
+		for i := 0; i < len(units) && !invalid; i++ {
+			if utf16.IsSurrogate(rune(units[i])) {
+				if i+1 < len(units) && utf16.DecodeRune(rune(units[i]), rune(units[i+1])) != utf8.RuneError {
+					i++
+					continue
+				}
+				invalid = true
+			}
+		}
+		if invalid {
+			return "", fmt.Errorf("%w %s", errInvalidText, td.Encoding)
+		}
+	}
+	if len(data)%2 != 0 {
+		runes = append(runes, utf8.RuneError)
+	}
+	return string(runes), nil
+}
@@ -181,6 +181,56 @@ func (mi *ModuleInstance) newTestInfo() (*sobek.Object, error) {
 				rt.Interrupt(&errext.InterruptError{Reason: reason})
 			}
 		},
+		// gracefully stop a single scenario, by default the current one
+		"stopScenario": func() interface{} {
+			return func(name, msg sobek.Value) {
+				es, scenario := mi.getScenarioControlArgs(name)
+				reason := errors.New("the scenario was stopped from the script")
+				if !common.IsNullish(msg) {
+					reason = fmt.Errorf("%w: %s", reason, msg.String())
+				}
+				if err := es.StopScenario(scenario, reason); err != nil {
+					common.Throw(rt, err)
+				}
+			}
+		},
+		"pauseScenario": func() interface{} {
+			return func(name sobek.Value) {
+				es, scenario := mi.getScenarioControlArgs(name)
+				if err := es.PauseScenario(scenario); err != nil {
+					common.Throw(rt, err)
+				}
+			}
+		},
+		"resumeScenario": func() interface{} {
+			return func(name sobek.Value) {
+				es, scenario := mi.getScenarioControlArgs(name)
+				if err := es.ResumeScenario(scenario); err != nil {
+					common.Throw(rt, err)
+				}
+			}
+		},
+		// get the current state of any scenario, by default the current one
+		"scenarioState": func() interface{} {
+			return func(name sobek.Value) *sobek.Object {
+				es, scenario := mi.getScenarioControlArgs(name)
+				stats, err := es.GetScenarioStats(scenario)
+				if err != nil {
+					common.Throw(rt, err)
+				}
+				return rt.ToValue(map[string]interface{}{
+					"name":                  scenario,
+					"started":               stats.Started,
+					"running":               stats.Started && !stats.Ended,
+					"ended":                 stats.Ended,
+					"paused":                stats.Paused,
+					"stopped":               stats.Stopped,
+					"vusActive":             stats.ActiveVUs,
+					"iterationsCompleted":   stats.FullIterations,
+					"iterationsInterrupted": stats.InterruptedIterations,
+				}).ToObject(rt)
+			}
+		},
 		"options": func() interface{} {
 			vuState := mi.vu.State()
 			if vuState == nil {
@@ -200,6 +250,29 @@ func (mi *ModuleInstance) newTestInfo() (*sobek.Object, error) {
 	return newInfoObj(rt, ti)
 }
 
+//nolint:gochecknoglobals
+var scenarioControlInitContextErr = common.NewInitContextError(
+	"controlling scenarios in the init context is not supported")
+
+// getScenarioControlArgs returns the execution state and the name of the
+// scenario that should be controlled, which is the current one if the given
+// name is undefined.
+func (mi *ModuleInstance) getScenarioControlArgs(name sobek.Value) (*lib.ExecutionState, string) {
+	rt := mi.vu.Runtime()
+	es := lib.GetExecutionState(mi.vu.Context())
+	if mi.vu.State() == nil || es == nil {
+		common.Throw(rt, scenarioControlInitContextErr)
+	}
+	if !common.IsNullish(name) {
+		return es, name.String()
+	}
+	ss := lib.GetScenarioState(mi.vu.Context())
+	if ss == nil {
+		common.Throw(rt, errors.New("a scenario name is required outside of a scenario"))
+	}
+	return es, ss.Name
+}
+
 //nolint:gochecknoglobals
 var vuInfoInitContextErr = common.NewInitContextError("getting VU information in the init context is not supported")
 
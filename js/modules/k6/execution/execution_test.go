@@ -223,7 +223,7 @@ func TestAbortTest(t *testing.T) { //nolint:tparallel
 func TestOptionsTestFull(t *testing.T) {
 	t.Parallel()
 
-	expected := `{"paused":true,"scenarios":{"const-vus":{"executor":"constant-vus","options":{"browser":{"someOption":true}},"startTime":"10s","gracefulStop":"30s","env":{"FOO":"bar"},"exec":"default","tags":{"tagkey":"tagvalue"},"vus":50,"duration":"10m0s"}},"executionSegment":"0:1/4","executionSegmentSequence":"0,1/4,1/2,1","noSetup":true,"setupTimeout":"1m0s","noTeardown":true,"teardownTimeout":"5m0s","rps":100,"dns":{"ttl":"1m","select":"roundRobin","policy":"any"},"maxRedirects":3,"userAgent":"k6-user-agent","batch":15,"batchPerHost":5,"httpDebug":"full","insecureSkipTLSVerify":true,"tlsCipherSuites":["TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"],"tlsVersion":{"min":"tls1.2","max":"tls1.3"},"tlsAuth":[{"domains":["example.com"],"cert":"mycert.pem","key":"mycert-key.pem","password":"mypwd"}],"throw":true,"thresholds":{"http_req_duration":[{"threshold":"rate>0.01","abortOnFail":true,"delayAbortEval":"10s"}]},"blacklistIPs":["192.0.2.0/24"],"blockHostnames":["test.k6.io","*.example.com"],"hosts":{"test.k6.io":"1.2.3.4:8443"},"noConnectionReuse":true,"noVUConnectionReuse":true,"minIterationDuration":"10s","ext":{"ext-one":{"rawkey":"rawvalue"}},"summaryTrendStats":["avg","min","max"],"summaryTimeUnit":"ms","systemTags":["iter","vu"],"tags":null,"metricSamplesBufferSize":8,"noCookiesReset":true,"discardResponseBodies":true,"consoleOutput":"loadtest.log","tags":{"runtag-key":"runtag-value"},"localIPs":"192.168.20.12-192.168.20.15,192.168.10.0/27"}`
+	expected := `{"paused":true,"scenarios":{"const-vus":{"executor":"constant-vus","options":{"browser":{"someOption":true}},"startTime":"10s","gracefulStop":"30s","env":{"FOO":"bar"},"exec":"default","tags":{"tagkey":"tagvalue"},"maxIterationDuration":"1m0s","recycleVUOnTimeout":true,"vus":50,"duration":"10m0s"}},"executionSegment":"0:1/4","executionSegmentSequence":"0,1/4,1/2,1","noSetup":true,"setupTimeout":"1m0s","noTeardown":true,"teardownTimeout":"5m0s","rps":100,"hostLimits":null,"dns":{"ttl":"1m","select":"roundRobin","policy":"any","servers":null,"overrides":null},"maxRedirects":3,"userAgent":"k6-user-agent","batch":15,"batchPerHost":5,"httpDebug":"full","insecureSkipTLSVerify":true,"tlsCipherSuites":["TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"],"tlsVersion":{"min":"tls1.2","max":"tls1.3"},"tlsAuth":[{"domains":["example.com"],"cert":"mycert.pem","key":"mycert-key.pem","password":"mypwd"}],"tlsSessionResumption":null,"tlsFullHandshakePerIteration":null,"throw":true,"thresholds":{"http_req_duration":[{"threshold":"rate>0.01","abortOnFail":true,"delayAbortEval":"10s"}]},"blacklistIPs":["192.0.2.0/24"],"blockHostnames":["test.k6.io","*.example.com"],"hosts":{"test.k6.io":"1.2.3.4:8443"},"proxy":null,"noConnectionReuse":true,"noVUConnectionReuse":true,"minIterationDuration":"10s","ext":{"ext-one":{"rawkey":"rawvalue"}},"summaryTrendStats":["avg","min","max"],"summaryTimeUnit":"ms","systemTags":["iter","vu"],"tags":null,"metricSamplesBufferSize":8,"noCookiesReset":true,"discardResponseBodies":true,"consoleOutput":"loadtest.log","tags":{"runtag-key":"runtag-value"},"localIPs":"192.168.20.12-192.168.20.15,192.168.10.0/27"}`
 
 	var (
 		rt    = sobek.New()
@@ -249,6 +249,8 @@ func TestOptionsTestFull(t *testing.T) {
 									"someOption": true,
 								},
 							},
+							MaxIterationDuration: types.NullDurationFrom(time.Minute),
+							RecycleVUOnTimeout:   null.BoolFrom(true),
 						},
 						VUs:      null.IntFrom(50),
 						Duration: types.NullDurationFrom(10 * time.Minute),
@@ -518,3 +520,92 @@ func TestTagsDynamicObjectSet(t *testing.T) {
 	require.NotNil(t, val)
 	assert.Equal(t, val.String(), "v1")
 }
+
+func TestScenarioControl(t *testing.T) {
+	t.Parallel()
+
+	et, err := lib.NewExecutionTuple(nil, nil)
+	require.NoError(t, err)
+	trs := &lib.TestRunState{
+		Options: lib.Options{
+			Scenarios: lib.ScenarioConfigs{
+				"login":    executor.NewPerVUIterationsConfig("login"),
+				"browse":   executor.NewConstantVUsConfig("browse"),
+				"checkout": executor.NewConstantVUsConfig("checkout"),
+			},
+		},
+	}
+	es := lib.NewExecutionState(trs, et, 0, 0)
+	es.MarkScenarioStarted("browse")
+	es.ModScenarioActiveVUsCount("browse", 3)
+	es.AddScenarioFullIterations("browse", 7)
+
+	ctx := lib.WithExecutionState(context.Background(), es)
+	ctx = lib.WithScenarioState(ctx, &lib.ScenarioState{Name: "login"})
+	rt := sobek.New()
+	m, ok := New().NewModuleInstance(
+		&modulestest.VU{
+			RuntimeField: rt,
+			CtxField:     ctx,
+			StateField:   &lib.State{},
+		},
+	).(*ModuleInstance)
+	require.True(t, ok)
+	require.NoError(t, rt.Set("exec", m.Exports().Default))
+
+	v, err := rt.RunString(`
+		exec.test.pauseScenario("browse");
+		var paused = exec.test.scenarioState("browse");
+		exec.test.resumeScenario("browse");
+		JSON.stringify([paused, exec.test.scenarioState("browse").paused])
+	`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{
+		"name": "browse", "started": true, "running": true, "ended": false, "paused": true, "stopped": false,
+		"vusActive": 3, "iterationsCompleted": 7, "iterationsInterrupted": 0
+	}, false]`, v.String())
+
+	_, err = rt.RunString(`exec.test.stopScenario(undefined, "out of data")`)
+	require.NoError(t, err)
+	stopped, reason := es.GetScenarioStopReason("login")
+	assert.True(t, stopped)
+	assert.EqualError(t, reason, "the scenario was stopped from the script: out of data")
+	stopped, _ = es.GetScenarioStopReason("checkout")
+	assert.False(t, stopped)
+
+	_, err = rt.RunString(`exec.test.stopScenario("checkout")`)
+	require.NoError(t, err)
+	v, err = rt.RunString(`exec.test.scenarioState("checkout").stopped`)
+	require.NoError(t, err)
+	assert.True(t, v.ToBoolean())
+
+	cases := map[string]string{
+		`exec.test.resumeScenario("browse")`: "scenario 'browse' isn't paused",
+		`exec.test.pauseScenario("unknown")`: "scenario 'unknown' doesn't exist",
+		`exec.test.scenarioState("unknown")`: "scenario 'unknown' doesn't exist",
+		`exec.test.stopScenario("unknown")`:  "scenario 'unknown' doesn't exist",
+	}
+	for code, expErr := range cases {
+		_, err = rt.RunString(code)
+		assert.ErrorContains(t, err, expErr, code)
+	}
+}
+
+func TestScenarioControlNotAvailableInInitContext(t *testing.T) {
+	t.Parallel()
+
+	rt := sobek.New()
+	m, ok := New().NewModuleInstance(
+		&modulestest.VU{
+			RuntimeField: rt,
+			CtxField:     context.Background(),
+		},
+	).(*ModuleInstance)
+	require.True(t, ok)
+	require.NoError(t, rt.Set("exec", m.Exports().Default))
+
+	for _, method := range []string{"stopScenario", "pauseScenario", "resumeScenario", "scenarioState"} {
+		_, err := rt.RunString(fmt.Sprintf("exec.test.%s()", method))
+		require.ErrorContains(t, err, "controlling scenarios in the init context is not supported")
+	}
+}
@@ -0,0 +1,286 @@
+package k6
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/grafana/sobek"
+	"github.com/tidwall/gjson"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/metrics"
+)
+
+// ErrExpectInInitContext is returned when expect() is used in the init context.
+var ErrExpectInInitContext = common.NewInitContextError("Using expect() in the init context is not supported")
+
+// maxActualValueLength is the maximum length of the actual values that are
+// reported with the failures of the assertions.
+const maxActualValueLength = 100
+
+// Assertion holds a value, whose matchers assert that it meets their
+// expectation and emit check metrics for it, like check() does.
+type Assertion struct {
+	k6     *K6
+	actual sobek.Value
+	name   string
+	tags   sobek.Value
+}
+
+// Expect returns an [Assertion] of the value. The optional name is the name of
+// the checks emitted by its matchers, which defaults to the description of the
+// matcher, and the optional tags are added to their metrics.
+func (mi *K6) Expect(actual sobek.Value, extras ...sobek.Value) (*Assertion, error) {
+	if mi.vu.State() == nil {
+		return nil, ErrExpectInInitContext
+	}
+
+	a := &Assertion{k6: mi, actual: actual}
+	if len(extras) > 0 && !common.IsNullish(extras[0]) {
+		a.name = extras[0].String()
+		if strings.Contains(a.name, lib.GroupSeparator) {
+			return nil, lib.ErrNameContainsGroupSeparator
+		}
+	}
+	if len(extras) > 1 {
+		a.tags = extras[1]
+	}
+
+	return a, nil
+}
+
+// ToBe asserts that the value is strictly equal to the expected one.
+func (a *Assertion) ToBe(expected sobek.Value) (bool, error) {
+	return a.assert(a.actual.StrictEquals(expected),
+		fmt.Sprintf("toBe(%s)", describe(expected)),
+		fmt.Sprintf("expected %s to be %s", describe(a.actual), describe(expected)))
+}
+
+// ToEqual asserts that the value is deeply equal to the expected one, once
+// both are converted to JSON.
+func (a *Assertion) ToEqual(expected sobek.Value) (bool, error) {
+	return a.assert(jsonEqual(exportValue(a.actual), exportValue(expected)),
+		fmt.Sprintf("toEqual(%s)", describe(expected)),
+		fmt.Sprintf("expected %s to equal %s", describe(a.actual), describe(expected)))
+}
+
+// ToBeTruthy asserts that the value is truthy.
+func (a *Assertion) ToBeTruthy() (bool, error) {
+	return a.assert(a.actual.ToBoolean(), "toBeTruthy()",
+		fmt.Sprintf("expected %s to be truthy", describe(a.actual)))
+}
+
+// ToBeGreaterThan asserts that the value is a number greater than n.
+func (a *Assertion) ToBeGreaterThan(n float64) (bool, error) {
+	return a.assert(a.number() > n,
+		fmt.Sprintf("toBeGreaterThan(%v)", n),
+		fmt.Sprintf("expected %s to be greater than %v", describe(a.actual), n))
+}
+
+// ToBeLessThan asserts that the value is a number less than n.
+func (a *Assertion) ToBeLessThan(n float64) (bool, error) {
+	return a.assert(a.number() < n,
+		fmt.Sprintf("toBeLessThan(%v)", n),
+		fmt.Sprintf("expected %s to be less than %v", describe(a.actual), n))
+}
+
+// ToBeBetween asserts that the value is a number in the inclusive range
+// between lower and upper.
+func (a *Assertion) ToBeBetween(lower, upper float64) (bool, error) {
+	n := a.number()
+	return a.assert(n >= lower && n <= upper,
+		fmt.Sprintf("toBeBetween(%v, %v)", lower, upper),
+		fmt.Sprintf("expected %s to be between %v and %v", describe(a.actual), lower, upper))
+}
+
+// ToMatch asserts that the value, converted to a string, matches the pattern,
+// which can be a RegExp or a string of its source.
+func (a *Assertion) ToMatch(pattern sobek.Value) (bool, error) {
+	rt := a.k6.vu.Runtime()
+
+	re, ok := pattern.(*sobek.Object)
+	if !ok || re.ClassName() != "RegExp" {
+		if common.IsNullish(pattern) {
+			return false, errors.New("toMatch() requires a pattern")
+		}
+		var err error
+		if re, err = rt.New(rt.Get("RegExp"), pattern); err != nil {
+			return false, err
+		}
+	}
+	test, ok := sobek.AssertFunction(re.Get("test"))
+	if !ok {
+		return false, errors.New("toMatch() requires a RegExp pattern")
+	}
+
+	matched, err := test(re, rt.ToValue(a.actual.String()))
+	if err != nil {
+		return false, err
+	}
+
+	return a.assert(matched.ToBoolean(),
+		fmt.Sprintf("toMatch(%s)", re.String()),
+		fmt.Sprintf("expected %s to match %s", describe(a.actual), re.String()))
+}
+
+// ToHaveJSONPath asserts that the value, which can be a JSON string or a value
+// that can be converted to it, has a value at the path, using the same syntax
+// as the selectors of Response.json(). If the expected value is provided, the
+// value at the path must also be equal to it, once both are converted to JSON.
+func (a *Assertion) ToHaveJSONPath(path string, expected ...sobek.Value) (bool, error) {
+	var body []byte
+	switch v := exportValue(a.actual).(type) {
+	case string:
+		body = []byte(v)
+	case []byte:
+		body = v
+	default:
+		body, _ = json.Marshal(v)
+	}
+
+	matcher := fmt.Sprintf("toHaveJSONPath(%q)", path)
+	if len(expected) > 0 {
+		matcher = fmt.Sprintf("toHaveJSONPath(%q, %s)", path, describe(expected[0]))
+	}
+
+	if !gjson.ValidBytes(body) {
+		return a.assert(false, matcher, fmt.Sprintf("expected %s to be JSON", describe(a.actual)))
+	}
+
+	result := gjson.GetBytes(body, path)
+	if !result.Exists() {
+		return a.assert(false, matcher, fmt.Sprintf("expected a value at %q", path))
+	}
+	if len(expected) == 0 {
+		return a.assert(true, matcher, "")
+	}
+
+	return a.assertWithActual(jsonEqual(result.Value(), exportValue(expected[0])), matcher,
+		fmt.Sprintf("expected %s at %q, got %s", describe(expected[0]), path, truncate(result.Raw)),
+		truncate(result.Raw))
+}
+
+// number returns the value converted to a number, which is NaN for the values
+// that are not numbers, so that they never meet the range expectations.
+func (a *Assertion) number() float64 {
+	switch a.actual.ExportType() {
+	case reflect.TypeOf(int64(0)), reflect.TypeOf(float64(0)):
+		return a.actual.ToFloat()
+	default:
+		return math.NaN()
+	}
+}
+
+func (a *Assertion) assert(pass bool, matcher, reason string) (bool, error) {
+	return a.assertWithActual(pass, matcher, reason, describe(a.actual))
+}
+
+// assertWithActual emits the check metric of the assertion, with the failure
+// reason and the actual value as metadata, when the assertion failed.
+func (a *Assertion) assertWithActual(pass bool, matcher, reason, actual string) (bool, error) {
+	state := a.k6.vu.State()
+	if state == nil {
+		return false, ErrExpectInInitContext
+	}
+
+	name := a.name
+	if name == "" {
+		name = strings.ReplaceAll(matcher, lib.GroupSeparator, ":")
+	}
+
+	tagsAndMeta := state.Tags.GetCurrentValues()
+	if !common.IsNullish(a.tags) {
+		if err := common.ApplyCustomUserTags(a.k6.vu.Runtime(), &tagsAndMeta, a.tags); err != nil {
+			return false, err
+		}
+	}
+	if state.Options.SystemTags.Has(metrics.TagCheck) {
+		tagsAndMeta.SetTag(metrics.TagCheck.String(), name)
+	}
+
+	if !pass {
+		tagsAndMeta.SetMetadata(lib.CheckFailureReasonMetadata, reason)
+		tagsAndMeta.SetMetadata(lib.CheckActualValueMetadata, actual)
+	}
+
+	sample := metrics.Sample{
+		TimeSeries: metrics.TimeSeries{
+			Metric: state.BuiltinMetrics.Checks,
+			Tags:   tagsAndMeta.Tags,
+		},
+		Time:     time.Now(),
+		Metadata: tagsAndMeta.Metadata,
+	}
+	if pass {
+		sample.Value = 1
+	}
+
+	metrics.PushIfNotDone(a.k6.vu.Context(), state.Samples, sample)
+
+	return pass, nil
+}
+
+// exportValue returns the exported value, with the ArrayBuffers exported to
+// their bytes.
+func exportValue(v sobek.Value) any {
+	if common.IsNullish(v) {
+		return nil
+	}
+	if ab, ok := v.Export().(sobek.ArrayBuffer); ok {
+		return ab.Bytes()
+	}
+	return v.Export()
+}
+
+// describe returns the JSON representation of the value, or its string
+// representation when it can't be converted to JSON, truncated to a readable
+// length.
+func describe(v sobek.Value) string {
+	if v == nil || sobek.IsUndefined(v) {
+		return "undefined"
+	}
+	b, err := json.Marshal(v.Export())
+	if err != nil {
+		return truncate(v.String())
+	}
+	return truncate(string(b))
+}
+
+func truncate(s string) string {
+	if len(s) <= maxActualValueLength {
+		return s
+	}
+	return strings.ToValidUTF8(s[:maxActualValueLength], "") + "..."
+}
+
+// jsonEqual returns whether the values are equal, once both are converted to
+// JSON, so that the numbers are compared regardless of their Go types.
+func jsonEqual(a, b any) bool {
+	normalize := func(v any) (any, bool) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, false
+		}
+		var normalized any
+		if err := json.Unmarshal(data, &normalized); err != nil {
+			return nil, false
+		}
+		return normalized, true
+	}
+
+	na, ok := normalize(a)
+	if !ok {
+		return false
+	}
+	nb, ok := normalize(b)
+	if !ok {
+		return false
+	}
+	return reflect.DeepEqual(na, nb)
+}
@@ -95,9 +95,8 @@ const parseSharedArrayNamePrefix = "csv.parse."
 // Parse parses the provided CSV file, and returns a promise that resolves to a shared array
 // containing the parsed data.
 func (mi *ModuleInstance) Parse(file sobek.Value, options sobek.Value) *sobek.Promise {
-	promise, resolve, reject := promises.New(mi.vu)
-
 	rt := mi.vu.Runtime()
+	promise, resolve, reject := rt.NewPromise()
 
 	// 1. Make sure the Sobek object is a fs.File (sobek operation)
 	var fileObj fs.File
@@ -122,6 +121,7 @@ func (mi *ModuleInstance) Parse(file sobek.Value, options sobek.Value) *sobek.Pr
 		return promise
 	}
 
+	callback := mi.vu.RegisterCallback()
 	go func() {
 		underlyingSharedArrayName := parseSharedArrayNamePrefix + strconv.Itoa(time.Now().Nanosecond())
 
@@ -130,7 +130,26 @@ func (mi *ModuleInstance) Parse(file sobek.Value, options sobek.Value) *sobek.Pr
 		// it multiple times.
 		//
 		// As such we hold a single instance of it in the RootModule, and we use it to create the shared array.
-		resolve(mi.RootModule.dataModuleInstance.NewSharedArrayFrom(mi.vu.Runtime(), underlyingSharedArrayName, r))
+		//
+		// The records are read here, off the event loop, but the shared array's JS object can only
+		// be built on it, so we wrap it in the callback.
+		err := mi.RootModule.dataModuleInstance.CreateSharedArrayFrom(underlyingSharedArrayName, recordReader{r})
+
+		callback(func() error {
+			if err != nil {
+				reject(fmt.Errorf("failed to parse the CSV file; reason: %w", err))
+				return nil
+			}
+
+			sharedArray, err := mi.RootModule.dataModuleInstance.WrapSharedArray(rt, underlyingSharedArrayName)
+			if err != nil {
+				reject(fmt.Errorf("failed to parse the CSV file; reason: %w", err))
+				return nil
+			}
+
+			resolve(sharedArray)
+			return nil
+		})
 	}()
 
 	return promise
@@ -192,14 +211,14 @@ func (p *Parser) Next() *sobek.Promise {
 	promise, resolve, reject := promises.New(p.vu)
 
 	go func() {
-		var records []string
+		var records []any
 		var done bool
 		var err error
 
-		records, err = p.reader.Read()
+		records, err = p.reader.ReadRecord()
 		if err != nil {
 			if errors.Is(err, io.EOF) {
-				resolve(parseResult{Done: true, Value: []string{}})
+				resolve(parseResult{Done: true, Value: []any{}})
 				return
 			}
 
@@ -222,7 +241,17 @@ type parseResult struct {
 	Done bool `js:"done"`
 
 	// Value holds the line's records value.
-	Value []string `js:"value"`
+	Value []any `js:"value"`
+}
+
+// recordReader adapts a [Reader] to the data module's ValueReader interface, so
+// that the shared arrays hold the converted and filtered records.
+type recordReader struct {
+	*Reader
+}
+
+func (r recordReader) Read() (any, error) {
+	return r.ReadRecord()
 }
 
 // options holds options used to configure CSV parsing when utilizing the module.
@@ -243,6 +272,19 @@ type options struct {
 
 	// ToLine indicates the line at which to stop reading the CSV file (inclusive).
 	ToLine null.Int `js:"toLine"`
+
+	// Schema holds the types the fields of the columns are converted to, by
+	// column name or index. Columns can only be named by the first line when
+	// it is skipped.
+	//
+	// The date columns are converted to ISO 8601 strings in UTC, not to Date
+	// objects, and their format is a Go layout, e.g. "2006-01-02", rather than
+	// a pattern like "YYYY-MM-DD".
+	Schema map[string]columnSchema `js:"schema"`
+
+	// Filter holds the conditions the fields of the columns must meet for
+	// their rows to be read, by column name or index.
+	Filter map[string][]condition `js:"filter"`
 }
 
 // newDefaultParserOptions creates a new options instance with default values.
@@ -284,6 +326,22 @@ func newParserOptionsFrom(obj *sobek.Object) (options, error) {
 		options.ToLine = null.IntFrom(v.ToInteger())
 	}
 
+	if v := obj.Get("schema"); !common.IsNullish(v) {
+		schema, err := parseSchema(v.Export())
+		if err != nil {
+			return options, err
+		}
+		options.Schema = schema
+	}
+
+	if v := obj.Get("filter"); !common.IsNullish(v) {
+		filter, err := parseFilter(v.Export())
+		if err != nil {
+			return options, err
+		}
+		options.Filter = filter
+	}
+
 	if options.FromLine.Valid && options.ToLine.Valid && options.FromLine.Int64 >= options.ToLine.Int64 {
 		return options, fmt.Errorf("fromLine must be less than or equal to toLine")
 	}
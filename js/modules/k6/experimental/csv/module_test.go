@@ -529,6 +529,103 @@ func TestParse(t *testing.T) {
 	})
 }
 
+func TestParseSchema(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parse stores the typed and filtered records and succeeds", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := newConfiguredRuntime(t)
+		require.NoError(t, err)
+
+		// Ensure the testdata.csv file is present on the test filesystem.
+		r.VU.InitEnvField.FileSystems["file"] = newTestFs(t, func(fs fsext.Fs) error {
+			return fsext.WriteFile(fs, testFilePath, []byte(csvTestData), 0o644)
+		})
+
+		_, err = r.RunOnEventLoop(wrapInAsyncLambda(fmt.Sprintf(`
+			const file = await fs.open(%q);
+			const csvRecords = await csv.parse(file, {
+				skipFirstLine: true,
+				schema: {
+					born: 'integer',
+					died: { type: 'integer', nullable: true },
+				},
+				filter: {
+					born: { gte: 1900 },
+				},
+			});
+
+			if (csvRecords.length !== 5) {
+				throw new Error("Expected 5 records, but got " + csvRecords.length);
+			}
+
+			const wantRecord = ["Dorman", "Avner", "Avner Dorman", 1975, null, "1975–"];
+			if (JSON.stringify(csvRecords[0]) !== JSON.stringify(wantRecord)) {
+				throw new Error("Unexpected first record " + JSON.stringify(csvRecords[0]));
+			}
+		`, testFilePath)))
+
+		require.NoError(t, err)
+	})
+
+	t.Run("next rejects the invalid fields with their position", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := newConfiguredRuntime(t)
+		require.NoError(t, err)
+
+		// Ensure the testdata.csv file is present on the test filesystem.
+		r.VU.InitEnvField.FileSystems["file"] = newTestFs(t, func(fs fsext.Fs) error {
+			return fsext.WriteFile(fs, testFilePath, []byte(csvTestData), 0o644)
+		})
+
+		_, err = r.RunOnEventLoop(wrapInAsyncLambda(fmt.Sprintf(`
+			const file = await fs.open(%q);
+			const parser = new csv.Parser(file, {
+				skipFirstLine: true,
+				schema: { died: 'integer' },
+			});
+
+			const { value } = await parser.next();
+			if (value[4] !== 1757) {
+				throw new Error("Expected died to be 1757, but got " + value[4]);
+			}
+
+			try {
+				await parser.next();
+			} catch (err) {
+				if (!String(err).includes('line 3, column 32: field "died": empty field')) {
+					throw new Error("Unexpected error " + err);
+				}
+				return;
+			}
+			throw new Error("Expected an error for the empty died field");
+		`, testFilePath)))
+
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid schema option fails", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := newConfiguredRuntime(t)
+		require.NoError(t, err)
+
+		// Ensure the testdata.csv file is present on the test filesystem.
+		r.VU.InitEnvField.FileSystems["file"] = newTestFs(t, func(fs fsext.Fs) error {
+			return fsext.WriteFile(fs, testFilePath, []byte(csvTestData), 0o644)
+		})
+
+		_, err = r.RunOnEventLoop(wrapInAsyncLambda(fmt.Sprintf(`
+			const file = await fs.open(%q);
+			new csv.Parser(file, { schema: { born: 'decimal' } });
+		`, testFilePath)))
+
+		require.ErrorContains(t, err, `unsupported type "decimal" for the "born" column`)
+	})
+}
+
 const initGlobals = `
 	globalThis.fs = require("k6/experimental/fs");
 	globalThis.csv = require("k6/experimental/csv");
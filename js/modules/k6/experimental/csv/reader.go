@@ -4,6 +4,7 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
+	"strconv"
 	"sync/atomic"
 )
 
@@ -18,6 +19,15 @@ type Reader struct {
 
 	// options holds the reader's options.
 	options options
+
+	// header holds the first line, when it is skipped, which names the columns.
+	header []string
+
+	// schema holds the schema of the columns, by index.
+	schema map[int]columnSchema
+
+	// filter holds the conditions of the columns, by index.
+	filter map[int][]condition
 }
 
 // NewReaderFrom creates a new CSV reader from the provided io.Reader.
@@ -74,28 +84,94 @@ func NewReaderFrom(r io.Reader, options options) (*Reader, error) {
 
 	// If the user wants to skip the first line, we consume and discard it.
 	if skipFirstLineSet && (!fromLineSet || options.FromLine.Int64 == 0) {
-		_, err := csvParser.Read()
+		header, err := csvParser.Read()
 		if err != nil {
 			return nil, fmt.Errorf("failed to skip the first line; reason: %w", err)
 		}
 
+		reader.header = header
 		reader.currentLine.Add(1)
 	}
 
 	if fromLineSet && options.FromLine.Int64 > 0 {
 		// We skip lines until we reach the specified line.
 		for reader.currentLine.Load() < options.FromLine.Int64 {
-			_, err := csvParser.Read()
+			record, err := csvParser.Read()
 			if err != nil {
 				return nil, fmt.Errorf("failed to skip lines until line %d; reason: %w", options.FromLine.Int64, err)
 			}
+			if skipFirstLineSet && reader.currentLine.Load() == 0 {
+				reader.header = record
+			}
 			reader.currentLine.Add(1)
 		}
 	}
 
+	if err := reader.resolveColumns(); err != nil {
+		return nil, err
+	}
+
 	return reader, nil
 }
 
+// resolveColumns resolves the columns of the schema and filter options, which
+// are either names of the header or indexes, to their index.
+func (r *Reader) resolveColumns() error {
+	r.schema = make(map[int]columnSchema, len(r.options.Schema))
+	for column, col := range r.options.Schema {
+		index, err := r.columnIndex(column)
+		if err != nil {
+			return fmt.Errorf("invalid 'schema' option; reason: %w", err)
+		}
+		r.schema[index] = col
+	}
+
+	r.filter = make(map[int][]condition, len(r.options.Filter))
+	for column, conditions := range r.options.Filter {
+		index, err := r.columnIndex(column)
+		if err != nil {
+			return fmt.Errorf("invalid 'filter' option; reason: %w", err)
+		}
+		for _, c := range conditions {
+			c, err := c.withOperands(r.schema[index])
+			if err != nil {
+				return fmt.Errorf("invalid 'filter' option for the %q column; reason: %w", column, err)
+			}
+			r.filter[index] = append(r.filter[index], c)
+		}
+	}
+
+	return nil
+}
+
+// columnIndex returns the index of the column, which is either a name of the
+// header or an index.
+func (r *Reader) columnIndex(column string) (int, error) {
+	for i, name := range r.header {
+		if name == column {
+			return i, nil
+		}
+	}
+
+	index, err := strconv.Atoi(column)
+	if err != nil || index < 0 {
+		if r.header == nil {
+			return 0, fmt.Errorf("unknown column %q, columns can only be named when the first line is skipped", column)
+		}
+		return 0, fmt.Errorf("unknown column %q", column)
+	}
+	return index, nil
+}
+
+// columnName returns the name of the column for the errors, which is the one
+// of the header if any, or its index.
+func (r *Reader) columnName(index int) string {
+	if index < len(r.header) {
+		return strconv.Quote(r.header[index])
+	}
+	return strconv.Itoa(index)
+}
+
 func (r *Reader) Read() ([]string, error) {
 	toLineSet := r.options.ToLine.Valid
 
@@ -113,3 +189,50 @@ func (r *Reader) Read() ([]string, error) {
 
 	return records, nil
 }
+
+// ReadRecord reads the next record meeting the conditions of the filter, with
+// its fields converted according to the schema. The fields without a schema are
+// left as strings.
+//
+// A conversion error points at the line and column of the field in the input.
+func (r *Reader) ReadRecord() ([]any, error) {
+	for {
+		fields, err := r.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		record := make([]any, len(fields))
+		for i, field := range fields {
+			col, ok := r.schema[i]
+			if !ok {
+				record[i] = field
+				continue
+			}
+			if record[i], err = col.convert(field); err != nil {
+				line, column := r.csv.FieldPos(i)
+				return nil, fmt.Errorf("line %d, column %d: field %s: %w", line, column, r.columnName(i), err)
+			}
+		}
+
+		if r.matches(record) {
+			return record, nil
+		}
+	}
+}
+
+// matches returns whether the record meets all the conditions of the filter.
+func (r *Reader) matches(record []any) bool {
+	for index, conditions := range r.filter {
+		var value any
+		if index < len(record) {
+			value = record[index]
+		}
+		for _, c := range conditions {
+			if !c.matches(value) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -1,6 +1,7 @@
 package csv
 
 import (
+	"errors"
 	"io"
 	"strings"
 	"testing"
@@ -170,3 +171,122 @@ func TestReader_Read(t *testing.T) {
 		require.ErrorIs(t, err, io.EOF)
 	})
 }
+
+func TestReader_ReadRecord(t *testing.T) {
+	t.Parallel()
+
+	const csvTestData = "name,age,score,admin,joined\n" +
+		"alice,31,9.5,true,2024-01-02\n" +
+		"bob,,7,false,2023-06-30\n" +
+		"carol,45,,1,2022-12-01\n"
+
+	newSchema := func(t *testing.T, schema map[string]any) map[string]columnSchema {
+		t.Helper()
+		s, err := parseSchema(schema)
+		require.NoError(t, err)
+		return s
+	}
+
+	t.Run("schema converts the fields and succeeds", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := NewReaderFrom(strings.NewReader(csvTestData), options{
+			SkipFirstLine: true,
+			Schema: newSchema(t, map[string]any{
+				"age":    map[string]any{"type": "integer", "nullable": true},
+				"2":      map[string]any{"type": "number", "default": "0"},
+				"admin":  "boolean",
+				"joined": map[string]any{"type": "date", "format": "2006-01-02"},
+			}),
+		})
+		require.NoError(t, err)
+
+		var records [][]any
+		for {
+			record, err := r.ReadRecord()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			records = append(records, record)
+		}
+
+		assert.Equal(t, [][]any{
+			{"alice", int64(31), 9.5, true, "2024-01-02T00:00:00.000Z"},
+			{"bob", nil, float64(7), false, "2023-06-30T00:00:00.000Z"},
+			{"carol", int64(45), float64(0), true, "2022-12-01T00:00:00.000Z"},
+		}, records)
+	})
+
+	t.Run("filter skips the rows not meeting its conditions and succeeds", func(t *testing.T) {
+		t.Parallel()
+
+		filter, err := parseFilter(map[string]any{
+			"age":   map[string]any{"gte": int64(30), "lt": "50"},
+			"admin": true,
+		})
+		require.NoError(t, err)
+
+		r, err := NewReaderFrom(strings.NewReader(csvTestData), options{
+			SkipFirstLine: true,
+			Schema: newSchema(t, map[string]any{
+				"age":   map[string]any{"type": "integer", "nullable": true},
+				"admin": "boolean",
+			}),
+			Filter: filter,
+		})
+		require.NoError(t, err)
+
+		record, err := r.ReadRecord()
+		require.NoError(t, err)
+		assert.Equal(t, "alice", record[0])
+
+		record, err = r.ReadRecord()
+		require.NoError(t, err)
+		assert.Equal(t, "carol", record[0])
+
+		_, err = r.ReadRecord()
+		require.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("invalid field reports its line and column and fails", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := NewReaderFrom(strings.NewReader(csvTestData), options{
+			SkipFirstLine: true,
+			Schema:        newSchema(t, map[string]any{"age": "integer"}),
+		})
+		require.NoError(t, err)
+
+		_, err = r.ReadRecord()
+		require.NoError(t, err)
+
+		_, err = r.ReadRecord()
+		require.ErrorIs(t, err, errEmptyField)
+		assert.ErrorContains(t, err, `line 3, column 5: field "age"`)
+	})
+
+	t.Run("non-finite number reports its line and column and fails", func(t *testing.T) {
+		t.Parallel()
+
+		for _, number := range []string{"NaN", "Inf", "-Infinity", "1e400"} {
+			r, err := NewReaderFrom(strings.NewReader("name,score\nalice,"+number+"\n"), options{
+				SkipFirstLine: true,
+				Schema:        newSchema(t, map[string]any{"score": "number"}),
+			})
+			require.NoError(t, err)
+
+			_, err = r.ReadRecord()
+			assert.ErrorContains(t, err, `line 2, column 7: field "score": invalid number "`+number+`"`)
+		}
+	})
+
+	t.Run("unknown column fails", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewReaderFrom(strings.NewReader(csvTestData), options{
+			Schema: newSchema(t, map[string]any{"age": "integer"}),
+		})
+		assert.ErrorContains(t, err, `unknown column "age"`)
+	})
+}
@@ -0,0 +1,305 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The types a column of the schema can be converted to.
+const (
+	columnTypeString  = "string"
+	columnTypeNumber  = "number"
+	columnTypeInteger = "integer"
+	columnTypeBoolean = "boolean"
+	columnTypeDate    = "date"
+)
+
+// isoDateFormat is the format of the dates once converted, which is the one of
+// JavaScript's Date.prototype.toISOString, so that they can be passed to the
+// Date constructor.
+const isoDateFormat = "2006-01-02T15:04:05.000Z"
+
+// columnSchema describes how the fields of a column are converted.
+type columnSchema struct {
+	// Type is the type the fields are converted to.
+	Type string
+
+	// Nullable indicates whether the empty fields are converted to null.
+	Nullable bool
+
+	// Default holds the value of the empty fields, if HasDefault is set.
+	Default    any
+	HasDefault bool
+
+	// Format holds the Go layout of the dates, e.g. "2006-01-02", which is
+	// RFC 3339 by default. The dates aren't converted to Date objects, but to
+	// ISO 8601 strings in UTC, which can be passed to the Date constructor.
+	Format string
+}
+
+// parseSchema returns the schema of the columns from the exported value of the
+// schema option, which maps the column names or indexes to either their type,
+// or an object with their type, nullable, default and format properties.
+func parseSchema(exported any) (map[string]columnSchema, error) {
+	columns, ok := exported.(map[string]any)
+	if !ok {
+		return nil, errors.New("schema must be an object")
+	}
+
+	schema := make(map[string]columnSchema, len(columns))
+	for column, value := range columns {
+		var col columnSchema
+		switch v := value.(type) {
+		case string:
+			col.Type = v
+		case map[string]any:
+			typ, ok := v["type"].(string)
+			if !ok {
+				return nil, fmt.Errorf("the type of the %q column must be a string", column)
+			}
+			col.Type = typ
+			col.Nullable, _ = v["nullable"].(bool)
+			col.Format, _ = v["format"].(string)
+			col.Default, col.HasDefault = v["default"]
+		default:
+			return nil, fmt.Errorf("the schema of the %q column must be a type or an object", column)
+		}
+
+		switch col.Type {
+		case columnTypeString, columnTypeNumber, columnTypeInteger, columnTypeBoolean:
+		case columnTypeDate:
+			if col.Format == "" {
+				col.Format = time.RFC3339
+			}
+		default:
+			return nil, fmt.Errorf("unsupported type %q for the %q column", col.Type, column)
+		}
+
+		if defaultValue, ok := col.Default.(string); ok && col.HasDefault {
+			converted, err := col.convert(defaultValue)
+			if err != nil {
+				return nil, fmt.Errorf("invalid default value for the %q column; reason: %w", column, err)
+			}
+			col.Default = converted
+		}
+
+		schema[column] = col
+	}
+
+	return schema, nil
+}
+
+var errEmptyField = errors.New("empty field")
+
+// convert returns the field converted to the type of the column.
+func (col columnSchema) convert(field string) (any, error) {
+	if field == "" {
+		switch {
+		case col.HasDefault:
+			return col.Default, nil
+		case col.Nullable:
+			return nil, nil
+		case col.Type == columnTypeString:
+			return field, nil
+		default:
+			return nil, fmt.Errorf("%w, expected type %s", errEmptyField, col.Type)
+		}
+	}
+
+	var (
+		value any
+		err   error
+	)
+	switch col.Type {
+	case columnTypeNumber:
+		var number float64
+		number, err = strconv.ParseFloat(strings.TrimSpace(field), 64)
+		// NaN and the infinities can't be marshaled to the JSON of the shared arrays
+		if err == nil && (math.IsNaN(number) || math.IsInf(number, 0)) {
+			err = errors.New("non-finite number")
+		}
+		value = number
+	case columnTypeInteger:
+		value, err = strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+	case columnTypeBoolean:
+		value, err = strconv.ParseBool(strings.TrimSpace(field))
+	case columnTypeDate:
+		var date time.Time
+		date, err = time.Parse(col.Format, field)
+		value = date.UTC().Format(isoDateFormat)
+	default:
+		value = field
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", col.Type, field)
+	}
+
+	return value, nil
+}
+
+// The operators of the filter conditions.
+const (
+	filterEqual          = "eq"
+	filterNotEqual       = "ne"
+	filterGreater        = "gt"
+	filterGreaterOrEqual = "gte"
+	filterLess           = "lt"
+	filterLessOrEqual    = "lte"
+	filterIn             = "in"
+)
+
+// condition is a condition of the filter, that the fields of a column must
+// meet for their row to be read.
+type condition struct {
+	Operator string
+	Value    any
+}
+
+// parseFilter returns the conditions of the columns from the exported value of
+// the filter option, which maps the column names or indexes to either the value
+// of their fields, or an object of operators and values.
+func parseFilter(exported any) (map[string][]condition, error) {
+	columns, ok := exported.(map[string]any)
+	if !ok {
+		return nil, errors.New("filter must be an object")
+	}
+
+	filter := make(map[string][]condition, len(columns))
+	for column, value := range columns {
+		operators, ok := value.(map[string]any)
+		if !ok {
+			filter[column] = []condition{{Operator: filterEqual, Value: value}}
+			continue
+		}
+
+		for operator, operand := range operators {
+			switch operator {
+			case filterEqual, filterNotEqual, filterGreater, filterGreaterOrEqual, filterLess, filterLessOrEqual:
+			case filterIn:
+				if _, ok := operand.([]any); !ok {
+					return nil, fmt.Errorf("the %q operator of the %q column expects an array", operator, column)
+				}
+			default:
+				return nil, fmt.Errorf("unsupported operator %q for the %q column", operator, column)
+			}
+			filter[column] = append(filter[column], condition{Operator: operator, Value: operand})
+		}
+	}
+
+	return filter, nil
+}
+
+// withOperands returns the condition with its string operands converted to
+// the type of the column, so that they can be written as in the CSV.
+func (c condition) withOperands(col columnSchema) (condition, error) {
+	convert := func(operand any) (any, error) {
+		if s, ok := operand.(string); ok && col.Type != "" {
+			return col.convert(s)
+		}
+		return operand, nil
+	}
+
+	var err error
+	if operands, ok := c.Value.([]any); ok {
+		converted := make([]any, len(operands))
+		for i, operand := range operands {
+			if converted[i], err = convert(operand); err != nil {
+				return c, err
+			}
+		}
+		c.Value = converted
+		return c, nil
+	}
+
+	c.Value, err = convert(c.Value)
+	return c, err
+}
+
+// matches returns whether the value meets the condition.
+func (c condition) matches(value any) bool {
+	if c.Operator == filterIn {
+		for _, operand := range c.Value.([]any) { //nolint:forcetypeassert
+			if cmp, ok := compareValues(value, operand); ok && cmp == 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	cmp, ok := compareValues(value, c.Value)
+	if !ok {
+		return c.Operator == filterNotEqual
+	}
+
+	switch c.Operator {
+	case filterEqual:
+		return cmp == 0
+	case filterNotEqual:
+		return cmp != 0
+	case filterGreater:
+		return cmp > 0
+	case filterGreaterOrEqual:
+		return cmp >= 0
+	case filterLess:
+		return cmp < 0
+	default:
+		return cmp <= 0
+	}
+}
+
+// compareValues compares the values, and returns whether they are comparable,
+// which is the case of numbers, strings, booleans and nulls of the same kind.
+func compareValues(a, b any) (int, bool) {
+	if a == nil || b == nil {
+		return 0, a == nil && b == nil
+	}
+
+	if af, ok := toFloat(a); ok {
+		bf, ok := toFloat(b)
+		switch {
+		case !ok:
+			return 0, false
+		case af < bf:
+			return -1, true
+		case af > bf:
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+
+	switch av := a.(type) {
+	case string:
+		bv, ok := b.(string)
+		return strings.Compare(av, bv), ok
+	case bool:
+		bv, ok := b.(bool)
+		switch {
+		case !ok:
+			return 0, false
+		case av == bv:
+			return 0, true
+		case bv:
+			return -1, true
+		default:
+			return 1, true
+		}
+	default:
+		return 0, false
+	}
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
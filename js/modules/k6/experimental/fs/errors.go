@@ -37,6 +37,10 @@ const (
 
 	// EOFError is emitted when the end of a file has been reached.
 	EOFError
+
+	// AlreadyExistsError is emitted when a file or directory that is being
+	// created already exists.
+	AlreadyExistsError
 )
 
 // fsError represents a custom error object emitted by the fs module.
@@ -37,6 +37,10 @@ type FileInfo struct {
 
 	// Size holds the size of the file in bytes.
 	Size int64 `json:"size"`
+
+	// IsDirectory indicates whether it is a directory, which can only be
+	// the case in the output directory.
+	IsDirectory bool `json:"isDirectory" js:"isDirectory"`
 }
 
 // Read reads up to len(into) bytes into the provided byte slice.
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"sync"
 
 	"go.k6.io/k6/lib/fsext"
 
@@ -23,12 +24,16 @@ type (
 	// module for each VU.
 	RootModule struct {
 		cache *cache
+
+		outputOnce sync.Once
+		output     *outputDir
 	}
 
 	// ModuleInstance represents an instance of the fs module for a single VU.
 	ModuleInstance struct {
-		vu    modules.VU
-		cache *cache
+		vu     modules.VU
+		cache  *cache
+		output *outputDir
 	}
 )
 
@@ -47,7 +52,13 @@ func New() *RootModule {
 // NewModuleInstance implements the modules.Module interface and returns a new
 // instance of our module for the given VU.
 func (rm *RootModule) NewModuleInstance(vu modules.VU) modules.Instance {
-	return &ModuleInstance{vu: vu, cache: rm.cache}
+	rm.outputOnce.Do(func() {
+		if initEnv := vu.InitEnv(); initEnv != nil && initEnv.TestPreInitState != nil {
+			rm.output = newOutputDir(initEnv.RuntimeOptions)
+		}
+	})
+
+	return &ModuleInstance{vu: vu, cache: rm.cache, output: rm.output}
 }
 
 // Exports implements the modules.Module interface and returns the exports of
@@ -55,7 +66,13 @@ func (rm *RootModule) NewModuleInstance(vu modules.VU) modules.Instance {
 func (mi *ModuleInstance) Exports() modules.Exports {
 	return modules.Exports{
 		Named: map[string]any{
-			"open": mi.Open,
+			"open":    mi.Open,
+			"create":  mi.Create,
+			"write":   mi.Write,
+			"append":  mi.Append,
+			"mkdir":   mi.Mkdir,
+			"readdir": mi.Readdir,
+			"stat":    mi.Stat,
 			"SeekMode": map[string]any{
 				"Start":   SeekModeStart,
 				"Current": SeekModeCurrent,
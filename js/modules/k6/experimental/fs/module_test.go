@@ -13,6 +13,7 @@ import (
 	"go.k6.io/k6/lib"
 	"go.k6.io/k6/lib/fsext"
 	"go.k6.io/k6/metrics"
+	"gopkg.in/guregu/null.v3"
 )
 
 const testFileName = "bonjour.txt"
@@ -686,7 +687,16 @@ const initGlobals = `
 `
 
 func newConfiguredRuntime(t testing.TB) (*modulestest.Runtime, error) {
+	return newConfiguredRuntimeWithOutputDir(t, "")
+}
+
+// newConfiguredRuntimeWithOutputDir is like newConfiguredRuntime, but with the
+// provided output directory, if it isn't empty.
+func newConfiguredRuntimeWithOutputDir(t testing.TB, outputDir string) (*modulestest.Runtime, error) {
 	runtime := modulestest.NewRuntime(t)
+	if outputDir != "" {
+		runtime.VU.InitEnvField.RuntimeOptions.FSOutputDir = null.StringFrom(outputDir)
+	}
 
 	err := runtime.SetupModuleSystem(map[string]interface{}{"k6/experimental/fs": New()}, nil, compiler.New(runtime.VU.InitEnv().Logger))
 	if err != nil {
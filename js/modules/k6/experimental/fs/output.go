@@ -0,0 +1,256 @@
+package fs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	"github.com/grafana/sobek"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/js/promises"
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/lib/fsext"
+)
+
+// outputDir is the writable area of the module, shared by all the VUs.
+//
+// It is a directory of the local file system of the machine running the test,
+// and is used to produce artifacts during the test run. Unlike the files opened
+// with [ModuleInstance.Open], its content is neither cached nor included in the
+// archives, and its paths are always relative to the directory itself, even the
+// absolute ones, so that they can't escape it.
+//
+// It's configured with the --fs-output-dir flag of k6 run, or the
+// K6_FS_OUTPUT_DIR environment variable, and not with the script options,
+// since it's specific to the machine running the test. For the same reason,
+// it isn't included in archives, so it needs to be set again when running one,
+// and cloud test runs don't have one.
+type outputDir struct {
+	fs fsext.Fs
+
+	// once ensures that the directory is created, on the first operation
+	// rather than in the init context, which is also executed by commands
+	// like `k6 archive` or `k6 inspect`.
+	once sync.Once
+	err  error
+}
+
+// newOutputDir returns the output directory configured by the runtime
+// options, or nil if there is none.
+func newOutputDir(opts lib.RuntimeOptions) *outputDir {
+	if !opts.FSOutputDir.Valid || opts.FSOutputDir.String == "" {
+		return nil
+	}
+	return &outputDir{fs: fsext.NewBasePathFs(fsext.NewOsFs(), opts.FSOutputDir.String)}
+}
+
+// filesystem returns the file system of the output directory, creating the
+// latter if it doesn't exist yet.
+func (o *outputDir) filesystem() (fsext.Fs, error) {
+	o.once.Do(func() {
+		o.err = o.fs.MkdirAll(fsext.FilePathSeparator, 0o755)
+	})
+	return o.fs, o.err
+}
+
+// Create creates a new empty file in the output directory, and returns a
+// promise that will be rejected with an AlreadyExistsError if it exists.
+func (mi *ModuleInstance) Create(path sobek.Value) *sobek.Promise {
+	return mi.outputOperation("create", path, func(fs fsext.Fs, path string) (any, error) {
+		f, err := fs.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		return sobek.Undefined(), f.Close()
+	})
+}
+
+// Write writes the data, which can be a string, an ArrayBuffer or a typed
+// array, to a file of the output directory, replacing its content if it
+// exists. The returned promise resolves to the number of bytes written.
+func (mi *ModuleInstance) Write(path sobek.Value, data sobek.Value) *sobek.Promise {
+	return mi.writeFile("write", path, data, os.O_TRUNC)
+}
+
+// Append appends the data, which can be a string, an ArrayBuffer or a typed
+// array, to a file of the output directory, creating it if it doesn't exist.
+// The returned promise resolves to the number of bytes written.
+func (mi *ModuleInstance) Append(path sobek.Value, data sobek.Value) *sobek.Promise {
+	return mi.writeFile("append", path, data, os.O_APPEND)
+}
+
+func (mi *ModuleInstance) writeFile(name string, path, data sobek.Value, flag int) *sobek.Promise {
+	dataBytes, err := exportBytes(mi.vu.Runtime(), data)
+	if err != nil {
+		promise, _, reject := promises.New(mi.vu)
+		reject(newFsError(TypeError, name+"() failed; reason: the data argument "+err.Error()))
+		return promise
+	}
+
+	return mi.outputOperation(name, path, func(fs fsext.Fs, path string) (any, error) {
+		f, err := fs.OpenFile(path, os.O_WRONLY|os.O_CREATE|flag, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		n, err := f.Write(dataBytes)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		return n, err
+	})
+}
+
+// Mkdir creates a directory in the output directory. With the recursive
+// option, its missing parents are created too, and it's not an error if it
+// already exists.
+func (mi *ModuleInstance) Mkdir(path sobek.Value, options sobek.Value) *sobek.Promise {
+	var recursive bool
+	if !common.IsNullish(options) {
+		recursive = options.ToObject(mi.vu.Runtime()).Get("recursive").ToBoolean()
+	}
+
+	return mi.outputOperation("mkdir", path, func(fs fsext.Fs, path string) (any, error) {
+		if recursive {
+			return sobek.Undefined(), fs.MkdirAll(path, 0o755)
+		}
+		return sobek.Undefined(), fs.Mkdir(path, 0o755)
+	})
+}
+
+// Readdir returns a promise that will resolve to the [FileInfo] of the entries
+// of a directory of the output directory, sorted by name.
+func (mi *ModuleInstance) Readdir(path sobek.Value) *sobek.Promise {
+	return mi.outputOperation("readdir", path, func(fs fsext.Fs, path string) (any, error) {
+		entries, err := fsext.ReadDir(fs, path)
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]*FileInfo, len(entries))
+		for i, entry := range entries {
+			infos[i] = newFileInfo(entry)
+		}
+		return infos, nil
+	})
+}
+
+// Stat returns a promise that will resolve to the [FileInfo] of a file or a
+// directory of the output directory.
+func (mi *ModuleInstance) Stat(path sobek.Value) *sobek.Promise {
+	return mi.outputOperation("stat", path, func(fs fsext.Fs, path string) (any, error) {
+		info, err := fs.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		return newFileInfo(info), nil
+	})
+}
+
+// outputOperation executes the operation on the path of the output directory
+// asynchronously, and returns a promise that will resolve to its result.
+//
+// The operations are allowed only in the VU context, so that the init context
+// stays free of side effects.
+func (mi *ModuleInstance) outputOperation(
+	name string, path sobek.Value, operation func(fs fsext.Fs, path string) (any, error),
+) *sobek.Promise {
+	promise, resolve, reject := promises.New(mi.vu)
+
+	if mi.vu.State() == nil {
+		reject(newFsError(ForbiddenError, name+"() failed; reason: the output directory is accessible only in the VU context"))
+		return promise
+	}
+
+	if mi.output == nil {
+		reject(newFsError(ForbiddenError, name+"() failed; reason: no output directory is configured, "+
+			"set it with the --fs-output-dir flag or the K6_FS_OUTPUT_DIR environment variable"))
+		return promise
+	}
+
+	if common.IsNullish(path) {
+		reject(newFsError(TypeError, name+"() failed; reason: path cannot be null or undefined"))
+		return promise
+	}
+
+	pathStr := path.String()
+	if pathStr == "" {
+		reject(newFsError(TypeError, name+"() failed; reason: path cannot be empty"))
+		return promise
+	}
+
+	go func() {
+		fs, err := mi.output.filesystem()
+		if err != nil {
+			reject(fmt.Errorf("%s() failed, unable to create the output directory; reason: %w", name, err))
+			return
+		}
+
+		// The path is cleaned as an absolute one, so that `..` can't be
+		// used to get out of the output directory.
+		result, err := operation(fs, filepath.Clean(fsext.FilePathSeparator+pathStr))
+		if err != nil {
+			reject(newOutputError(name, pathStr, err))
+			return
+		}
+
+		resolve(result)
+	}()
+
+	return promise
+}
+
+// newOutputError converts the error of an operation on the output directory
+// to its fsError counterpart, when there is one.
+func newOutputError(name, path string, err error) error {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return newFsError(NotFoundError, fmt.Sprintf("%s() failed; reason: no such file or directory %q", name, path))
+	case errors.Is(err, fs.ErrExist):
+		return newFsError(AlreadyExistsError, fmt.Sprintf("%s() failed; reason: %q already exists", name, path))
+	case errors.Is(err, syscall.EISDIR):
+		return newFsError(InvalidResourceError, fmt.Sprintf("%s() failed; reason: %q is a directory", name, path))
+	case errors.Is(err, syscall.ENOTDIR):
+		return newFsError(InvalidResourceError, fmt.Sprintf("%s() failed; reason: %q is not a directory", name, path))
+	default:
+		return fmt.Errorf("%s() failed; reason: %w", name, err)
+	}
+}
+
+func newFileInfo(info fs.FileInfo) *FileInfo {
+	return &FileInfo{Name: info.Name(), Size: info.Size(), IsDirectory: info.IsDir()}
+}
+
+// exportBytes returns a copy of the bytes of a string, an ArrayBuffer or a
+// typed array, so that they can be used outside of the event loop.
+func exportBytes(rt *sobek.Runtime, v sobek.Value) ([]byte, error) {
+	if common.IsNullish(v) {
+		return nil, errors.New("cannot be null or undefined")
+	}
+
+	switch data := v.Export().(type) {
+	case string:
+		return []byte(data), nil
+	case sobek.ArrayBuffer:
+		return bytes.Clone(data.Bytes()), nil
+	}
+
+	obj := v.ToObject(rt)
+	if buffer := obj.Get("buffer"); buffer != nil {
+		if ab, ok := buffer.Export().(sobek.ArrayBuffer); ok {
+			// the properties can be overridden, so they aren't trusted
+			offset, length := obj.Get("byteOffset").ToInteger(), obj.Get("byteLength").ToInteger()
+			b := ab.Bytes()
+			if offset < 0 || length < 0 || offset > int64(len(b)) || length > int64(len(b))-offset {
+				return nil, errors.New("has a byteOffset and a byteLength outside of its buffer")
+			}
+			return bytes.Clone(b[offset : offset+length]), nil
+		}
+	}
+
+	return nil, errors.New("must be a string, an ArrayBuffer or a typed array")
+}
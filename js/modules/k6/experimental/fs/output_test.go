@@ -0,0 +1,165 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.k6.io/k6/js/modulestest"
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/metrics"
+)
+
+func TestOutput(t *testing.T) {
+	t.Parallel()
+
+	newVURuntime := func(t *testing.T, outputDir string) *modulestest.Runtime {
+		t.Helper()
+
+		runtime, err := newConfiguredRuntimeWithOutputDir(t, outputDir)
+		require.NoError(t, err)
+
+		runtime.MoveToVUContext(&lib.State{
+			Tags: lib.NewVUStateTags(metrics.NewRegistry().RootTagSet()),
+		})
+		return runtime
+	}
+
+	t.Run("writing and appending to files should succeed", func(t *testing.T) {
+		t.Parallel()
+
+		outputDir := filepath.Join(t.TempDir(), "output")
+		runtime := newVURuntime(t, outputDir)
+
+		_, err := runtime.RunOnEventLoop(wrapInAsyncLambda(`
+			await fs.mkdir('dumps/failed', { recursive: true });
+
+			let n = await fs.write('dumps/failed/1.txt', 'Bonjour');
+			if (n !== 7) {
+				throw 'unexpected number of bytes written ' + n;
+			}
+
+			n = await fs.append('dumps/failed/1.txt', new Uint8Array([44, 32, 108, 101]).subarray(1));
+			if (n !== 3) {
+				throw 'unexpected number of bytes appended ' + n;
+			}
+
+			await fs.append('/tokens.txt', 'a\n');
+			await fs.append('../../tokens.txt', 'b\n');
+			await fs.create('empty.txt');
+		`))
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(outputDir, "dumps", "failed", "1.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "Bonjour le", string(data))
+
+		data, err = os.ReadFile(filepath.Join(outputDir, "tokens.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "a\nb\n", string(data))
+
+		data, err = os.ReadFile(filepath.Join(outputDir, "empty.txt"))
+		require.NoError(t, err)
+		assert.Empty(t, data)
+	})
+
+	t.Run("listing and inspecting files should succeed", func(t *testing.T) {
+		t.Parallel()
+
+		outputDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(outputDir, "b.txt"), []byte("Bonjour"), 0o644))
+		require.NoError(t, os.Mkdir(filepath.Join(outputDir, "a"), 0o755))
+		runtime := newVURuntime(t, outputDir)
+
+		_, err := runtime.RunOnEventLoop(wrapInAsyncLambda(`
+			const entries = await fs.readdir('/');
+			const names = entries.map((e) => e.name + ':' + e.isDirectory).join(',');
+			if (names !== 'a:true,b.txt:false') {
+				throw 'unexpected entries ' + names;
+			}
+
+			const info = await fs.stat('b.txt');
+			if (info.name !== 'b.txt' || info.size !== 7 || info.isDirectory) {
+				throw 'unexpected file info ' + JSON.stringify(info);
+			}
+		`))
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid operations should fail", func(t *testing.T) {
+		t.Parallel()
+
+		outputDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(outputDir, "file.txt"), nil, 0o644))
+		runtime := newVURuntime(t, outputDir)
+
+		_, err := runtime.RunOnEventLoop(wrapInAsyncLambda(`
+			const expectError = async (name, fn) => {
+				try {
+					await fn();
+				} catch (err) {
+					if (err.name !== name) {
+						throw 'unexpected error: ' + err;
+					}
+					return;
+				}
+				throw 'expected ' + name;
+			};
+
+			await expectError('AlreadyExistsError', () => fs.create('file.txt'));
+			await expectError('AlreadyExistsError', () => fs.mkdir('file.txt'));
+			await expectError('NotFoundError', () => fs.write('missing/file.txt', 'data'));
+			await expectError('NotFoundError', () => fs.stat('missing.txt'));
+			await expectError('NotFoundError', () => fs.mkdir('missing/dir'));
+			await expectError('InvalidResourceError', () => fs.readdir('file.txt'));
+			await expectError('TypeError', () => fs.write('file.txt', null));
+			await expectError('TypeError', () => fs.write('file.txt', 42));
+			const buffer = new ArrayBuffer(2);
+			await expectError('TypeError', () => fs.write('file.txt', { buffer, byteOffset: 1, byteLength: 5 }));
+			await expectError('TypeError', () => fs.write('file.txt', { buffer, byteOffset: -1, byteLength: 1 }));
+			await expectError('TypeError', () => fs.stat(''));
+		`))
+		assert.NoError(t, err)
+	})
+
+	t.Run("operations without an output directory should fail", func(t *testing.T) {
+		t.Parallel()
+
+		runtime := newVURuntime(t, "")
+
+		_, err := runtime.RunOnEventLoop(wrapInAsyncLambda(`
+			try {
+				await fs.write('file.txt', 'data');
+				throw 'unexpected promise resolution';
+			} catch (err) {
+				if (err.name !== 'ForbiddenError') {
+					throw 'unexpected error: ' + err;
+				}
+			}
+		`))
+		assert.NoError(t, err)
+	})
+
+	t.Run("operations in the init context should fail", func(t *testing.T) {
+		t.Parallel()
+
+		outputDir := filepath.Join(t.TempDir(), "output")
+		runtime, err := newConfiguredRuntimeWithOutputDir(t, outputDir)
+		require.NoError(t, err)
+
+		_, err = runtime.RunOnEventLoop(wrapInAsyncLambda(`
+			try {
+				await fs.write('file.txt', 'data');
+				throw 'unexpected promise resolution';
+			} catch (err) {
+				if (err.name !== 'ForbiddenError') {
+					throw 'unexpected error: ' + err;
+				}
+			}
+		`))
+		assert.NoError(t, err)
+		assert.NoDirExists(t, outputDir)
+	})
+}
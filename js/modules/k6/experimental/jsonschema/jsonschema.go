@@ -0,0 +1,65 @@
+// Package jsonschema provides a k6 module that validates JSON values against
+// JSON Schemas, which are compiled once in the init context.
+package jsonschema
+
+import (
+	"errors"
+
+	"github.com/grafana/sobek"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/js/modules"
+	"go.k6.io/k6/js/modules/k6/internal/jsonschema"
+)
+
+type (
+	// RootModule is the global module instance that will create instances of our
+	// module for each VU.
+	RootModule struct{}
+
+	// ModuleInstance represents an instance of the jsonschema module for a single VU.
+	ModuleInstance struct {
+		vu modules.VU
+	}
+)
+
+var (
+	_ modules.Module   = &RootModule{}
+	_ modules.Instance = &ModuleInstance{}
+)
+
+// New returns a pointer to a new [RootModule] instance.
+func New() *RootModule {
+	return &RootModule{}
+}
+
+// NewModuleInstance implements the modules.Module interface and returns a new
+// instance of our module for the given VU.
+func (*RootModule) NewModuleInstance(vu modules.VU) modules.Instance {
+	return &ModuleInstance{vu: vu}
+}
+
+// Exports implements the modules.Module interface and returns the exports of
+// our module.
+func (mi *ModuleInstance) Exports() modules.Exports {
+	return modules.Exports{
+		Named: map[string]any{
+			"compile": mi.Compile,
+		},
+	}
+}
+
+// Compile compiles the schema, which can be an object or a JSON string, and
+// returns a [jsonschema.Validator] for it. Schemas are compiled with the draft 2020-12,
+// unless they declare another one with $schema.
+func (mi *ModuleInstance) Compile(schema sobek.Value) (*jsonschema.Validator, error) {
+	if mi.vu.State() != nil {
+		return nil, errors.New("compile() must be called in the init context")
+	}
+
+	if common.IsNullish(schema) {
+		return nil, errors.New("compile() requires a schema")
+	}
+
+	return jsonschema.NewValidator(mi.vu, schema.Export())
+}
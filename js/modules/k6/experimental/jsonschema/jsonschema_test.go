@@ -0,0 +1,129 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.k6.io/k6/js/modulestest"
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/metrics"
+)
+
+func newTestRuntime(t *testing.T) (*modulestest.Runtime, chan metrics.SampleContainer) {
+	t.Helper()
+
+	runtime := modulestest.NewRuntime(t)
+	m, ok := New().NewModuleInstance(runtime.VU).(*ModuleInstance)
+	require.True(t, ok)
+	require.NoError(t, runtime.VU.Runtime().Set("jsonschema", m.Exports().Named))
+
+	return runtime, make(chan metrics.SampleContainer, 100)
+}
+
+func moveToVUContext(runtime *modulestest.Runtime, samples chan metrics.SampleContainer) {
+	registry := metrics.NewRegistry()
+	runtime.MoveToVUContext(&lib.State{
+		Samples:        samples,
+		BuiltinMetrics: metrics.RegisterBuiltinMetrics(registry),
+		Tags:           lib.NewVUStateTags(registry.RootTagSet().With("tag-vu", "mytag")),
+	})
+}
+
+func TestValidator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Validate", func(t *testing.T) {
+		t.Parallel()
+
+		runtime, samples := newTestRuntime(t)
+		_, err := runtime.VU.Runtime().RunString(`
+			var validator = jsonschema.compile({
+				$defs: {
+					id: { type: "integer", minimum: 1 },
+				},
+				type: "object",
+				required: ["id", "tags"],
+				properties: {
+					id: { $ref: "#/$defs/id" },
+					tags: { type: "array", items: { type: "string" }, uniqueItems: true },
+				},
+				additionalProperties: false,
+			});
+		`)
+		require.NoError(t, err)
+
+		moveToVUContext(runtime, samples)
+		_, err = runtime.VU.Runtime().RunString(`
+			var result = validator.validate({ id: 1, tags: ["a", "b"] });
+			if (!result.valid || result.errors.length !== 0) {
+				throw new Error("Expected the value to be valid, but got: " + JSON.stringify(result));
+			}
+
+			result = validator.validate({ id: 0, tags: ["a", 1], extra: true });
+			if (result.valid) {
+				throw new Error("Expected the value to be invalid");
+			}
+
+			var errors = result.errors.map((e) => e.path + " " + e.schemaPath).sort();
+			var want = [
+				" /additionalProperties",
+				"/id /properties/id/$ref/minimum",
+				"/tags/1 /properties/tags/items/type",
+			];
+			if (JSON.stringify(errors) !== JSON.stringify(want)) {
+				throw new Error("Unexpected errors: " + JSON.stringify(result.errors));
+			}
+		`)
+		require.NoError(t, err)
+
+		var validations []float64
+		for _, sc := range metrics.GetBufferedSamples(samples) {
+			for _, s := range sc.GetSamples() {
+				assert.Equal(t, metrics.JSONSchemaFailedName, s.Metric.Name)
+				assert.Equal(t, map[string]string{"tag-vu": "mytag"}, s.Tags.Map())
+				validations = append(validations, s.Value)
+			}
+		}
+		assert.Equal(t, []float64{0, 1}, validations)
+	})
+
+	t.Run("CompileString", func(t *testing.T) {
+		t.Parallel()
+
+		runtime, _ := newTestRuntime(t)
+		_, err := runtime.VU.Runtime().RunString(`
+			var validator = jsonschema.compile('{"type": "string", "maxLength": 3}');
+			if (!validator.validate("abc").valid || validator.validate("abcd").valid) {
+				throw new Error("Unexpected validation");
+			}
+		`)
+		require.NoError(t, err)
+	})
+
+	t.Run("InvalidSchema", func(t *testing.T) {
+		t.Parallel()
+
+		runtime, _ := newTestRuntime(t)
+		_, err := runtime.VU.Runtime().RunString(`jsonschema.compile({ type: "unknown" })`)
+		assert.ErrorContains(t, err, "invalid schema")
+	})
+
+	t.Run("RemoteReference", func(t *testing.T) {
+		t.Parallel()
+
+		runtime, _ := newTestRuntime(t)
+		_, err := runtime.VU.Runtime().RunString(`jsonschema.compile({ $ref: "https://example.com/schema.json" })`)
+		assert.ErrorContains(t, err, `loading the "https://example.com/schema.json" schema is not supported`)
+	})
+
+	t.Run("CompileInVUContext", func(t *testing.T) {
+		t.Parallel()
+
+		runtime, samples := newTestRuntime(t)
+		moveToVUContext(runtime, samples)
+		_, err := runtime.VU.Runtime().RunString(`jsonschema.compile({ type: "string" })`)
+		assert.ErrorContains(t, err, "compile() must be called in the init context")
+	})
+}
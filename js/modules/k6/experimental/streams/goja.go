@@ -1,7 +1,9 @@
 package streams
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
 
 	"github.com/grafana/sobek"
@@ -59,6 +61,125 @@ func promiseThen(
 	return newPromise, nil
 }
 
+// uponPromise implements the specification's [react] operation, running the
+// given steps upon the fulfillment or the rejection of the promise.
+//
+// [react]: https://webidl.spec.whatwg.org/#dfn-perform-steps-once-promise-is-settled
+func uponPromise(rt *sobek.Runtime, promise *sobek.Promise, onFulfilled, onRejected func(sobek.Value)) {
+	if onFulfilled == nil {
+		onFulfilled = func(sobek.Value) {}
+	}
+	if onRejected == nil {
+		onRejected = func(sobek.Value) {}
+	}
+
+	if _, err := promiseThen(rt, promise, onFulfilled, onRejected); err != nil {
+		common.Throw(rt, err)
+	}
+}
+
+// setPromiseIsHandled marks the promise as handled, so its rejection is not
+// reported as an unhandled one.
+//
+// See https://github.com/dop251/goja/issues/565
+func setPromiseIsHandled(rt *sobek.Runtime, promise *sobek.Promise) {
+	uponPromise(rt, promise, nil, nil)
+}
+
+// promiseResolve returns a promise resolved with the value, which is the
+// value itself when it is already a promise.
+func promiseResolve(vu modules.VU, value sobek.Value) *sobek.Promise {
+	if !common.IsNullish(value) {
+		if p, ok := value.Export().(*sobek.Promise); ok {
+			return p
+		}
+	}
+
+	if value == nil {
+		value = sobek.Undefined()
+	}
+
+	return newResolvedPromise(vu, value)
+}
+
+// promiseCall invokes the function with the given this value and arguments,
+// and returns a promise resolved with its result, or rejected with the
+// exception it throws.
+func promiseCall(vu modules.VU, fn sobek.Callable, this sobek.Value, args ...sobek.Value) *sobek.Promise {
+	v, err := fn(this, args...)
+	if err != nil {
+		var ex *sobek.Exception
+		if errors.As(err, &ex) {
+			return newRejectedPromise(vu, ex.Value())
+		}
+		return newRejectedPromise(vu, err)
+	}
+
+	return promiseResolve(vu, v)
+}
+
+// promiseCapability holds a promise along with the functions that settle it,
+// as the specification's [PromiseCapability] records do.
+//
+// [PromiseCapability]: https://tc39.es/ecma262/#sec-promisecapability-records
+type promiseCapability struct {
+	promise *sobek.Promise
+	resolve func(any)
+	reject  func(any)
+
+	runtime *sobek.Runtime
+}
+
+// newPromiseCapability returns a new [promiseCapability] with a pending promise.
+func newPromiseCapability(rt *sobek.Runtime) *promiseCapability {
+	promise, resolve, reject := rt.NewPromise()
+	return &promiseCapability{promise: promise, resolve: resolve, reject: reject, runtime: rt}
+}
+
+// isPending returns true if the promise has not been settled yet.
+func (pc *promiseCapability) isPending() bool {
+	return pc.promise.State() == sobek.PromiseStatePending
+}
+
+// newRejectedPromiseCapability returns a new [promiseCapability] with a promise
+// rejected with the reason, and marked as handled.
+func newRejectedPromiseCapability(rt *sobek.Runtime, reason sobek.Value) *promiseCapability {
+	pc := newPromiseCapability(rt)
+	pc.reject(reason)
+	setPromiseIsHandled(rt, pc.promise)
+	return pc
+}
+
+// ensurePromiseRejected rejects the promise with the reason if it is still
+// pending, or returns a new one rejected with it otherwise. Either way, the
+// returned promise is marked as handled.
+func ensurePromiseRejected(pc *promiseCapability, reason sobek.Value) *promiseCapability {
+	if !pc.isPending() {
+		return newRejectedPromiseCapability(pc.runtime, reason)
+	}
+
+	pc.reject(reason)
+	setPromiseIsHandled(pc.runtime, pc.promise)
+	return pc
+}
+
+// errorValue returns the JS value of the given error, as errors are held as
+// different types across the module.
+func errorValue(rt *sobek.Runtime, e any) sobek.Value {
+	switch err := e.(type) {
+	case nil:
+		return sobek.Undefined()
+	case sobek.Value:
+		return err
+	case *jsError:
+		return err.Err()
+	case *sobek.Exception:
+		return err.Value()
+	default:
+		return rt.ToValue(e)
+	}
+}
+
 // isNumber returns true if the given sobek.Value holds a number
 func isNumber(value sobek.Value) bool {
 	_, isFloat := value.Export().(float64)
@@ -105,3 +226,141 @@ func setReadOnlyPropertyOf(obj *sobek.Object, objName, propName string, propValu
 func isObject(val sobek.Value) bool {
 	return val != nil && val.ExportType() != nil && val.ExportType().Kind() == reflect.Map
 }
+
+// arrayBufferView holds the internal slots of an [ArrayBufferView], either a typed array or
+// a DataView, the byte streams operate on.
+//
+// [ArrayBufferView]: https://webidl.spec.whatwg.org/#ArrayBufferView
+type arrayBufferView struct {
+	// object is the view itself.
+	object *sobek.Object
+
+	// buffer is the view's [[ViewedArrayBuffer]].
+	buffer sobek.ArrayBuffer
+
+	// byteOffset and byteLength are the view's [[ByteOffset]] and [[ByteLength]].
+	byteOffset, byteLength int
+
+	// length is the number of elements of the view, which is its byte length for a DataView.
+	length int
+
+	// elementSize is the size of the view's elements, in bytes, which is 1 for a DataView.
+	elementSize int
+
+	// constructor is the intrinsic constructor of the view's type.
+	constructor sobek.Value
+
+	// isDataView is true if the view is a DataView, false if it is a typed array.
+	isDataView bool
+}
+
+// asArrayBufferView returns the [arrayBufferView] held by the given value,
+// or false if the value is neither a typed array nor a DataView.
+func asArrayBufferView(rt *sobek.Runtime, value sobek.Value) (arrayBufferView, bool) {
+	obj, ok := value.(*sobek.Object)
+	if !ok {
+		return arrayBufferView{}, false
+	}
+
+	isView, ok := sobek.AssertFunction(rt.Get("ArrayBuffer").ToObject(rt).Get("isView"))
+	if !ok {
+		return arrayBufferView{}, false
+	}
+	if res, err := isView(sobek.Undefined(), obj); err != nil || !res.ToBoolean() {
+		return arrayBufferView{}, false
+	}
+
+	buffer, ok := obj.Get("buffer").Export().(sobek.ArrayBuffer)
+	if !ok {
+		return arrayBufferView{}, false
+	}
+
+	// The [Symbol.toStringTag] of a view holds its [[TypedArrayName]],
+	// or "DataView" when the view is not a typed array.
+	name := obj.GetSymbol(sobek.SymToStringTag).String()
+
+	view := arrayBufferView{
+		object:      obj,
+		buffer:      buffer,
+		byteOffset:  int(obj.Get("byteOffset").ToInteger()),
+		byteLength:  int(obj.Get("byteLength").ToInteger()),
+		elementSize: 1,
+		constructor: rt.Get(name),
+		isDataView:  name == "DataView",
+	}
+
+	if view.isDataView {
+		view.length = view.byteLength
+	} else {
+		view.length = int(obj.Get("length").ToInteger())
+		view.elementSize = int(view.constructor.ToObject(rt).Get("BYTES_PER_ELEMENT").ToInteger())
+	}
+
+	return view, true
+}
+
+// newArrayBufferView constructs a view of the given type over the buffer, as the
+// specification does with Construct(ctor, « buffer, byteOffset, length »).
+func newArrayBufferView(
+	rt *sobek.Runtime,
+	constructor sobek.Value,
+	buffer sobek.ArrayBuffer,
+	byteOffset, length int,
+) (*sobek.Object, error) {
+	return rt.New(constructor, rt.ToValue(buffer), rt.ToValue(byteOffset), rt.ToValue(length))
+}
+
+// newUint8Array constructs a Uint8Array over the given bytes of the buffer.
+func newUint8Array(rt *sobek.Runtime, buffer sobek.ArrayBuffer, byteOffset, byteLength int) (*sobek.Object, error) {
+	return newArrayBufferView(rt, rt.Get("Uint8Array"), buffer, byteOffset, byteLength)
+}
+
+// transferArrayBuffer implements the specification's [TransferArrayBuffer] abstract operation.
+//
+// [TransferArrayBuffer]: https://streams.spec.whatwg.org/#transfer-array-buffer
+func transferArrayBuffer(rt *sobek.Runtime, buffer sobek.ArrayBuffer) (sobek.ArrayBuffer, error) {
+	// 1. Assert: ! IsDetachedBuffer(O) is false.
+	if buffer.Detached() {
+		return sobek.ArrayBuffer{}, newTypeError(rt, "cannot transfer a detached ArrayBuffer")
+	}
+
+	// 2. Let arrayBufferData be O.[[ArrayBufferData]].
+	data := buffer.Bytes()
+
+	// 4. Perform ? DetachArrayBuffer(O).
+	buffer.Detach()
+
+	// 5. Return a new ArrayBuffer object whose [[ArrayBufferData]] is arrayBufferData.
+	return rt.NewArrayBuffer(data), nil
+}
+
+// cloneArrayBuffer returns a new ArrayBuffer holding a copy of the given range of bytes.
+func cloneArrayBuffer(rt *sobek.Runtime, buffer sobek.ArrayBuffer, byteOffset, byteLength int) (sobek.ArrayBuffer, error) {
+	if buffer.Detached() {
+		return sobek.ArrayBuffer{}, newTypeError(rt, "cannot clone a detached ArrayBuffer")
+	}
+
+	data := make([]byte, byteLength)
+	copy(data, buffer.Bytes()[byteOffset:byteOffset+byteLength])
+
+	return rt.NewArrayBuffer(data), nil
+}
+
+// toEnforcedRangeInteger converts the value to a non-negative integer, as WebIDL does
+// for [EnforceRange] unsigned long long values, and returns false if it is out of range.
+func toEnforcedRangeInteger(value sobek.Value) (int64, bool) {
+	f := value.ToFloat()
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+
+	f = math.Trunc(f)
+	if f < 0 || f > maxSafeInteger {
+		return 0, false
+	}
+
+	return int64(f), true
+}
+
+// maxSafeInteger is the value of JavaScript's Number.MAX_SAFE_INTEGER.
+const maxSafeInteger = 1<<53 - 1
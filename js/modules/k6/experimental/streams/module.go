@@ -43,7 +43,12 @@ func (mi *ModuleInstance) Exports() modules.Exports {
 	return modules.Exports{Named: map[string]interface{}{
 		"ReadableStream":              mi.NewReadableStream,
 		"CountQueuingStrategy":        mi.NewCountQueuingStrategy,
+		"ByteLengthQueuingStrategy":   mi.NewByteLengthQueuingStrategy,
 		"ReadableStreamDefaultReader": mi.NewReadableStreamDefaultReader,
+		"ReadableStreamBYOBReader":    mi.NewReadableStreamBYOBReader,
+		"WritableStream":              mi.NewWritableStream,
+		"WritableStreamDefaultWriter": mi.NewWritableStreamDefaultWriter,
+		"TransformStream":             mi.NewTransformStream,
 	}}
 }
 
@@ -92,8 +97,22 @@ func newReadableStream(vu modules.VU, call sobek.ConstructorCall) *sobek.Object
 	stream.initialize()
 
 	// 4. If underlyingSourceDict["type"] is "bytes":
-	if underlyingSourceDict.Type == "bytes" {
-		common.Throw(stream.runtime, newError(NotSupportedError, "'bytes' stream is not supported yet"))
+	if underlyingSourceDict.Type == ReadableStreamTypeBytes {
+		// 4.1. If strategy["size"] exists, throw a RangeError exception.
+		if !common.IsNullish(strategy.Get("size")) {
+			throw(rt, newRangeError(rt, "size must not be set for byte streams"))
+		}
+
+		// 4.2. Let highWaterMark be ? ExtractHighWaterMark(strategy, 0).
+		highWaterMark := extractHighWaterMark(rt, strategy, 0)
+
+		// 4.3. Perform ? SetUpReadableByteStreamControllerFromUnderlyingSource(this, underlyingSource,
+		// underlyingSourceDict, highWaterMark).
+		stream.setupReadableByteStreamControllerFromUnderlyingSource(
+			underlyingSource,
+			underlyingSourceDict,
+			highWaterMark,
+		)
 	} else { // 5. Otherwise,
 		// 5.1. Assert: underlyingSourceDict["type"] does not exist.
 		if underlyingSourceDict.Type != "" {
@@ -137,28 +156,35 @@ func newReadableStream(vu modules.VU, call sobek.ConstructorCall) *sobek.Object
 func defaultSizeFunc(_ sobek.Value) (float64, error) { return 1.0, nil }
 
 func initializeStrategy(rt *sobek.Runtime, call sobek.ConstructorCall) *sobek.Object {
+	// If the stream type is 'bytes', we don't want the size function.
+	// Except, when it is manually specified.
+	isBytes := false
+	if len(call.Arguments) > 0 && !common.IsNullish(call.Arguments[0]) {
+		srcArg := call.Arguments[0].ToObject(rt)
+		srcTypeArg := srcArg.Get("type")
+		isBytes = !common.IsNullish(srcTypeArg) && srcTypeArg.String() == ReadableStreamTypeBytes
+	}
+
 	// Either if the strategy is not provided or if it doesn't have a 'highWaterMark',
-	// we need to set its default value (highWaterMark=1).
+	// we need to set its default value (highWaterMark=1, or 0 for byte streams).
 	// https://streams.spec.whatwg.org/#rs-prototype
 	strArg := rt.NewObject()
 	if len(call.Arguments) > 1 && !common.IsNullish(call.Arguments[1]) {
 		strArg = call.Arguments[1].ToObject(rt)
 	}
 	if common.IsNullish(strArg.Get("highWaterMark")) {
-		if err := strArg.Set("highWaterMark", rt.ToValue(1)); err != nil {
+		defaultHWM := 1
+		if isBytes {
+			defaultHWM = 0
+		}
+		if err := strArg.Set("highWaterMark", rt.ToValue(defaultHWM)); err != nil {
 			common.Throw(rt, newError(RuntimeError, err.Error()))
 		}
 	}
 
-	// If the stream type is 'bytes', we don't want the size function.
-	// Except, when it is manually specified.
 	size := rt.ToValue(defaultSizeFunc)
-	if len(call.Arguments) > 0 && !common.IsNullish(call.Arguments[0]) {
-		srcArg := call.Arguments[0].ToObject(rt)
-		srcTypeArg := srcArg.Get("type")
-		if !common.IsNullish(srcTypeArg) && srcTypeArg.String() == ReadableStreamTypeBytes {
-			size = nil
-		}
+	if isBytes {
+		size = nil
 	}
 	if strArg.Get("size") != nil {
 		size = strArg.Get("size")
@@ -186,9 +212,34 @@ func newCountQueuingStrategy(
 	rt *sobek.Runtime,
 	call sobek.ConstructorCall,
 	size sobek.Value,
+) *sobek.Object {
+	return newQueuingStrategy(rt, "CountQueuingStrategy", call, size)
+}
+
+// NewByteLengthQueuingStrategy is the constructor for the [ByteLengthQueuingStrategy] object.
+//
+// [ByteLengthQueuingStrategy]: https://streams.spec.whatwg.org/#blqs-class
+func (mi *ModuleInstance) NewByteLengthQueuingStrategy(call sobek.ConstructorCall) *sobek.Object {
+	rt := mi.vu.Runtime()
+
+	// The size of a chunk is its byteLength, as the chunks are expected to be
+	// ArrayBuffers or ArrayBufferViews.
+	size := rt.ToValue(func(chunk sobek.Value) sobek.Value {
+		return chunk.ToObject(rt).Get("byteLength")
+	})
+
+	return newQueuingStrategy(rt, "ByteLengthQueuingStrategy", call, size)
+}
+
+// newQueuingStrategy creates a queuing strategy object, with the 'highWaterMark'
+// given as argument and the 'size' function, if any.
+func newQueuingStrategy(
+	rt *sobek.Runtime,
+	objName string,
+	call sobek.ConstructorCall,
+	size sobek.Value,
 ) *sobek.Object {
 	obj := rt.NewObject()
-	objName := "CountQueuingStrategy"
 
 	if len(call.Arguments) != 1 {
 		throw(rt, newTypeError(rt, objName+" takes a single argument"))
@@ -291,6 +342,228 @@ func (mi *ModuleInstance) NewReadableStreamDefaultReader(call sobek.ConstructorC
 	return object
 }
 
+// NewReadableStreamBYOBReader is the constructor for the [ReadableStreamBYOBReader] object.
+//
+// [ReadableStreamBYOBReader]: https://streams.spec.whatwg.org/#byob-reader-class
+func (mi *ModuleInstance) NewReadableStreamBYOBReader(call sobek.ConstructorCall) *sobek.Object {
+	rt := mi.vu.Runtime()
+
+	if len(call.Arguments) != 1 {
+		throw(rt, newTypeError(rt, "ReadableStreamBYOBReader takes a single argument"))
+	}
+
+	stream, ok := call.Argument(0).Export().(*ReadableStream)
+	if !ok {
+		throw(rt, newTypeError(rt, "ReadableStreamBYOBReader argument must be a ReadableStream"))
+	}
+
+	// 1. Perform ? SetUpReadableStreamBYOBReader(this, stream).
+	reader := &ReadableStreamBYOBReader{}
+	reader.setup(stream)
+
+	object, err := NewReadableStreamBYOBReaderObject(reader)
+	if err != nil {
+		throw(rt, err)
+	}
+
+	return object
+}
+
+// NewWritableStream is the constructor for the [WritableStream] object.
+//
+// [WritableStream]: https://streams.spec.whatwg.org/#ws-class
+func (mi *ModuleInstance) NewWritableStream(call sobek.ConstructorCall) *sobek.Object {
+	return newWritableStream(mi.vu, call)
+}
+
+func newWritableStream(vu modules.VU, call sobek.ConstructorCall) *sobek.Object {
+	var (
+		// 1. If underlyingSink is missing, set it to null.
+		underlyingSink *sobek.Object
+
+		rt = vu.Runtime()
+
+		err                error
+		underlyingSinkDict UnderlyingSink
+	)
+
+	// We look for the queuing strategy's size function first, as it
+	// is converted before the underlying sink is.
+	strategy := strategyFromArgument(rt, call.Argument(1))
+	sizeAlgorithm := extractSizeAlgorithm(rt, strategy)
+
+	// 2. Let underlyingSinkDict be underlyingSink, converted to an IDL value of type UnderlyingSink.
+	if len(call.Arguments) > 0 && !sobek.IsUndefined(call.Arguments[0]) {
+		// We first assert that it is an object (requirement)
+		if !isObject(call.Arguments[0]) {
+			throw(rt, newTypeError(rt, "underlyingSink must be an object"))
+		}
+
+		// Then we try to convert it to an UnderlyingSink
+		underlyingSink = call.Arguments[0].ToObject(rt)
+		underlyingSinkDict, err = NewUnderlyingSinkFromObject(rt, underlyingSink)
+		if err != nil {
+			throw(rt, err)
+		}
+	}
+
+	// 3. If underlyingSinkDict["type"] exists, throw a RangeError exception.
+	if !common.IsNullish(underlyingSinkDict.Type) {
+		throw(rt, newRangeError(rt, "invalid underlying sink type"))
+	}
+
+	// 4. Perform ! InitializeWritableStream(this).
+	stream := &WritableStream{
+		runtime: rt,
+		vu:      vu,
+	}
+	stream.initialize()
+
+	// 5. Let sizeAlgorithm be ! ExtractSizeAlgorithm(strategy).
+	// 6. Let highWaterMark be ? ExtractHighWaterMark(strategy, 1).
+	highWaterMark := extractHighWaterMark(rt, strategy, 1)
+
+	// 7. Perform ? SetUpWritableStreamDefaultControllerFromUnderlyingSink(this, underlyingSink,
+	// underlyingSinkDict, highWaterMark, sizeAlgorithm).
+	controller := &WritableStreamDefaultController{}
+	controller.setupFromUnderlyingSink(stream, underlyingSink, underlyingSinkDict, highWaterMark, sizeAlgorithm)
+
+	streamObj := rt.ToValue(stream).ToObject(rt)
+	if err = streamObj.SetPrototype(call.This.Prototype()); err != nil {
+		common.Throw(rt, newError(RuntimeError, err.Error()))
+	}
+
+	return streamObj
+}
+
+// strategyFromArgument returns the queuing strategy passed as the argument,
+// or an empty one when it is missing, as strategies default to {}.
+func strategyFromArgument(rt *sobek.Runtime, arg sobek.Value) *sobek.Object {
+	if common.IsNullish(arg) {
+		return rt.NewObject()
+	}
+
+	return arg.ToObject(rt)
+}
+
+// NewWritableStreamDefaultWriter is the constructor for the [WritableStreamDefaultWriter] object.
+//
+// [WritableStreamDefaultWriter]: https://streams.spec.whatwg.org/#default-writer-class
+func (mi *ModuleInstance) NewWritableStreamDefaultWriter(call sobek.ConstructorCall) *sobek.Object {
+	rt := mi.vu.Runtime()
+
+	if len(call.Arguments) != 1 {
+		throw(rt, newTypeError(rt, "WritableStreamDefaultWriter takes a single argument"))
+	}
+
+	stream, ok := call.Argument(0).Export().(*WritableStream)
+	if !ok {
+		throw(rt, newTypeError(rt, "WritableStreamDefaultWriter argument must be a WritableStream"))
+	}
+
+	// 1. Perform ? SetUpWritableStreamDefaultWriter(this, stream).
+	writer := &WritableStreamDefaultWriter{}
+	writer.setup(stream)
+
+	object, err := NewWritableStreamDefaultWriterObject(writer)
+	if err != nil {
+		throw(rt, err)
+	}
+
+	return object
+}
+
+// NewTransformStream is the constructor for the [TransformStream] object.
+//
+// [TransformStream]: https://streams.spec.whatwg.org/#ts-class
+func (mi *ModuleInstance) NewTransformStream(call sobek.ConstructorCall) *sobek.Object {
+	var (
+		// 1. If transformer is missing, set it to null.
+		transformer *sobek.Object
+
+		rt = mi.vu.Runtime()
+
+		err             error
+		transformerDict Transformer
+	)
+
+	// We look for the queuing strategies' size functions first, as they
+	// are converted before the transformer is.
+	writableStrategy := strategyFromArgument(rt, call.Argument(1))
+	writableSizeAlgorithm := extractSizeAlgorithm(rt, writableStrategy)
+	readableStrategy := strategyFromArgument(rt, call.Argument(2))
+	readableSizeAlgorithm := extractSizeAlgorithm(rt, readableStrategy)
+
+	// 2. Let transformerDict be transformer, converted to an IDL value of type Transformer.
+	if len(call.Arguments) > 0 && !sobek.IsUndefined(call.Arguments[0]) {
+		// We first assert that it is an object (requirement)
+		if !isObject(call.Arguments[0]) {
+			throw(rt, newTypeError(rt, "transformer must be an object"))
+		}
+
+		// Then we try to convert it to a Transformer
+		transformer = call.Arguments[0].ToObject(rt)
+		transformerDict, err = NewTransformerFromObject(rt, transformer)
+		if err != nil {
+			throw(rt, err)
+		}
+	}
+
+	// 3. If transformerDict["readableType"] exists, throw a RangeError exception.
+	if !common.IsNullish(transformerDict.ReadableType) {
+		throw(rt, newRangeError(rt, "invalid readable type"))
+	}
+
+	// 4. If transformerDict["writableType"] exists, throw a RangeError exception.
+	if !common.IsNullish(transformerDict.WritableType) {
+		throw(rt, newRangeError(rt, "invalid writable type"))
+	}
+
+	// 5. Let readableHighWaterMark be ? ExtractHighWaterMark(readableStrategy, 0).
+	readableHighWaterMark := extractHighWaterMark(rt, readableStrategy, 0)
+
+	// 6. Let readableSizeAlgorithm be ! ExtractSizeAlgorithm(readableStrategy).
+	// 7. Let writableHighWaterMark be ? ExtractHighWaterMark(writableStrategy, 1).
+	writableHighWaterMark := extractHighWaterMark(rt, writableStrategy, 1)
+
+	// 8. Let writableSizeAlgorithm be ! ExtractSizeAlgorithm(writableStrategy).
+	// 9. Let startPromise be a new promise.
+	startPromise, resolveStartPromise, _ := rt.NewPromise()
+
+	// 10. Perform ! InitializeTransformStream(this, startPromise, writableHighWaterMark,
+	// writableSizeAlgorithm, readableHighWaterMark, readableSizeAlgorithm).
+	stream := &TransformStream{
+		runtime: rt,
+		vu:      mi.vu,
+	}
+	stream.initialize(startPromise, writableHighWaterMark, writableSizeAlgorithm,
+		readableHighWaterMark, readableSizeAlgorithm)
+
+	// 11. Perform ? SetUpTransformStreamDefaultControllerFromTransformer(this, transformer, transformerDict).
+	controller := &TransformStreamDefaultController{}
+	controller.setupFromTransformer(stream, transformer, transformerDict)
+
+	if start, ok := sobek.AssertFunction(transformerDict.Start); ok {
+		// 12. If transformerDict["start"] exists, then resolve startPromise with the result of invoking
+		// transformerDict["start"] with argument list « this.[[controller]] » and callback this value transformer.
+		startResult, err := start(transformer, controller.object)
+		if err != nil {
+			panic(err)
+		}
+		resolveStartPromise(startResult)
+	} else {
+		// 13. Otherwise, resolve startPromise with undefined.
+		resolveStartPromise(sobek.Undefined())
+	}
+
+	streamObj := rt.ToValue(stream).ToObject(rt)
+	if err = streamObj.SetPrototype(call.This.Prototype()); err != nil {
+		common.Throw(rt, newError(RuntimeError, err.Error()))
+	}
+
+	return streamObj
+}
+
 // NewReadableStreamFromReader is the equivalent of [NewReadableStreamDefaultReader] but to initialize
 // a new [ReadableStream] from a given [io.Reader] in Go code.
 // It is useful for those situations when a [io.Reader] needs to be surfaced up to the JS runtime.
@@ -302,6 +575,22 @@ func NewReadableStreamFromReader(vu modules.VU, reader io.Reader) *sobek.Object
 	})
 }
 
+// NewReadableStreamFromSource initializes a new [ReadableStream] from the given underlying source
+// and queuing strategy in Go code, the same way the constructor does when called from JS.
+// It is useful for those situations when a stream needs finer control than [NewReadableStreamFromReader],
+// e.g. to read its data off the event loop.
+func NewReadableStreamFromSource(vu modules.VU, underlyingSource, strategy *sobek.Object) *sobek.Object {
+	rt := vu.Runtime()
+	args := []sobek.Value{underlyingSource}
+	if strategy != nil {
+		args = append(args, strategy)
+	}
+	return newReadableStream(vu, sobek.ConstructorCall{
+		Arguments: args,
+		This:      rt.NewObject(),
+	})
+}
+
 func underlyingSourceFromReader(vu modules.VU, reader io.Reader) *sobek.Object {
 	rt := vu.Runtime()
 
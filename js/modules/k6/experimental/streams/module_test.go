@@ -39,3 +39,266 @@ func TestNewReadableStreamFromReader(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, exp, p.Result().String())
 }
+
+func TestWritableStreamBasics(t *testing.T) {
+	t.Parallel()
+
+	runTestScript(t, `
+(async () => {
+  const events = [];
+  const ws = new WritableStream({
+    write(chunk) { events.push(chunk); return Promise.resolve(); },
+    close() { events.push("closed"); },
+  }, { highWaterMark: 2 });
+
+  const writer = ws.getWriter();
+  assert(ws.locked, "stream should be locked");
+  assert(writer.desiredSize === 2, "unexpected desiredSize: " + writer.desiredSize);
+
+  await writer.ready;
+  writer.write("a");
+  writer.write("b");
+  await writer.close();
+  await writer.closed;
+  assert(events.join() === "a,b,closed", "unexpected events: " + events);
+
+  const failing = new WritableStream({ write() { throw new Error("boom"); } }).getWriter();
+  await assertRejects(failing.write(1), e => e.message === "boom");
+  await assertRejects(failing.closed, e => e.message === "boom");
+
+  let abortReason;
+  await new WritableStream({ abort(reason) { abortReason = reason; } }).abort("why");
+  assert(abortReason === "why", "unexpected abort reason: " + abortReason);
+})();
+`)
+}
+
+func TestTransformStreamBasics(t *testing.T) {
+	t.Parallel()
+
+	runTestScript(t, `
+(async () => {
+  const ts = new TransformStream({
+    transform(chunk, controller) {
+      controller.enqueue(chunk.toUpperCase());
+      controller.enqueue(chunk);
+    },
+    flush(controller) { controller.enqueue("end"); },
+  });
+  assert(ts.readable === ts.readable, "readable should always be the same object");
+
+  const writer = ts.writable.getWriter();
+  writer.write("a");
+  writer.write("b");
+  writer.close();
+  const chunks = await readAll(ts.readable.getReader());
+  assert(chunks.join() === "A,a,B,b,end", "unexpected chunks: " + chunks);
+
+  const identity = new TransformStream();
+  identity.writable.getWriter().write(1);
+  const { value } = await identity.readable.getReader().read();
+  assert(value === 1, "chunks should be passed through by default");
+
+  const failing = new TransformStream({ transform() { throw new Error("bad"); } });
+  await assertRejects(failing.writable.getWriter().write(1), e => e.message === "bad");
+  await assertRejects(failing.readable.getReader().read(), e => e.message === "bad");
+})();
+`)
+}
+
+func TestReadableStreamPipe(t *testing.T) {
+	t.Parallel()
+
+	runTestScript(t, `
+(async () => {
+  const rs = new ReadableStream({ start(c) { c.enqueue("x"); c.enqueue("y"); c.close(); } });
+  const events = [];
+  await rs
+    .pipeThrough(new TransformStream({ transform(chunk, c) { c.enqueue(chunk + chunk); } }))
+    .pipeTo(new WritableStream({ write(chunk) { events.push(chunk); }, close() { events.push("closed"); } }));
+  assert(events.join() === "xx,yy,closed", "unexpected events: " + events);
+  assert(!rs.locked, "source should be unlocked once piped");
+
+  let aborted;
+  const errored = new ReadableStream({ start(c) { c.error(new Error("src")); } });
+  await assertRejects(errored.pipeTo(new WritableStream({ abort(r) { aborted = r; } })), e => e.message === "src");
+  assert(aborted && aborted.message === "src", "destination should be aborted");
+
+  let canceled;
+  const source = new ReadableStream({ pull(c) { c.enqueue(1); }, cancel(r) { canceled = r; } });
+  await assertRejects(source.pipeTo(new WritableStream({ write() { throw new Error("dst"); } })), e => e.message === "dst");
+  assert(canceled && canceled.message === "dst", "source should be canceled");
+
+  const kept = new WritableStream();
+  await new ReadableStream({ start(c) { c.close(); } }).pipeTo(kept, { preventClose: true });
+  await kept.getWriter().write("still open");
+})();
+`)
+}
+
+func TestReadableByteStreamBasics(t *testing.T) {
+	t.Parallel()
+
+	runTestScript(t, `
+(async () => {
+  const rs = new ReadableStream({ type: "bytes", start(c) { c.enqueue(new Uint8Array([1, 2, 3])); c.close(); } });
+  const chunks = await readAll(rs.getReader());
+  assert(chunks.length === 1 && chunks[0] instanceof Uint8Array, "unexpected chunks: " + chunks);
+  assert(chunks[0].join() === "1,2,3", "unexpected chunk: " + chunks[0]);
+
+  let pulls = 0;
+  const pulled = new ReadableStream({
+    type: "bytes",
+    pull(c) {
+      const view = c.byobRequest.view;
+      view[0] = 10 + pulls;
+      view[1] = 20 + pulls;
+      pulls++;
+      c.byobRequest.respond(2);
+      if (pulls === 2) c.close();
+    },
+  });
+  const reader = pulled.getReader({ mode: "byob" });
+  const buffer = new ArrayBuffer(4);
+  let res = await reader.read(new Uint8Array(buffer));
+  assert(buffer.byteLength === 0, "the given buffer should be transferred");
+  assert(res.value.join() === "10,20" && res.value.buffer.byteLength === 4, "unexpected view: " + res.value);
+  res = await reader.read(new Uint8Array(res.value.buffer));
+  assert(res.value.join() === "11,21", "unexpected view: " + res.value);
+  res = await reader.read(new Uint8Array(4));
+  assert(res.done && res.value.byteLength === 0, "stream should be done");
+
+  const queued = new ReadableStream({
+    type: "bytes",
+    start(c) { c.enqueue(new Uint8Array([1, 0, 2, 0, 3])); c.enqueue(new Uint8Array([0])); c.close(); },
+  });
+  res = await queued.getReader({ mode: "byob" }).read(new Uint16Array(3), { min: 3 });
+  assert(res.value.length === 3 && res.value[0] === 1 && res.value[2] === 3, "unexpected view: " + res.value);
+
+  const allocated = new ReadableStream({
+    type: "bytes",
+    autoAllocateChunkSize: 8,
+    pull(c) {
+      assert(c.byobRequest.view.byteLength === 8, "unexpected view size");
+      c.byobRequest.view[0] = 42;
+      c.byobRequest.respond(1);
+      c.close();
+    },
+  });
+  res = await allocated.getReader().read();
+  assert(res.value.join() === "42", "unexpected chunk: " + res.value);
+
+  assertThrows(() => new ReadableStream({ type: "bytes" }, { size() { return 1; } }), RangeError);
+  assertThrows(() => new ReadableStream({ type: "bytes", autoAllocateChunkSize: 0 }), TypeError);
+  assertThrows(() => new ReadableStream().getReader({ mode: "byob" }), TypeError);
+
+  const errored = new ReadableStream({ type: "bytes", start(c) { c.error(new Error("bad")); } });
+  await assertRejects(errored.getReader({ mode: "byob" }).read(new Uint8Array(1)), e => e.message === "bad");
+  const empty = new ReadableStream({ type: "bytes" }).getReader({ mode: "byob" });
+  await assertRejects(empty.read(new Uint8Array(0)), e => e instanceof TypeError);
+
+  const canceled = new ReadableStream({ type: "bytes" });
+  const byobReader = new ReadableStreamBYOBReader(canceled);
+  const pending = byobReader.read(new DataView(new ArrayBuffer(2)));
+  await byobReader.cancel();
+  res = await pending;
+  assert(res.done && res.value === undefined, "pending read should be done");
+  byobReader.releaseLock();
+  assert(!canceled.locked, "stream should be unlocked");
+
+  const strategy = new ByteLengthQueuingStrategy({ highWaterMark: 16 });
+  assert(strategy.highWaterMark === 16 && strategy.size(new Uint8Array(5)) === 5, "unexpected strategy");
+  const writer = new WritableStream({}, new ByteLengthQueuingStrategy({ highWaterMark: 4 })).getWriter();
+  writer.write(new Uint8Array(3));
+  assert(writer.desiredSize === 1, "unexpected desiredSize: " + writer.desiredSize);
+
+  const written = [];
+  await new ReadableStream({ type: "bytes", start(c) { c.enqueue(new Uint8Array([7])); c.enqueue(new Uint8Array([8])); c.close(); } })
+    .pipeTo(new WritableStream({ write(chunk) { written.push(...chunk); } }));
+  assert(written.join() === "7,8", "unexpected bytes: " + written);
+})();
+`)
+}
+
+func TestReadableStreamBYOBRequest(t *testing.T) {
+	t.Parallel()
+
+	runTestScript(t, `
+(async () => {
+  let controller;
+  const rs = new ReadableStream({ type: "bytes", start(c) { controller = c; } });
+  const reader = rs.getReader({ mode: "byob" });
+
+  const read = reader.read(new Uint8Array(4));
+  const request = controller.byobRequest;
+  const view = new Uint8Array(request.view.buffer, request.view.byteOffset, 2);
+  view.set([5, 6]);
+  request.respondWithNewView(view);
+  assert(request.view === null, "request should be invalidated");
+  assertThrows(() => request.respond(1), TypeError);
+  let res = await read;
+  assert(res.value.join() === "5,6", "unexpected view: " + res.value);
+
+  const enqueued = reader.read(new Uint8Array(3));
+  controller.enqueue(new Uint8Array([9, 8, 7, 6, 5]));
+  res = await enqueued;
+  assert(res.value.join() === "9,8,7", "unexpected view: " + res.value);
+  res = await reader.read(new Uint8Array(10));
+  assert(res.value.join() === "6,5", "unexpected view: " + res.value);
+
+  const released = reader.read(new Uint8Array(4));
+  reader.releaseLock();
+  await assertRejects(released, e => e instanceof TypeError);
+
+  const defaultReader = rs.getReader();
+  controller.byobRequest.view[0] = 1;
+  controller.byobRequest.respond(1);
+  res = await defaultReader.read();
+  assert(res.value.join() === "1", "bytes responded after release should be queued");
+
+  controller.close();
+  res = await defaultReader.read();
+  assert(res.done, "stream should be done");
+})();
+`)
+}
+
+// runTestScript runs the given script with the module's exports, and a few
+// assertion helpers, available as globals. The test fails if the script
+// throws or leaves an unhandled promise rejection behind.
+func runTestScript(t *testing.T, script string) {
+	t.Helper()
+
+	r := modulestest.NewRuntime(t)
+	m := new(RootModule).NewModuleInstance(r.VU)
+	for k, v := range m.Exports().Named {
+		require.NoError(t, r.VU.Runtime().Set(k, v))
+	}
+
+	_, err := r.VU.Runtime().RunString(`
+function assert(cond, msg) { if (!cond) throw new Error(msg); }
+function assertThrows(fn, type) {
+  try { fn(); } catch (e) { assert(e instanceof type, "unexpected error: " + e); return; }
+  throw new Error("expected " + type.name + " to be thrown");
+}
+async function assertRejects(promise, check) {
+  try { await promise; } catch (e) { assert(check(e), "unexpected rejection: " + e); return; }
+  throw new Error("expected promise to be rejected");
+}
+async function readAll(reader) {
+  const chunks = [];
+  for (;;) {
+    const { value, done } = await reader.read();
+    if (done) return chunks;
+    chunks.push(value);
+  }
+}
+`)
+	require.NoError(t, err)
+
+	err = r.EventLoop.Start(func() error {
+		_, err := r.VU.Runtime().RunString(script)
+		return err
+	})
+	require.NoError(t, err)
+}
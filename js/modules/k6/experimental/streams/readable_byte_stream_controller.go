@@ -0,0 +1,1771 @@
+package streams
+
+import (
+	"math"
+
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/common"
+	"gopkg.in/guregu/null.v3"
+)
+
+// ReadableByteStreamController is the controller of a readable byte stream. It has
+// methods to control the stream's state and internal queue, and to respond to the
+// reads performed by a [ReadableStreamBYOBReader].
+//
+// For more details, see the [specification].
+//
+// [specification]: https://streams.spec.whatwg.org/#rbs-controller-class
+type ReadableByteStreamController struct {
+	// autoAllocateChunkSize is a positive integer, when the automatic buffer allocation
+	// feature is enabled, in which case it is the size of the buffer to allocate, or
+	// zero otherwise.
+	autoAllocateChunkSize int64
+
+	// byobRequest is a [ReadableStreamBYOBRequest] instance representing the current
+	// BYOB pull request, or nil if there are no pending requests.
+	byobRequest *ReadableStreamBYOBRequest
+
+	// cancelAlgorithm is a promise-returning algorithm, taking one argument (the cancel
+	// reason), which communicates a requested cancelation to the underlying byte source.
+	cancelAlgorithm UnderlyingSourceCancelCallback
+
+	// closeRequested is a boolean flag indicating whether the stream has been closed by
+	// its underlying byte source, but still has chunks in its internal queue that have
+	// not yet been read.
+	closeRequested bool
+
+	// pullAgain is a boolean flag set to true if the stream's mechanisms requested a call
+	// to the underlying byte source's pull algorithm to pull more data, but the pull could
+	// not yet be done since a previous call is still executing.
+	pullAgain bool
+
+	// pullAlgorithm is a promise-returning algorithm that pulls data from the underlying
+	// byte source.
+	pullAlgorithm UnderlyingSourcePullCallback
+
+	// pulling is a boolean flag set to true while the underlying byte source's pull
+	// algorithm is executing and the returned promise has not yet fulfilled, used to
+	// prevent reentrant calls.
+	pulling bool
+
+	// pendingPullIntos is a list of pull-into descriptors.
+	pendingPullIntos []*pullIntoDescriptor
+
+	// queue is a list representing the stream's internal queue of chunks.
+	queue []*readableByteStreamQueueEntry
+
+	// queueTotalSize is the total size, in bytes, of all the chunks stored in queue.
+	queueTotalSize float64
+
+	// started is a boolean flag indicating whether the underlying byte source has
+	// finished starting.
+	started bool
+
+	// strategyHWM is a number supplied to the constructor as part of the stream's queuing
+	// strategy, indicating the point at which the stream will apply backpressure to its
+	// underlying byte source.
+	strategyHWM float64
+
+	// stream is the readable stream that this controller controls.
+	stream *ReadableStream
+
+	// object is the [sobek.Object] representing the controller in the runtime.
+	object *sobek.Object
+}
+
+// Ensure that ReadableByteStreamController implements the ReadableStreamController interface.
+var _ ReadableStreamController = &ReadableByteStreamController{}
+
+// readableByteStreamQueueEntry encapsulates the important aspects of a chunk
+// for the specific case of readable byte streams.
+//
+// [specification]: https://streams.spec.whatwg.org/#readable-byte-stream-queue-entry
+type readableByteStreamQueueEntry struct {
+	buffer     sobek.ArrayBuffer
+	byteOffset int
+	byteLength int
+}
+
+// readerType is the type of the reader a [pullIntoDescriptor] was created for.
+type readerType string
+
+const (
+	readerTypeDefault readerType = "default"
+	readerTypeBYOB    readerType = "byob"
+	readerTypeNone    readerType = "none"
+)
+
+// pullIntoDescriptor holds the state of a pending read into a buffer.
+//
+// [specification]: https://streams.spec.whatwg.org/#pull-into-descriptor
+type pullIntoDescriptor struct {
+	// buffer is an ArrayBuffer.
+	buffer sobek.ArrayBuffer
+
+	// bufferByteLength is a positive integer representing the initial byte length of buffer.
+	bufferByteLength int
+
+	// byteOffset is a nonnegative integer byte offset into the buffer where the
+	// underlying byte source will start writing.
+	byteOffset int
+
+	// byteLength is a positive integer number of bytes which can be written into the buffer.
+	byteLength int
+
+	// bytesFilled is a nonnegative integer number of bytes that have been written into
+	// the buffer so far.
+	bytesFilled int
+
+	// minimumFill is a positive integer representing the minimum number of bytes that
+	// must be written into the buffer before the associated read() request may be fulfilled.
+	minimumFill int
+
+	// elementSize is a positive integer representing the number of bytes that can be
+	// written into the buffer at a time, using views of the type described by the
+	// viewConstructor.
+	elementSize int
+
+	// viewConstructor is a typed array constructor or %DataView%, which will be used for
+	// constructing a view with which to write into the buffer.
+	viewConstructor sobek.Value
+
+	// readerType is either "default", "byob" or "none", indicating what type of readable
+	// stream reader initiated this request.
+	readerType readerType
+}
+
+// NewReadableByteStreamControllerObject creates a new [sobek.Object] from a
+// [ReadableByteStreamController] instance.
+func NewReadableByteStreamControllerObject(controller *ReadableByteStreamController) (*sobek.Object, error) {
+	rt := controller.stream.runtime
+	obj := rt.NewObject()
+	objName := "ReadableByteStreamController"
+
+	err := obj.DefineAccessorProperty("byobRequest", rt.ToValue(func() sobek.Value {
+		return controller.ByobRequest()
+	}), nil, sobek.FLAG_FALSE, sobek.FLAG_TRUE)
+	if err != nil {
+		return nil, err
+	}
+
+	err = obj.DefineAccessorProperty("desiredSize", rt.ToValue(func() sobek.Value {
+		desiredSize := controller.getDesiredSize()
+		if !desiredSize.Valid {
+			return sobek.Null()
+		}
+		return rt.ToValue(desiredSize.Float64)
+	}), nil, sobek.FLAG_FALSE, sobek.FLAG_TRUE)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "close", rt.ToValue(controller.Close)); err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "enqueue", rt.ToValue(controller.Enqueue)); err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "error", rt.ToValue(controller.Error)); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// ByobRequest returns the current BYOB pull request, or null if there isn't one.
+//
+// It implements the ReadableByteStreamController.byobRequest [specification] getter.
+//
+// [specification]: https://streams.spec.whatwg.org/#rbs-controller-byob-request
+func (controller *ReadableByteStreamController) ByobRequest() sobek.Value {
+	// 1. Return ! ReadableByteStreamControllerGetBYOBRequest(this).
+	request := controller.getBYOBRequest()
+	if request == nil {
+		return sobek.Null()
+	}
+
+	return request.toObject()
+}
+
+// Close closes the stream.
+//
+// It implements the ReadableByteStreamController.close() [specification] algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#rbs-controller-close
+func (controller *ReadableByteStreamController) Close() {
+	rt := controller.stream.runtime
+
+	// 1. If this.[[closeRequested]] is true, throw a TypeError exception.
+	if controller.closeRequested {
+		throw(rt, newTypeError(rt, "stream is already closing"))
+	}
+
+	// 2. If this.[[stream]].[[state]] is not "readable", throw a TypeError exception.
+	if controller.stream.state != ReadableStreamStateReadable {
+		throw(rt, newTypeError(rt, "cannot close a stream that is not readable"))
+	}
+
+	// 3. Perform ? ReadableByteStreamControllerClose(this).
+	if err := controller.close(); err != nil {
+		throw(rt, err)
+	}
+}
+
+// Enqueue enqueues the given chunk, which must be an ArrayBufferView, to the stream.
+//
+// It implements the ReadableByteStreamController.enqueue(chunk) [specification] algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#rbs-controller-enqueue
+func (controller *ReadableByteStreamController) Enqueue(chunk sobek.Value) {
+	rt := controller.stream.runtime
+
+	view, ok := asArrayBufferView(rt, chunk)
+	if !ok {
+		throw(rt, newTypeError(rt, "chunk must be an ArrayBufferView"))
+	}
+
+	// 1. If chunk.[[ByteLength]] is 0, throw a TypeError exception.
+	if view.byteLength == 0 {
+		throw(rt, newTypeError(rt, "chunk must have a non-zero byteLength"))
+	}
+
+	// 2. If chunk.[[ViewedArrayBuffer]].[[ArrayBufferByteLength]] is 0, throw a TypeError exception.
+	if len(view.buffer.Bytes()) == 0 {
+		throw(rt, newTypeError(rt, "chunk's buffer must have a non-zero byteLength"))
+	}
+
+	// 3. If this.[[closeRequested]] is true, throw a TypeError exception.
+	if controller.closeRequested {
+		throw(rt, newTypeError(rt, "stream is closed or draining"))
+	}
+
+	// 4. If this.[[stream]].[[state]] is not "readable", throw a TypeError exception.
+	if controller.stream.state != ReadableStreamStateReadable {
+		throw(rt, newTypeError(rt, "cannot enqueue to a stream that is not readable"))
+	}
+
+	// 5. Return ? ReadableByteStreamControllerEnqueue(this, chunk).
+	if err := controller.enqueue(view); err != nil {
+		throw(rt, err)
+	}
+}
+
+// Error signals that the stream has been errored, and performs the necessary cleanup
+// steps.
+//
+// It implements the ReadableByteStreamController.error(e) [specification] algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#rbs-controller-error
+func (controller *ReadableByteStreamController) Error(err sobek.Value) {
+	if err == nil {
+		err = sobek.Undefined()
+	}
+
+	// 1. Perform ! ReadableByteStreamControllerError(this, e).
+	controller.error(err)
+}
+
+// cancelSteps implements the ReadableByteStreamController [[CancelSteps]] [specification]
+// algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#rbs-controller-private-cancel
+func (controller *ReadableByteStreamController) cancelSteps(reason any) *sobek.Promise {
+	// 1. Perform ! ReadableByteStreamControllerClearPendingPullIntos(this).
+	controller.clearPendingPullIntos()
+
+	// 2. Perform ! ResetQueue(this).
+	controller.resetQueue()
+
+	// 3. Let result be the result of performing this.[[cancelAlgorithm]], passing in reason.
+	result := controller.cancelAlgorithm(reason)
+
+	// 4. Perform ! ReadableByteStreamControllerClearAlgorithms(this).
+	controller.clearAlgorithms()
+
+	// 5. Return result.
+	if p, ok := result.Export().(*sobek.Promise); ok {
+		return p
+	}
+
+	return newRejectedPromise(controller.stream.vu, newError(RuntimeError, "cancel algorithm error"))
+}
+
+// pullSteps implements the ReadableByteStreamController [[PullSteps]] [specification]
+// algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#rbs-controller-private-pull
+func (controller *ReadableByteStreamController) pullSteps(readRequest ReadRequest) {
+	rt := controller.stream.runtime
+
+	// 1. Let stream be this.[[stream]].
+	stream := controller.stream
+
+	// 2. Assert: ! ReadableStreamHasDefaultReader(stream) is true.
+	if !stream.hasDefaultReader() {
+		common.Throw(rt, newError(AssertionError, "stream does not have a default reader"))
+	}
+
+	// 3. If this.[[queueTotalSize]] > 0,
+	if controller.queueTotalSize > 0 {
+		// 3.1. Assert: ! ReadableStreamGetNumReadRequests(stream) is 0.
+		if stream.getNumReadRequests() != 0 {
+			common.Throw(rt, newError(AssertionError, "stream has pending read requests"))
+		}
+
+		// 3.2. Perform ! ReadableByteStreamControllerFillReadRequestFromQueue(this, readRequest).
+		controller.fillReadRequestFromQueue(readRequest)
+
+		// 3.3. Return.
+		return
+	}
+
+	// 4. Let autoAllocateChunkSize be this.[[autoAllocateChunkSize]].
+	autoAllocateChunkSize := int(controller.autoAllocateChunkSize)
+
+	// 5. If autoAllocateChunkSize is not undefined,
+	if autoAllocateChunkSize > 0 {
+		// 5.1. Let buffer be Construct(%ArrayBuffer%, « autoAllocateChunkSize »).
+		// 5.2. If buffer is an abrupt completion,
+		//   5.2.1. Perform readRequest’s error steps, given buffer.[[Value]].
+		//   5.2.2. Return.
+		buffer := rt.NewArrayBuffer(make([]byte, autoAllocateChunkSize))
+
+		// 5.3. Let pullIntoDescriptor be a new pull-into descriptor with...
+		pullIntoDescriptor := &pullIntoDescriptor{
+			buffer:           buffer,
+			bufferByteLength: autoAllocateChunkSize,
+			byteOffset:       0,
+			byteLength:       autoAllocateChunkSize,
+			bytesFilled:      0,
+			minimumFill:      1,
+			elementSize:      1,
+			viewConstructor:  rt.Get("Uint8Array"),
+			readerType:       readerTypeDefault,
+		}
+
+		// 5.4. Append pullIntoDescriptor to this.[[pendingPullIntos]].
+		controller.pendingPullIntos = append(controller.pendingPullIntos, pullIntoDescriptor)
+	}
+
+	// 6. Perform ! ReadableStreamAddReadRequest(stream, readRequest).
+	stream.addReadRequest(readRequest)
+
+	// 7. Perform ! ReadableByteStreamControllerCallPullIfNeeded(this).
+	controller.callPullIfNeeded()
+}
+
+// releaseSteps implements the ReadableByteStreamController [[ReleaseSteps]] [specification]
+// algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#abstract-opdef-readablebytestreamcontroller-releasesteps
+func (controller *ReadableByteStreamController) releaseSteps() {
+	// 1. If this.[[pendingPullIntos]] is not empty,
+	if len(controller.pendingPullIntos) > 0 {
+		// 1.1. Let firstPendingPullInto be this.[[pendingPullIntos]][0].
+		firstPendingPullInto := controller.pendingPullIntos[0]
+
+		// 1.2. Set firstPendingPullInto’s reader type to "none".
+		firstPendingPullInto.readerType = readerTypeNone
+
+		// 1.3. Set this.[[pendingPullIntos]] to the list « firstPendingPullInto ».
+		controller.pendingPullIntos = []*pullIntoDescriptor{firstPendingPullInto}
+	}
+}
+
+// toObject returns the [sobek.Object] representing the controller, which is created
+// once and reused afterward, so the underlying byte source always sees the same one.
+func (controller *ReadableByteStreamController) toObject() (*sobek.Object, error) {
+	if controller.object != nil {
+		return controller.object, nil
+	}
+
+	obj, err := NewReadableByteStreamControllerObject(controller)
+	if err != nil {
+		return nil, err
+	}
+	controller.object = obj
+
+	return obj, nil
+}
+
+// setup implements the specification's [SetUpReadableByteStreamController] abstract operation.
+//
+// [SetUpReadableByteStreamController]: https://streams.spec.whatwg.org/#set-up-readable-byte-stream-controller
+func (controller *ReadableByteStreamController) setup(
+	stream *ReadableStream,
+	startAlgorithm UnderlyingSourceStartCallback,
+	pullAlgorithm UnderlyingSourcePullCallback,
+	cancelAlgorithm UnderlyingSourceCancelCallback,
+	highWaterMark float64,
+	autoAllocateChunkSize int64,
+) {
+	rt := stream.runtime
+
+	// 1. Assert: stream.[[controller]] is undefined.
+	if stream.controller != nil {
+		common.Throw(rt, newError(AssertionError, "stream.[[controller]] is not undefined"))
+	}
+
+	// 2. If autoAllocateChunkSize is not undefined,
+	//   2.1. Assert: ! IsInteger(autoAllocateChunkSize) is true.
+	//   2.2. Assert: autoAllocateChunkSize is positive.
+	if autoAllocateChunkSize < 0 {
+		common.Throw(rt, newError(AssertionError, "autoAllocateChunkSize is not positive"))
+	}
+
+	// 3. Set controller.[[stream]] to stream.
+	controller.stream = stream
+
+	// 4. Set controller.[[pullAgain]] and controller.[[pulling]] to false.
+	controller.pullAgain, controller.pulling = false, false
+
+	// 5. Set controller.[[byobRequest]] to null.
+	controller.byobRequest = nil
+
+	// 6. Perform ! ResetQueue(controller).
+	controller.resetQueue()
+
+	// 7. Set controller.[[closeRequested]] and controller.[[started]] to false.
+	controller.closeRequested, controller.started = false, false
+
+	// 8. Set controller.[[strategyHWM]] to highWaterMark.
+	controller.strategyHWM = highWaterMark
+
+	// 9. Set controller.[[pullAlgorithm]] to pullAlgorithm.
+	controller.pullAlgorithm = pullAlgorithm
+
+	// 10. Set controller.[[cancelAlgorithm]] to cancelAlgorithm.
+	controller.cancelAlgorithm = cancelAlgorithm
+
+	// 11. Set controller.[[autoAllocateChunkSize]] to autoAllocateChunkSize.
+	controller.autoAllocateChunkSize = autoAllocateChunkSize
+
+	// 12. Set controller.[[pendingPullIntos]] to a new empty list.
+	controller.pendingPullIntos = []*pullIntoDescriptor{}
+
+	// 13. Set stream.[[controller]] to controller.
+	stream.controller = controller
+
+	// 14. Let startResult be the result of performing startAlgorithm.
+	controllerObj, err := controller.toObject()
+	if err != nil {
+		common.Throw(rt, newError(RuntimeError, err.Error()))
+	}
+	startResult := startAlgorithm(controllerObj)
+
+	// 15. Let startPromise be a promise resolved with startResult.
+	startPromise := promiseResolve(stream.vu, startResult)
+
+	uponPromise(rt, startPromise,
+		// 16. Upon fulfillment of startPromise,
+		func(sobek.Value) {
+			// 16.1. Set controller.[[started]] to true.
+			controller.started = true
+
+			// 16.2. Assert: controller.[[pulling]] is false.
+			if controller.pulling {
+				common.Throw(rt, newError(AssertionError, "controller `pulling` state is not false"))
+			}
+
+			// 16.3. Assert: controller.[[pullAgain]] is false.
+			if controller.pullAgain {
+				common.Throw(rt, newError(AssertionError, "controller `pullAgain` state is not false"))
+			}
+
+			// 16.4. Perform ! ReadableByteStreamControllerCallPullIfNeeded(controller).
+			controller.callPullIfNeeded()
+		},
+		// 17. Upon rejection of startPromise with reason r,
+		func(r sobek.Value) {
+			// 17.1. Perform ! ReadableByteStreamControllerError(controller, r).
+			controller.error(r)
+		},
+	)
+}
+
+// callPullIfNeeded implements the specification's [ReadableByteStreamControllerCallPullIfNeeded]
+// abstract operation.
+//
+// [ReadableByteStreamControllerCallPullIfNeeded]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-call-pull-if-needed
+func (controller *ReadableByteStreamController) callPullIfNeeded() {
+	rt := controller.stream.runtime
+
+	// 1. Let shouldPull be ! ReadableByteStreamControllerShouldCallPull(controller).
+	// 2. If shouldPull is false, return.
+	if !controller.shouldCallPull() {
+		return
+	}
+
+	// 3. If controller.[[pulling]] is true,
+	if controller.pulling {
+		// 3.1. Set controller.[[pullAgain]] to true.
+		controller.pullAgain = true
+		// 3.2. Return.
+		return
+	}
+
+	// 4. Assert: controller.[[pullAgain]] is false.
+	if controller.pullAgain {
+		common.Throw(rt, newError(AssertionError, "controller.pullAgain is true"))
+	}
+
+	// 5. Set controller.[[pulling]] to true.
+	controller.pulling = true
+
+	// 6. Let pullPromise be the result of performing controller.[[pullAlgorithm]].
+	controllerObj, err := controller.toObject()
+	if err != nil {
+		common.Throw(rt, newError(RuntimeError, err.Error()))
+	}
+	pullPromise := controller.pullAlgorithm(controllerObj)
+
+	uponPromise(rt, pullPromise,
+		// 7. Upon fulfillment of pullPromise,
+		func(sobek.Value) {
+			// 7.1. Set controller.[[pulling]] to false.
+			controller.pulling = false
+
+			// 7.2. If controller.[[pullAgain]] is true,
+			if controller.pullAgain {
+				// 7.2.1. Set controller.[[pullAgain]] to false.
+				controller.pullAgain = false
+				// 7.2.2. Perform ! ReadableByteStreamControllerCallPullIfNeeded(controller).
+				controller.callPullIfNeeded()
+			}
+		},
+		// 8. Upon rejection of pullPromise with reason e,
+		func(e sobek.Value) {
+			// 8.1. Perform ! ReadableByteStreamControllerError(controller, e).
+			controller.error(e)
+		},
+	)
+}
+
+// clearAlgorithms implements the specification's [ReadableByteStreamControllerClearAlgorithms]
+// abstract operation.
+//
+// [ReadableByteStreamControllerClearAlgorithms]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-clear-algorithms
+func (controller *ReadableByteStreamController) clearAlgorithms() {
+	// 1. Set controller.[[pullAlgorithm]] to undefined.
+	controller.pullAlgorithm = nil
+
+	// 2. Set controller.[[cancelAlgorithm]] to undefined.
+	controller.cancelAlgorithm = nil
+}
+
+// clearPendingPullIntos implements the specification's [ReadableByteStreamControllerClearPendingPullIntos]
+// abstract operation.
+//
+// [ReadableByteStreamControllerClearPendingPullIntos]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-clear-pending-pull-intos
+func (controller *ReadableByteStreamController) clearPendingPullIntos() {
+	// 1. Perform ! ReadableByteStreamControllerInvalidateBYOBRequest(controller).
+	controller.invalidateBYOBRequest()
+
+	// 2. Set controller.[[pendingPullIntos]] to a new empty list.
+	controller.pendingPullIntos = []*pullIntoDescriptor{}
+}
+
+// close implements the specification's [ReadableByteStreamControllerClose] abstract operation.
+//
+// [ReadableByteStreamControllerClose]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-close
+func (controller *ReadableByteStreamController) close() error {
+	// 1. Let stream be controller.[[stream]].
+	stream := controller.stream
+
+	// 2. If controller.[[closeRequested]] is true or stream.[[state]] is not "readable", return.
+	if controller.closeRequested || stream.state != ReadableStreamStateReadable {
+		return nil
+	}
+
+	// 3. If controller.[[queueTotalSize]] > 0,
+	if controller.queueTotalSize > 0 {
+		// 3.1. Set controller.[[closeRequested]] to true.
+		controller.closeRequested = true
+		// 3.2. Return.
+		return nil
+	}
+
+	// 4. If controller.[[pendingPullIntos]] is not empty,
+	if len(controller.pendingPullIntos) > 0 {
+		// 4.1. Let firstPendingPullInto be controller.[[pendingPullIntos]][0].
+		firstPendingPullInto := controller.pendingPullIntos[0]
+
+		// 4.2. If the remainder after dividing firstPendingPullInto’s bytes filled by
+		// firstPendingPullInto’s element size is not 0,
+		if firstPendingPullInto.bytesFilled%firstPendingPullInto.elementSize != 0 {
+			// 4.2.1. Let e be a new TypeError exception.
+			e := newTypeError(stream.runtime, "insufficient bytes to fill elements in the given buffer")
+			// 4.2.2. Perform ! ReadableByteStreamControllerError(controller, e).
+			controller.error(e.Err())
+			// 4.2.3. Throw e.
+			return e
+		}
+	}
+
+	// 5. Perform ! ReadableByteStreamControllerClearAlgorithms(controller).
+	controller.clearAlgorithms()
+
+	// 6. Perform ! ReadableStreamClose(stream).
+	stream.close()
+
+	return nil
+}
+
+// commitPullIntoDescriptor implements the specification's [ReadableByteStreamControllerCommitPullIntoDescriptor]
+// abstract operation.
+//
+// [ReadableByteStreamControllerCommitPullIntoDescriptor]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-commit-pull-into-descriptor
+func (controller *ReadableByteStreamController) commitPullIntoDescriptor(pullIntoDescriptor *pullIntoDescriptor) {
+	stream := controller.stream
+	rt := stream.runtime
+
+	// 1. Assert: stream.[[state]] is not "errored".
+	if stream.state == ReadableStreamStateErrored {
+		common.Throw(rt, newError(AssertionError, "stream is errored"))
+	}
+
+	// 2. Assert: pullIntoDescriptor.reader type is not "none".
+	if pullIntoDescriptor.readerType == readerTypeNone {
+		common.Throw(rt, newError(AssertionError, "pull-into descriptor has no reader"))
+	}
+
+	// 3. Let done be false.
+	done := false
+
+	// 4. If stream.[[state]] is "closed",
+	if stream.state == ReadableStreamStateClosed {
+		// 4.1. Assert: the remainder after dividing pullIntoDescriptor’s bytes filled by
+		// pullIntoDescriptor’s element size is 0.
+		if pullIntoDescriptor.bytesFilled%pullIntoDescriptor.elementSize != 0 {
+			common.Throw(rt, newError(AssertionError, "pull-into descriptor holds a partial element"))
+		}
+
+		// 4.2. Set done to true.
+		done = true
+	}
+
+	// 5. Let filledView be ! ReadableByteStreamControllerConvertPullIntoDescriptor(pullIntoDescriptor).
+	filledView := controller.convertPullIntoDescriptor(pullIntoDescriptor)
+
+	// 6. If pullIntoDescriptor’s reader type is "default",
+	if pullIntoDescriptor.readerType == readerTypeDefault {
+		// 6.1. Perform ! ReadableStreamFulfillReadRequest(stream, filledView, done).
+		stream.fulfillReadRequest(filledView, done)
+		return
+	}
+
+	// 7. Otherwise,
+	// 7.1. Assert: pullIntoDescriptor’s reader type is "byob".
+	// 7.2. Perform ! ReadableStreamFulfillReadIntoRequest(stream, filledView, done).
+	stream.fulfillReadIntoRequest(filledView, done)
+}
+
+// convertPullIntoDescriptor implements the specification's [ReadableByteStreamControllerConvertPullIntoDescriptor]
+// abstract operation.
+//
+// [ReadableByteStreamControllerConvertPullIntoDescriptor]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-convert-pull-into-descriptor
+func (controller *ReadableByteStreamController) convertPullIntoDescriptor(
+	pullIntoDescriptor *pullIntoDescriptor,
+) *sobek.Object {
+	rt := controller.stream.runtime
+
+	// 1. Let bytesFilled be pullIntoDescriptor’s bytes filled.
+	bytesFilled := pullIntoDescriptor.bytesFilled
+
+	// 2. Let elementSize be pullIntoDescriptor’s element size.
+	elementSize := pullIntoDescriptor.elementSize
+
+	// 3. Assert: bytesFilled ≤ pullIntoDescriptor’s byte length.
+	if bytesFilled > pullIntoDescriptor.byteLength {
+		common.Throw(rt, newError(AssertionError, "pull-into descriptor is overfilled"))
+	}
+
+	// 4. Assert: the remainder after dividing bytesFilled by elementSize is 0.
+	if bytesFilled%elementSize != 0 {
+		common.Throw(rt, newError(AssertionError, "pull-into descriptor holds a partial element"))
+	}
+
+	// 5. Let buffer be ! TransferArrayBuffer(pullIntoDescriptor’s buffer).
+	buffer, err := transferArrayBuffer(rt, pullIntoDescriptor.buffer)
+	if err != nil {
+		common.Throw(rt, err)
+	}
+
+	// 6. Return ! Construct(pullIntoDescriptor’s view constructor,
+	// « buffer, pullIntoDescriptor’s byte offset, bytesFilled ÷ elementSize »).
+	view, err := newArrayBufferView(
+		rt, pullIntoDescriptor.viewConstructor, buffer, pullIntoDescriptor.byteOffset, bytesFilled/elementSize,
+	)
+	if err != nil {
+		common.Throw(rt, err)
+	}
+
+	return view
+}
+
+// enqueue implements the specification's [ReadableByteStreamControllerEnqueue] abstract operation.
+//
+// [ReadableByteStreamControllerEnqueue]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-enqueue
+func (controller *ReadableByteStreamController) enqueue(chunk arrayBufferView) error {
+	rt := controller.stream.runtime
+
+	// 1. Let stream be controller.[[stream]].
+	stream := controller.stream
+
+	// 2. If controller.[[closeRequested]] is true or stream.[[state]] is not "readable", return.
+	if controller.closeRequested || stream.state != ReadableStreamStateReadable {
+		return nil
+	}
+
+	// 3. Let buffer be chunk.[[ViewedArrayBuffer]].
+	// 4. Let byteOffset be chunk.[[ByteOffset]].
+	// 5. Let byteLength be chunk.[[ByteLength]].
+	buffer, byteOffset, byteLength := chunk.buffer, chunk.byteOffset, chunk.byteLength
+
+	// 6. If ! IsDetachedBuffer(buffer) is true, throw a TypeError exception.
+	if buffer.Detached() {
+		return newTypeError(rt, "chunk's buffer is detached")
+	}
+
+	// 7. Let transferredBuffer be ? TransferArrayBuffer(buffer).
+	transferredBuffer, err := transferArrayBuffer(rt, buffer)
+	if err != nil {
+		return err
+	}
+
+	// 8. If controller.[[pendingPullIntos]] is not empty,
+	if len(controller.pendingPullIntos) > 0 {
+		// 8.1. Let firstPendingPullInto be controller.[[pendingPullIntos]][0].
+		firstPendingPullInto := controller.pendingPullIntos[0]
+
+		// 8.2. If ! IsDetachedBuffer(firstPendingPullInto’s buffer) is true, throw a TypeError exception.
+		if firstPendingPullInto.buffer.Detached() {
+			return newTypeError(rt, "the BYOB request's buffer has been detached")
+		}
+
+		// 8.3. Perform ! ReadableByteStreamControllerInvalidateBYOBRequest(controller).
+		controller.invalidateBYOBRequest()
+
+		// 8.4. Set firstPendingPullInto’s buffer to ! TransferArrayBuffer(firstPendingPullInto’s buffer).
+		firstPendingPullInto.buffer, err = transferArrayBuffer(rt, firstPendingPullInto.buffer)
+		if err != nil {
+			return err
+		}
+
+		// 8.5. If firstPendingPullInto’s reader type is "none", perform ?
+		// ReadableByteStreamControllerEnqueueDetachedPullIntoToQueue(controller, firstPendingPullInto).
+		if firstPendingPullInto.readerType == readerTypeNone {
+			if err := controller.enqueueDetachedPullIntoToQueue(firstPendingPullInto); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch {
+	// 9. If ! ReadableStreamHasDefaultReader(stream) is true,
+	case stream.hasDefaultReader():
+		// 9.1. Perform ! ReadableByteStreamControllerProcessReadRequestsUsingQueue(controller).
+		controller.processReadRequestsUsingQueue()
+
+		// 9.2. If ! ReadableStreamGetNumReadRequests(stream) is 0,
+		if stream.getNumReadRequests() == 0 {
+			// 9.2.1. Assert: controller.[[pendingPullIntos]] is empty.
+			if len(controller.pendingPullIntos) > 0 {
+				common.Throw(rt, newError(AssertionError, "controller has pending pull-into descriptors"))
+			}
+
+			// 9.2.2. Perform ! ReadableByteStreamControllerEnqueueChunkToQueue(controller,
+			// transferredBuffer, byteOffset, byteLength).
+			controller.enqueueChunkToQueue(transferredBuffer, byteOffset, byteLength)
+		} else { // 9.3. Otherwise,
+			// 9.3.1. Assert: controller.[[queue]] is empty.
+			if len(controller.queue) > 0 {
+				common.Throw(rt, newError(AssertionError, "controller's queue is not empty"))
+			}
+
+			// 9.3.2. If controller.[[pendingPullIntos]] is not empty,
+			if len(controller.pendingPullIntos) > 0 {
+				// 9.3.2.1. Assert: controller.[[pendingPullIntos]][0]'s reader type is "default".
+				if controller.pendingPullIntos[0].readerType != readerTypeDefault {
+					common.Throw(rt, newError(AssertionError, "pull-into descriptor is not for a default reader"))
+				}
+
+				// 9.3.2.2. Perform ! ReadableByteStreamControllerShiftPendingPullInto(controller).
+				controller.shiftPendingPullInto()
+			}
+
+			// 9.3.3. Let transferredView be ! Construct(%Uint8Array%, « transferredBuffer, byteOffset, byteLength »).
+			transferredView, err := newUint8Array(rt, transferredBuffer, byteOffset, byteLength)
+			if err != nil {
+				return err
+			}
+
+			// 9.3.4. Perform ! ReadableStreamFulfillReadRequest(stream, transferredView, false).
+			stream.fulfillReadRequest(transferredView, false)
+		}
+
+	// 10. Otherwise, if ! ReadableStreamHasBYOBReader(stream) is true,
+	case stream.hasBYOBReader():
+		// 10.1. Perform ! ReadableByteStreamControllerEnqueueChunkToQueue(controller,
+		// transferredBuffer, byteOffset, byteLength).
+		controller.enqueueChunkToQueue(transferredBuffer, byteOffset, byteLength)
+
+		// 10.2. Let filledPullIntos be the result of performing !
+		// ReadableByteStreamControllerProcessPullIntoDescriptorsUsingQueue(controller).
+		filledPullIntos := controller.processPullIntoDescriptorsUsingQueue()
+
+		// 10.3. For each filledPullInto of filledPullIntos,
+		for _, filledPullInto := range filledPullIntos {
+			// 10.3.1. Perform ! ReadableByteStreamControllerCommitPullIntoDescriptor(stream, filledPullInto).
+			controller.commitPullIntoDescriptor(filledPullInto)
+		}
+
+	// 11. Otherwise,
+	default:
+		// 11.1. Assert: ! IsReadableStreamLocked(stream) is false.
+		if stream.isLocked() {
+			common.Throw(rt, newError(AssertionError, "stream is locked"))
+		}
+
+		// 11.2. Perform ! ReadableByteStreamControllerEnqueueChunkToQueue(controller,
+		// transferredBuffer, byteOffset, byteLength).
+		controller.enqueueChunkToQueue(transferredBuffer, byteOffset, byteLength)
+	}
+
+	// 12. Perform ! ReadableByteStreamControllerCallPullIfNeeded(controller).
+	controller.callPullIfNeeded()
+
+	return nil
+}
+
+// enqueueChunkToQueue implements the specification's [ReadableByteStreamControllerEnqueueChunkToQueue]
+// abstract operation.
+//
+// [ReadableByteStreamControllerEnqueueChunkToQueue]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-enqueue-chunk-to-queue
+func (controller *ReadableByteStreamController) enqueueChunkToQueue(
+	buffer sobek.ArrayBuffer,
+	byteOffset, byteLength int,
+) {
+	// 1. Append a new readable byte stream queue entry with buffer buffer, byte offset
+	// byteOffset, and byte length byteLength to controller.[[queue]].
+	controller.queue = append(controller.queue, &readableByteStreamQueueEntry{
+		buffer:     buffer,
+		byteOffset: byteOffset,
+		byteLength: byteLength,
+	})
+
+	// 2. Set controller.[[queueTotalSize]] to controller.[[queueTotalSize]] + byteLength.
+	controller.queueTotalSize += float64(byteLength)
+}
+
+// enqueueClonedChunkToQueue implements the specification's [ReadableByteStreamControllerEnqueueClonedChunkToQueue]
+// abstract operation.
+//
+// [ReadableByteStreamControllerEnqueueClonedChunkToQueue]: https://streams.spec.whatwg.org/#abstract-opdef-readablebytestreamcontrollerenqueueclonedchunktoqueue
+func (controller *ReadableByteStreamController) enqueueClonedChunkToQueue(
+	buffer sobek.ArrayBuffer,
+	byteOffset, byteLength int,
+) error {
+	// 1. Let cloneResult be CloneArrayBuffer(buffer, byteOffset, byteLength, %ArrayBuffer%).
+	cloneResult, err := cloneArrayBuffer(controller.stream.runtime, buffer, byteOffset, byteLength)
+
+	// 2. If cloneResult is an abrupt completion,
+	if err != nil {
+		// 2.1. Perform ! ReadableByteStreamControllerError(controller, cloneResult.[[Value]]).
+		controller.error(err)
+		// 2.2. Return cloneResult.
+		return err
+	}
+
+	// 3. Perform ! ReadableByteStreamControllerEnqueueChunkToQueue(controller,
+	// cloneResult.[[Value]], 0, byteLength).
+	controller.enqueueChunkToQueue(cloneResult, 0, byteLength)
+
+	return nil
+}
+
+// enqueueDetachedPullIntoToQueue implements the specification's
+// [ReadableByteStreamControllerEnqueueDetachedPullIntoToQueue] abstract operation.
+//
+// [ReadableByteStreamControllerEnqueueDetachedPullIntoToQueue]: https://streams.spec.whatwg.org/#abstract-opdef-readablebytestreamcontrollerenqueuedetachedpullintotoqueue
+func (controller *ReadableByteStreamController) enqueueDetachedPullIntoToQueue(
+	pullIntoDescriptor *pullIntoDescriptor,
+) error {
+	// 1. Assert: pullIntoDescriptor’s reader type is "none".
+	if pullIntoDescriptor.readerType != readerTypeNone {
+		common.Throw(controller.stream.runtime, newError(AssertionError, "pull-into descriptor has a reader"))
+	}
+
+	// 2. If pullIntoDescriptor’s bytes filled > 0, perform ?
+	// ReadableByteStreamControllerEnqueueClonedChunkToQueue(controller, pullIntoDescriptor’s buffer,
+	// pullIntoDescriptor’s byte offset, pullIntoDescriptor’s bytes filled).
+	if pullIntoDescriptor.bytesFilled > 0 {
+		err := controller.enqueueClonedChunkToQueue(
+			pullIntoDescriptor.buffer, pullIntoDescriptor.byteOffset, pullIntoDescriptor.bytesFilled,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// 3. Perform ! ReadableByteStreamControllerShiftPendingPullInto(controller).
+	controller.shiftPendingPullInto()
+
+	return nil
+}
+
+// error implements the specification's [ReadableByteStreamControllerError] abstract operation.
+//
+// [ReadableByteStreamControllerError]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-error
+func (controller *ReadableByteStreamController) error(e any) {
+	// 1. Let stream be controller.[[stream]].
+	stream := controller.stream
+
+	// 2. If stream.[[state]] is not "readable", return.
+	if stream.state != ReadableStreamStateReadable {
+		return
+	}
+
+	// 3. Perform ! ReadableByteStreamControllerClearPendingPullIntos(controller).
+	controller.clearPendingPullIntos()
+
+	// 4. Perform ! ResetQueue(controller).
+	controller.resetQueue()
+
+	// 5. Perform ! ReadableByteStreamControllerClearAlgorithms(controller).
+	controller.clearAlgorithms()
+
+	// 6. Perform ! ReadableStreamError(stream, e).
+	stream.error(e)
+}
+
+// fillHeadPullIntoDescriptor implements the specification's [ReadableByteStreamControllerFillHeadPullIntoDescriptor]
+// abstract operation.
+//
+// [ReadableByteStreamControllerFillHeadPullIntoDescriptor]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-fill-head-pull-into-descriptor
+func (controller *ReadableByteStreamController) fillHeadPullIntoDescriptor(
+	size int,
+	pullIntoDescriptor *pullIntoDescriptor,
+) {
+	rt := controller.stream.runtime
+
+	// 1. Assert: either controller.[[pendingPullIntos]] is empty, or
+	// controller.[[pendingPullIntos]][0] is pullIntoDescriptor.
+	if len(controller.pendingPullIntos) > 0 && controller.pendingPullIntos[0] != pullIntoDescriptor {
+		common.Throw(rt, newError(AssertionError, "pull-into descriptor is not the head one"))
+	}
+
+	// 2. Assert: controller.[[byobRequest]] is null.
+	if controller.byobRequest != nil {
+		common.Throw(rt, newError(AssertionError, "controller.[[byobRequest]] is not null"))
+	}
+
+	// 3. Set pullIntoDescriptor’s bytes filled to bytes filled + size.
+	pullIntoDescriptor.bytesFilled += size
+}
+
+// fillPullIntoDescriptorFromQueue implements the specification's
+// [ReadableByteStreamControllerFillPullIntoDescriptorFromQueue] abstract operation.
+//
+// [ReadableByteStreamControllerFillPullIntoDescriptorFromQueue]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-fill-pull-into-descriptor-from-queue
+func (controller *ReadableByteStreamController) fillPullIntoDescriptorFromQueue(
+	pullIntoDescriptor *pullIntoDescriptor,
+) bool {
+	rt := controller.stream.runtime
+
+	// 1. Let maxBytesToCopy be min(controller.[[queueTotalSize]],
+	// pullIntoDescriptor’s byte length − pullIntoDescriptor’s bytes filled).
+	maxBytesToCopy := int(math.Min(
+		controller.queueTotalSize,
+		float64(pullIntoDescriptor.byteLength-pullIntoDescriptor.bytesFilled),
+	))
+
+	// 2. Let maxBytesFilled be pullIntoDescriptor’s bytes filled + maxBytesToCopy.
+	maxBytesFilled := pullIntoDescriptor.bytesFilled + maxBytesToCopy
+
+	// 3. Let totalBytesToCopyRemaining be maxBytesToCopy.
+	totalBytesToCopyRemaining := maxBytesToCopy
+
+	// 4. Let ready be false.
+	ready := false
+
+	// 5. Assert: pullIntoDescriptor’s bytes filled < pullIntoDescriptor’s minimum fill.
+	if pullIntoDescriptor.bytesFilled >= pullIntoDescriptor.minimumFill {
+		common.Throw(rt, newError(AssertionError, "pull-into descriptor is already filled"))
+	}
+
+	// 6. Let remainderBytes be the remainder after dividing maxBytesFilled by
+	// pullIntoDescriptor’s element size.
+	remainderBytes := maxBytesFilled % pullIntoDescriptor.elementSize
+
+	// 7. Let maxAlignedBytes be maxBytesFilled − remainderBytes.
+	maxAlignedBytes := maxBytesFilled - remainderBytes
+
+	// 8. If maxAlignedBytes ≥ pullIntoDescriptor’s minimum fill,
+	if maxAlignedBytes >= pullIntoDescriptor.minimumFill {
+		// 8.1. Set totalBytesToCopyRemaining to maxAlignedBytes − pullIntoDescriptor’s bytes filled.
+		totalBytesToCopyRemaining = maxAlignedBytes - pullIntoDescriptor.bytesFilled
+		// 8.2. Set ready to true.
+		ready = true
+	}
+
+	// 9. Let queue be controller.[[queue]].
+	// 10. While totalBytesToCopyRemaining > 0,
+	for totalBytesToCopyRemaining > 0 {
+		// 10.1. Let headOfQueue be queue[0].
+		headOfQueue := controller.queue[0]
+
+		// 10.2. Let bytesToCopy be min(totalBytesToCopyRemaining, headOfQueue’s byte length).
+		bytesToCopy := min(totalBytesToCopyRemaining, headOfQueue.byteLength)
+
+		// 10.3. Let destStart be pullIntoDescriptor’s byte offset + pullIntoDescriptor’s bytes filled.
+		destStart := pullIntoDescriptor.byteOffset + pullIntoDescriptor.bytesFilled
+
+		// 10.4. Perform ! CopyDataBlockBytes(pullIntoDescriptor’s buffer.[[ArrayBufferData]], destStart,
+		// headOfQueue’s buffer.[[ArrayBufferData]], headOfQueue’s byte offset, bytesToCopy).
+		copy(
+			pullIntoDescriptor.buffer.Bytes()[destStart:destStart+bytesToCopy],
+			headOfQueue.buffer.Bytes()[headOfQueue.byteOffset:headOfQueue.byteOffset+bytesToCopy],
+		)
+
+		// 10.5. If headOfQueue’s byte length is bytesToCopy,
+		if headOfQueue.byteLength == bytesToCopy {
+			// 10.5.1. Remove queue[0].
+			controller.queue = controller.queue[1:]
+		} else { // 10.6. Otherwise,
+			// 10.6.1. Set headOfQueue’s byte offset to headOfQueue’s byte offset + bytesToCopy.
+			headOfQueue.byteOffset += bytesToCopy
+			// 10.6.2. Set headOfQueue’s byte length to headOfQueue’s byte length − bytesToCopy.
+			headOfQueue.byteLength -= bytesToCopy
+		}
+
+		// 10.7. Set controller.[[queueTotalSize]] to controller.[[queueTotalSize]] − bytesToCopy.
+		controller.queueTotalSize -= float64(bytesToCopy)
+
+		// 10.8. Perform ! ReadableByteStreamControllerFillHeadPullIntoDescriptor(controller,
+		// bytesToCopy, pullIntoDescriptor).
+		controller.fillHeadPullIntoDescriptor(bytesToCopy, pullIntoDescriptor)
+
+		// 10.9. Set totalBytesToCopyRemaining to totalBytesToCopyRemaining − bytesToCopy.
+		totalBytesToCopyRemaining -= bytesToCopy
+	}
+
+	// 11. If ready is false,
+	if !ready {
+		// 11.1. Assert: controller.[[queueTotalSize]] is 0.
+		// 11.2. Assert: pullIntoDescriptor’s bytes filled > 0.
+		// 11.3. Assert: pullIntoDescriptor’s bytes filled < pullIntoDescriptor’s minimum fill.
+		if controller.queueTotalSize != 0 || pullIntoDescriptor.bytesFilled <= 0 ||
+			pullIntoDescriptor.bytesFilled >= pullIntoDescriptor.minimumFill {
+			common.Throw(rt, newError(AssertionError, "pull-into descriptor is in an unexpected state"))
+		}
+	}
+
+	// 12. Return ready.
+	return ready
+}
+
+// fillReadRequestFromQueue implements the specification's [ReadableByteStreamControllerFillReadRequestFromQueue]
+// abstract operation.
+//
+// [ReadableByteStreamControllerFillReadRequestFromQueue]: https://streams.spec.whatwg.org/#abstract-opdef-readablebytestreamcontrollerfillreadrequestfromqueue
+func (controller *ReadableByteStreamController) fillReadRequestFromQueue(readRequest ReadRequest) {
+	rt := controller.stream.runtime
+
+	// 1. Assert: controller.[[queueTotalSize]] > 0.
+	if controller.queueTotalSize <= 0 {
+		common.Throw(rt, newError(AssertionError, "controller's queue is empty"))
+	}
+
+	// 2. Let entry be controller.[[queue]][0].
+	entry := controller.queue[0]
+
+	// 3. Remove entry from controller.[[queue]].
+	controller.queue = controller.queue[1:]
+
+	// 4. Set controller.[[queueTotalSize]] to controller.[[queueTotalSize]] − entry’s byte length.
+	controller.queueTotalSize -= float64(entry.byteLength)
+
+	// 5. Perform ! ReadableByteStreamControllerHandleQueueDrain(controller).
+	controller.handleQueueDrain()
+
+	// 6. Let view be ! Construct(%Uint8Array%, « entry’s buffer, entry’s byte offset, entry’s byte length »).
+	view, err := newUint8Array(rt, entry.buffer, entry.byteOffset, entry.byteLength)
+	if err != nil {
+		readRequest.errorSteps(err)
+		return
+	}
+
+	// 7. Perform readRequest’s chunk steps, given view.
+	readRequest.chunkSteps(view)
+}
+
+// getBYOBRequest implements the specification's [ReadableByteStreamControllerGetBYOBRequest]
+// abstract operation.
+//
+// [ReadableByteStreamControllerGetBYOBRequest]: https://streams.spec.whatwg.org/#abstract-opdef-readablebytestreamcontrollergetbyobrequest
+func (controller *ReadableByteStreamController) getBYOBRequest() *ReadableStreamBYOBRequest {
+	rt := controller.stream.runtime
+
+	// 1. If controller.[[byobRequest]] is null and controller.[[pendingPullIntos]] is not empty,
+	if controller.byobRequest == nil && len(controller.pendingPullIntos) > 0 {
+		// 1.1. Let firstDescriptor be controller.[[pendingPullIntos]][0].
+		firstDescriptor := controller.pendingPullIntos[0]
+
+		// 1.2. Let view be ! Construct(%Uint8Array%, « firstDescriptor’s buffer, firstDescriptor’s
+		// byte offset + firstDescriptor’s bytes filled, firstDescriptor’s byte length − firstDescriptor’s
+		// bytes filled »).
+		view, err := newUint8Array(
+			rt,
+			firstDescriptor.buffer,
+			firstDescriptor.byteOffset+firstDescriptor.bytesFilled,
+			firstDescriptor.byteLength-firstDescriptor.bytesFilled,
+		)
+		if err != nil {
+			common.Throw(rt, err)
+		}
+
+		// 1.3. Let byobRequest be a new ReadableStreamBYOBRequest.
+		// 1.4. Set byobRequest.[[controller]] to controller.
+		// 1.5. Set byobRequest.[[view]] to view.
+		// 1.6. Set controller.[[byobRequest]] to byobRequest.
+		controller.byobRequest = &ReadableStreamBYOBRequest{
+			controller: controller,
+			view:       view,
+			runtime:    rt,
+		}
+	}
+
+	// 2. Return controller.[[byobRequest]].
+	return controller.byobRequest
+}
+
+// getDesiredSize implements the specification's [ReadableByteStreamControllerGetDesiredSize]
+// abstract operation.
+//
+// [ReadableByteStreamControllerGetDesiredSize]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-get-desired-size
+func (controller *ReadableByteStreamController) getDesiredSize() null.Float {
+	// 1. Let state be controller.[[stream]].[[state]].
+	state := controller.stream.state
+
+	// 2. If state is "errored", return null.
+	if state == ReadableStreamStateErrored {
+		return null.NewFloat(0, false)
+	}
+
+	// 3. If state is "closed", return 0.
+	if state == ReadableStreamStateClosed {
+		return null.NewFloat(0, true)
+	}
+
+	// 4. Return controller.[[strategyHWM]] − controller.[[queueTotalSize]].
+	return null.NewFloat(controller.strategyHWM-controller.queueTotalSize, true)
+}
+
+// handleQueueDrain implements the specification's [ReadableByteStreamControllerHandleQueueDrain]
+// abstract operation.
+//
+// [ReadableByteStreamControllerHandleQueueDrain]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-handle-queue-drain
+func (controller *ReadableByteStreamController) handleQueueDrain() {
+	// 1. Assert: controller.[[stream]].[[state]] is "readable".
+	if controller.stream.state != ReadableStreamStateReadable {
+		common.Throw(controller.stream.runtime, newError(AssertionError, "stream is not readable"))
+	}
+
+	// 2. If controller.[[queueTotalSize]] is 0 and controller.[[closeRequested]] is true,
+	if controller.queueTotalSize == 0 && controller.closeRequested {
+		// 2.1. Perform ! ReadableByteStreamControllerClearAlgorithms(controller).
+		controller.clearAlgorithms()
+		// 2.2. Perform ! ReadableStreamClose(controller.[[stream]]).
+		controller.stream.close()
+		return
+	}
+
+	// 3. Otherwise,
+	// 3.1. Perform ! ReadableByteStreamControllerCallPullIfNeeded(controller).
+	controller.callPullIfNeeded()
+}
+
+// invalidateBYOBRequest implements the specification's [ReadableByteStreamControllerInvalidateBYOBRequest]
+// abstract operation.
+//
+// [ReadableByteStreamControllerInvalidateBYOBRequest]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-invalidate-byob-request
+func (controller *ReadableByteStreamController) invalidateBYOBRequest() {
+	// 1. If controller.[[byobRequest]] is null, return.
+	if controller.byobRequest == nil {
+		return
+	}
+
+	// 2. Set controller.[[byobRequest]].[[controller]] to undefined.
+	controller.byobRequest.controller = nil
+
+	// 3. Set controller.[[byobRequest]].[[view]] to null.
+	controller.byobRequest.view = nil
+
+	// 4. Set controller.[[byobRequest]] to null.
+	controller.byobRequest = nil
+}
+
+// processPullIntoDescriptorsUsingQueue implements the specification's
+// [ReadableByteStreamControllerProcessPullIntoDescriptorsUsingQueue] abstract operation.
+//
+// [ReadableByteStreamControllerProcessPullIntoDescriptorsUsingQueue]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-process-pull-into-descriptors-using-queue
+func (controller *ReadableByteStreamController) processPullIntoDescriptorsUsingQueue() []*pullIntoDescriptor {
+	// 1. Assert: controller.[[closeRequested]] is false.
+	if controller.closeRequested {
+		common.Throw(controller.stream.runtime, newError(AssertionError, "controller.[[closeRequested]] is true"))
+	}
+
+	// 2. Let filledPullIntos be a new empty list.
+	var filledPullIntos []*pullIntoDescriptor
+
+	// 3. While controller.[[pendingPullIntos]] is not empty,
+	for len(controller.pendingPullIntos) > 0 {
+		// 3.1. If controller.[[queueTotalSize]] is 0, then break.
+		if controller.queueTotalSize == 0 {
+			break
+		}
+
+		// 3.2. Let pullIntoDescriptor be controller.[[pendingPullIntos]][0].
+		pullIntoDescriptor := controller.pendingPullIntos[0]
+
+		// 3.3. If ! ReadableByteStreamControllerFillPullIntoDescriptorFromQueue(controller,
+		// pullIntoDescriptor) is true,
+		if controller.fillPullIntoDescriptorFromQueue(pullIntoDescriptor) {
+			// 3.3.1. Perform ! ReadableByteStreamControllerShiftPendingPullInto(controller).
+			controller.shiftPendingPullInto()
+			// 3.3.2. Append pullIntoDescriptor to filledPullIntos.
+			filledPullIntos = append(filledPullIntos, pullIntoDescriptor)
+		}
+	}
+
+	// 4. Return filledPullIntos.
+	return filledPullIntos
+}
+
+// processReadRequestsUsingQueue implements the specification's
+// [ReadableByteStreamControllerProcessReadRequestsUsingQueue] abstract operation.
+//
+// [ReadableByteStreamControllerProcessReadRequestsUsingQueue]: https://streams.spec.whatwg.org/#abstract-opdef-readablebytestreamcontrollerprocessreadrequestsusingqueue
+func (controller *ReadableByteStreamController) processReadRequestsUsingQueue() {
+	// 1. Let reader be controller.[[stream]].[[reader]].
+	// 2. Assert: reader implements ReadableStreamDefaultReader.
+	reader, ok := controller.stream.reader.(*ReadableStreamDefaultReader)
+	if !ok {
+		common.Throw(controller.stream.runtime, newError(AssertionError, "reader is not a ReadableStreamDefaultReader"))
+	}
+
+	// 3. While reader.[[readRequests]] is not empty,
+	for len(reader.readRequests) > 0 {
+		// 3.1. If controller.[[queueTotalSize]] is 0, return.
+		if controller.queueTotalSize == 0 {
+			return
+		}
+
+		// 3.2. Let readRequest be reader.[[readRequests]][0].
+		readRequest := reader.readRequests[0]
+
+		// 3.3. Remove readRequest from reader.[[readRequests]].
+		reader.readRequests = reader.readRequests[1:]
+
+		// 3.4. Perform ! ReadableByteStreamControllerFillReadRequestFromQueue(controller, readRequest).
+		controller.fillReadRequestFromQueue(readRequest)
+	}
+}
+
+// pullInto implements the specification's [ReadableByteStreamControllerPullInto] abstract operation.
+//
+// [ReadableByteStreamControllerPullInto]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-pull-into
+func (controller *ReadableByteStreamController) pullInto(
+	view arrayBufferView,
+	minimum int,
+	readIntoRequest ReadIntoRequest,
+) {
+	rt := controller.stream.runtime
+
+	// 1. Let stream be controller.[[stream]].
+	stream := controller.stream
+
+	// 2. Let elementSize be 1.
+	// 3. Let ctor be %DataView%.
+	// 4. If view has a [[TypedArrayName]] internal slot (i.e., it is not a DataView),
+	//   4.1. Set elementSize to the element size specified in the typed array constructors
+	//   table for view.[[TypedArrayName]].
+	//   4.2. Set ctor to the constructor specified in the typed array constructors table
+	//   for view.[[TypedArrayName]].
+	elementSize, ctor := view.elementSize, view.constructor
+
+	// 5. Let minimumFill be min × elementSize.
+	minimumFill := minimum * elementSize
+
+	// 6. Assert: minimumFill ≥ 0 and minimumFill ≤ view.[[ByteLength]].
+	// 7. Assert: the remainder after dividing minimumFill by elementSize is 0.
+	if minimumFill < 0 || minimumFill > view.byteLength || minimumFill%elementSize != 0 {
+		common.Throw(rt, newError(AssertionError, "minimum fill is out of the view's range"))
+	}
+
+	// 8. Let byteOffset be view.[[ByteOffset]].
+	// 9. Let byteLength be view.[[ByteLength]].
+	byteOffset, byteLength := view.byteOffset, view.byteLength
+
+	// 10. Let bufferResult be TransferArrayBuffer(view.[[ViewedArrayBuffer]]).
+	buffer, err := transferArrayBuffer(rt, view.buffer)
+
+	// 11. If bufferResult is an abrupt completion,
+	if err != nil {
+		// 11.1. Perform readIntoRequest’s error steps, given bufferResult.[[Value]].
+		readIntoRequest.errorSteps(errorValue(rt, err))
+		// 11.2. Return.
+		return
+	}
+
+	// 12. Let buffer be bufferResult.[[Value]].
+	// 13. Let pullIntoDescriptor be a new pull-into descriptor with...
+	pullIntoDescriptor := &pullIntoDescriptor{
+		buffer:           buffer,
+		bufferByteLength: len(buffer.Bytes()),
+		byteOffset:       byteOffset,
+		byteLength:       byteLength,
+		bytesFilled:      0,
+		minimumFill:      minimumFill,
+		elementSize:      elementSize,
+		viewConstructor:  ctor,
+		readerType:       readerTypeBYOB,
+	}
+
+	// 14. If controller.[[pendingPullIntos]] is not empty,
+	if len(controller.pendingPullIntos) > 0 {
+		// 14.1. Append pullIntoDescriptor to controller.[[pendingPullIntos]].
+		controller.pendingPullIntos = append(controller.pendingPullIntos, pullIntoDescriptor)
+
+		// 14.2. Perform ! ReadableStreamAddReadIntoRequest(stream, readIntoRequest).
+		stream.addReadIntoRequest(readIntoRequest)
+
+		// 14.3. Return.
+		return
+	}
+
+	// 15. If stream.[[state]] is "closed",
+	if stream.state == ReadableStreamStateClosed {
+		// 15.1. Let emptyView be ! Construct(ctor, « pullIntoDescriptor’s buffer,
+		// pullIntoDescriptor’s byte offset, 0 »).
+		emptyView, err := newArrayBufferView(rt, ctor, pullIntoDescriptor.buffer, pullIntoDescriptor.byteOffset, 0)
+		if err != nil {
+			common.Throw(rt, err)
+		}
+
+		// 15.2. Perform readIntoRequest’s close steps, given emptyView.
+		readIntoRequest.closeSteps(emptyView)
+
+		// 15.3. Return.
+		return
+	}
+
+	// 16. If controller.[[queueTotalSize]] > 0,
+	if controller.queueTotalSize > 0 {
+		// 16.1. If ! ReadableByteStreamControllerFillPullIntoDescriptorFromQueue(controller,
+		// pullIntoDescriptor) is true,
+		if controller.fillPullIntoDescriptorFromQueue(pullIntoDescriptor) {
+			// 16.1.1. Let filledView be ! ReadableByteStreamControllerConvertPullIntoDescriptor(pullIntoDescriptor).
+			filledView := controller.convertPullIntoDescriptor(pullIntoDescriptor)
+
+			// 16.1.2. Perform ! ReadableByteStreamControllerHandleQueueDrain(controller).
+			controller.handleQueueDrain()
+
+			// 16.1.3. Perform readIntoRequest’s chunk steps, given filledView.
+			readIntoRequest.chunkSteps(filledView)
+
+			// 16.1.4. Return.
+			return
+		}
+
+		// 16.2. If controller.[[closeRequested]] is true,
+		if controller.closeRequested {
+			// 16.2.1. Let e be a TypeError exception.
+			e := newTypeError(rt, "insufficient bytes to fill elements in the given buffer").Err()
+
+			// 16.2.2. Perform ! ReadableByteStreamControllerError(controller, e).
+			controller.error(e)
+
+			// 16.2.3. Perform readIntoRequest’s error steps, given e.
+			readIntoRequest.errorSteps(e)
+
+			// 16.2.4. Return.
+			return
+		}
+	}
+
+	// 17. Append pullIntoDescriptor to controller.[[pendingPullIntos]].
+	controller.pendingPullIntos = append(controller.pendingPullIntos, pullIntoDescriptor)
+
+	// 18. Perform ! ReadableStreamAddReadIntoRequest(stream, readIntoRequest).
+	stream.addReadIntoRequest(readIntoRequest)
+
+	// 19. Perform ! ReadableByteStreamControllerCallPullIfNeeded(controller).
+	controller.callPullIfNeeded()
+}
+
+// resetQueue implements the specification's [ResetQueue] abstract operation,
+// for the readable byte stream's queue.
+//
+// [ResetQueue]: https://streams.spec.whatwg.org/#reset-queue
+func (controller *ReadableByteStreamController) resetQueue() {
+	// 1. Assert: container has [[queue]] and [[queueTotalSize]] internal slots.
+	// 2. Set container.[[queue]] to a new empty list.
+	controller.queue = []*readableByteStreamQueueEntry{}
+
+	// 3. Set container.[[queueTotalSize]] to 0.
+	controller.queueTotalSize = 0
+}
+
+// respond implements the specification's [ReadableByteStreamControllerRespond] abstract operation.
+//
+// [ReadableByteStreamControllerRespond]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-respond
+func (controller *ReadableByteStreamController) respond(bytesWritten int) error {
+	rt := controller.stream.runtime
+
+	// 1. Assert: controller.[[pendingPullIntos]] is not empty.
+	if len(controller.pendingPullIntos) == 0 {
+		common.Throw(rt, newError(AssertionError, "controller has no pending pull-into descriptors"))
+	}
+
+	// 2. Let firstDescriptor be controller.[[pendingPullIntos]][0].
+	firstDescriptor := controller.pendingPullIntos[0]
+
+	// 3. Let state be controller.[[stream]].[[state]].
+	state := controller.stream.state
+
+	if state == ReadableStreamStateClosed {
+		// 4. If state is "closed",
+		// 4.1. If bytesWritten is not 0, throw a TypeError exception.
+		if bytesWritten != 0 {
+			return newTypeError(rt, "bytesWritten must be 0 when calling respond() on a closed stream")
+		}
+	} else { // 5. Otherwise,
+		// 5.1. Assert: state is "readable".
+		// 5.2. If bytesWritten is 0, throw a TypeError exception.
+		if bytesWritten == 0 {
+			return newTypeError(rt, "bytesWritten must be greater than 0 when calling respond() on a readable stream")
+		}
+
+		// 5.3. If firstDescriptor’s bytes filled + bytesWritten > firstDescriptor’s byte length,
+		// throw a RangeError exception.
+		if firstDescriptor.bytesFilled+bytesWritten > firstDescriptor.byteLength {
+			return newRangeError(rt, "bytesWritten out of range")
+		}
+	}
+
+	// 6. Set firstDescriptor’s buffer to ! TransferArrayBuffer(firstDescriptor’s buffer).
+	buffer, err := transferArrayBuffer(rt, firstDescriptor.buffer)
+	if err != nil {
+		return err
+	}
+	firstDescriptor.buffer = buffer
+
+	// 7. Perform ? ReadableByteStreamControllerRespondInternal(controller, bytesWritten).
+	return controller.respondInternal(bytesWritten)
+}
+
+// respondInClosedState implements the specification's [ReadableByteStreamControllerRespondInClosedState]
+// abstract operation.
+//
+// [ReadableByteStreamControllerRespondInClosedState]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-respond-in-closed-state
+func (controller *ReadableByteStreamController) respondInClosedState(firstDescriptor *pullIntoDescriptor) {
+	// 1. Assert: the remainder after dividing firstDescriptor’s bytes filled by firstDescriptor’s
+	// element size is 0.
+	if firstDescriptor.bytesFilled%firstDescriptor.elementSize != 0 {
+		common.Throw(controller.stream.runtime, newError(AssertionError, "pull-into descriptor holds a partial element"))
+	}
+
+	// 2. If firstDescriptor’s reader type is "none", perform !
+	// ReadableByteStreamControllerShiftPendingPullInto(controller).
+	if firstDescriptor.readerType == readerTypeNone {
+		controller.shiftPendingPullInto()
+	}
+
+	// 3. Let stream be controller.[[stream]].
+	stream := controller.stream
+
+	// 4. If ! ReadableStreamHasBYOBReader(stream) is true,
+	if stream.hasBYOBReader() {
+		// 4.1. While ! ReadableStreamGetNumReadIntoRequests(stream) > 0,
+		for stream.getNumReadIntoRequests() > 0 {
+			// 4.1.1. Let pullIntoDescriptor be ! ReadableByteStreamControllerShiftPendingPullInto(controller).
+			pullIntoDescriptor := controller.shiftPendingPullInto()
+
+			// 4.1.2. Perform ! ReadableByteStreamControllerCommitPullIntoDescriptor(stream, pullIntoDescriptor).
+			controller.commitPullIntoDescriptor(pullIntoDescriptor)
+		}
+	}
+}
+
+// respondInReadableState implements the specification's [ReadableByteStreamControllerRespondInReadableState]
+// abstract operation.
+//
+// [ReadableByteStreamControllerRespondInReadableState]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-respond-in-readable-state
+func (controller *ReadableByteStreamController) respondInReadableState(
+	bytesWritten int,
+	pullIntoDescriptor *pullIntoDescriptor,
+) error {
+	// 1. Assert: pullIntoDescriptor’s bytes filled + bytesWritten ≤ pullIntoDescriptor’s byte length.
+	if pullIntoDescriptor.bytesFilled+bytesWritten > pullIntoDescriptor.byteLength {
+		common.Throw(controller.stream.runtime, newError(AssertionError, "bytesWritten overfills the pull-into descriptor"))
+	}
+
+	// 2. Perform ! ReadableByteStreamControllerFillHeadPullIntoDescriptor(controller, bytesWritten,
+	// pullIntoDescriptor).
+	controller.fillHeadPullIntoDescriptor(bytesWritten, pullIntoDescriptor)
+
+	// 3. If pullIntoDescriptor’s reader type is "none",
+	if pullIntoDescriptor.readerType == readerTypeNone {
+		// 3.1. Perform ? ReadableByteStreamControllerEnqueueDetachedPullIntoToQueue(controller, pullIntoDescriptor).
+		if err := controller.enqueueDetachedPullIntoToQueue(pullIntoDescriptor); err != nil {
+			return err
+		}
+
+		// 3.2. Let filledPullIntos be the result of performing !
+		// ReadableByteStreamControllerProcessPullIntoDescriptorsUsingQueue(controller).
+		filledPullIntos := controller.processPullIntoDescriptorsUsingQueue()
+
+		// 3.3. For each filledPullInto of filledPullIntos,
+		for _, filledPullInto := range filledPullIntos {
+			// 3.3.1. Perform ! ReadableByteStreamControllerCommitPullIntoDescriptor(controller.[[stream]], filledPullInto).
+			controller.commitPullIntoDescriptor(filledPullInto)
+		}
+
+		// 3.4. Return.
+		return nil
+	}
+
+	// 4. If pullIntoDescriptor’s bytes filled < pullIntoDescriptor’s minimum fill, return.
+	if pullIntoDescriptor.bytesFilled < pullIntoDescriptor.minimumFill {
+		return nil
+	}
+
+	// 5. Perform ! ReadableByteStreamControllerShiftPendingPullInto(controller).
+	controller.shiftPendingPullInto()
+
+	// 6. Let remainderSize be the remainder after dividing pullIntoDescriptor’s bytes filled by
+	// pullIntoDescriptor’s element size.
+	remainderSize := pullIntoDescriptor.bytesFilled % pullIntoDescriptor.elementSize
+
+	// 7. If remainderSize > 0,
+	if remainderSize > 0 {
+		// 7.1. Let end be pullIntoDescriptor’s byte offset + pullIntoDescriptor’s bytes filled.
+		end := pullIntoDescriptor.byteOffset + pullIntoDescriptor.bytesFilled
+
+		// 7.2. Perform ? ReadableByteStreamControllerEnqueueClonedChunkToQueue(controller,
+		// pullIntoDescriptor’s buffer, end − remainderSize, remainderSize).
+		if err := controller.enqueueClonedChunkToQueue(pullIntoDescriptor.buffer, end-remainderSize, remainderSize); err != nil {
+			return err
+		}
+	}
+
+	// 8. Set pullIntoDescriptor’s bytes filled to pullIntoDescriptor’s bytes filled − remainderSize.
+	pullIntoDescriptor.bytesFilled -= remainderSize
+
+	// 9. Let filledPullIntos be the result of performing !
+	// ReadableByteStreamControllerProcessPullIntoDescriptorsUsingQueue(controller).
+	filledPullIntos := controller.processPullIntoDescriptorsUsingQueue()
+
+	// 10. Perform ! ReadableByteStreamControllerCommitPullIntoDescriptor(controller.[[stream]], pullIntoDescriptor).
+	controller.commitPullIntoDescriptor(pullIntoDescriptor)
+
+	// 11. For each filledPullInto of filledPullIntos,
+	for _, filledPullInto := range filledPullIntos {
+		// 11.1. Perform ! ReadableByteStreamControllerCommitPullIntoDescriptor(controller.[[stream]], filledPullInto).
+		controller.commitPullIntoDescriptor(filledPullInto)
+	}
+
+	return nil
+}
+
+// respondInternal implements the specification's [ReadableByteStreamControllerRespondInternal]
+// abstract operation.
+//
+// [ReadableByteStreamControllerRespondInternal]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-respond-internal
+func (controller *ReadableByteStreamController) respondInternal(bytesWritten int) error {
+	rt := controller.stream.runtime
+
+	// 1. Let firstDescriptor be controller.[[pendingPullIntos]][0].
+	firstDescriptor := controller.pendingPullIntos[0]
+
+	// 2. Assert: ! CanTransferArrayBuffer(firstDescriptor’s buffer) is true.
+	if firstDescriptor.buffer.Detached() {
+		common.Throw(rt, newError(AssertionError, "the pull-into descriptor's buffer is detached"))
+	}
+
+	// 3. Perform ! ReadableByteStreamControllerInvalidateBYOBRequest(controller).
+	controller.invalidateBYOBRequest()
+
+	// 4. Let state be controller.[[stream]].[[state]].
+	state := controller.stream.state
+
+	if state == ReadableStreamStateClosed {
+		// 5. If state is "closed",
+		// 5.1. Assert: bytesWritten is 0.
+		if bytesWritten != 0 {
+			common.Throw(rt, newError(AssertionError, "bytesWritten is not 0"))
+		}
+
+		// 5.2. Perform ! ReadableByteStreamControllerRespondInClosedState(controller, firstDescriptor).
+		controller.respondInClosedState(firstDescriptor)
+	} else { // 6. Otherwise,
+		// 6.1. Assert: state is "readable".
+		// 6.2. Assert: bytesWritten > 0.
+		if state != ReadableStreamStateReadable || bytesWritten <= 0 {
+			common.Throw(rt, newError(AssertionError, "cannot respond to a stream that is not readable"))
+		}
+
+		// 6.3. Perform ? ReadableByteStreamControllerRespondInReadableState(controller, bytesWritten,
+		// firstDescriptor).
+		if err := controller.respondInReadableState(bytesWritten, firstDescriptor); err != nil {
+			return err
+		}
+	}
+
+	// 7. Perform ! ReadableByteStreamControllerCallPullIfNeeded(controller).
+	controller.callPullIfNeeded()
+
+	return nil
+}
+
+// respondWithNewView implements the specification's [ReadableByteStreamControllerRespondWithNewView]
+// abstract operation.
+//
+// [ReadableByteStreamControllerRespondWithNewView]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-respond-with-new-view
+func (controller *ReadableByteStreamController) respondWithNewView(view arrayBufferView) error {
+	rt := controller.stream.runtime
+
+	// 1. Assert: controller.[[pendingPullIntos]] is not empty.
+	if len(controller.pendingPullIntos) == 0 {
+		common.Throw(rt, newError(AssertionError, "controller has no pending pull-into descriptors"))
+	}
+
+	// 2. Assert: ! IsDetachedBuffer(view.[[ViewedArrayBuffer]]) is false.
+	if view.buffer.Detached() {
+		common.Throw(rt, newError(AssertionError, "the view's buffer is detached"))
+	}
+
+	// 3. Let firstDescriptor be controller.[[pendingPullIntos]][0].
+	firstDescriptor := controller.pendingPullIntos[0]
+
+	// 4. Let state be controller.[[stream]].[[state]].
+	state := controller.stream.state
+
+	if state == ReadableStreamStateClosed {
+		// 5. If state is "closed",
+		// 5.1. If view.[[ByteLength]] is not 0, throw a TypeError exception.
+		if view.byteLength != 0 {
+			return newTypeError(rt, "the view's length must be 0 when calling respondWithNewView() on a closed stream")
+		}
+	} else { // 6. Otherwise,
+		// 6.1. Assert: state is "readable".
+		// 6.2. If view.[[ByteLength]] is 0, throw a TypeError exception.
+		if view.byteLength == 0 {
+			return newTypeError(rt,
+				"the view's length must be greater than 0 when calling respondWithNewView() on a readable stream")
+		}
+	}
+
+	// 7. If firstDescriptor’s byte offset + firstDescriptor’ bytes filled is not view.[[ByteOffset]],
+	// throw a RangeError exception.
+	if firstDescriptor.byteOffset+firstDescriptor.bytesFilled != view.byteOffset {
+		return newRangeError(rt, "the region specified by view does not match byobRequest")
+	}
+
+	// 8. If firstDescriptor’s buffer byte length is not view.[[ViewedArrayBuffer]].[[ByteLength]],
+	// throw a RangeError exception.
+	if firstDescriptor.bufferByteLength != len(view.buffer.Bytes()) {
+		return newRangeError(rt, "the buffer of view has different capacity than byobRequest")
+	}
+
+	// 9. If firstDescriptor’s bytes filled + view.[[ByteLength]] > firstDescriptor’s byte length,
+	// throw a RangeError exception.
+	if firstDescriptor.bytesFilled+view.byteLength > firstDescriptor.byteLength {
+		return newRangeError(rt, "the region specified by view is larger than byobRequest")
+	}
+
+	// 10. Let viewByteLength be view.[[ByteLength]].
+	viewByteLength := view.byteLength
+
+	// 11. Set firstDescriptor’s buffer to ? TransferArrayBuffer(view.[[ViewedArrayBuffer]]).
+	buffer, err := transferArrayBuffer(rt, view.buffer)
+	if err != nil {
+		return err
+	}
+	firstDescriptor.buffer = buffer
+
+	// 12. Perform ? ReadableByteStreamControllerRespondInternal(controller, viewByteLength).
+	return controller.respondInternal(viewByteLength)
+}
+
+// shiftPendingPullInto implements the specification's [ReadableByteStreamControllerShiftPendingPullInto]
+// abstract operation.
+//
+// [ReadableByteStreamControllerShiftPendingPullInto]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-shift-pending-pull-into
+func (controller *ReadableByteStreamController) shiftPendingPullInto() *pullIntoDescriptor {
+	// 1. Assert: controller.[[byobRequest]] is null.
+	if controller.byobRequest != nil {
+		common.Throw(controller.stream.runtime, newError(AssertionError, "controller.[[byobRequest]] is not null"))
+	}
+
+	// 2. Let descriptor be controller.[[pendingPullIntos]][0].
+	descriptor := controller.pendingPullIntos[0]
+
+	// 3. Remove descriptor from controller.[[pendingPullIntos]].
+	controller.pendingPullIntos = controller.pendingPullIntos[1:]
+
+	// 4. Return descriptor.
+	return descriptor
+}
+
+// shouldCallPull implements the specification's [ReadableByteStreamControllerShouldCallPull]
+// abstract operation.
+//
+// [ReadableByteStreamControllerShouldCallPull]: https://streams.spec.whatwg.org/#readable-byte-stream-controller-should-call-pull
+func (controller *ReadableByteStreamController) shouldCallPull() bool {
+	// 1. Let stream be controller.[[stream]].
+	stream := controller.stream
+
+	// 2. If stream.[[state]] is not "readable", return false.
+	if stream.state != ReadableStreamStateReadable {
+		return false
+	}
+
+	// 3. If controller.[[closeRequested]] is true, return false.
+	if controller.closeRequested {
+		return false
+	}
+
+	// 4. If controller.[[started]] is false, return false.
+	if !controller.started {
+		return false
+	}
+
+	// 5. If ! ReadableStreamHasDefaultReader(stream) is true and ! ReadableStreamGetNumReadRequests(stream) > 0,
+	// return true.
+	if stream.hasDefaultReader() && stream.getNumReadRequests() > 0 {
+		return true
+	}
+
+	// 6. If ! ReadableStreamHasBYOBReader(stream) is true and ! ReadableStreamGetNumReadIntoRequests(stream) > 0,
+	// return true.
+	if stream.hasBYOBReader() && stream.getNumReadIntoRequests() > 0 {
+		return true
+	}
+
+	// 7. Let desiredSize be ! ReadableByteStreamControllerGetDesiredSize(controller).
+	desiredSize := controller.getDesiredSize()
+
+	// 8. Assert: desiredSize is not null.
+	if !desiredSize.Valid {
+		common.Throw(stream.runtime, newError(AssertionError, "desiredSize is null"))
+	}
+
+	// 9. If desiredSize > 0, return true.
+	// 10. Return false.
+	return desiredSize.Float64 > 0
+}
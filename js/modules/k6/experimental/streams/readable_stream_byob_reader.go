@@ -0,0 +1,270 @@
+package streams
+
+import (
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/common"
+)
+
+// ReadableStreamBYOBReader represents a BYOB ("bring your own buffer") reader designed
+// to be vended by a readable byte stream.
+//
+// For more details, see the [specification].
+//
+// [specification]: https://streams.spec.whatwg.org/#byob-reader-class
+type ReadableStreamBYOBReader struct {
+	BaseReadableStreamReader
+
+	// readIntoRequests holds a list of read-into requests, used when a consumer requests
+	// chunks sooner than they are available.
+	readIntoRequests []ReadIntoRequest
+}
+
+// Ensure the ReadableStreamGenericReader interface is implemented correctly
+var _ ReadableStreamGenericReader = &ReadableStreamBYOBReader{}
+
+// ReadIntoRequest is a struct containing three algorithms to perform in reaction to filling
+// the readable byte stream's internal queue or changing its state.
+//
+// [specification]: https://streams.spec.whatwg.org/#read-into-request
+type ReadIntoRequest struct {
+	// chunkSteps is an algorithm taking a chunk, called when a chunk is available for reading.
+	chunkSteps func(chunk any)
+
+	// closeSteps is an algorithm taking a chunk or undefined, called when no chunks are
+	// available because the stream is closed.
+	closeSteps func(chunk any)
+
+	// errorSteps is an algorithm taking a JavaScript value, called when no chunks are
+	// available because the stream is errored.
+	errorSteps func(e any)
+}
+
+// NewReadableStreamBYOBReaderObject creates a new sobek.Object from a [ReadableStreamBYOBReader] instance.
+func NewReadableStreamBYOBReaderObject(reader *ReadableStreamBYOBReader) (*sobek.Object, error) {
+	rt := reader.stream.runtime
+	obj := rt.NewObject()
+	objName := "ReadableStreamBYOBReader"
+
+	err := obj.DefineAccessorProperty("closed", rt.ToValue(func() *sobek.Promise {
+		p, _, _ := reader.GetClosed()
+		return p
+	}), nil, sobek.FLAG_FALSE, sobek.FLAG_TRUE)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "cancel", rt.ToValue(reader.Cancel)); err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "read", rt.ToValue(reader.Read)); err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "releaseLock", rt.ToValue(reader.ReleaseLock)); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// Read reads bytes into the given view, and returns a [sobek.Promise] resolved with
+// the view filled with them, once at least options.min elements are available.
+//
+// It implements the ReadableStreamBYOBReader.read(view, options) [specification] algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#byob-reader-read
+func (reader *ReadableStreamBYOBReader) Read(view sobek.Value, options sobek.Value) *sobek.Promise {
+	rt := reader.runtime
+
+	v, ok := asArrayBufferView(rt, view)
+	if !ok {
+		return newRejectedPromise(reader.vu, newTypeError(rt, "view must be an ArrayBufferView").Err())
+	}
+
+	// 1. If view.[[ByteLength]] is 0, return a promise rejected with a TypeError exception.
+	if v.byteLength == 0 {
+		return newRejectedPromise(reader.vu, newTypeError(rt, "view must have a non-zero byteLength").Err())
+	}
+
+	// 2. If view.[[ViewedArrayBuffer]].[[ArrayBufferByteLength]] is 0, return a promise rejected with a
+	// TypeError exception.
+	if len(v.buffer.Bytes()) == 0 {
+		return newRejectedPromise(reader.vu, newTypeError(rt, "view's buffer must have a non-zero byteLength").Err())
+	}
+
+	// 3. If ! IsDetachedBuffer(view.[[ViewedArrayBuffer]]) is true, return a promise rejected with a
+	// TypeError exception.
+	if v.buffer.Detached() {
+		return newRejectedPromise(reader.vu, newTypeError(rt, "view's buffer has been detached").Err())
+	}
+
+	minimum := int64(1)
+	if obj, isObj := options.(*sobek.Object); isObj {
+		if m := obj.Get("min"); m != nil && !sobek.IsUndefined(m) {
+			if minimum, ok = toEnforcedRangeInteger(m); !ok {
+				return newRejectedPromise(reader.vu, newTypeError(rt, "options.min must be a non-negative integer").Err())
+			}
+		}
+	}
+
+	// 4. If options["min"] is 0, return a promise rejected with a TypeError exception.
+	if minimum == 0 {
+		return newRejectedPromise(reader.vu, newTypeError(rt, "options.min must be greater than 0").Err())
+	}
+
+	// 5. If view has a [[TypedArrayName]] internal slot,
+	//   5.1. If options["min"] > view.[[ArrayLength]], return a promise rejected with a RangeError exception.
+	// 6. Otherwise (i.e., it is a DataView),
+	//   6.1. If options["min"] > view.[[ByteLength]], return a promise rejected with a RangeError exception.
+	if minimum > int64(v.length) {
+		return newRejectedPromise(reader.vu, newRangeError(rt, "options.min must not be greater than the view's length").Err())
+	}
+
+	// 7. If this.[[stream]] is undefined, return a promise rejected with a TypeError exception.
+	if reader.stream == nil {
+		return newRejectedPromise(reader.vu, newTypeError(rt, "stream is undefined").Err())
+	}
+
+	// 8. Let promise be a new promise.
+	promise, resolve, reject := rt.NewPromise()
+
+	// 9. Let readIntoRequest be a new read-into request with the following items:
+	readIntoRequest := ReadIntoRequest{
+		chunkSteps: func(chunk any) {
+			// Resolve promise with «[ "value" → chunk, "done" → false ]».
+			resolve(map[string]any{"value": chunk, "done": false})
+		},
+		closeSteps: func(chunk any) {
+			// Resolve promise with «[ "value" → chunk, "done" → true ]».
+			if chunk == nil {
+				chunk = sobek.Undefined()
+			}
+			resolve(map[string]any{"value": chunk, "done": true})
+		},
+		errorSteps: func(e any) {
+			// Reject promise with e.
+			reject(errorValue(rt, e))
+		},
+	}
+
+	// 10. Perform ! ReadableStreamBYOBReaderRead(this, view, options["min"], readIntoRequest).
+	reader.read(v, int(minimum), readIntoRequest)
+
+	// 11. Return promise.
+	return promise
+}
+
+// Cancel returns a [sobek.Promise] that resolves when the stream is canceled.
+//
+// It implements the ReadableStreamGenericReader.cancel(reason) [specification] algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#generic-reader-cancel
+func (reader *ReadableStreamBYOBReader) Cancel(reason sobek.Value) *sobek.Promise {
+	// 1. If this.[[stream]] is undefined, return a promise rejected with a TypeError exception.
+	if reader.stream == nil {
+		return newRejectedPromise(reader.vu, newTypeError(reader.runtime, "stream is undefined").Err())
+	}
+
+	// 2. Return ! ReadableStreamReaderGenericCancel(this, reason).
+	return reader.BaseReadableStreamReader.Cancel(reason)
+}
+
+// ReleaseLock releases the reader's lock on the stream.
+//
+// It implements the ReadableStreamBYOBReader.releaseLock() [specification] algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#byob-reader-release-lock
+func (reader *ReadableStreamBYOBReader) ReleaseLock() {
+	// 1. If this.[[stream]] is undefined, return.
+	if reader.stream == nil {
+		return
+	}
+
+	// 2. Perform ! ReadableStreamBYOBReaderRelease(this).
+	reader.release()
+}
+
+// setup implements the [SetUpReadableStreamBYOBReader] algorithm.
+//
+// [SetUpReadableStreamBYOBReader]: https://streams.spec.whatwg.org/#set-up-readable-stream-byob-reader
+func (reader *ReadableStreamBYOBReader) setup(stream *ReadableStream) {
+	rt := stream.runtime
+
+	// 1. If ! IsReadableStreamLocked(stream) is true, throw a TypeError exception.
+	if stream.isLocked() {
+		throw(rt, newTypeError(rt, "stream is locked"))
+	}
+
+	// 2. If stream.[[controller]] does not implement ReadableByteStreamController, throw a TypeError exception.
+	if _, ok := stream.controller.(*ReadableByteStreamController); !ok {
+		throw(rt, newTypeError(rt, "cannot get a BYOB reader for a stream that is not a byte stream"))
+	}
+
+	// 3. Perform ! ReadableStreamReaderGenericInitialize(reader, stream).
+	ReadableStreamReaderGenericInitialize(reader, stream)
+
+	// 4. Set reader.[[readIntoRequests]] to a new empty list.
+	reader.readIntoRequests = []ReadIntoRequest{}
+}
+
+// errorReadIntoRequests implements the [ReadableStreamBYOBReaderErrorReadIntoRequests] algorithm.
+//
+// [ReadableStreamBYOBReaderErrorReadIntoRequests]: https://streams.spec.whatwg.org/#abstract-opdef-readablestreambyobreadererrorreadintorequests
+func (reader *ReadableStreamBYOBReader) errorReadIntoRequests(e any) {
+	// 1. Let readIntoRequests be reader.[[readIntoRequests]].
+	readIntoRequests := reader.readIntoRequests
+
+	// 2. Set reader.[[readIntoRequests]] to a new empty list.
+	reader.readIntoRequests = []ReadIntoRequest{}
+
+	// 3. For each readIntoRequest of readIntoRequests,
+	for _, readIntoRequest := range readIntoRequests {
+		// 3.1. Perform readIntoRequest’s error steps, given e.
+		readIntoRequest.errorSteps(e)
+	}
+}
+
+// read implements the [ReadableStreamBYOBReaderRead] algorithm.
+//
+// [ReadableStreamBYOBReaderRead]: https://streams.spec.whatwg.org/#readable-stream-byob-reader-read
+func (reader *ReadableStreamBYOBReader) read(view arrayBufferView, minimum int, readIntoRequest ReadIntoRequest) {
+	// 1. Let stream be reader.[[stream]].
+	stream := reader.stream
+
+	// 2. Assert: stream is not undefined.
+	if stream == nil {
+		common.Throw(reader.runtime, newError(AssertionError, "stream is undefined"))
+	}
+
+	// 3. Set stream.[[disturbed]] to true.
+	stream.disturbed = true
+
+	// 4. If stream.[[state]] is "errored", perform readIntoRequest’s error steps given stream.[[storedError]].
+	if stream.state == ReadableStreamStateErrored {
+		readIntoRequest.errorSteps(stream.storedError)
+		return
+	}
+
+	// 5. Otherwise, perform ! ReadableByteStreamControllerPullInto(stream.[[controller]], view, min, readIntoRequest).
+	controller, ok := stream.controller.(*ReadableByteStreamController)
+	if !ok {
+		common.Throw(reader.runtime, newError(AssertionError, "stream's controller is not a ReadableByteStreamController"))
+	}
+
+	controller.pullInto(view, minimum, readIntoRequest)
+}
+
+// release implements the [ReadableStreamBYOBReaderRelease] algorithm.
+//
+// [ReadableStreamBYOBReaderRelease]: https://streams.spec.whatwg.org/#abstract-opdef-readablestreambyobreaderrelease
+func (reader *ReadableStreamBYOBReader) release() {
+	// 1. Perform ! ReadableStreamReaderGenericRelease(reader).
+	reader.BaseReadableStreamReader.release()
+
+	// 2. Let e be a new TypeError exception.
+	e := newTypeError(reader.runtime, "reader released")
+
+	// 3. Perform ! ReadableStreamBYOBReaderErrorReadIntoRequests(reader, e).
+	reader.errorReadIntoRequests(e.Err())
+}
@@ -0,0 +1,149 @@
+package streams
+
+import (
+	"github.com/grafana/sobek"
+)
+
+// ReadableStreamBYOBRequest represents a pull-into request in a [ReadableByteStreamController].
+//
+// For more details, see the [specification].
+//
+// [specification]: https://streams.spec.whatwg.org/#rs-byob-request-class
+type ReadableStreamBYOBRequest struct {
+	// controller is the parent [ReadableByteStreamController] instance, or nil once
+	// the request has been invalidated.
+	controller *ReadableByteStreamController
+
+	// view is a typed array representing the destination region to which the
+	// controller can write generated data, or nil after the BYOB request has
+	// been invalidated.
+	view *sobek.Object
+
+	// object is the [sobek.Object] representing the request in the runtime.
+	object *sobek.Object
+
+	runtime *sobek.Runtime
+}
+
+// NewReadableStreamBYOBRequestObject creates a new [sobek.Object] from a
+// [ReadableStreamBYOBRequest] instance.
+func NewReadableStreamBYOBRequestObject(rt *sobek.Runtime, request *ReadableStreamBYOBRequest) (*sobek.Object, error) {
+	obj := rt.NewObject()
+	objName := "ReadableStreamBYOBRequest"
+
+	err := obj.DefineAccessorProperty("view", rt.ToValue(func() sobek.Value {
+		return request.View()
+	}), nil, sobek.FLAG_FALSE, sobek.FLAG_TRUE)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "respond", rt.ToValue(request.Respond)); err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "respondWithNewView", rt.ToValue(request.RespondWithNewView)); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// View returns the view for writing in to, or null if the BYOB request has already been responded to.
+//
+// It implements the ReadableStreamBYOBRequest.view [specification] getter.
+//
+// [specification]: https://streams.spec.whatwg.org/#rs-byob-request-view
+func (request *ReadableStreamBYOBRequest) View() sobek.Value {
+	// 1. Return this.[[view]].
+	if request.view == nil {
+		return sobek.Null()
+	}
+
+	return request.view
+}
+
+// Respond indicates to the associated readable byte stream that bytesWritten bytes
+// were written into view, causing the result be surfaced to the consumer.
+//
+// It implements the ReadableStreamBYOBRequest.respond(bytesWritten) [specification] algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#rs-byob-request-respond
+func (request *ReadableStreamBYOBRequest) Respond(bytesWritten sobek.Value) {
+	rt := request.runtime
+
+	if bytesWritten == nil {
+		bytesWritten = sobek.Undefined()
+	}
+
+	written, ok := toEnforcedRangeInteger(bytesWritten)
+	if !ok {
+		throw(rt, newTypeError(rt, "bytesWritten must be a non-negative integer"))
+	}
+
+	// 1. If this.[[controller]] is undefined, throw a TypeError exception.
+	if request.controller == nil {
+		throw(rt, newTypeError(rt, "this BYOB request has been invalidated"))
+	}
+
+	// 2. If ! IsDetachedBuffer(this.[[view]].[[ArrayBuffer]]) is true, throw a TypeError exception.
+	view, ok := asArrayBufferView(rt, request.view)
+	if !ok || view.buffer.Detached() {
+		throw(rt, newTypeError(rt, "the BYOB request's buffer has been detached"))
+	}
+
+	// 3. Assert: this.[[view]].[[ByteLength]] > 0.
+	// 4. Assert: this.[[view]].[[ViewedArrayBuffer]].[[ByteLength]] > 0.
+
+	// 5. Perform ? ReadableByteStreamControllerRespond(this.[[controller]], bytesWritten).
+	if err := request.controller.respond(int(written)); err != nil {
+		throw(rt, err)
+	}
+}
+
+// RespondWithNewView indicates to the associated readable byte stream that instead of
+// writing into view, the underlying byte source is providing a new ArrayBufferView,
+// which will be given to the consumer.
+//
+// It implements the ReadableStreamBYOBRequest.respondWithNewView(view) [specification] algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#rs-byob-request-respond-with-new-view
+func (request *ReadableStreamBYOBRequest) RespondWithNewView(view sobek.Value) {
+	rt := request.runtime
+
+	newView, ok := asArrayBufferView(rt, view)
+	if !ok {
+		throw(rt, newTypeError(rt, "view must be an ArrayBufferView"))
+	}
+
+	// 1. If this.[[controller]] is undefined, throw a TypeError exception.
+	if request.controller == nil {
+		throw(rt, newTypeError(rt, "this BYOB request has been invalidated"))
+	}
+
+	// 2. If ! IsDetachedBuffer(view.[[ViewedArrayBuffer]]) is true, throw a TypeError exception.
+	if newView.buffer.Detached() {
+		throw(rt, newTypeError(rt, "the given view's buffer has been detached"))
+	}
+
+	// 3. Return ? ReadableByteStreamControllerRespondWithNewView(this.[[controller]], view).
+	if err := request.controller.respondWithNewView(newView); err != nil {
+		throw(rt, err)
+	}
+}
+
+// toObject returns the [sobek.Object] representing the request, which is created
+// once and reused afterward.
+func (request *ReadableStreamBYOBRequest) toObject() *sobek.Object {
+	if request.object != nil {
+		return request.object
+	}
+
+	obj, err := NewReadableStreamBYOBRequestObject(request.runtime, request)
+	if err != nil {
+		throw(request.runtime, err)
+	}
+	request.object = obj
+
+	return obj
+}
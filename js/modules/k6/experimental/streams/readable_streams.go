@@ -81,8 +81,13 @@ func (stream *ReadableStream) GetReader(options *sobek.Object) sobek.Value {
 	}
 
 	// 3. Return ? AcquireReadableStreamBYOBReader(this).
-	common.Throw(stream.runtime, newError(NotSupportedError, "'byob' mode is not supported yet"))
-	return sobek.Undefined()
+	byobReader := stream.acquireBYOBReader()
+	byobReaderObj, err := NewReadableStreamBYOBReaderObject(byobReader)
+	if err != nil {
+		common.Throw(stream.runtime, err)
+	}
+
+	return byobReaderObj
 }
 
 // Tee implements the [tee] operation.
@@ -122,6 +127,23 @@ func (stream *ReadableStream) isLocked() bool {
 	return stream.reader != nil
 }
 
+// IsReadableStreamLocked implements the specification's [IsReadableStreamLocked()] abstract
+// operation, for the modules building on readable streams (e.g. to tell whether a fetch body
+// is unusable).
+//
+// [IsReadableStreamLocked()]: https://streams.spec.whatwg.org/#is-readable-stream-locked
+func IsReadableStreamLocked(stream *ReadableStream) bool {
+	return stream.isLocked()
+}
+
+// IsReadableStreamDisturbed implements the specification's [is readable stream disturbed]
+// operation, and reports whether the stream has ever been read from or canceled.
+//
+// [is readable stream disturbed]: https://streams.spec.whatwg.org/#is-readable-stream-disturbed
+func IsReadableStreamDisturbed(stream *ReadableStream) bool {
+	return stream.disturbed
+}
+
 // initialize implements the specification's [InitializeReadableStream()] abstract operation.
 //
 // [InitializeReadableStream()]: https://streams.spec.whatwg.org/#initialize-readable-stream
@@ -186,6 +208,69 @@ func (stream *ReadableStream) setupReadableStreamDefaultControllerFromUnderlying
 	stream.setupDefaultController(controller, startAlgorithm, pullAlgorithm, cancelAlgorithm, highWaterMark, sizeAlgorithm)
 }
 
+// setupReadableByteStreamControllerFromUnderlyingSource implements the [specification]'s
+// SetUpReadableByteStreamControllerFromUnderlyingSource abstract operation.
+//
+// [specification]: https://streams.spec.whatwg.org/#set-up-readable-byte-stream-controller-from-underlying-source
+func (stream *ReadableStream) setupReadableByteStreamControllerFromUnderlyingSource(
+	underlyingSource *sobek.Object,
+	underlyingSourceDict UnderlyingSource,
+	highWaterMark float64,
+) {
+	// 1. Let controller be a new ReadableByteStreamController.
+	controller := &ReadableByteStreamController{}
+
+	// 2. Let startAlgorithm be an algorithm that returns undefined.
+	var startAlgorithm UnderlyingSourceStartCallback = func(*sobek.Object) sobek.Value {
+		return sobek.Undefined()
+	}
+
+	// 3. Let pullAlgorithm be an algorithm that returns a promise resolved with undefined.
+	var pullAlgorithm UnderlyingSourcePullCallback = func(*sobek.Object) *sobek.Promise {
+		return newResolvedPromise(stream.vu, sobek.Undefined())
+	}
+
+	// 4. Let cancelAlgorithm be an algorithm that returns a promise resolved with undefined.
+	var cancelAlgorithm UnderlyingSourceCancelCallback = func(any) sobek.Value {
+		return stream.vu.Runtime().ToValue(newResolvedPromise(stream.vu, sobek.Undefined()))
+	}
+
+	// 5. If underlyingSourceDict["start"] exists, then set startAlgorithm to an algorithm
+	// which returns the result of invoking underlyingSourceDict["start"] with argument
+	// list « controller » and callback this value underlyingSource.
+	if underlyingSourceDict.startSet {
+		startAlgorithm = stream.startAlgorithm(underlyingSource, underlyingSourceDict)
+	}
+
+	// 6. If underlyingSourceDict["pull"] exists, then set pullAlgorithm to an algorithm which
+	// returns the result of invoking underlyingSourceDict["pull"] with argument list
+	// « controller » and callback this value underlyingSource.
+	if underlyingSourceDict.pullSet {
+		pullAlgorithm = stream.pullAlgorithm(underlyingSource, underlyingSourceDict)
+	}
+
+	// 7. If underlyingSourceDict["cancel"] exists, then set cancelAlgorithm to an algorithm which takes an argument
+	// reason and returns the result of invoking underlyingSourceDict["cancel"] with argument list « reason » and
+	// callback this value underlyingSource.
+	if underlyingSourceDict.cancelSet {
+		cancelAlgorithm = stream.cancelAlgorithm(underlyingSource, underlyingSourceDict)
+	}
+
+	// 8. Let autoAllocateChunkSize be underlyingSourceDict["autoAllocateChunkSize"], if it exists, or undefined otherwise.
+	autoAllocateChunkSize := underlyingSourceDict.AutoAllocateChunkSize
+
+	// 9. If autoAllocateChunkSize is 0, then throw a TypeError exception.
+	if autoAllocateChunkSize.Valid && autoAllocateChunkSize.Int64 == 0 {
+		throw(stream.runtime, newTypeError(stream.runtime, "autoAllocateChunkSize must be greater than 0"))
+	}
+
+	// 10. Perform ? SetUpReadableByteStreamController(stream, controller, startAlgorithm, pullAlgorithm,
+	// cancelAlgorithm, highWaterMark, autoAllocateChunkSize).
+	controller.setup(
+		stream, startAlgorithm, pullAlgorithm, cancelAlgorithm, highWaterMark, autoAllocateChunkSize.Int64,
+	)
+}
+
 func (stream *ReadableStream) startAlgorithm(
 	underlyingSource *sobek.Object,
 	underlyingSourceDict UnderlyingSource,
@@ -351,6 +436,40 @@ func (stream *ReadableStream) setupDefaultController(
 	}
 }
 
+// createReadableStream implements the specification's [CreateReadableStream] abstract operation,
+// used by other specifications to create a [ReadableStream] whose controller is driven by Go.
+//
+// [CreateReadableStream]: https://streams.spec.whatwg.org/#create-readable-stream
+func createReadableStream(
+	vu modules.VU,
+	startAlgorithm UnderlyingSourceStartCallback,
+	pullAlgorithm UnderlyingSourcePullCallback,
+	cancelAlgorithm UnderlyingSourceCancelCallback,
+	highWaterMark float64,
+	sizeAlgorithm SizeAlgorithm,
+) *ReadableStream {
+	// 1. If highWaterMark was not passed, set it to 1.
+	// 2. If sizeAlgorithm was not passed, set it to an algorithm that returns 1.
+	// 3. Assert: ! IsNonNegativeNumber(highWaterMark) is true.
+	// 4. Let stream be a new ReadableStream.
+	// 5. Perform ! InitializeReadableStream(stream).
+	stream := &ReadableStream{
+		runtime: vu.Runtime(),
+		vu:      vu,
+	}
+	stream.initialize()
+
+	// 6. Let controller be a new ReadableStreamDefaultController.
+	controller := &ReadableStreamDefaultController{}
+
+	// 7. Perform ? SetUpReadableStreamDefaultController(stream, controller, startAlgorithm,
+	// pullAlgorithm, cancelAlgorithm, highWaterMark, sizeAlgorithm).
+	stream.setupDefaultController(controller, startAlgorithm, pullAlgorithm, cancelAlgorithm, highWaterMark, sizeAlgorithm)
+
+	// 8. Return stream.
+	return stream
+}
+
 // acquireDefaultReader implements the specification's [AcquireReadableStreamDefaultReader] algorithm.
 //
 // [AcquireReadableStreamDefaultReader]: https://streams.spec.whatwg.org/#acquire-readable-stream-reader
@@ -365,6 +484,41 @@ func (stream *ReadableStream) acquireDefaultReader() *ReadableStreamDefaultReade
 	return reader
 }
 
+// acquireBYOBReader implements the specification's [AcquireReadableStreamBYOBReader] algorithm.
+//
+// [AcquireReadableStreamBYOBReader]: https://streams.spec.whatwg.org/#acquire-readable-stream-byob-reader
+func (stream *ReadableStream) acquireBYOBReader() *ReadableStreamBYOBReader {
+	// 1. Let reader be a new ReadableStreamBYOBReader.
+	reader := &ReadableStreamBYOBReader{}
+
+	// 2. Perform ? SetUpReadableStreamBYOBReader(reader, stream).
+	reader.setup(stream)
+
+	// 3. Return reader.
+	return reader
+}
+
+// addReadIntoRequest implements the specification's [ReadableStreamAddReadIntoRequest()] abstract operation.
+//
+// [ReadableStreamAddReadIntoRequest()]: https://streams.spec.whatwg.org/#readable-stream-add-read-into-request
+func (stream *ReadableStream) addReadIntoRequest(readIntoRequest ReadIntoRequest) {
+	// 1. Assert: stream.[[reader]] implements ReadableStreamBYOBReader.
+	byobReader, ok := stream.reader.(*ReadableStreamBYOBReader)
+	if !ok {
+		readIntoRequest.errorSteps(newError(RuntimeError, "reader is not a ReadableStreamBYOBReader"))
+		return
+	}
+
+	// 2. Assert: stream.[[state]] is "readable" or "closed".
+	if stream.state != ReadableStreamStateReadable && stream.state != ReadableStreamStateClosed {
+		readIntoRequest.errorSteps(newError(AssertionError, "stream is neither readable nor closed"))
+		return
+	}
+
+	// 3. Append readIntoRequest to stream.[[reader]].[[readIntoRequests]].
+	byobReader.readIntoRequests = append(byobReader.readIntoRequests, readIntoRequest)
+}
+
 // addReadRequest implements the specification's [ReadableStreamAddReadRequest()] abstract operation.
 //
 // [ReadableStreamAddReadRequest()]: https://streams.spec.whatwg.org/#readable-stream-add-read-request
@@ -411,7 +565,19 @@ func (stream *ReadableStream) cancel(reason sobek.Value) *sobek.Promise {
 
 	// 5. Let reader be stream.[[reader]].
 	// 6. If reader is not undefined and reader implements ReadableStreamBYOBReader,
-	// Not implemented yet: ReadableStreamBYOBReader is not supported yet.
+	if byobReader, ok := stream.reader.(*ReadableStreamBYOBReader); ok {
+		// 6.1. Let readIntoRequests be reader.[[readIntoRequests]].
+		readIntoRequests := byobReader.readIntoRequests
+
+		// 6.2. Set reader.[[readIntoRequests]] to an empty list.
+		byobReader.readIntoRequests = []ReadIntoRequest{}
+
+		// 6.3. For each readIntoRequest of readIntoRequests,
+		for _, readIntoRequest := range readIntoRequests {
+			// 6.3.1. Perform readIntoRequest’s close steps, given undefined.
+			readIntoRequest.closeSteps(sobek.Undefined())
+		}
+	}
 
 	// 7. Let sourceCancelPromise be ! stream.[[controller]].[[CancelSteps]](reason).
 	sourceCancelPromise := stream.controller.cancelSteps(reason)
@@ -529,9 +695,15 @@ func (stream *ReadableStream) error(e any) {
 		return
 	}
 
-	// 9. OTHERWISE, reader is a ReadableStreamBYOBReader
+	// 9. Otherwise,
 	// 9.1. Assert: reader implements ReadableStreamBYOBReader.
-	common.Throw(stream.vu.Runtime(), newError(NotSupportedError, "ReadableStreamBYOBReader is not supported yet"))
+	byobReader, ok := reader.(*ReadableStreamBYOBReader)
+	if !ok {
+		common.Throw(stream.vu.Runtime(), newError(AssertionError, "reader is not a ReadableStreamBYOBReader"))
+	}
+
+	// 9.2. Perform ! ReadableStreamBYOBReaderErrorReadIntoRequests(reader, e).
+	byobReader.errorReadIntoRequests(e)
 }
 
 // fulfillReadRequest implements the [ReadableStreamFulfillReadRequest()] algorithm.
@@ -569,6 +741,51 @@ func (stream *ReadableStream) fulfillReadRequest(chunk any, done bool) {
 	}
 }
 
+// fulfillReadIntoRequest implements the [ReadableStreamFulfillReadIntoRequest()] algorithm.
+//
+// [ReadableStreamFulfillReadIntoRequest()]: https://streams.spec.whatwg.org/#readable-stream-fulfill-read-into-request
+func (stream *ReadableStream) fulfillReadIntoRequest(chunk any, done bool) {
+	// 1. Assert: ! ReadableStreamHasBYOBReader(stream) is true.
+	// 2. Let reader be stream.[[reader]].
+	reader, ok := stream.reader.(*ReadableStreamBYOBReader)
+	if !ok {
+		common.Throw(stream.vu.Runtime(), newError(AssertionError, "stream does not have a BYOB reader"))
+	}
+
+	// 3. Assert: reader.[[readIntoRequests]] is not empty.
+	if len(reader.readIntoRequests) == 0 {
+		common.Throw(stream.vu.Runtime(), newError(AssertionError, "reader.[[readIntoRequests]] is empty"))
+	}
+
+	// 4. Let readIntoRequest be reader.[[readIntoRequests]][0].
+	readIntoRequest := reader.readIntoRequests[0]
+
+	// 5. Remove readIntoRequest from reader.[[readIntoRequests]].
+	reader.readIntoRequests = reader.readIntoRequests[1:]
+
+	if done {
+		// 6. If done is true, perform readIntoRequest’s close steps, given chunk.
+		readIntoRequest.closeSteps(chunk)
+	} else {
+		// 7. Otherwise, perform readIntoRequest’s chunk steps, given chunk.
+		readIntoRequest.chunkSteps(chunk)
+	}
+}
+
+// getNumReadIntoRequests implements the [ReadableStreamGetNumReadIntoRequests()] algorithm.
+//
+// [ReadableStreamGetNumReadIntoRequests()]: https://streams.spec.whatwg.org/#readable-stream-get-num-read-into-requests
+func (stream *ReadableStream) getNumReadIntoRequests() int {
+	// 1. Assert: ! ReadableStreamHasBYOBReader(stream) is true.
+	reader, ok := stream.reader.(*ReadableStreamBYOBReader)
+	if !ok {
+		common.Throw(stream.vu.Runtime(), newError(AssertionError, "stream does not have a BYOB reader"))
+	}
+
+	// 2. Return stream.[[reader]].[[readIntoRequests]]'s size.
+	return len(reader.readIntoRequests)
+}
+
 // getNumReadRequests implements the [ReadableStreamGetNumReadRequests()] algorithm.
 //
 // [ReadableStreamGetNumReadRequests()]: https://streams.spec.whatwg.org/#readable-stream-get-num-read-requests
@@ -603,3 +820,20 @@ func (stream *ReadableStream) hasDefaultReader() bool {
 	_, ok := reader.(*ReadableStreamDefaultReader)
 	return ok
 }
+
+// hasBYOBReader implements the [ReadableStreamHasBYOBReader()] algorithm.
+//
+// [ReadableStreamHasBYOBReader()]: https://streams.spec.whatwg.org/#readable-stream-has-byob-reader
+func (stream *ReadableStream) hasBYOBReader() bool {
+	// 1. Let reader be stream.[[reader]].
+	reader := stream.reader
+
+	// 2. If reader is undefined, return false.
+	if reader == nil {
+		return false
+	}
+
+	// 3. If reader implements ReadableStreamBYOBReader, return true.
+	_, ok := reader.(*ReadableStreamBYOBReader)
+	return ok
+}
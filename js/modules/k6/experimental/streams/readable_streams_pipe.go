@@ -0,0 +1,374 @@
+package streams
+
+import (
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/common"
+)
+
+// StreamPipeOptions holds the options of the pipeTo() and pipeThrough() operations.
+//
+// [specification]: https://streams.spec.whatwg.org/#dictdef-streampipeoptions
+type StreamPipeOptions struct {
+	// PreventAbort prevents errors in the source stream from aborting the destination.
+	PreventAbort bool
+
+	// PreventCancel prevents errors in the destination stream from canceling the source.
+	PreventCancel bool
+
+	// PreventClose prevents the closing of the source stream from closing the destination.
+	PreventClose bool
+}
+
+// NewStreamPipeOptionsFromValue creates a new StreamPipeOptions from a sobek.Value.
+func NewStreamPipeOptionsFromValue(rt *sobek.Runtime, value sobek.Value) (StreamPipeOptions, error) {
+	var options StreamPipeOptions
+
+	if common.IsNullish(value) {
+		return options, nil
+	}
+
+	obj, ok := value.(*sobek.Object)
+	if !ok {
+		return options, newTypeError(rt, "options must be an object")
+	}
+
+	options.PreventAbort = toBoolean(obj.Get("preventAbort"))
+	options.PreventCancel = toBoolean(obj.Get("preventCancel"))
+	options.PreventClose = toBoolean(obj.Get("preventClose"))
+
+	if !common.IsNullish(obj.Get("signal")) {
+		return options, newError(NotSupportedError, "options.signal is not supported yet")
+	}
+
+	return options, nil
+}
+
+func toBoolean(v sobek.Value) bool {
+	return v != nil && v.ToBoolean()
+}
+
+// PipeTo implements the [pipeTo] operation.
+//
+// [pipeTo]: https://streams.spec.whatwg.org/#rs-pipe-to
+func (stream *ReadableStream) PipeTo(destination sobek.Value, options sobek.Value) *sobek.Promise {
+	rt := stream.runtime
+
+	dest, ok := exportOrNil(destination).(*WritableStream)
+	if !ok {
+		return newRejectedPromise(stream.vu, newTypeError(rt, "destination must be a WritableStream").Err())
+	}
+
+	pipeOptions, err := NewStreamPipeOptionsFromValue(rt, options)
+	if err != nil {
+		return newRejectedPromise(stream.vu, errorValue(rt, err))
+	}
+
+	// 1. If ! IsReadableStreamLocked(this) is true, return a promise rejected with a TypeError exception.
+	if stream.isLocked() {
+		return newRejectedPromise(stream.vu, newTypeError(rt, "cannot pipe a locked stream").Err())
+	}
+
+	// 2. If ! IsWritableStreamLocked(destination) is true, return a promise rejected with a TypeError exception.
+	if dest.isLocked() {
+		return newRejectedPromise(stream.vu, newTypeError(rt, "cannot pipe to a locked stream").Err())
+	}
+
+	// 3. Let signal be options["signal"] if it exists, or undefined otherwise.
+	// 4. Return ! ReadableStreamPipeTo(this, destination, options["preventClose"],
+	// options["preventAbort"], options["preventCancel"], signal).
+	return stream.pipeTo(dest, pipeOptions)
+}
+
+// PipeThrough implements the [pipeThrough] operation.
+//
+// [pipeThrough]: https://streams.spec.whatwg.org/#rs-pipe-through
+func (stream *ReadableStream) PipeThrough(transform sobek.Value, options sobek.Value) sobek.Value {
+	rt := stream.runtime
+
+	transformObj, ok := transform.(*sobek.Object)
+	if !ok {
+		throw(rt, newTypeError(rt, "transform must be an object"))
+	}
+
+	readable := transformObj.Get("readable")
+	if _, isReadable := exportOrNil(readable).(*ReadableStream); !isReadable {
+		throw(rt, newTypeError(rt, "transform.readable must be a ReadableStream"))
+	}
+
+	writable, ok := exportOrNil(transformObj.Get("writable")).(*WritableStream)
+	if !ok {
+		throw(rt, newTypeError(rt, "transform.writable must be a WritableStream"))
+	}
+
+	pipeOptions, err := NewStreamPipeOptionsFromValue(rt, options)
+	if err != nil {
+		throw(rt, err)
+	}
+
+	// 1. If ! IsReadableStreamLocked(this) is true, throw a TypeError exception.
+	if stream.isLocked() {
+		throw(rt, newTypeError(rt, "cannot pipe a locked stream"))
+	}
+
+	// 2. If ! IsWritableStreamLocked(transform["writable"]) is true, throw a TypeError exception.
+	if writable.isLocked() {
+		throw(rt, newTypeError(rt, "cannot pipe to a locked stream"))
+	}
+
+	// 3. Let signal be options["signal"] if it exists, or undefined otherwise.
+	// 4. Let promise be ! ReadableStreamPipeTo(this, transform["writable"], options["preventClose"],
+	// options["preventAbort"], options["preventCancel"], signal).
+	promise := stream.pipeTo(writable, pipeOptions)
+
+	// 5. Set promise.[[PromiseIsHandled]] to true.
+	setPromiseIsHandled(rt, promise)
+
+	// 6. Return transform["readable"].
+	return readable
+}
+
+// pipeTo implements the specification's [ReadableStreamPipeTo] abstract operation.
+//
+// The piping is driven by the reactions to the promises of the reader and the
+// writer, the same way the reference implementation does it, instead of doing it
+// in parallel.
+//
+// [ReadableStreamPipeTo]: https://streams.spec.whatwg.org/#readable-stream-pipe-to
+func (stream *ReadableStream) pipeTo(dest *WritableStream, options StreamPipeOptions) *sobek.Promise {
+	rt := stream.runtime
+	source := stream
+
+	// 1. Assert: source implements ReadableStream.
+	// 2. Assert: dest implements WritableStream.
+	// 3. Assert: preventClose, preventAbort, and preventCancel are all booleans.
+	// 4. If signal was not given, let signal be undefined.
+	// 5. Assert: either signal is undefined, or signal implements AbortSignal.
+	// 6. Assert: ! IsReadableStreamLocked(source) is false.
+	// 7. Assert: ! IsWritableStreamLocked(dest) is false.
+	// 8. If source.[[controller]] implements ReadableByteStreamController, let reader be either
+	// ! AcquireReadableStreamBYOBReader(source) or ! AcquireReadableStreamDefaultReader(source),
+	// at the user agent's discretion.
+	// 9. Otherwise, let reader be ! AcquireReadableStreamDefaultReader(source).
+	reader := source.acquireDefaultReader()
+	readerClosedPromise, _, _ := reader.GetClosed()
+
+	// 10. Let writer be ! AcquireWritableStreamDefaultWriter(dest).
+	writer := dest.acquireDefaultWriter()
+	writerClosedPromise := writer.closedPromise.promise
+
+	// 11. Set source.[[disturbed]] to true.
+	source.disturbed = true
+
+	// 12. Let shuttingDown be false.
+	shuttingDown := false
+
+	// currentWrite is fulfilled once the last chunk read has been written.
+	currentWrite := newResolvedPromise(stream.vu, sobek.Undefined())
+
+	// 13. Let promise be a new promise.
+	promise := newPromiseCapability(rt)
+
+	// 14. If signal is not undefined,
+	// Not implemented yet: AbortSignal is not supported yet.
+
+	// 15. In parallel but not really; see #905, using reader and writer, read all chunks from
+	// source and write them to dest.
+	var pipeStep func()
+	pipeStep = func() {
+		if shuttingDown {
+			return
+		}
+
+		// Backpressure must be enforced: while the desired size of dest is non-positive,
+		// no chunks are read from source.
+		uponPromise(rt, writer.readyPromise.promise, func(sobek.Value) {
+			if shuttingDown {
+				return
+			}
+
+			reader.read(ReadRequest{
+				chunkSteps: func(chunk any) {
+					written := newPromiseCapability(rt)
+					uponPromise(rt, writer.write(rt.ToValue(chunk)),
+						func(sobek.Value) { written.resolve(sobek.Undefined()) },
+						func(sobek.Value) { written.resolve(sobek.Undefined()) },
+					)
+					currentWrite = written.promise
+
+					pipeStep()
+				},
+				closeSteps: func() {},
+				errorSteps: func(any) {},
+			})
+		}, nil)
+	}
+
+	// waitForWritesToFinish runs the steps once every chunk that has been read has been written.
+	var waitForWritesToFinish func(steps func())
+	waitForWritesToFinish = func(steps func()) {
+		oldCurrentWrite := currentWrite
+		uponPromise(rt, currentWrite, func(sobek.Value) {
+			if oldCurrentWrite != currentWrite {
+				waitForWritesToFinish(steps)
+				return
+			}
+			steps()
+		}, nil)
+	}
+
+	// Finalize: both forms of shutdown will eventually ask to finalize, optionally
+	// with an error error.
+	finalize := func(isError bool, e sobek.Value) {
+		// 1. Perform ! WritableStreamDefaultWriterRelease(writer).
+		writer.release()
+
+		// 2. If reader implements ReadableStreamBYOBReader, perform ! ReadableStreamBYOBReaderRelease(reader).
+		// 3. Otherwise, perform ! ReadableStreamDefaultReaderRelease(reader).
+		reader.release()
+
+		// 4. If signal is not undefined, remove abortAlgorithm from signal.
+		// 5. If error was given, reject promise with error.
+		// 6. Otherwise, resolve promise with undefined.
+		if isError {
+			promise.reject(e)
+		} else {
+			promise.resolve(sobek.Undefined())
+		}
+	}
+
+	// Shutdown with an action: if any of the requirements ask to shutdown with an action
+	// action, optionally with an error originalError, then:
+	shutdownWithAnAction := func(action func() *sobek.Promise, originalIsError bool, originalError sobek.Value) {
+		// 1. If shuttingDown is true, abort these substeps.
+		if shuttingDown {
+			return
+		}
+
+		// 2. Set shuttingDown to true.
+		shuttingDown = true
+
+		doTheRest := func() {
+			// 4. Let p be the result of performing action.
+			p := action()
+
+			uponPromise(rt, p,
+				// 5. Upon fulfillment of p, finalize, passing along originalError if it was given.
+				func(sobek.Value) { finalize(originalIsError, originalError) },
+				// 6. Upon rejection of p with reason newError, finalize with newError.
+				func(newError sobek.Value) { finalize(true, newError) },
+			)
+		}
+
+		// 3. If dest.[[state]] is "writable" and ! WritableStreamCloseQueuedOrInFlight(dest) is false,
+		// 3.1. If any chunks have been read but not yet written, write them to dest.
+		// 3.2. Wait until every chunk that has been read has been written (i.e. the corresponding
+		// promises have settled).
+		if dest.state == WritableStreamStateWritable && !dest.closeQueuedOrInFlight() {
+			waitForWritesToFinish(doTheRest)
+		} else {
+			doTheRest()
+		}
+	}
+
+	// Shutdown: if any of the requirements ask to shutdown, optionally with an error error, then:
+	shutdown := func(isError bool, e sobek.Value) {
+		// 1. If shuttingDown is true, abort these substeps.
+		if shuttingDown {
+			return
+		}
+
+		// 2. Set shuttingDown to true.
+		shuttingDown = true
+
+		// 3. If dest.[[state]] is "writable" and ! WritableStreamCloseQueuedOrInFlight(dest) is false,
+		// 3.1. If any chunks have been read but not yet written, write them to dest.
+		// 3.2. Wait until every chunk that has been read has been written.
+		if dest.state == WritableStreamStateWritable && !dest.closeQueuedOrInFlight() {
+			waitForWritesToFinish(func() { finalize(isError, e) })
+		} else {
+			// 4. Finalize, passing along error if it was given.
+			finalize(isError, e)
+		}
+	}
+
+	// Errors must be propagated forward: if source.[[state]] is or becomes "errored", then
+	sourceErrored := func(storedError sobek.Value) {
+		if !options.PreventAbort {
+			// 1. If preventAbort is false, shutdown with an action of ! WritableStreamAbort(dest,
+			// source.[[storedError]]) and with source.[[storedError]].
+			shutdownWithAnAction(func() *sobek.Promise { return dest.abort(storedError) }, true, storedError)
+		} else {
+			// 2. Otherwise, shutdown with source.[[storedError]].
+			shutdown(true, storedError)
+		}
+	}
+	if source.state == ReadableStreamStateErrored {
+		sourceErrored(errorValue(rt, source.storedError))
+	} else {
+		uponPromise(rt, readerClosedPromise, nil, sourceErrored)
+	}
+
+	// Errors must be propagated backward: if dest.[[state]] is or becomes "errored", then
+	destErrored := func(storedError sobek.Value) {
+		if !options.PreventCancel {
+			// 1. If preventCancel is false, shutdown with an action of ! ReadableStreamCancel(source,
+			// dest.[[storedError]]) and with dest.[[storedError]].
+			shutdownWithAnAction(func() *sobek.Promise { return source.cancel(storedError) }, true, storedError)
+		} else {
+			// 2. Otherwise, shutdown with dest.[[storedError]].
+			shutdown(true, storedError)
+		}
+	}
+	if dest.state == WritableStreamStateErrored {
+		destErrored(dest.storedError)
+	} else {
+		uponPromise(rt, writerClosedPromise, nil, destErrored)
+	}
+
+	// Closing must be propagated forward: if source.[[state]] is or becomes "closed", then
+	sourceClosed := func(sobek.Value) {
+		if !options.PreventClose {
+			// 1. If preventClose is false, shutdown with an action of
+			// ! WritableStreamDefaultWriterCloseWithErrorPropagation(writer).
+			shutdownWithAnAction(writer.closeWithErrorPropagation, false, nil)
+		} else {
+			// 2. Otherwise, shutdown.
+			shutdown(false, nil)
+		}
+	}
+	if source.state == ReadableStreamStateClosed {
+		sourceClosed(sobek.Undefined())
+	} else {
+		uponPromise(rt, readerClosedPromise, sourceClosed, nil)
+	}
+
+	// Closing must be propagated backward: if ! WritableStreamCloseQueuedOrInFlight(dest) is true
+	// or dest.[[state]] is "closed", then
+	if dest.closeQueuedOrInFlight() || dest.state == WritableStreamStateClosed {
+		// 1. Assert: no chunks have been read or written.
+		// 2. Let destClosed be a new TypeError.
+		destClosed := newTypeError(rt, "the destination stream closed before all data could be piped to it").Err()
+
+		if !options.PreventCancel {
+			// 3. If preventCancel is false, shutdown with an action of ! ReadableStreamCancel(source,
+			// destClosed) and with destClosed.
+			shutdownWithAnAction(func() *sobek.Promise { return source.cancel(destClosed) }, true, destClosed)
+		} else {
+			// 4. Otherwise, shutdown with destClosed.
+			shutdown(true, destClosed)
+		}
+	}
+
+	pipeStep()
+
+	// 16. Return promise.
+	return promise.promise
+}
+
+// exportOrNil returns the exported value, or nil for a nil value.
+func exportOrNil(v sobek.Value) any {
+	if v == nil {
+		return nil
+	}
+	return v.Export()
+}
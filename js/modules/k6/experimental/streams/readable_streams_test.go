@@ -29,13 +29,98 @@ func TestReadableStream(t *testing.T) {
 		"templated.any.js",
 	}
 
+	runTestSuites(t, "tests/wpt/streams/readable-streams", suites)
+}
+
+func TestReadableByteStream(t *testing.T) {
+	t.Parallel()
+
+	suites := []string{
+		"bad-buffers-and-views.any.js",
+		"construct-byob-request.any.js",
+		"general.any.js",
+		"read-min.any.js",
+		"respond-after-enqueue.any.js",
+	}
+
+	runTestSuites(t, "tests/wpt/streams/readable-byte-streams", suites)
+}
+
+func TestWritableStream(t *testing.T) {
+	t.Parallel()
+
+	suites := []string{
+		"aborting.any.js",
+		"bad-strategies.any.js",
+		"bad-underlying-sinks.any.js",
+		"byte-length-queuing-strategy.any.js",
+		"close.any.js",
+		"constructor.any.js",
+		"count-queuing-strategy.any.js",
+		"error.any.js",
+		"floating-point-total-queue-size.any.js",
+		"general.any.js",
+		"properties.any.js",
+		"reentrant-strategy.any.js",
+		"start.any.js",
+		"write.any.js",
+	}
+
+	runTestSuites(t, "tests/wpt/streams/writable-streams", suites)
+}
+
+func TestTransformStream(t *testing.T) {
+	t.Parallel()
+
+	suites := []string{
+		"backpressure.any.js",
+		"cancel.any.js",
+		"errors.any.js",
+		"flush.any.js",
+		"general.any.js",
+		"lipfuzz.any.js",
+		"patched-global.any.js",
+		"properties.any.js",
+		"reentrant-strategies.any.js",
+		"strategies.any.js",
+		"terminate.any.js",
+	}
+
+	runTestSuites(t, "tests/wpt/streams/transform-streams", suites)
+}
+
+func TestPiping(t *testing.T) {
+	t.Parallel()
+
+	// The "abort.any.js" suite is left out, as the AbortSignal
+	// option of pipeTo() and pipeThrough() is not supported yet.
+	suites := []string{
+		"close-propagation-backward.any.js",
+		"close-propagation-forward.any.js",
+		"error-propagation-backward.any.js",
+		"error-propagation-forward.any.js",
+		"flow-control.any.js",
+		"general.any.js",
+		"multiple-propagation.any.js",
+		"pipe-through.any.js",
+		"then-interception.any.js",
+		"throwing-options.any.js",
+		"transform-streams.any.js",
+	}
+
+	runTestSuites(t, "tests/wpt/streams/piping", suites)
+}
+
+func runTestSuites(t *testing.T, base string, suites []string) {
+	t.Helper()
+
 	for _, suite := range suites {
 		suite := suite
 		t.Run(suite, func(t *testing.T) {
 			t.Parallel()
 			ts := newConfiguredRuntime(t)
 			gotErr := ts.EventLoop.Start(func() error {
-				return executeTestScript(ts.VU, "tests/wpt/streams/readable-streams", suite)
+				return executeTestScript(ts.VU, base, suite)
 			})
 			assert.NoError(t, gotErr)
 		})
@@ -65,6 +150,7 @@ func newConfiguredRuntime(t testing.TB) *modulestest.Runtime {
 
 	// And the Streams-specific test utilities.
 	files := []string{
+		"resources/recording-streams.js",
 		"resources/rs-test-templates.js",
 		"resources/rs-utils.js",
 		"resources/test-utils.js",
@@ -0,0 +1,306 @@
+package streams
+
+import (
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/common"
+)
+
+// TransformStreamDefaultController is the default controller for a TransformStream. It
+// allows the [Transformer] to manipulate the associated readable and writable streams.
+//
+// For more details, see the [specification].
+//
+// [specification]: https://streams.spec.whatwg.org/#ts-default-controller-class
+type TransformStreamDefaultController struct {
+	// cancelAlgorithm is a promise-returning algorithm, taking one argument (the reason
+	// for cancellation), which communicates a requested cancellation to the transformer.
+	cancelAlgorithm TransformerCancelCallback
+
+	// finishPromise is resolved on completion of either the flush or cancel algorithms,
+	// and is used to avoid running both of them.
+	finishPromise *promiseCapability
+
+	// flushAlgorithm is a promise-returning algorithm which communicates a requested
+	// close to the transformer.
+	flushAlgorithm TransformerFlushCallback
+
+	// stream is the transform stream that this controller controls.
+	stream *TransformStream
+
+	// transformAlgorithm is a promise-returning algorithm, taking one argument (the
+	// chunk to transform), which requests the transformer perform its transformation.
+	transformAlgorithm TransformerTransformCallback
+
+	// object is the JS object representing the controller.
+	object *sobek.Object
+}
+
+// NewTransformStreamDefaultControllerObject creates a new [sobek.Object] from a
+// [TransformStreamDefaultController] instance.
+func NewTransformStreamDefaultControllerObject(controller *TransformStreamDefaultController) (*sobek.Object, error) {
+	rt := controller.stream.runtime
+	obj := rt.NewObject()
+	objName := "TransformStreamDefaultController"
+
+	err := obj.DefineAccessorProperty("desiredSize", rt.ToValue(func() sobek.Value {
+		desiredSize := controller.stream.readableController().getDesiredSize()
+		if !desiredSize.Valid {
+			return sobek.Null()
+		}
+		return rt.ToValue(desiredSize.Float64)
+	}), nil, sobek.FLAG_FALSE, sobek.FLAG_TRUE)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "enqueue", rt.ToValue(controller.Enqueue)); err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "error", rt.ToValue(controller.Error)); err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "terminate", rt.ToValue(controller.Terminate)); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// Enqueue enqueues the chunk to the readable side of the stream.
+//
+// It implements the TransformStreamDefaultController.enqueue(chunk) [specification] algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#ts-default-controller-enqueue
+func (controller *TransformStreamDefaultController) Enqueue(chunk sobek.Value) {
+	if chunk == nil {
+		chunk = sobek.Undefined()
+	}
+
+	// 1. Perform ? TransformStreamDefaultControllerEnqueue(this, chunk).
+	if err := controller.enqueue(chunk); err != nil {
+		throw(controller.stream.runtime, err)
+	}
+}
+
+// Error errors both the readable side and the writable side of the stream.
+//
+// It implements the TransformStreamDefaultController.error(reason) [specification] algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#ts-default-controller-error
+func (controller *TransformStreamDefaultController) Error(reason sobek.Value) {
+	if reason == nil {
+		reason = sobek.Undefined()
+	}
+
+	// 1. Perform ? TransformStreamDefaultControllerError(this, reason).
+	controller.stream.error(reason)
+}
+
+// Terminate closes the readable side and errors the writable side of the stream.
+//
+// It implements the TransformStreamDefaultController.terminate() [specification] algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#ts-default-controller-terminate
+func (controller *TransformStreamDefaultController) Terminate() {
+	// 1. Perform ? TransformStreamDefaultControllerTerminate(this).
+	controller.terminate()
+}
+
+// setup implements the specification's [SetUpTransformStreamDefaultController] abstract operation.
+//
+// [SetUpTransformStreamDefaultController]: https://streams.spec.whatwg.org/#set-up-transform-stream-default-controller
+func (controller *TransformStreamDefaultController) setup(
+	stream *TransformStream,
+	transformAlgorithm TransformerTransformCallback,
+	flushAlgorithm TransformerFlushCallback,
+	cancelAlgorithm TransformerCancelCallback,
+) {
+	// 1. Assert: stream implements TransformStream.
+	// 2. Assert: stream.[[controller]] is undefined.
+	if stream.controller != nil {
+		common.Throw(stream.runtime, newError(AssertionError, "stream.[[controller]] is not undefined"))
+	}
+
+	// 3. Set controller.[[stream]] to stream.
+	controller.stream = stream
+
+	// 4. Set stream.[[controller]] to controller.
+	stream.controller = controller
+
+	// 5. Set controller.[[transformAlgorithm]] to transformAlgorithm.
+	// 6. Set controller.[[flushAlgorithm]] to flushAlgorithm.
+	// 7. Set controller.[[cancelAlgorithm]] to cancelAlgorithm.
+	controller.transformAlgorithm, controller.flushAlgorithm, controller.cancelAlgorithm =
+		transformAlgorithm, flushAlgorithm, cancelAlgorithm
+
+	object, err := NewTransformStreamDefaultControllerObject(controller)
+	if err != nil {
+		common.Throw(stream.runtime, newError(RuntimeError, err.Error()))
+	}
+	controller.object = object
+}
+
+// setupFromTransformer implements the specification's
+// [SetUpTransformStreamDefaultControllerFromTransformer] abstract operation.
+//
+// [SetUpTransformStreamDefaultControllerFromTransformer]: https://streams.spec.whatwg.org/#set-up-transform-stream-default-controller-from-transformer
+func (controller *TransformStreamDefaultController) setupFromTransformer(
+	stream *TransformStream,
+	transformer *sobek.Object,
+	transformerDict Transformer,
+) {
+	vu := stream.vu
+
+	// 1. Let controller be a new TransformStreamDefaultController.
+	// 2. Let transformAlgorithm be the following steps, taking a chunk argument:
+	var transformAlgorithm TransformerTransformCallback = func(chunk sobek.Value) *sobek.Promise {
+		// 2.1. Let result be TransformStreamDefaultControllerEnqueue(controller, chunk).
+		// 2.2. If result is an abrupt completion, return a promise rejected with result.[[Value]].
+		if err := controller.enqueue(chunk); err != nil {
+			return newRejectedPromise(vu, errorValue(vu.Runtime(), err))
+		}
+
+		// 2.3. Otherwise, return a promise resolved with undefined.
+		return newResolvedPromise(vu, sobek.Undefined())
+	}
+
+	// 3. Let flushAlgorithm be an algorithm which returns a promise resolved with undefined.
+	var flushAlgorithm TransformerFlushCallback = func() *sobek.Promise {
+		return newResolvedPromise(vu, sobek.Undefined())
+	}
+
+	// 4. Let cancelAlgorithm be an algorithm which returns a promise resolved with undefined.
+	var cancelAlgorithm TransformerCancelCallback = func(sobek.Value) *sobek.Promise {
+		return newResolvedPromise(vu, sobek.Undefined())
+	}
+
+	// 5. If transformerDict["transform"] exists, set transformAlgorithm to an algorithm which takes an
+	// argument chunk and returns the result of invoking transformerDict["transform"] with argument list
+	// « chunk, controller » and callback this value transformer.
+	if transform, ok := sobek.AssertFunction(transformerDict.Transform); ok {
+		transformAlgorithm = func(chunk sobek.Value) *sobek.Promise {
+			return promiseCall(vu, transform, transformer, chunk, controller.object)
+		}
+	}
+
+	// 6. If transformerDict["flush"] exists, set flushAlgorithm to an algorithm which returns the result
+	// of invoking transformerDict["flush"] with argument list « controller » and callback this value
+	// transformer.
+	if flush, ok := sobek.AssertFunction(transformerDict.Flush); ok {
+		flushAlgorithm = func() *sobek.Promise {
+			return promiseCall(vu, flush, transformer, controller.object)
+		}
+	}
+
+	// 7. If transformerDict["cancel"] exists, set cancelAlgorithm to an algorithm which takes an argument
+	// reason and returns the result of invoking transformerDict["cancel"] with argument list « reason »
+	// and callback this value transformer.
+	if cancel, ok := sobek.AssertFunction(transformerDict.Cancel); ok {
+		cancelAlgorithm = func(reason sobek.Value) *sobek.Promise {
+			return promiseCall(vu, cancel, transformer, reason)
+		}
+	}
+
+	// 8. Perform ! SetUpTransformStreamDefaultController(stream, controller, transformAlgorithm,
+	// flushAlgorithm, cancelAlgorithm).
+	controller.setup(stream, transformAlgorithm, flushAlgorithm, cancelAlgorithm)
+}
+
+// clearAlgorithms is called once the stream is closed or errored and the algorithms will
+// not be executed anymore.
+//
+// It implements the [TransformStreamDefaultControllerClearAlgorithms] algorithm.
+//
+// [TransformStreamDefaultControllerClearAlgorithms]: https://streams.spec.whatwg.org/#transform-stream-default-controller-clear-algorithms
+func (controller *TransformStreamDefaultController) clearAlgorithms() {
+	// 1. Set controller.[[transformAlgorithm]] to undefined.
+	controller.transformAlgorithm = nil
+
+	// 2. Set controller.[[flushAlgorithm]] to undefined.
+	controller.flushAlgorithm = nil
+
+	// 3. Set controller.[[cancelAlgorithm]] to undefined.
+	controller.cancelAlgorithm = nil
+}
+
+// enqueue implements the [TransformStreamDefaultControllerEnqueue] algorithm.
+//
+// [TransformStreamDefaultControllerEnqueue]: https://streams.spec.whatwg.org/#transform-stream-default-controller-enqueue
+func (controller *TransformStreamDefaultController) enqueue(chunk sobek.Value) error {
+	// 1. Let stream be controller.[[stream]].
+	stream := controller.stream
+
+	// 2. Let readableController be stream.[[readable]].[[controller]].
+	readableController := stream.readableController()
+
+	// 3. If ! ReadableStreamDefaultControllerCanCloseOrEnqueue(readableController) is false, throw a TypeError exception.
+	if !readableController.canCloseOrEnqueue() {
+		return newTypeError(stream.runtime, "readable side is not in a state that permits enqueue")
+	}
+
+	// 4. Let enqueueResult be ReadableStreamDefaultControllerEnqueue(readableController, chunk).
+	// 5. If enqueueResult is an abrupt completion,
+	if err := readableController.enqueue(chunk); err != nil {
+		// 5.1. Perform ! TransformStreamErrorWritableAndUnblockWrite(stream, enqueueResult.[[Value]]).
+		stream.errorWritableAndUnblockWrite(errorValue(stream.runtime, err))
+
+		// 5.2. Throw stream.[[readable]].[[storedError]].
+		return err
+	}
+
+	// 6. Let backpressure be ! ReadableStreamDefaultControllerHasBackpressure(readableController).
+	backpressure := !readableController.shouldCallPull()
+
+	// 7. If backpressure is not stream.[[backpressure]],
+	if backpressure != stream.backpressure {
+		// 7.1. Assert: backpressure is true.
+		// 7.2. Perform ! TransformStreamSetBackpressure(stream, true).
+		stream.setBackpressure(true)
+	}
+
+	return nil
+}
+
+// performTransform implements the [TransformStreamDefaultControllerPerformTransform] algorithm.
+//
+// [TransformStreamDefaultControllerPerformTransform]: https://streams.spec.whatwg.org/#transform-stream-default-controller-perform-transform
+func (controller *TransformStreamDefaultController) performTransform(chunk sobek.Value) *sobek.Promise {
+	stream := controller.stream
+
+	// 1. Let transformPromise be the result of performing controller.[[transformAlgorithm]], passing chunk.
+	transformPromise := controller.transformAlgorithm(chunk)
+
+	// 2. Return the result of reacting to transformPromise with the following rejection steps given the argument r:
+	result := newPromiseCapability(stream.runtime)
+	uponPromise(stream.runtime, transformPromise,
+		func(sobek.Value) { result.resolve(sobek.Undefined()) },
+		func(r sobek.Value) {
+			// 2.1. Perform ! TransformStreamError(controller.[[stream]], r).
+			stream.error(r)
+			// 2.2. Throw r.
+			result.reject(r)
+		},
+	)
+
+	return result.promise
+}
+
+// terminate implements the [TransformStreamDefaultControllerTerminate] algorithm.
+//
+// [TransformStreamDefaultControllerTerminate]: https://streams.spec.whatwg.org/#transform-stream-default-controller-terminate
+func (controller *TransformStreamDefaultController) terminate() {
+	// 1. Let stream be controller.[[stream]].
+	stream := controller.stream
+
+	// 2. Let readableController be stream.[[readable]].[[controller]].
+	// 3. Perform ! ReadableStreamDefaultControllerClose(readableController).
+	stream.readableController().close()
+
+	// 4. Let error be a TypeError exception indicating that the stream has been terminated.
+	err := newTypeError(stream.runtime, "the stream has been terminated")
+
+	// 5. Perform ! TransformStreamErrorWritableAndUnblockWrite(stream, error).
+	stream.errorWritableAndUnblockWrite(err.Err())
+}
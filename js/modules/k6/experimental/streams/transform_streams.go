@@ -0,0 +1,404 @@
+package streams
+
+import (
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/js/modules"
+)
+
+// TransformStream is a concrete instance of the general [transform stream] concept.
+//
+// It consists of a pair of streams: a writable stream, known as its writable side,
+// and a readable stream, known as its readable side. Writing to the writable side
+// results in new data being made available for reading from the readable side,
+// once transformed by its [Transformer].
+//
+// [transform stream]: https://streams.spec.whatwg.org/#ts-class
+type TransformStream struct {
+	// Readable is the readable side of the transform stream.
+	Readable *sobek.Object `js:"readable"`
+
+	// Writable is the writable side of the transform stream.
+	Writable *sobek.Object `js:"writable"`
+
+	// backpressure is true when there is backpressure on the readable side.
+	backpressure bool
+
+	// backpressureChangePromise is fulfilled and replaced every time the value
+	// of backpressure changes.
+	backpressureChangePromise *promiseCapability
+
+	// controller holds the [TransformStreamDefaultController] created with the
+	// ability to control the transform stream.
+	controller *TransformStreamDefaultController
+
+	// readable holds the readable side of the transform stream.
+	readable *ReadableStream
+
+	// writable holds the writable side of the transform stream.
+	writable *WritableStream
+
+	runtime *sobek.Runtime
+	vu      modules.VU
+}
+
+// initialize implements the specification's [InitializeTransformStream] abstract operation.
+//
+// [InitializeTransformStream]: https://streams.spec.whatwg.org/#initialize-transform-stream
+func (stream *TransformStream) initialize(
+	startPromise *sobek.Promise,
+	writableHighWaterMark float64,
+	writableSizeAlgorithm SizeAlgorithm,
+	readableHighWaterMark float64,
+	readableSizeAlgorithm SizeAlgorithm,
+) {
+	rt := stream.runtime
+
+	// 1. Let startAlgorithm be an algorithm that returns startPromise.
+	startAlgorithm := func(*sobek.Object) sobek.Value {
+		return rt.ToValue(startPromise)
+	}
+
+	// 2. Let writeAlgorithm be the following steps, taking a chunk argument:
+	//   1. Return ! TransformStreamDefaultSinkWriteAlgorithm(stream, chunk).
+	writeAlgorithm := stream.sinkWriteAlgorithm
+
+	// 3. Let abortAlgorithm be the following steps, taking a reason argument:
+	//   1. Return ! TransformStreamDefaultSinkAbortAlgorithm(stream, reason).
+	abortAlgorithm := stream.sinkAbortAlgorithm
+
+	// 4. Let closeAlgorithm be the following steps:
+	//   1. Return ! TransformStreamDefaultSinkCloseAlgorithm(stream).
+	closeAlgorithm := stream.sinkCloseAlgorithm
+
+	// 5. Set stream.[[writable]] to ! CreateWritableStream(startAlgorithm, writeAlgorithm,
+	// closeAlgorithm, abortAlgorithm, writableHighWaterMark, writableSizeAlgorithm).
+	stream.writable = createWritableStream(stream.vu, startAlgorithm, writeAlgorithm, closeAlgorithm,
+		abortAlgorithm, writableHighWaterMark, writableSizeAlgorithm)
+
+	// 6. Let pullAlgorithm be the following steps:
+	//   1. Return ! TransformStreamDefaultSourcePullAlgorithm(stream).
+	pullAlgorithm := func(*sobek.Object) *sobek.Promise {
+		return stream.sourcePullAlgorithm()
+	}
+
+	// 7. Let cancelAlgorithm be the following steps, taking a reason argument:
+	//   1. Return ! TransformStreamDefaultSourceCancelAlgorithm(stream, reason).
+	cancelAlgorithm := func(reason any) sobek.Value {
+		return rt.ToValue(stream.sourceCancelAlgorithm(errorValue(rt, reason)))
+	}
+
+	// 8. Set stream.[[readable]] to ! CreateReadableStream(startAlgorithm, pullAlgorithm,
+	// cancelAlgorithm, readableHighWaterMark, readableSizeAlgorithm).
+	stream.readable = createReadableStream(stream.vu, startAlgorithm, pullAlgorithm, cancelAlgorithm,
+		readableHighWaterMark, readableSizeAlgorithm)
+
+	// 9. Set stream.[[backpressure]] and stream.[[backpressureChangePromise]] to undefined.
+	stream.backpressure, stream.backpressureChangePromise = false, nil
+
+	// 10. Perform ! TransformStreamSetBackpressure(stream, true).
+	stream.setBackpressure(true)
+
+	// 11. Set stream.[[controller]] to undefined.
+	stream.controller = nil
+
+	stream.Readable = rt.ToValue(stream.readable).ToObject(rt)
+	stream.Writable = rt.ToValue(stream.writable).ToObject(rt)
+}
+
+// error implements the specification's [TransformStreamError] abstract operation.
+//
+// [TransformStreamError]: https://streams.spec.whatwg.org/#transform-stream-error
+func (stream *TransformStream) error(e sobek.Value) {
+	// 1. Perform ! ReadableStreamDefaultControllerError(stream.[[readable]].[[controller]], e).
+	stream.readableController().error(e)
+
+	// 2. Perform ! TransformStreamErrorWritableAndUnblockWrite(stream, e).
+	stream.errorWritableAndUnblockWrite(e)
+}
+
+// errorWritableAndUnblockWrite implements the specification's [TransformStreamErrorWritableAndUnblockWrite]
+// abstract operation.
+//
+// [TransformStreamErrorWritableAndUnblockWrite]: https://streams.spec.whatwg.org/#transform-stream-error-writable-and-unblock-write
+func (stream *TransformStream) errorWritableAndUnblockWrite(e sobek.Value) {
+	// 1. Perform ! TransformStreamDefaultControllerClearAlgorithms(stream.[[controller]]).
+	stream.controller.clearAlgorithms()
+
+	// 2. Perform ! WritableStreamDefaultControllerErrorIfNeeded(stream.[[writable]].[[controller]], e).
+	stream.writable.controller.errorIfNeeded(e)
+
+	// 3. Perform ! TransformStreamUnblockWrite(stream).
+	stream.unblockWrite()
+}
+
+// setBackpressure implements the specification's [TransformStreamSetBackpressure] abstract operation.
+//
+// [TransformStreamSetBackpressure]: https://streams.spec.whatwg.org/#transform-stream-set-backpressure
+func (stream *TransformStream) setBackpressure(backpressure bool) {
+	// 1. Assert: stream.[[backpressure]] is not backpressure.
+	// 2. If stream.[[backpressureChangePromise]] is not undefined, resolve
+	// stream.[[backpressureChangePromise]] with undefined.
+	if stream.backpressureChangePromise != nil {
+		stream.backpressureChangePromise.resolve(sobek.Undefined())
+	}
+
+	// 3. Set stream.[[backpressureChangePromise]] to a new promise.
+	stream.backpressureChangePromise = newPromiseCapability(stream.runtime)
+
+	// 4. Set stream.[[backpressure]] to backpressure.
+	stream.backpressure = backpressure
+}
+
+// unblockWrite implements the specification's [TransformStreamUnblockWrite] abstract operation.
+//
+// [TransformStreamUnblockWrite]: https://streams.spec.whatwg.org/#transform-stream-unblock-write
+func (stream *TransformStream) unblockWrite() {
+	// 1. If stream.[[backpressure]] is true, perform ! TransformStreamSetBackpressure(stream, false).
+	if stream.backpressure {
+		stream.setBackpressure(false)
+	}
+}
+
+// sinkWriteAlgorithm implements the specification's [TransformStreamDefaultSinkWriteAlgorithm]
+// abstract operation.
+//
+// [TransformStreamDefaultSinkWriteAlgorithm]: https://streams.spec.whatwg.org/#transform-stream-default-sink-write-algorithm
+func (stream *TransformStream) sinkWriteAlgorithm(chunk sobek.Value) *sobek.Promise {
+	// 1. Assert: stream.[[writable]].[[state]] is "writable".
+	// 2. Let controller be stream.[[controller]].
+	controller := stream.controller
+
+	// 3. If stream.[[backpressure]] is true,
+	if !stream.backpressure {
+		// 4. Return ! TransformStreamDefaultControllerPerformTransform(controller, chunk).
+		return controller.performTransform(chunk)
+	}
+
+	// 3.1. Let backpressureChangePromise be stream.[[backpressureChangePromise]].
+	// 3.2. Assert: backpressureChangePromise is not undefined.
+	backpressureChangePromise := stream.backpressureChangePromise
+
+	// 3.3. Return the result of reacting to backpressureChangePromise with the following fulfillment steps:
+	result := newPromiseCapability(stream.runtime)
+	uponPromise(stream.runtime, backpressureChangePromise.promise,
+		func(sobek.Value) {
+			// 3.3.1. Let writable be stream.[[writable]].
+			writable := stream.writable
+
+			// 3.3.2. Let state be writable.[[state]].
+			// 3.3.3. If state is "erroring", throw writable.[[storedError]].
+			if writable.state == WritableStreamStateErroring {
+				result.reject(writable.storedError)
+				return
+			}
+
+			// 3.3.4. Assert: state is "writable".
+			// 3.3.5. Return ! TransformStreamDefaultControllerPerformTransform(controller, chunk).
+			uponPromise(stream.runtime, controller.performTransform(chunk),
+				func(sobek.Value) { result.resolve(sobek.Undefined()) },
+				func(r sobek.Value) { result.reject(r) },
+			)
+		},
+		func(r sobek.Value) { result.reject(r) },
+	)
+
+	return result.promise
+}
+
+// sinkAbortAlgorithm implements the specification's [TransformStreamDefaultSinkAbortAlgorithm]
+// abstract operation.
+//
+// [TransformStreamDefaultSinkAbortAlgorithm]: https://streams.spec.whatwg.org/#transform-stream-default-sink-abort-algorithm
+func (stream *TransformStream) sinkAbortAlgorithm(reason sobek.Value) *sobek.Promise {
+	// 1. Let controller be stream.[[controller]].
+	controller := stream.controller
+
+	// 2. If controller.[[finishPromise]] is not undefined, return controller.[[finishPromise]].
+	if controller.finishPromise != nil {
+		return controller.finishPromise.promise
+	}
+
+	// 3. Let readable be stream.[[readable]].
+	readable := stream.readable
+
+	// 4. Let controller.[[finishPromise]] be a new promise.
+	finishPromise := newPromiseCapability(stream.runtime)
+	controller.finishPromise = finishPromise
+
+	// 5. Let cancelPromise be the result of performing controller.[[cancelAlgorithm]], passing reason.
+	cancelPromise := controller.cancelAlgorithm(reason)
+
+	// 6. Perform ! TransformStreamDefaultControllerClearAlgorithms(controller).
+	controller.clearAlgorithms()
+
+	// 7. React to cancelPromise:
+	uponPromise(stream.runtime, cancelPromise,
+		// 7.1. If cancelPromise was fulfilled, then:
+		func(sobek.Value) {
+			if readable.state == ReadableStreamStateErrored {
+				// 7.1.1. If readable.[[state]] is "errored", reject controller.[[finishPromise]] with
+				// readable.[[storedError]].
+				finishPromise.reject(errorValue(stream.runtime, readable.storedError))
+			} else {
+				// 7.1.2. Otherwise:
+				// 7.1.2.1. Perform ! ReadableStreamDefaultControllerError(readable.[[controller]], reason).
+				stream.readableController().error(reason)
+				// 7.1.2.2. Resolve controller.[[finishPromise]] with undefined.
+				finishPromise.resolve(sobek.Undefined())
+			}
+		},
+		// 7.2. If cancelPromise was rejected with reason r, then:
+		func(r sobek.Value) {
+			// 7.2.1. Perform ! ReadableStreamDefaultControllerError(readable.[[controller]], r).
+			stream.readableController().error(r)
+			// 7.2.2. Reject controller.[[finishPromise]] with r.
+			finishPromise.reject(r)
+		},
+	)
+
+	// 8. Return controller.[[finishPromise]].
+	return finishPromise.promise
+}
+
+// sinkCloseAlgorithm implements the specification's [TransformStreamDefaultSinkCloseAlgorithm]
+// abstract operation.
+//
+// [TransformStreamDefaultSinkCloseAlgorithm]: https://streams.spec.whatwg.org/#transform-stream-default-sink-close-algorithm
+func (stream *TransformStream) sinkCloseAlgorithm() *sobek.Promise {
+	// 1. Let controller be stream.[[controller]].
+	controller := stream.controller
+
+	// 2. If controller.[[finishPromise]] is not undefined, return controller.[[finishPromise]].
+	if controller.finishPromise != nil {
+		return controller.finishPromise.promise
+	}
+
+	// 3. Let readable be stream.[[readable]].
+	readable := stream.readable
+
+	// 4. Let controller.[[finishPromise]] be a new promise.
+	finishPromise := newPromiseCapability(stream.runtime)
+	controller.finishPromise = finishPromise
+
+	// 5. Let flushPromise be the result of performing controller.[[flushAlgorithm]].
+	flushPromise := controller.flushAlgorithm()
+
+	// 6. Perform ! TransformStreamDefaultControllerClearAlgorithms(controller).
+	controller.clearAlgorithms()
+
+	// 7. React to flushPromise:
+	uponPromise(stream.runtime, flushPromise,
+		// 7.1. If flushPromise was fulfilled, then:
+		func(sobek.Value) {
+			if readable.state == ReadableStreamStateErrored {
+				// 7.1.1. If readable.[[state]] is "errored", reject controller.[[finishPromise]] with
+				// readable.[[storedError]].
+				finishPromise.reject(errorValue(stream.runtime, readable.storedError))
+			} else {
+				// 7.1.2. Otherwise:
+				// 7.1.2.1. Perform ! ReadableStreamDefaultControllerClose(readable.[[controller]]).
+				stream.readableController().close()
+				// 7.1.2.2. Resolve controller.[[finishPromise]] with undefined.
+				finishPromise.resolve(sobek.Undefined())
+			}
+		},
+		// 7.2. If flushPromise was rejected with reason r, then:
+		func(r sobek.Value) {
+			// 7.2.1. Perform ! ReadableStreamDefaultControllerError(readable.[[controller]], r).
+			stream.readableController().error(r)
+			// 7.2.2. Reject controller.[[finishPromise]] with r.
+			finishPromise.reject(r)
+		},
+	)
+
+	// 8. Return controller.[[finishPromise]].
+	return finishPromise.promise
+}
+
+// sourceCancelAlgorithm implements the specification's [TransformStreamDefaultSourceCancelAlgorithm]
+// abstract operation.
+//
+// [TransformStreamDefaultSourceCancelAlgorithm]: https://streams.spec.whatwg.org/#transform-stream-default-source-cancel
+func (stream *TransformStream) sourceCancelAlgorithm(reason sobek.Value) *sobek.Promise {
+	// 1. Let controller be stream.[[controller]].
+	controller := stream.controller
+
+	// 2. If controller.[[finishPromise]] is not undefined, return controller.[[finishPromise]].
+	if controller.finishPromise != nil {
+		return controller.finishPromise.promise
+	}
+
+	// 3. Let writable be stream.[[writable]].
+	writable := stream.writable
+
+	// 4. Let controller.[[finishPromise]] be a new promise.
+	finishPromise := newPromiseCapability(stream.runtime)
+	controller.finishPromise = finishPromise
+
+	// 5. Let cancelPromise be the result of performing controller.[[cancelAlgorithm]], passing reason.
+	cancelPromise := controller.cancelAlgorithm(reason)
+
+	// 6. Perform ! TransformStreamDefaultControllerClearAlgorithms(controller).
+	controller.clearAlgorithms()
+
+	// 7. React to cancelPromise:
+	uponPromise(stream.runtime, cancelPromise,
+		// 7.1. If cancelPromise was fulfilled, then:
+		func(sobek.Value) {
+			if writable.state == WritableStreamStateErrored {
+				// 7.1.1. If writable.[[state]] is "errored", reject controller.[[finishPromise]] with
+				// writable.[[storedError]].
+				finishPromise.reject(writable.storedError)
+			} else {
+				// 7.1.2. Otherwise:
+				// 7.1.2.1. Perform ! WritableStreamDefaultControllerErrorIfNeeded(writable.[[controller]], reason).
+				writable.controller.errorIfNeeded(reason)
+				// 7.1.2.2. Perform ! TransformStreamUnblockWrite(stream).
+				stream.unblockWrite()
+				// 7.1.2.3. Resolve controller.[[finishPromise]] with undefined.
+				finishPromise.resolve(sobek.Undefined())
+			}
+		},
+		// 7.2. If cancelPromise was rejected with reason r, then:
+		func(r sobek.Value) {
+			// 7.2.1. Perform ! WritableStreamDefaultControllerErrorIfNeeded(writable.[[controller]], r).
+			writable.controller.errorIfNeeded(r)
+			// 7.2.2. Perform ! TransformStreamUnblockWrite(stream).
+			stream.unblockWrite()
+			// 7.2.3. Reject controller.[[finishPromise]] with r.
+			finishPromise.reject(r)
+		},
+	)
+
+	// 8. Return controller.[[finishPromise]].
+	return finishPromise.promise
+}
+
+// sourcePullAlgorithm implements the specification's [TransformStreamDefaultSourcePullAlgorithm]
+// abstract operation.
+//
+// [TransformStreamDefaultSourcePullAlgorithm]: https://streams.spec.whatwg.org/#transform-stream-default-source-pull
+func (stream *TransformStream) sourcePullAlgorithm() *sobek.Promise {
+	// 1. Assert: stream.[[backpressure]] is true.
+	if !stream.backpressure {
+		common.Throw(stream.runtime, newError(AssertionError, "stream has no backpressure"))
+	}
+
+	// 2. Assert: stream.[[backpressureChangePromise]] is not undefined.
+	// 3. Perform ! TransformStreamSetBackpressure(stream, false).
+	stream.setBackpressure(false)
+
+	// 4. Return stream.[[backpressureChangePromise]].
+	return stream.backpressureChangePromise.promise
+}
+
+// readableController returns the controller of the readable side of the stream.
+func (stream *TransformStream) readableController() *ReadableStreamDefaultController {
+	controller, ok := stream.readable.controller.(*ReadableStreamDefaultController)
+	if !ok {
+		common.Throw(stream.runtime, newError(AssertionError, "readable controller is not a default controller"))
+	}
+
+	return controller
+}
@@ -0,0 +1,82 @@
+package streams
+
+import (
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/common"
+)
+
+// Transformer represents the transformer of a TransformStream, and defines how the
+// chunks written to its writable side are transformed into the chunks read from its
+// readable side.
+//
+// [specification]: https://streams.spec.whatwg.org/#transformer-api
+type Transformer struct {
+	// Start is called immediately during the creation of a TransformStream.
+	//
+	// Typically, this is used to enqueue prefix chunks. If the setup process is
+	// asynchronous, it can return a promise to signal success or failure; a rejected
+	// promise will error the stream.
+	Start sobek.Value `json:"start"`
+
+	// Transform is called when a new chunk originally written to the writable side
+	// is ready to be transformed.
+	//
+	// It can enqueue any number of chunks to the readable side, using the controller.
+	// If the transformation process is asynchronous, it can return a promise to signal
+	// success or failure. When no transform is provided, the chunks are passed through.
+	Transform sobek.Value `json:"transform"`
+
+	// Flush is called after all the chunks written to the writable side have been
+	// transformed, and the writable side is about to be closed.
+	//
+	// Typically, this is used to enqueue suffix chunks.
+	Flush sobek.Value `json:"flush"`
+
+	// Cancel is called when the readable side is canceled, or the writable side is
+	// aborted.
+	Cancel sobek.Value `json:"cancel"`
+
+	// ReadableType is reserved for future use, and any attempts to supply a value
+	// will throw an exception.
+	ReadableType sobek.Value `json:"readableType"`
+
+	// WritableType is reserved for future use, and any attempts to supply a value
+	// will throw an exception.
+	WritableType sobek.Value `json:"writableType"`
+}
+
+// NewTransformerFromObject creates a new Transformer from a sobek.Object.
+func NewTransformerFromObject(rt *sobek.Runtime, obj *sobek.Object) (Transformer, error) {
+	var transformer Transformer
+
+	if common.IsNullish(obj) {
+		// If the user didn't provide a transformer, use the default one.
+		return transformer, nil
+	}
+
+	for _, name := range []string{"cancel", "flush", "start", "transform"} {
+		if v := obj.Get(name); !common.IsNullish(v) {
+			if _, ok := sobek.AssertFunction(v); !ok {
+				return transformer, newTypeError(rt, "transformer."+name+" must be a function")
+			}
+		}
+	}
+
+	transformer.Start = obj.Get("start")
+	transformer.Transform = obj.Get("transform")
+	transformer.Flush = obj.Get("flush")
+	transformer.Cancel = obj.Get("cancel")
+	transformer.ReadableType = obj.Get("readableType")
+	transformer.WritableType = obj.Get("writableType")
+
+	return transformer, nil
+}
+
+// TransformerTransformCallback is a function that is called when a new chunk is ready to be transformed.
+type TransformerTransformCallback func(chunk sobek.Value) *sobek.Promise
+
+// TransformerFlushCallback is a function that is called after all the written chunks have been transformed.
+type TransformerFlushCallback func() *sobek.Promise
+
+// TransformerCancelCallback is a function that is called when the stream is canceled or aborted.
+type TransformerCancelCallback func(reason sobek.Value) *sobek.Promise
@@ -0,0 +1,84 @@
+package streams
+
+import (
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/common"
+)
+
+// UnderlyingSink represents the underlying sink of a WritableStream, and defines how
+// the data written to the stream is delivered to its destination.
+//
+// [specification]: https://streams.spec.whatwg.org/#underlying-sink-api
+type UnderlyingSink struct {
+	// Start is called immediately during the creation of a WritableStream.
+	//
+	// Typically, this is used to acquire access to the underlying sink resource
+	// being represented. If the setup process is asynchronous, it can return a
+	// promise to signal success or failure; a rejected promise will error the stream.
+	Start sobek.Value `json:"start"`
+
+	// Write is called when a new chunk of data is ready to be written to the
+	// underlying sink.
+	//
+	// It is guaranteed to be called only after previous writes have succeeded,
+	// and never before start() has succeeded or after close() or abort() have
+	// been called. If the write process is asynchronous, it can return a promise
+	// to signal success or failure.
+	Write sobek.Value `json:"write"`
+
+	// Close is called after the producer signals that it is done writing chunks
+	// to the stream, and all queued-up writes have successfully completed.
+	Close sobek.Value `json:"close"`
+
+	// Abort is called after the producer signals that it wishes to abruptly close
+	// the stream and put it in an errored state.
+	//
+	// It takes as its argument the same value as was passed to the abort()
+	// methods by the producer.
+	Abort sobek.Value `json:"abort"`
+
+	// Type is reserved for future use, and any attempts to supply a value
+	// will throw an exception.
+	Type sobek.Value `json:"type"`
+}
+
+// UnderlyingSinkStartCallback is a function that is called immediately during the creation of a WritableStream.
+type UnderlyingSinkStartCallback func(controller *sobek.Object) sobek.Value
+
+// UnderlyingSinkWriteCallback is a function that is called when a new chunk of data is ready to be written
+// to the underlying sink.
+type UnderlyingSinkWriteCallback func(chunk sobek.Value) *sobek.Promise
+
+// UnderlyingSinkCloseCallback is a function that is called after the producer signals that it is done
+// writing chunks to the stream.
+type UnderlyingSinkCloseCallback func() *sobek.Promise
+
+// UnderlyingSinkAbortCallback is a function that is called after the producer signals that it wishes to
+// abruptly close the stream.
+type UnderlyingSinkAbortCallback func(reason sobek.Value) *sobek.Promise
+
+// NewUnderlyingSinkFromObject creates a new UnderlyingSink from a sobek.Object.
+func NewUnderlyingSinkFromObject(rt *sobek.Runtime, obj *sobek.Object) (UnderlyingSink, error) {
+	var underlyingSink UnderlyingSink
+
+	if common.IsNullish(obj) {
+		// If the user didn't provide an underlying sink, use the default one.
+		return underlyingSink, nil
+	}
+
+	for _, name := range []string{"abort", "close", "start", "write"} {
+		if v := obj.Get(name); !common.IsNullish(v) {
+			if _, ok := sobek.AssertFunction(v); !ok {
+				return underlyingSink, newTypeError(rt, "underlyingSink."+name+" must be a function")
+			}
+		}
+	}
+
+	underlyingSink.Start = obj.Get("start")
+	underlyingSink.Write = obj.Get("write")
+	underlyingSink.Close = obj.Get("close")
+	underlyingSink.Abort = obj.Get("abort")
+	underlyingSink.Type = obj.Get("type")
+
+	return underlyingSink, nil
+}
@@ -95,6 +95,16 @@ func NewUnderlyingSourceFromObject(rt *sobek.Runtime, obj *sobek.Object) (Underl
 		return underlyingSource, newTypeError(rt, "invalid underlying source object")
 	}
 
+	// The autoAllocateChunkSize is an [EnforceRange] unsigned long long,
+	// so we convert it by hand, and reject any value out of that range.
+	if v := obj.Get("autoAllocateChunkSize"); !common.IsNullish(v) {
+		size, ok := toEnforcedRangeInteger(v)
+		if !ok {
+			return underlyingSource, newTypeError(rt, "autoAllocateChunkSize must be a non-negative integer")
+		}
+		underlyingSource.AutoAllocateChunkSize = null.IntFrom(size)
+	}
+
 	if underlyingSource.Start != nil {
 		underlyingSource.startSet = true
 	}
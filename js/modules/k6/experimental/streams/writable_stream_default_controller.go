@@ -0,0 +1,530 @@
+package streams
+
+import (
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/common"
+)
+
+// WritableStreamDefaultController is the default controller for a WritableStream. It
+// allows control of the stream's state, and is given to the [UnderlyingSink]'s callbacks.
+//
+// For more details, see the [specification].
+//
+// [specification]: https://streams.spec.whatwg.org/#ws-default-controller-class
+type WritableStreamDefaultController struct {
+	// abortAlgorithm is a promise-returning algorithm, taking one argument (the abort
+	// reason), which communicates a requested abort to the underlying sink.
+	abortAlgorithm UnderlyingSinkAbortCallback
+
+	// closeAlgorithm is a promise-returning algorithm which communicates a requested
+	// close to the underlying sink.
+	closeAlgorithm UnderlyingSinkCloseCallback
+
+	// queue is a list representing the stream's internal queue of chunks.
+	//
+	// The close requests are enqueued as nil values, the specification's close
+	// sentinel, as the chunks are never nil.
+	queue *QueueWithSizes
+
+	// started is a boolean flag indicating whether the [UnderlyingSink] has finished starting.
+	started bool
+
+	// strategyHWM is a number supplied by the creator of the stream as part of the stream's
+	// queuing strategy, indicating the point at which the stream will apply backpressure
+	// to its [UnderlyingSink].
+	strategyHWM float64
+
+	// strategySizeAlgorithm is an algorithm to calculate the size of enqueued chunks, as part
+	// of the stream's queuing strategy.
+	strategySizeAlgorithm SizeAlgorithm
+
+	// stream is the writable stream that this controller controls.
+	stream *WritableStream
+
+	// writeAlgorithm is a promise-returning algorithm, taking one argument (the chunk
+	// to write), which writes data to the underlying sink.
+	writeAlgorithm UnderlyingSinkWriteCallback
+
+	// object is the JS object representing the controller.
+	object *sobek.Object
+}
+
+// NewWritableStreamDefaultControllerObject creates a new [sobek.Object] from a
+// [WritableStreamDefaultController] instance.
+func NewWritableStreamDefaultControllerObject(controller *WritableStreamDefaultController) (*sobek.Object, error) {
+	rt := controller.stream.runtime
+	obj := rt.NewObject()
+	objName := "WritableStreamDefaultController"
+
+	if err := setReadOnlyPropertyOf(obj, objName, "error", rt.ToValue(controller.Error)); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// Error errors the stream, so that all future interactions with it fail with the given error.
+//
+// It implements the WritableStreamDefaultController.error(e) [specification] algorithm.
+//
+// [specification]: https://streams.spec.whatwg.org/#ws-default-controller-error
+func (controller *WritableStreamDefaultController) Error(e sobek.Value) {
+	if e == nil {
+		e = sobek.Undefined()
+	}
+
+	// 1. Let state be this.[[stream]].[[state]].
+	// 2. If state is not "writable", return.
+	if controller.stream.state != WritableStreamStateWritable {
+		return
+	}
+
+	// 3. Perform ! WritableStreamDefaultControllerError(this, e).
+	controller.error(e)
+}
+
+// abortSteps implements the [AbortSteps] contract of the controller.
+//
+// [AbortSteps]: https://streams.spec.whatwg.org/#ws-default-controller-private-abort
+func (controller *WritableStreamDefaultController) abortSteps(reason sobek.Value) *sobek.Promise {
+	// 1. Let result be the result of performing this.[[abortAlgorithm]], passing reason.
+	result := controller.abortAlgorithm(reason)
+
+	// 2. Perform ! WritableStreamDefaultControllerClearAlgorithms(this).
+	controller.clearAlgorithms()
+
+	// 3. Return result.
+	return result
+}
+
+// errorSteps implements the [ErrorSteps] contract of the controller.
+//
+// [ErrorSteps]: https://streams.spec.whatwg.org/#ws-default-controller-private-error
+func (controller *WritableStreamDefaultController) errorSteps() {
+	// 1. Perform ! ResetQueue(this).
+	controller.queue.Reset()
+}
+
+// setup implements the specification's [SetUpWritableStreamDefaultController] abstract operation.
+//
+// [SetUpWritableStreamDefaultController]: https://streams.spec.whatwg.org/#set-up-writable-stream-default-controller
+func (controller *WritableStreamDefaultController) setup(
+	stream *WritableStream,
+	startAlgorithm UnderlyingSinkStartCallback,
+	writeAlgorithm UnderlyingSinkWriteCallback,
+	closeAlgorithm UnderlyingSinkCloseCallback,
+	abortAlgorithm UnderlyingSinkAbortCallback,
+	highWaterMark float64,
+	sizeAlgorithm SizeAlgorithm,
+) {
+	rt := stream.runtime
+
+	// 1. Assert: stream implements WritableStream.
+	// 2. Assert: stream.[[controller]] is undefined.
+	if stream.controller != nil {
+		common.Throw(rt, newError(AssertionError, "stream.[[controller]] is not undefined"))
+	}
+
+	// 3. Set controller.[[stream]] to stream.
+	controller.stream = stream
+
+	// 4. Set stream.[[controller]] to controller.
+	stream.controller = controller
+
+	// 5. Perform ! ResetQueue(controller).
+	controller.queue = NewQueueWithSizes(rt)
+
+	// 6. Set controller.[[abortController]] to a new AbortController.
+	// Not implemented yet: AbortSignal is not supported yet.
+
+	// 7. Set controller.[[started]] to false.
+	controller.started = false
+
+	// 8. Set controller.[[strategySizeAlgorithm]] to sizeAlgorithm.
+	// 9. Set controller.[[strategyHWM]] to highWaterMark.
+	controller.strategySizeAlgorithm, controller.strategyHWM = sizeAlgorithm, highWaterMark
+
+	// 10. Set controller.[[writeAlgorithm]] to writeAlgorithm.
+	// 11. Set controller.[[closeAlgorithm]] to closeAlgorithm.
+	// 12. Set controller.[[abortAlgorithm]] to abortAlgorithm.
+	controller.writeAlgorithm, controller.closeAlgorithm, controller.abortAlgorithm =
+		writeAlgorithm, closeAlgorithm, abortAlgorithm
+
+	// 13. Let backpressure be ! WritableStreamDefaultControllerGetBackpressure(controller).
+	// 14. Perform ! WritableStreamUpdateBackpressure(stream, backpressure).
+	stream.updateBackpressure(controller.getBackpressure())
+
+	// 15. Let startResult be the result of performing startAlgorithm. (This may throw an exception.)
+	controllerObj, err := NewWritableStreamDefaultControllerObject(controller)
+	if err != nil {
+		common.Throw(rt, newError(RuntimeError, err.Error()))
+	}
+	controller.object = controllerObj
+	startResult := startAlgorithm(controllerObj)
+
+	// 16. Let startPromise be a promise resolved with startResult.
+	startPromise := promiseResolve(stream.vu, startResult)
+
+	uponPromise(rt, startPromise,
+		// 17. Upon fulfillment of startPromise,
+		func(sobek.Value) {
+			// 17.1. Assert: stream.[[state]] is "writable" or "erroring".
+			// 17.2. Set controller.[[started]] to true.
+			controller.started = true
+			// 17.3. Perform ! WritableStreamDefaultControllerAdvanceQueueIfNeeded(controller).
+			controller.advanceQueueIfNeeded()
+		},
+		// 18. Upon rejection of startPromise with reason r,
+		func(r sobek.Value) {
+			// 18.1. Assert: stream.[[state]] is "writable" or "erroring".
+			// 18.2. Set controller.[[started]] to true.
+			controller.started = true
+			// 18.3. Perform ! WritableStreamDealWithRejection(stream, r).
+			stream.dealWithRejection(r)
+		},
+	)
+}
+
+// setupFromUnderlyingSink implements the specification's
+// [SetUpWritableStreamDefaultControllerFromUnderlyingSink] abstract operation.
+//
+// [SetUpWritableStreamDefaultControllerFromUnderlyingSink]: https://streams.spec.whatwg.org/#set-up-writable-stream-default-controller-from-underlying-sink
+func (controller *WritableStreamDefaultController) setupFromUnderlyingSink(
+	stream *WritableStream,
+	underlyingSink *sobek.Object,
+	underlyingSinkDict UnderlyingSink,
+	highWaterMark float64,
+	sizeAlgorithm SizeAlgorithm,
+) {
+	// 1. Let controller be a new WritableStreamDefaultController.
+	// 2. Let startAlgorithm be an algorithm that returns undefined.
+	var startAlgorithm UnderlyingSinkStartCallback = func(*sobek.Object) sobek.Value {
+		return sobek.Undefined()
+	}
+
+	// 3. Let writeAlgorithm be an algorithm that returns a promise resolved with undefined.
+	var writeAlgorithm UnderlyingSinkWriteCallback = func(sobek.Value) *sobek.Promise {
+		return newResolvedPromise(stream.vu, sobek.Undefined())
+	}
+
+	// 4. Let closeAlgorithm be an algorithm that returns a promise resolved with undefined.
+	var closeAlgorithm UnderlyingSinkCloseCallback = func() *sobek.Promise {
+		return newResolvedPromise(stream.vu, sobek.Undefined())
+	}
+
+	// 5. Let abortAlgorithm be an algorithm that returns a promise resolved with undefined.
+	var abortAlgorithm UnderlyingSinkAbortCallback = func(sobek.Value) *sobek.Promise {
+		return newResolvedPromise(stream.vu, sobek.Undefined())
+	}
+
+	// 6. If underlyingSinkDict["start"] exists, then set startAlgorithm to an algorithm which returns
+	// the result of invoking underlyingSinkDict["start"] with argument list « controller », exception
+	// behavior "rethrow", and callback this value underlyingSink.
+	if start, ok := sobek.AssertFunction(underlyingSinkDict.Start); ok {
+		startAlgorithm = func(obj *sobek.Object) sobek.Value {
+			v, err := start(underlyingSink, obj)
+			if err != nil {
+				panic(err)
+			}
+
+			return v
+		}
+	}
+
+	// 7. If underlyingSinkDict["write"] exists, then set writeAlgorithm to an algorithm which takes an
+	// argument chunk and returns the result of invoking underlyingSinkDict["write"] with argument list
+	// « chunk, controller » and callback this value underlyingSink.
+	if write, ok := sobek.AssertFunction(underlyingSinkDict.Write); ok {
+		writeAlgorithm = func(chunk sobek.Value) *sobek.Promise {
+			return promiseCall(stream.vu, write, underlyingSink, chunk, controller.object)
+		}
+	}
+
+	// 8. If underlyingSinkDict["close"] exists, then set closeAlgorithm to an algorithm which returns
+	// the result of invoking underlyingSinkDict["close"] with argument list «» and callback this value
+	// underlyingSink.
+	if closeFn, ok := sobek.AssertFunction(underlyingSinkDict.Close); ok {
+		closeAlgorithm = func() *sobek.Promise {
+			return promiseCall(stream.vu, closeFn, underlyingSink)
+		}
+	}
+
+	// 9. If underlyingSinkDict["abort"] exists, then set abortAlgorithm to an algorithm which takes an
+	// argument reason and returns the result of invoking underlyingSinkDict["abort"] with argument list
+	// « reason » and callback this value underlyingSink.
+	if abort, ok := sobek.AssertFunction(underlyingSinkDict.Abort); ok {
+		abortAlgorithm = func(reason sobek.Value) *sobek.Promise {
+			return promiseCall(stream.vu, abort, underlyingSink, reason)
+		}
+	}
+
+	// 10. Perform ? SetUpWritableStreamDefaultController(stream, controller, startAlgorithm,
+	// writeAlgorithm, closeAlgorithm, abortAlgorithm, highWaterMark, sizeAlgorithm).
+	controller.setup(stream, startAlgorithm, writeAlgorithm, closeAlgorithm, abortAlgorithm, highWaterMark, sizeAlgorithm)
+}
+
+// advanceQueueIfNeeded implements the specification's [WritableStreamDefaultControllerAdvanceQueueIfNeeded]
+// abstract operation.
+//
+// [WritableStreamDefaultControllerAdvanceQueueIfNeeded]: https://streams.spec.whatwg.org/#writable-stream-default-controller-advance-queue-if-needed
+func (controller *WritableStreamDefaultController) advanceQueueIfNeeded() {
+	// 1. Let stream be controller.[[stream]].
+	stream := controller.stream
+
+	// 2. If controller.[[started]] is false, return.
+	if !controller.started {
+		return
+	}
+
+	// 3. If stream.[[inFlightWriteRequest]] is not undefined, return.
+	if stream.inFlightWriteRequest != nil {
+		return
+	}
+
+	// 4. Let state be stream.[[state]].
+	// 5. Assert: state is not "closed" or "errored".
+	// 6. If state is "erroring",
+	if stream.state == WritableStreamStateErroring {
+		// 6.1. Perform ! WritableStreamFinishErroring(stream).
+		stream.finishErroring()
+		// 6.2. Return.
+		return
+	}
+
+	// 7. If controller.[[queue]] is empty, return.
+	if controller.queue.Len() == 0 {
+		return
+	}
+
+	// 8. Let value be ! PeekQueueValue(controller).
+	value, err := controller.queue.Peek()
+	if err != nil {
+		common.Throw(stream.runtime, err)
+	}
+
+	if value == nil {
+		// 9. If value is the close sentinel, perform ! WritableStreamDefaultControllerProcessClose(controller).
+		controller.processClose()
+	} else {
+		// 10. Otherwise, perform ! WritableStreamDefaultControllerProcessWrite(controller, value).
+		controller.processWrite(value)
+	}
+}
+
+// clearAlgorithms is called once the stream is closed or errored and the algorithms will
+// not be executed anymore.
+//
+// It implements the [WritableStreamDefaultControllerClearAlgorithms] algorithm.
+//
+// [WritableStreamDefaultControllerClearAlgorithms]: https://streams.spec.whatwg.org/#writable-stream-default-controller-clear-algorithms
+func (controller *WritableStreamDefaultController) clearAlgorithms() {
+	// 1. Set controller.[[writeAlgorithm]] to undefined.
+	controller.writeAlgorithm = nil
+
+	// 2. Set controller.[[closeAlgorithm]] to undefined.
+	controller.closeAlgorithm = nil
+
+	// 3. Set controller.[[abortAlgorithm]] to undefined.
+	controller.abortAlgorithm = nil
+
+	// 4. Set controller.[[strategySizeAlgorithm]] to undefined.
+	controller.strategySizeAlgorithm = nil
+}
+
+// close implements the [WritableStreamDefaultControllerClose] algorithm.
+//
+// [WritableStreamDefaultControllerClose]: https://streams.spec.whatwg.org/#writable-stream-default-controller-close
+func (controller *WritableStreamDefaultController) close() {
+	// 1. Perform ! EnqueueValueWithSize(controller, close sentinel, 0).
+	if err := controller.queue.Enqueue(nil, 0); err != nil {
+		common.Throw(controller.stream.runtime, err)
+	}
+
+	// 2. Perform ! WritableStreamDefaultControllerAdvanceQueueIfNeeded(controller).
+	controller.advanceQueueIfNeeded()
+}
+
+// error implements the [WritableStreamDefaultControllerError] algorithm.
+//
+// [WritableStreamDefaultControllerError]: https://streams.spec.whatwg.org/#writable-stream-default-controller-error
+func (controller *WritableStreamDefaultController) error(e sobek.Value) {
+	// 1. Let stream be controller.[[stream]].
+	// 2. Assert: stream.[[state]] is "writable".
+	stream := controller.stream
+
+	// 3. Perform ! WritableStreamDefaultControllerClearAlgorithms(controller).
+	controller.clearAlgorithms()
+
+	// 4. Perform ! WritableStreamStartErroring(stream, error).
+	stream.startErroring(e)
+}
+
+// errorIfNeeded implements the [WritableStreamDefaultControllerErrorIfNeeded] algorithm.
+//
+// [WritableStreamDefaultControllerErrorIfNeeded]: https://streams.spec.whatwg.org/#writable-stream-default-controller-error-if-needed
+func (controller *WritableStreamDefaultController) errorIfNeeded(e sobek.Value) {
+	// 1. If controller.[[stream]].[[state]] is "writable", perform ! WritableStreamDefaultControllerError(controller, error).
+	if controller.stream.state == WritableStreamStateWritable {
+		controller.error(e)
+	}
+}
+
+// getBackpressure implements the [WritableStreamDefaultControllerGetBackpressure] algorithm.
+//
+// [WritableStreamDefaultControllerGetBackpressure]: https://streams.spec.whatwg.org/#writable-stream-default-controller-get-backpressure
+func (controller *WritableStreamDefaultController) getBackpressure() bool {
+	// 1. Let desiredSize be ! WritableStreamDefaultControllerGetDesiredSize(controller).
+	// 2. Return true if desiredSize ≤ 0, or false otherwise.
+	return controller.getDesiredSize() <= 0
+}
+
+// getChunkSize implements the [WritableStreamDefaultControllerGetChunkSize] algorithm.
+//
+// [WritableStreamDefaultControllerGetChunkSize]: https://streams.spec.whatwg.org/#writable-stream-default-controller-get-chunk-size
+func (controller *WritableStreamDefaultController) getChunkSize(chunk sobek.Value) float64 {
+	// 1. If controller.[[strategySizeAlgorithm]] is undefined, then:
+	if controller.strategySizeAlgorithm == nil {
+		// 1.1. Assert: controller.[[stream]].[[state]] is not "writable".
+		// 1.2. Return 1.
+		return 1
+	}
+
+	// 2. Let returnValue be the result of performing controller.[[strategySizeAlgorithm]],
+	// passing in chunk, and interpreting the result as a completion record.
+	size, err := controller.strategySizeAlgorithm(sobek.Undefined(), chunk)
+
+	// 3. If returnValue is an abrupt completion,
+	if err != nil {
+		// 3.1. Perform ! WritableStreamDefaultControllerErrorIfNeeded(controller, returnValue.[[Value]]).
+		controller.errorIfNeeded(errorValue(controller.stream.runtime, err))
+		// 3.2. Return 1.
+		return 1
+	}
+
+	// 4. Return returnValue.[[Value]].
+	return size.ToFloat()
+}
+
+// getDesiredSize implements the [WritableStreamDefaultControllerGetDesiredSize] algorithm.
+//
+// [WritableStreamDefaultControllerGetDesiredSize]: https://streams.spec.whatwg.org/#writable-stream-default-controller-get-desired-size
+func (controller *WritableStreamDefaultController) getDesiredSize() float64 {
+	// 1. Return controller.[[strategyHWM]] − controller.[[queueTotalSize]].
+	return controller.strategyHWM - controller.queue.QueueTotalSize
+}
+
+// processClose implements the [WritableStreamDefaultControllerProcessClose] algorithm.
+//
+// [WritableStreamDefaultControllerProcessClose]: https://streams.spec.whatwg.org/#writable-stream-default-controller-process-close
+func (controller *WritableStreamDefaultController) processClose() {
+	// 1. Let stream be controller.[[stream]].
+	stream := controller.stream
+
+	// 2. Perform ! WritableStreamMarkCloseRequestInFlight(stream).
+	stream.markCloseRequestInFlight()
+
+	// 3. Perform ! DequeueValue(controller).
+	if _, err := controller.queue.Dequeue(); err != nil {
+		common.Throw(stream.runtime, err)
+	}
+
+	// 4. Assert: controller.[[queue]] is empty.
+	// 5. Let sinkClosePromise be the result of performing controller.[[closeAlgorithm]].
+	sinkClosePromise := controller.closeAlgorithm()
+
+	// 6. Perform ! WritableStreamDefaultControllerClearAlgorithms(controller).
+	controller.clearAlgorithms()
+
+	uponPromise(stream.runtime, sinkClosePromise,
+		// 7. Upon fulfillment of sinkClosePromise,
+		func(sobek.Value) {
+			// 7.1. Perform ! WritableStreamFinishInFlightClose(stream).
+			stream.finishInFlightClose()
+		},
+		// 8. Upon rejection of sinkClosePromise with reason reason,
+		func(reason sobek.Value) {
+			// 8.1. Perform ! WritableStreamFinishInFlightCloseWithError(stream, reason).
+			stream.finishInFlightCloseWithError(reason)
+		},
+	)
+}
+
+// processWrite implements the [WritableStreamDefaultControllerProcessWrite] algorithm.
+//
+// [WritableStreamDefaultControllerProcessWrite]: https://streams.spec.whatwg.org/#writable-stream-default-controller-process-write
+func (controller *WritableStreamDefaultController) processWrite(chunk sobek.Value) {
+	// 1. Let stream be controller.[[stream]].
+	stream := controller.stream
+
+	// 2. Perform ! WritableStreamMarkFirstWriteRequestInFlight(stream).
+	stream.markFirstWriteRequestInFlight()
+
+	// 3. Let sinkWritePromise be the result of performing controller.[[writeAlgorithm]], passing in chunk.
+	sinkWritePromise := controller.writeAlgorithm(chunk)
+
+	uponPromise(stream.runtime, sinkWritePromise,
+		// 4. Upon fulfillment of sinkWritePromise,
+		func(sobek.Value) {
+			// 4.1. Perform ! WritableStreamFinishInFlightWrite(stream).
+			stream.finishInFlightWrite()
+
+			// 4.2. Let state be stream.[[state]].
+			// 4.3. Assert: state is "writable" or "erroring".
+			state := stream.state
+
+			// 4.4. Perform ! DequeueValue(controller).
+			if _, err := controller.queue.Dequeue(); err != nil {
+				common.Throw(stream.runtime, err)
+			}
+
+			// 4.5. If ! WritableStreamCloseQueuedOrInFlight(stream) is false and state is "writable",
+			if !stream.closeQueuedOrInFlight() && state == WritableStreamStateWritable {
+				// 4.5.1. Let backpressure be ! WritableStreamDefaultControllerGetBackpressure(controller).
+				// 4.5.2. Perform ! WritableStreamUpdateBackpressure(stream, backpressure).
+				stream.updateBackpressure(controller.getBackpressure())
+			}
+
+			// 4.6. Perform ! WritableStreamDefaultControllerAdvanceQueueIfNeeded(controller).
+			controller.advanceQueueIfNeeded()
+		},
+		// 5. Upon rejection of sinkWritePromise with reason,
+		func(reason sobek.Value) {
+			// 5.1. If stream.[[state]] is "writable", perform ! WritableStreamDefaultControllerClearAlgorithms(controller).
+			if stream.state == WritableStreamStateWritable {
+				controller.clearAlgorithms()
+			}
+
+			// 5.2. Perform ! WritableStreamFinishInFlightWriteWithError(stream, reason).
+			stream.finishInFlightWriteWithError(reason)
+		},
+	)
+}
+
+// write implements the [WritableStreamDefaultControllerWrite] algorithm.
+//
+// [WritableStreamDefaultControllerWrite]: https://streams.spec.whatwg.org/#writable-stream-default-controller-write
+func (controller *WritableStreamDefaultController) write(chunk sobek.Value, chunkSize float64) {
+	// 1. Let enqueueResult be EnqueueValueWithSize(controller, chunk, chunkSize).
+	err := controller.queue.Enqueue(chunk, chunkSize)
+
+	// 2. If enqueueResult is an abrupt completion,
+	if err != nil {
+		// 2.1. Perform ! WritableStreamDefaultControllerErrorIfNeeded(controller, enqueueResult.[[Value]]).
+		controller.errorIfNeeded(errorValue(controller.stream.runtime, err))
+		// 2.2. Return.
+		return
+	}
+
+	// 3. Let stream be controller.[[stream]].
+	stream := controller.stream
+
+	// 4. If ! WritableStreamCloseQueuedOrInFlight(stream) is false and stream.[[state]] is "writable",
+	if !stream.closeQueuedOrInFlight() && stream.state == WritableStreamStateWritable {
+		// 4.1. Let backpressure be ! WritableStreamDefaultControllerGetBackpressure(controller).
+		// 4.2. Perform ! WritableStreamUpdateBackpressure(stream, backpressure).
+		stream.updateBackpressure(controller.getBackpressure())
+	}
+
+	// 5. Perform ! WritableStreamDefaultControllerAdvanceQueueIfNeeded(controller).
+	controller.advanceQueueIfNeeded()
+}
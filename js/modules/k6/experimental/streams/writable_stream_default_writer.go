@@ -0,0 +1,394 @@
+package streams
+
+import (
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/common"
+	"gopkg.in/guregu/null.v3"
+)
+
+// WritableStreamDefaultWriter represents a default writer designed to be vended by a [WritableStream].
+//
+// For more details, see the [specification].
+//
+// [specification]: https://streams.spec.whatwg.org/#default-writer-class
+type WritableStreamDefaultWriter struct {
+	// closedPromise is resolved when the stream is closed, or rejected when it is errored.
+	closedPromise *promiseCapability
+
+	// readyPromise is resolved when the desired size of the stream's queue
+	// becomes positive, signaling that writes won't apply backpressure.
+	readyPromise *promiseCapability
+
+	// stream is the [WritableStream] instance that owns this writer.
+	stream *WritableStream
+
+	runtime *sobek.Runtime
+}
+
+// NewWritableStreamDefaultWriterObject creates a new sobek.Object from a [WritableStreamDefaultWriter] instance.
+func NewWritableStreamDefaultWriterObject(writer *WritableStreamDefaultWriter) (*sobek.Object, error) {
+	rt := writer.stream.runtime
+	obj := rt.NewObject()
+	objName := "WritableStreamDefaultWriter"
+
+	err := obj.DefineAccessorProperty("closed", rt.ToValue(func() *sobek.Promise {
+		return writer.closedPromise.promise
+	}), nil, sobek.FLAG_FALSE, sobek.FLAG_TRUE)
+	if err != nil {
+		return nil, err
+	}
+
+	err = obj.DefineAccessorProperty("desiredSize", rt.ToValue(writer.DesiredSize), nil, sobek.FLAG_FALSE, sobek.FLAG_TRUE)
+	if err != nil {
+		return nil, err
+	}
+
+	err = obj.DefineAccessorProperty("ready", rt.ToValue(func() *sobek.Promise {
+		return writer.readyPromise.promise
+	}), nil, sobek.FLAG_FALSE, sobek.FLAG_TRUE)
+	if err != nil {
+		return nil, err
+	}
+
+	// Exposing the methods of the [WritableStreamDefaultWriter] interface
+	if err := setReadOnlyPropertyOf(obj, objName, "abort", rt.ToValue(writer.Abort)); err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "close", rt.ToValue(writer.Close)); err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "releaseLock", rt.ToValue(writer.ReleaseLock)); err != nil {
+		return nil, err
+	}
+
+	if err := setReadOnlyPropertyOf(obj, objName, "write", rt.ToValue(writer.Write)); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// DesiredSize returns the desired size required to fill the stream's internal queue.
+//
+// It implements the [desiredSize] getter.
+//
+// [desiredSize]: https://streams.spec.whatwg.org/#default-writer-desired-size
+func (writer *WritableStreamDefaultWriter) DesiredSize() sobek.Value {
+	// 1. If this.[[stream]] is undefined, throw a TypeError exception.
+	if writer.stream == nil {
+		rt := writer.runtime
+		throw(rt, newTypeError(rt, "writer has been released"))
+	}
+
+	// 2. Return ! WritableStreamDefaultWriterGetDesiredSize(this).
+	desiredSize := writer.getDesiredSize()
+	if !desiredSize.Valid {
+		return sobek.Null()
+	}
+
+	return writer.stream.runtime.ToValue(desiredSize.Float64)
+}
+
+// Abort aborts the stream, signaling that the producer can no longer successfully
+// write to the stream.
+//
+// It implements the [abort] operation.
+//
+// [abort]: https://streams.spec.whatwg.org/#default-writer-abort
+func (writer *WritableStreamDefaultWriter) Abort(reason sobek.Value) *sobek.Promise {
+	// 1. If this.[[stream]] is undefined, return a promise rejected with a TypeError exception.
+	if writer.stream == nil {
+		return writer.releasedPromise()
+	}
+
+	// 2. Return ! WritableStreamDefaultWriterAbort(this, reason).
+	return writer.stream.abort(reason)
+}
+
+// Close closes the stream, once all the previously written chunks are processed.
+//
+// It implements the [close] operation.
+//
+// [close]: https://streams.spec.whatwg.org/#default-writer-close
+func (writer *WritableStreamDefaultWriter) Close() *sobek.Promise {
+	// 1. Let stream be this.[[stream]].
+	stream := writer.stream
+
+	// 2. If stream is undefined, return a promise rejected with a TypeError exception.
+	if stream == nil {
+		return writer.releasedPromise()
+	}
+
+	// 3. If ! WritableStreamCloseQueuedOrInFlight(stream) is true, return a promise rejected with a TypeError exception.
+	if stream.closeQueuedOrInFlight() {
+		return newRejectedPromise(stream.vu, newTypeError(stream.runtime, "stream is already closing").Err())
+	}
+
+	// 4. Return ! WritableStreamDefaultWriterClose(this).
+	return stream.close()
+}
+
+// ReleaseLock releases the writer's lock on the stream.
+//
+// It implements the [releaseLock] operation.
+//
+// [releaseLock]: https://streams.spec.whatwg.org/#default-writer-release-lock
+func (writer *WritableStreamDefaultWriter) ReleaseLock() {
+	// 1. Let stream be this.[[stream]].
+	// 2. If stream is undefined, return.
+	if writer.stream == nil {
+		return
+	}
+
+	// 3. Assert: stream.[[writer]] is not undefined.
+	// 4. Perform ! WritableStreamDefaultWriterRelease(this).
+	writer.release()
+}
+
+// Write writes the chunk to the stream, once all the previously written chunks are processed.
+//
+// It implements the [write] operation.
+//
+// [write]: https://streams.spec.whatwg.org/#default-writer-write
+func (writer *WritableStreamDefaultWriter) Write(chunk sobek.Value) *sobek.Promise {
+	// 1. If this.[[stream]] is undefined, return a promise rejected with a TypeError exception.
+	if writer.stream == nil {
+		return writer.releasedPromise()
+	}
+
+	if chunk == nil {
+		chunk = sobek.Undefined()
+	}
+
+	// 2. Return ! WritableStreamDefaultWriterWrite(this, chunk).
+	return writer.write(chunk)
+}
+
+// releasedPromise returns a promise rejected with the TypeError of the
+// operations performed on a released writer.
+func (writer *WritableStreamDefaultWriter) releasedPromise() *sobek.Promise {
+	rt := writer.runtime
+	promise, _, reject := rt.NewPromise()
+	reject(newTypeError(rt, "writer has been released").Err())
+	return promise
+}
+
+// setup implements the [SetUpWritableStreamDefaultWriter] algorithm.
+//
+// [SetUpWritableStreamDefaultWriter]: https://streams.spec.whatwg.org/#set-up-writable-stream-default-writer
+func (writer *WritableStreamDefaultWriter) setup(stream *WritableStream) {
+	rt := stream.runtime
+
+	// 1. If ! IsWritableStreamLocked(stream) is true, throw a TypeError exception.
+	if stream.isLocked() {
+		throw(rt, newTypeError(rt, "stream is locked"))
+	}
+
+	// 2. Set writer.[[stream]] to stream.
+	writer.stream = stream
+	writer.runtime = rt
+
+	// 3. Set stream.[[writer]] to writer.
+	stream.writer = writer
+	stream.Locked = true
+
+	// 4. Let state be stream.[[state]].
+	switch state := stream.state; state {
+	// 5. If state is "writable",
+	case WritableStreamStateWritable:
+		// 5.1. If ! WritableStreamCloseQueuedOrInFlight(stream) is false and stream.[[backpressure]] is true,
+		// set writer.[[readyPromise]] to a new promise.
+		// 5.2. Otherwise, set writer.[[readyPromise]] to a promise resolved with undefined.
+		writer.readyPromise = newPromiseCapability(rt)
+		if stream.closeQueuedOrInFlight() || !stream.backpressure {
+			writer.readyPromise.resolve(sobek.Undefined())
+		}
+
+		// 5.3. Set writer.[[closedPromise]] to a new promise.
+		writer.closedPromise = newPromiseCapability(rt)
+
+	// 6. Otherwise, if state is "erroring",
+	case WritableStreamStateErroring:
+		// 6.1. Set writer.[[readyPromise]] to a promise rejected with stream.[[storedError]].
+		// 6.2. Set writer.[[readyPromise]].[[PromiseIsHandled]] to true.
+		writer.readyPromise = newRejectedPromiseCapability(rt, stream.storedError)
+
+		// 6.3. Set writer.[[closedPromise]] to a new promise.
+		writer.closedPromise = newPromiseCapability(rt)
+
+	// 7. Otherwise, if state is "closed",
+	case WritableStreamStateClosed:
+		// 7.1. Set writer.[[readyPromise]] to a promise resolved with undefined.
+		writer.readyPromise = newPromiseCapability(rt)
+		writer.readyPromise.resolve(sobek.Undefined())
+
+		// 7.2. Set writer.[[closedPromise]] to a promise resolved with undefined.
+		writer.closedPromise = newPromiseCapability(rt)
+		writer.closedPromise.resolve(sobek.Undefined())
+
+	// 8. Otherwise,
+	default:
+		// 8.1. Assert: state is "errored".
+		if state != WritableStreamStateErrored {
+			common.Throw(rt, newError(AssertionError, "stream.state is not \"errored\""))
+		}
+
+		// 8.2. Let storedError be stream.[[storedError]].
+		// 8.3. Set writer.[[readyPromise]] to a promise rejected with storedError.
+		// 8.4. Set writer.[[readyPromise]].[[PromiseIsHandled]] to true.
+		writer.readyPromise = newRejectedPromiseCapability(rt, stream.storedError)
+
+		// 8.5. Set writer.[[closedPromise]] to a promise rejected with storedError.
+		// 8.6. Set writer.[[closedPromise]].[[PromiseIsHandled]] to true.
+		writer.closedPromise = newRejectedPromiseCapability(rt, stream.storedError)
+	}
+}
+
+// closeWithErrorPropagation implements the [WritableStreamDefaultWriterCloseWithErrorPropagation] algorithm.
+//
+// [WritableStreamDefaultWriterCloseWithErrorPropagation]: https://streams.spec.whatwg.org/#writable-stream-default-writer-close-with-error-propagation
+func (writer *WritableStreamDefaultWriter) closeWithErrorPropagation() *sobek.Promise {
+	// 1. Let stream be writer.[[stream]].
+	// 2. Assert: stream is not undefined.
+	stream := writer.stream
+
+	// 3. Let state be stream.[[state]].
+	state := stream.state
+
+	// 4. If ! WritableStreamCloseQueuedOrInFlight(stream) is true or state is "closed",
+	// return a promise resolved with undefined.
+	if stream.closeQueuedOrInFlight() || state == WritableStreamStateClosed {
+		return newResolvedPromise(stream.vu, sobek.Undefined())
+	}
+
+	// 5. If state is "errored", return a promise rejected with stream.[[storedError]].
+	if state == WritableStreamStateErrored {
+		return newRejectedPromise(stream.vu, stream.storedError)
+	}
+
+	// 6. Assert: state is "writable" or "erroring".
+	// 7. Return ! WritableStreamDefaultWriterClose(writer).
+	return stream.close()
+}
+
+// ensureClosedPromiseRejected implements the [WritableStreamDefaultWriterEnsureClosedPromiseRejected] algorithm.
+//
+// [WritableStreamDefaultWriterEnsureClosedPromiseRejected]: https://streams.spec.whatwg.org/#writable-stream-default-writer-ensure-closed-promise-rejected
+func (writer *WritableStreamDefaultWriter) ensureClosedPromiseRejected(e sobek.Value) {
+	// 1. If writer.[[closedPromise]].[[PromiseState]] is "pending", reject writer.[[closedPromise]] with error.
+	// 2. Otherwise, set writer.[[closedPromise]] to a promise rejected with error.
+	// 3. Set writer.[[closedPromise]].[[PromiseIsHandled]] to true.
+	writer.closedPromise = ensurePromiseRejected(writer.closedPromise, e)
+}
+
+// ensureReadyPromiseRejected implements the [WritableStreamDefaultWriterEnsureReadyPromiseRejected] algorithm.
+//
+// [WritableStreamDefaultWriterEnsureReadyPromiseRejected]: https://streams.spec.whatwg.org/#writable-stream-default-writer-ensure-ready-promise-rejected
+func (writer *WritableStreamDefaultWriter) ensureReadyPromiseRejected(e sobek.Value) {
+	// 1. If writer.[[readyPromise]].[[PromiseState]] is "pending", reject writer.[[readyPromise]] with error.
+	// 2. Otherwise, set writer.[[readyPromise]] to a promise rejected with error.
+	// 3. Set writer.[[readyPromise]].[[PromiseIsHandled]] to true.
+	writer.readyPromise = ensurePromiseRejected(writer.readyPromise, e)
+}
+
+// getDesiredSize implements the [WritableStreamDefaultWriterGetDesiredSize] algorithm.
+//
+// [WritableStreamDefaultWriterGetDesiredSize]: https://streams.spec.whatwg.org/#writable-stream-default-writer-get-desired-size
+func (writer *WritableStreamDefaultWriter) getDesiredSize() null.Float {
+	// 1. Let stream be writer.[[stream]].
+	stream := writer.stream
+
+	// 2. Let state be stream.[[state]].
+	switch stream.state {
+	// 3. If state is "errored" or "erroring", return null.
+	case WritableStreamStateErrored, WritableStreamStateErroring:
+		return null.NewFloat(0, false)
+	// 4. If state is "closed", return 0.
+	case WritableStreamStateClosed:
+		return null.NewFloat(0, true)
+	}
+
+	// 5. Return ! WritableStreamDefaultControllerGetDesiredSize(stream.[[controller]]).
+	return null.NewFloat(stream.controller.getDesiredSize(), true)
+}
+
+// release implements the [WritableStreamDefaultWriterRelease] algorithm.
+//
+// [WritableStreamDefaultWriterRelease]: https://streams.spec.whatwg.org/#writable-stream-default-writer-release
+func (writer *WritableStreamDefaultWriter) release() {
+	// 1. Let stream be writer.[[stream]].
+	// 2. Assert: stream is not undefined.
+	stream := writer.stream
+
+	// 3. Assert: stream.[[writer]] is writer.
+	if stream.writer != writer {
+		common.Throw(stream.runtime, newError(AssertionError, "stream writer isn't writer"))
+	}
+
+	// 4. Let releasedError be a new TypeError.
+	releasedError := newTypeError(stream.runtime, "writer has been released").Err()
+
+	// 5. Perform ! WritableStreamDefaultWriterEnsureReadyPromiseRejected(writer, releasedError).
+	writer.ensureReadyPromiseRejected(releasedError)
+
+	// 6. Perform ! WritableStreamDefaultWriterEnsureClosedPromiseRejected(writer, releasedError).
+	writer.ensureClosedPromiseRejected(releasedError)
+
+	// 7. Set stream.[[writer]] to undefined.
+	stream.writer = nil
+	stream.Locked = false
+
+	// 8. Set writer.[[stream]] to undefined.
+	writer.stream = nil
+}
+
+// write implements the [WritableStreamDefaultWriterWrite] algorithm.
+//
+// [WritableStreamDefaultWriterWrite]: https://streams.spec.whatwg.org/#writable-stream-default-writer-write
+func (writer *WritableStreamDefaultWriter) write(chunk sobek.Value) *sobek.Promise {
+	// 1. Let stream be writer.[[stream]].
+	// 2. Assert: stream is not undefined.
+	stream := writer.stream
+
+	// 3. Let controller be stream.[[controller]].
+	controller := stream.controller
+
+	// 4. Let chunkSize be ! WritableStreamDefaultControllerGetChunkSize(controller, chunk).
+	chunkSize := controller.getChunkSize(chunk)
+
+	// 5. If stream is not equal to writer.[[stream]], return a promise rejected with a TypeError exception.
+	if stream != writer.stream {
+		return writer.releasedPromise()
+	}
+
+	// 6. Let state be stream.[[state]].
+	state := stream.state
+
+	// 7. If state is "errored", return a promise rejected with stream.[[storedError]].
+	if state == WritableStreamStateErrored {
+		return newRejectedPromise(stream.vu, stream.storedError)
+	}
+
+	// 8. If ! WritableStreamCloseQueuedOrInFlight(stream) is true or state is "closed", return a promise
+	// rejected with a TypeError exception indicating that the stream is closing or closed.
+	if stream.closeQueuedOrInFlight() || state == WritableStreamStateClosed {
+		return newRejectedPromise(stream.vu, newTypeError(stream.runtime, "stream is closing or closed").Err())
+	}
+
+	// 9. If state is "erroring", return a promise rejected with stream.[[storedError]].
+	if state == WritableStreamStateErroring {
+		return newRejectedPromise(stream.vu, stream.storedError)
+	}
+
+	// 10. Assert: state is "writable".
+	// 11. Let promise be ! WritableStreamAddWriteRequest(stream).
+	promise := stream.addWriteRequest()
+
+	// 12. Perform ! WritableStreamDefaultControllerWrite(controller, chunk, chunkSize).
+	controller.write(chunk, chunkSize)
+
+	// 13. Return promise.
+	return promise
+}
@@ -0,0 +1,666 @@
+package streams
+
+import (
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/js/modules"
+)
+
+// WritableStream is a concrete instance of the general [writable stream] concept.
+//
+// It provides a standard abstraction for writing streaming data to a destination,
+// known as an underlying sink, with built-in backpressure and queuing.
+//
+// [writable stream]: https://streams.spec.whatwg.org/#ws-class
+type WritableStream struct {
+	// Locked indicates whether the writable stream is locked to a writer.
+	Locked bool
+
+	// backpressure is true when the stream's queue is full, and more chunks
+	// should not be written until it drains.
+	backpressure bool
+
+	// closeRequest holds the promise returned from the writer's close() method,
+	// until the close is marked as in flight.
+	closeRequest *promiseCapability
+
+	// controller holds the [WritableStreamDefaultController] created with the
+	// ability to control the state and queue of this stream.
+	controller *WritableStreamDefaultController
+
+	// inFlightWriteRequest holds the promise of the write currently being
+	// processed by the underlying sink.
+	inFlightWriteRequest *promiseCapability
+
+	// inFlightCloseRequest holds the promise of the close currently being
+	// processed by the underlying sink.
+	inFlightCloseRequest *promiseCapability
+
+	// pendingAbortRequest holds the pending abort request, if any.
+	pendingAbortRequest *pendingAbortRequest
+
+	// state holds the current state of the stream.
+	state WritableStreamState
+
+	// storedError holds the error that caused the stream to be errored.
+	storedError sobek.Value
+
+	// writer holds the current writer of the stream if the stream is locked
+	// to a writer, or nil otherwise.
+	writer *WritableStreamDefaultWriter
+
+	// writeRequests holds the promises of the writes that have not yet been
+	// processed by the underlying sink.
+	writeRequests []*promiseCapability
+
+	runtime *sobek.Runtime
+	vu      modules.VU
+}
+
+// pendingAbortRequest is the specification's [pending abort request] struct.
+//
+// [pending abort request]: https://streams.spec.whatwg.org/#pending-abort-request
+type pendingAbortRequest struct {
+	promise            *promiseCapability
+	reason             sobek.Value
+	wasAlreadyErroring bool
+}
+
+// WritableStreamState represents the current state of a WritableStream.
+type WritableStreamState string
+
+const (
+	// WritableStreamStateWritable indicates that the stream can be written to.
+	WritableStreamStateWritable WritableStreamState = "writable"
+
+	// WritableStreamStateClosed indicates that the stream is closed and cannot be written to.
+	WritableStreamStateClosed WritableStreamState = "closed"
+
+	// WritableStreamStateErroring indicates that the stream is being errored, but
+	// still has an operation in flight.
+	WritableStreamStateErroring WritableStreamState = "erroring"
+
+	// WritableStreamStateErrored indicates that the stream has been errored.
+	WritableStreamStateErrored WritableStreamState = "errored"
+)
+
+// Abort implements the [abort] operation.
+//
+// [abort]: https://streams.spec.whatwg.org/#ws-abort
+func (stream *WritableStream) Abort(reason sobek.Value) *sobek.Promise {
+	// 1. If ! IsWritableStreamLocked(this) is true, return a promise rejected with a TypeError exception.
+	if stream.isLocked() {
+		return newRejectedPromise(stream.vu, newTypeError(stream.runtime, "cannot abort a locked stream").Err())
+	}
+
+	// 2. Return ! WritableStreamAbort(this, reason).
+	return stream.abort(reason)
+}
+
+// Close implements the [close] operation.
+//
+// [close]: https://streams.spec.whatwg.org/#ws-close
+func (stream *WritableStream) Close() *sobek.Promise {
+	// 1. If ! IsWritableStreamLocked(this) is true, return a promise rejected with a TypeError exception.
+	if stream.isLocked() {
+		return newRejectedPromise(stream.vu, newTypeError(stream.runtime, "cannot close a locked stream").Err())
+	}
+
+	// 2. If ! WritableStreamCloseQueuedOrInFlight(this) is true, return a promise rejected with a TypeError exception.
+	if stream.closeQueuedOrInFlight() {
+		return newRejectedPromise(stream.vu, newTypeError(stream.runtime, "stream is already closing").Err())
+	}
+
+	// 3. Return ! WritableStreamClose(this).
+	return stream.close()
+}
+
+// GetWriter implements the [getWriter] operation.
+//
+// [getWriter]: https://streams.spec.whatwg.org/#ws-get-writer
+func (stream *WritableStream) GetWriter() *sobek.Object {
+	// 1. Return ? AcquireWritableStreamDefaultWriter(this).
+	writer := stream.acquireDefaultWriter()
+
+	object, err := NewWritableStreamDefaultWriterObject(writer)
+	if err != nil {
+		common.Throw(stream.runtime, err)
+	}
+
+	return object
+}
+
+// createWritableStream implements the specification's [CreateWritableStream] abstract operation,
+// used by other specifications to create a [WritableStream] whose controller is driven by Go.
+//
+// [CreateWritableStream]: https://streams.spec.whatwg.org/#create-writable-stream
+func createWritableStream(
+	vu modules.VU,
+	startAlgorithm UnderlyingSinkStartCallback,
+	writeAlgorithm UnderlyingSinkWriteCallback,
+	closeAlgorithm UnderlyingSinkCloseCallback,
+	abortAlgorithm UnderlyingSinkAbortCallback,
+	highWaterMark float64,
+	sizeAlgorithm SizeAlgorithm,
+) *WritableStream {
+	// 1. Assert: ! IsNonNegativeNumber(highWaterMark) is true.
+	// 2. Let stream be a new WritableStream.
+	// 3. Perform ! InitializeWritableStream(stream).
+	stream := &WritableStream{
+		runtime: vu.Runtime(),
+		vu:      vu,
+	}
+	stream.initialize()
+
+	// 4. Let controller be a new WritableStreamDefaultController.
+	controller := &WritableStreamDefaultController{}
+
+	// 5. Perform ? SetUpWritableStreamDefaultController(stream, controller, startAlgorithm,
+	// writeAlgorithm, closeAlgorithm, abortAlgorithm, highWaterMark, sizeAlgorithm).
+	controller.setup(stream, startAlgorithm, writeAlgorithm, closeAlgorithm, abortAlgorithm, highWaterMark, sizeAlgorithm)
+
+	// 6. Return stream.
+	return stream
+}
+
+// initialize implements the specification's [InitializeWritableStream()] abstract operation.
+//
+// [InitializeWritableStream()]: https://streams.spec.whatwg.org/#initialize-writable-stream
+func (stream *WritableStream) initialize() {
+	stream.state = WritableStreamStateWritable
+	stream.storedError = sobek.Undefined()
+	stream.writer = nil
+	stream.Locked = false
+	stream.controller = nil
+	stream.inFlightWriteRequest = nil
+	stream.closeRequest = nil
+	stream.inFlightCloseRequest = nil
+	stream.pendingAbortRequest = nil
+	stream.writeRequests = []*promiseCapability{}
+	stream.backpressure = false
+}
+
+// isLocked implements the specification's [IsWritableStreamLocked()] abstract operation.
+//
+// [IsWritableStreamLocked()]: https://streams.spec.whatwg.org/#is-writable-stream-locked
+func (stream *WritableStream) isLocked() bool {
+	return stream.writer != nil
+}
+
+// acquireDefaultWriter implements the specification's [AcquireWritableStreamDefaultWriter()] abstract operation.
+//
+// [AcquireWritableStreamDefaultWriter()]: https://streams.spec.whatwg.org/#acquire-writable-stream-default-writer
+func (stream *WritableStream) acquireDefaultWriter() *WritableStreamDefaultWriter {
+	// 1. Let writer be a new WritableStreamDefaultWriter.
+	writer := &WritableStreamDefaultWriter{}
+
+	// 2. Perform ? SetUpWritableStreamDefaultWriter(writer, stream).
+	writer.setup(stream)
+
+	// 3. Return writer.
+	return writer
+}
+
+// abort implements the specification's [WritableStreamAbort()] abstract operation.
+//
+// [WritableStreamAbort()]: https://streams.spec.whatwg.org/#writable-stream-abort
+func (stream *WritableStream) abort(reason sobek.Value) *sobek.Promise {
+	if reason == nil {
+		reason = sobek.Undefined()
+	}
+
+	// 1. If stream.[[state]] is "closed" or "errored", return a promise resolved with undefined.
+	// 2. Signal abort on stream.[[controller]].[[abortController]] with reason.
+	// Not implemented yet: AbortSignal is not supported yet.
+	// 3. Let state be stream.[[state]].
+	// 4. If state is "closed" or "errored", return a promise resolved with undefined.
+	state := stream.state
+	if state == WritableStreamStateClosed || state == WritableStreamStateErrored {
+		return newResolvedPromise(stream.vu, sobek.Undefined())
+	}
+
+	// 5. If stream.[[pendingAbortRequest]] is not undefined, return stream.[[pendingAbortRequest]]'s promise.
+	if stream.pendingAbortRequest != nil {
+		return stream.pendingAbortRequest.promise.promise
+	}
+
+	// 6. Assert: state is "writable" or "erroring".
+	// 7. Let wasAlreadyErroring be false.
+	wasAlreadyErroring := false
+
+	// 8. If state is "erroring",
+	if state == WritableStreamStateErroring {
+		// 8.1. Set wasAlreadyErroring to true.
+		wasAlreadyErroring = true
+		// 8.2. Set reason to undefined.
+		reason = sobek.Undefined()
+	}
+
+	// 9. Let promise be a new promise.
+	promise := newPromiseCapability(stream.runtime)
+
+	// 10. Set stream.[[pendingAbortRequest]] to a new pending abort request whose promise
+	// is promise, reason is reason, and was already erroring is wasAlreadyErroring.
+	stream.pendingAbortRequest = &pendingAbortRequest{
+		promise:            promise,
+		reason:             reason,
+		wasAlreadyErroring: wasAlreadyErroring,
+	}
+
+	// 11. If wasAlreadyErroring is false, perform ! WritableStreamStartErroring(stream, reason).
+	if !wasAlreadyErroring {
+		stream.startErroring(reason)
+	}
+
+	// 12. Return promise.
+	return promise.promise
+}
+
+// close implements the specification's [WritableStreamClose()] abstract operation.
+//
+// [WritableStreamClose()]: https://streams.spec.whatwg.org/#writable-stream-close
+func (stream *WritableStream) close() *sobek.Promise {
+	// 1. Let state be stream.[[state]].
+	state := stream.state
+
+	// 2. If state is "closed" or "errored", return a promise rejected with a TypeError exception.
+	if state == WritableStreamStateClosed || state == WritableStreamStateErrored {
+		return newRejectedPromise(stream.vu, newTypeError(stream.runtime, "stream is closed or errored").Err())
+	}
+
+	// 3. Assert: state is "writable" or "erroring".
+	// 4. Assert: ! WritableStreamCloseQueuedOrInFlight(stream) is false.
+	if stream.closeQueuedOrInFlight() {
+		common.Throw(stream.runtime, newError(AssertionError, "stream is already closing"))
+	}
+
+	// 5. Let promise be a new promise.
+	promise := newPromiseCapability(stream.runtime)
+
+	// 6. Set stream.[[closeRequest]] to promise.
+	stream.closeRequest = promise
+
+	// 7. Let writer be stream.[[writer]].
+	writer := stream.writer
+
+	// 8. If writer is not undefined, and stream.[[backpressure]] is true, and state is "writable",
+	// resolve writer.[[readyPromise]] with undefined.
+	if writer != nil && stream.backpressure && state == WritableStreamStateWritable {
+		writer.readyPromise.resolve(sobek.Undefined())
+	}
+
+	// 9. Perform ! WritableStreamDefaultControllerClose(stream.[[controller]]).
+	stream.controller.close()
+
+	// 10. Return promise.
+	return promise.promise
+}
+
+// addWriteRequest implements the specification's [WritableStreamAddWriteRequest()] abstract operation.
+//
+// [WritableStreamAddWriteRequest()]: https://streams.spec.whatwg.org/#writable-stream-add-write-request
+func (stream *WritableStream) addWriteRequest() *sobek.Promise {
+	// 1. Assert: ! IsWritableStreamLocked(stream) is true.
+	if !stream.isLocked() {
+		common.Throw(stream.runtime, newError(AssertionError, "stream is not locked"))
+	}
+
+	// 2. Assert: stream.[[state]] is "writable".
+	if stream.state != WritableStreamStateWritable {
+		common.Throw(stream.runtime, newError(AssertionError, "stream is not writable"))
+	}
+
+	// 3. Let promise be a new promise.
+	promise := newPromiseCapability(stream.runtime)
+
+	// 4. Append promise to stream.[[writeRequests]].
+	stream.writeRequests = append(stream.writeRequests, promise)
+
+	// 5. Return promise.
+	return promise.promise
+}
+
+// closeQueuedOrInFlight implements the specification's [WritableStreamCloseQueuedOrInFlight()] abstract operation.
+//
+// [WritableStreamCloseQueuedOrInFlight()]: https://streams.spec.whatwg.org/#writable-stream-close-queued-or-in-flight
+func (stream *WritableStream) closeQueuedOrInFlight() bool {
+	return stream.closeRequest != nil || stream.inFlightCloseRequest != nil
+}
+
+// dealWithRejection implements the specification's [WritableStreamDealWithRejection()] abstract operation.
+//
+// [WritableStreamDealWithRejection()]: https://streams.spec.whatwg.org/#writable-stream-deal-with-rejection
+func (stream *WritableStream) dealWithRejection(e sobek.Value) {
+	// 1. Let state be stream.[[state]].
+	// 2. If state is "writable",
+	if stream.state == WritableStreamStateWritable {
+		// 2.1. Perform ! WritableStreamStartErroring(stream, error).
+		stream.startErroring(e)
+		// 2.2. Return.
+		return
+	}
+
+	// 3. Assert: state is "erroring".
+	if stream.state != WritableStreamStateErroring {
+		common.Throw(stream.runtime, newError(AssertionError, "stream is not erroring"))
+	}
+
+	// 4. Perform ! WritableStreamFinishErroring(stream).
+	stream.finishErroring()
+}
+
+// finishErroring implements the specification's [WritableStreamFinishErroring()] abstract operation.
+//
+// [WritableStreamFinishErroring()]: https://streams.spec.whatwg.org/#writable-stream-finish-erroring
+func (stream *WritableStream) finishErroring() {
+	// 1. Assert: stream.[[state]] is "erroring".
+	if stream.state != WritableStreamStateErroring {
+		common.Throw(stream.runtime, newError(AssertionError, "stream is not erroring"))
+	}
+
+	// 2. Assert: ! WritableStreamHasOperationMarkedInFlight(stream) is false.
+	if stream.hasOperationMarkedInFlight() {
+		common.Throw(stream.runtime, newError(AssertionError, "stream has an operation in flight"))
+	}
+
+	// 3. Set stream.[[state]] to "errored".
+	stream.state = WritableStreamStateErrored
+
+	// 4. Perform ! stream.[[controller]].[[ErrorSteps]]().
+	stream.controller.errorSteps()
+
+	// 5. Let storedError be stream.[[storedError]].
+	storedError := stream.storedError
+
+	// 6. For each writeRequest of stream.[[writeRequests]], reject writeRequest with storedError.
+	for _, writeRequest := range stream.writeRequests {
+		writeRequest.reject(storedError)
+	}
+
+	// 7. Set stream.[[writeRequests]] to an empty list.
+	stream.writeRequests = []*promiseCapability{}
+
+	// 8. If stream.[[pendingAbortRequest]] is undefined,
+	if stream.pendingAbortRequest == nil {
+		// 8.1. Perform ! WritableStreamRejectCloseAndClosedPromiseIfNeeded(stream).
+		stream.rejectCloseAndClosedPromiseIfNeeded()
+		// 8.2. Return.
+		return
+	}
+
+	// 9. Let abortRequest be stream.[[pendingAbortRequest]].
+	abortRequest := stream.pendingAbortRequest
+
+	// 10. Set stream.[[pendingAbortRequest]] to undefined.
+	stream.pendingAbortRequest = nil
+
+	// 11. If abortRequest's was already erroring is true,
+	if abortRequest.wasAlreadyErroring {
+		// 11.1. Reject abortRequest's promise with storedError.
+		abortRequest.promise.reject(storedError)
+		// 11.2. Perform ! WritableStreamRejectCloseAndClosedPromiseIfNeeded(stream).
+		stream.rejectCloseAndClosedPromiseIfNeeded()
+		// 11.3. Return.
+		return
+	}
+
+	// 12. Let promise be ! stream.[[controller]].[[AbortSteps]](abortRequest's reason).
+	promise := stream.controller.abortSteps(abortRequest.reason)
+
+	uponPromise(stream.runtime, promise,
+		// 13. Upon fulfillment of promise,
+		func(sobek.Value) {
+			// 13.1. Resolve abortRequest's promise with undefined.
+			abortRequest.promise.resolve(sobek.Undefined())
+			// 13.2. Perform ! WritableStreamRejectCloseAndClosedPromiseIfNeeded(stream).
+			stream.rejectCloseAndClosedPromiseIfNeeded()
+		},
+		// 14. Upon rejection of promise with reason reason,
+		func(reason sobek.Value) {
+			// 14.1. Reject abortRequest's promise with reason.
+			abortRequest.promise.reject(reason)
+			// 14.2. Perform ! WritableStreamRejectCloseAndClosedPromiseIfNeeded(stream).
+			stream.rejectCloseAndClosedPromiseIfNeeded()
+		},
+	)
+}
+
+// finishInFlightClose implements the specification's [WritableStreamFinishInFlightClose()] abstract operation.
+//
+// [WritableStreamFinishInFlightClose()]: https://streams.spec.whatwg.org/#writable-stream-finish-in-flight-close
+func (stream *WritableStream) finishInFlightClose() {
+	// 1. Assert: stream.[[inFlightCloseRequest]] is not undefined.
+	if stream.inFlightCloseRequest == nil {
+		common.Throw(stream.runtime, newError(AssertionError, "stream has no close request in flight"))
+	}
+
+	// 2. Resolve stream.[[inFlightCloseRequest]] with undefined.
+	stream.inFlightCloseRequest.resolve(sobek.Undefined())
+
+	// 3. Set stream.[[inFlightCloseRequest]] to undefined.
+	stream.inFlightCloseRequest = nil
+
+	// 4. Let state be stream.[[state]].
+	// 5. Assert: stream.[[state]] is "writable" or "erroring".
+	// 6. If state is "erroring",
+	if stream.state == WritableStreamStateErroring {
+		// 6.1. Set stream.[[storedError]] to undefined.
+		stream.storedError = sobek.Undefined()
+
+		// 6.2. If stream.[[pendingAbortRequest]] is not undefined,
+		if stream.pendingAbortRequest != nil {
+			// 6.2.1. Resolve stream.[[pendingAbortRequest]]'s promise with undefined.
+			stream.pendingAbortRequest.promise.resolve(sobek.Undefined())
+			// 6.2.2. Set stream.[[pendingAbortRequest]] to undefined.
+			stream.pendingAbortRequest = nil
+		}
+	}
+
+	// 7. Set stream.[[state]] to "closed".
+	stream.state = WritableStreamStateClosed
+
+	// 8. Let writer be stream.[[writer]].
+	// 9. If writer is not undefined, resolve writer.[[closedPromise]] with undefined.
+	if stream.writer != nil {
+		stream.writer.closedPromise.resolve(sobek.Undefined())
+	}
+}
+
+// finishInFlightCloseWithError implements the specification's
+// [WritableStreamFinishInFlightCloseWithError()] abstract operation.
+//
+// [WritableStreamFinishInFlightCloseWithError()]: https://streams.spec.whatwg.org/#writable-stream-finish-in-flight-close-with-error
+func (stream *WritableStream) finishInFlightCloseWithError(e sobek.Value) {
+	// 1. Assert: stream.[[inFlightCloseRequest]] is not undefined.
+	if stream.inFlightCloseRequest == nil {
+		common.Throw(stream.runtime, newError(AssertionError, "stream has no close request in flight"))
+	}
+
+	// 2. Reject stream.[[inFlightCloseRequest]] with error.
+	stream.inFlightCloseRequest.reject(e)
+
+	// 3. Set stream.[[inFlightCloseRequest]] to undefined.
+	stream.inFlightCloseRequest = nil
+
+	// 4. Assert: stream.[[state]] is "writable" or "erroring".
+	// 5. If stream.[[pendingAbortRequest]] is not undefined,
+	if stream.pendingAbortRequest != nil {
+		// 5.1. Reject stream.[[pendingAbortRequest]]'s promise with error.
+		stream.pendingAbortRequest.promise.reject(e)
+		// 5.2. Set stream.[[pendingAbortRequest]] to undefined.
+		stream.pendingAbortRequest = nil
+	}
+
+	// 6. Perform ! WritableStreamDealWithRejection(stream, error).
+	stream.dealWithRejection(e)
+}
+
+// finishInFlightWrite implements the specification's [WritableStreamFinishInFlightWrite()] abstract operation.
+//
+// [WritableStreamFinishInFlightWrite()]: https://streams.spec.whatwg.org/#writable-stream-finish-in-flight-write
+func (stream *WritableStream) finishInFlightWrite() {
+	// 1. Assert: stream.[[inFlightWriteRequest]] is not undefined.
+	if stream.inFlightWriteRequest == nil {
+		common.Throw(stream.runtime, newError(AssertionError, "stream has no write request in flight"))
+	}
+
+	// 2. Resolve stream.[[inFlightWriteRequest]] with undefined.
+	stream.inFlightWriteRequest.resolve(sobek.Undefined())
+
+	// 3. Set stream.[[inFlightWriteRequest]] to undefined.
+	stream.inFlightWriteRequest = nil
+}
+
+// finishInFlightWriteWithError implements the specification's
+// [WritableStreamFinishInFlightWriteWithError()] abstract operation.
+//
+// [WritableStreamFinishInFlightWriteWithError()]: https://streams.spec.whatwg.org/#writable-stream-finish-in-flight-write-with-error
+func (stream *WritableStream) finishInFlightWriteWithError(e sobek.Value) {
+	// 1. Assert: stream.[[inFlightWriteRequest]] is not undefined.
+	if stream.inFlightWriteRequest == nil {
+		common.Throw(stream.runtime, newError(AssertionError, "stream has no write request in flight"))
+	}
+
+	// 2. Reject stream.[[inFlightWriteRequest]] with error.
+	stream.inFlightWriteRequest.reject(e)
+
+	// 3. Set stream.[[inFlightWriteRequest]] to undefined.
+	stream.inFlightWriteRequest = nil
+
+	// 4. Assert: stream.[[state]] is "writable" or "erroring".
+	// 5. Perform ! WritableStreamDealWithRejection(stream, error).
+	stream.dealWithRejection(e)
+}
+
+// hasOperationMarkedInFlight implements the specification's
+// [WritableStreamHasOperationMarkedInFlight()] abstract operation.
+//
+// [WritableStreamHasOperationMarkedInFlight()]: https://streams.spec.whatwg.org/#writable-stream-has-operation-marked-in-flight
+func (stream *WritableStream) hasOperationMarkedInFlight() bool {
+	return stream.inFlightWriteRequest != nil || stream.inFlightCloseRequest != nil
+}
+
+// markCloseRequestInFlight implements the specification's [WritableStreamMarkCloseRequestInFlight()]
+// abstract operation.
+//
+// [WritableStreamMarkCloseRequestInFlight()]: https://streams.spec.whatwg.org/#writable-stream-mark-close-request-in-flight
+func (stream *WritableStream) markCloseRequestInFlight() {
+	// 1. Assert: stream.[[inFlightCloseRequest]] is undefined.
+	// 2. Assert: stream.[[closeRequest]] is not undefined.
+	if stream.inFlightCloseRequest != nil || stream.closeRequest == nil {
+		common.Throw(stream.runtime, newError(AssertionError, "stream has no close request to mark in flight"))
+	}
+
+	// 3. Set stream.[[inFlightCloseRequest]] to stream.[[closeRequest]].
+	stream.inFlightCloseRequest = stream.closeRequest
+
+	// 4. Set stream.[[closeRequest]] to undefined.
+	stream.closeRequest = nil
+}
+
+// markFirstWriteRequestInFlight implements the specification's [WritableStreamMarkFirstWriteRequestInFlight()]
+// abstract operation.
+//
+// [WritableStreamMarkFirstWriteRequestInFlight()]: https://streams.spec.whatwg.org/#writable-stream-mark-first-write-request-in-flight
+func (stream *WritableStream) markFirstWriteRequestInFlight() {
+	// 1. Assert: stream.[[inFlightWriteRequest]] is undefined.
+	// 2. Assert: stream.[[writeRequests]] is not empty.
+	if stream.inFlightWriteRequest != nil || len(stream.writeRequests) == 0 {
+		common.Throw(stream.runtime, newError(AssertionError, "stream has no write request to mark in flight"))
+	}
+
+	// 3. Let writeRequest be stream.[[writeRequests]][0].
+	// 4. Remove writeRequest from stream.[[writeRequests]].
+	// 5. Set stream.[[inFlightWriteRequest]] to writeRequest.
+	stream.inFlightWriteRequest, stream.writeRequests = stream.writeRequests[0], stream.writeRequests[1:]
+}
+
+// rejectCloseAndClosedPromiseIfNeeded implements the specification's
+// [WritableStreamRejectCloseAndClosedPromiseIfNeeded()] abstract operation.
+//
+// [WritableStreamRejectCloseAndClosedPromiseIfNeeded()]: https://streams.spec.whatwg.org/#writable-stream-reject-close-and-closed-promise-if-needed
+func (stream *WritableStream) rejectCloseAndClosedPromiseIfNeeded() {
+	// 1. Assert: stream.[[state]] is "errored".
+	if stream.state != WritableStreamStateErrored {
+		common.Throw(stream.runtime, newError(AssertionError, "stream is not errored"))
+	}
+
+	// 2. If stream.[[closeRequest]] is not undefined,
+	if stream.closeRequest != nil {
+		// 2.1. Assert: stream.[[inFlightCloseRequest]] is undefined.
+		// 2.2. Reject stream.[[closeRequest]] with stream.[[storedError]].
+		stream.closeRequest.reject(stream.storedError)
+		// 2.3. Set stream.[[closeRequest]] to undefined.
+		stream.closeRequest = nil
+	}
+
+	// 3. Let writer be stream.[[writer]].
+	writer := stream.writer
+
+	// 4. If writer is not undefined,
+	if writer != nil {
+		// 4.1. Reject writer.[[closedPromise]] with stream.[[storedError]].
+		writer.closedPromise.reject(stream.storedError)
+		// 4.2. Set writer.[[closedPromise]].[[PromiseIsHandled]] to true.
+		setPromiseIsHandled(stream.runtime, writer.closedPromise.promise)
+	}
+}
+
+// startErroring implements the specification's [WritableStreamStartErroring()] abstract operation.
+//
+// [WritableStreamStartErroring()]: https://streams.spec.whatwg.org/#writable-stream-start-erroring
+func (stream *WritableStream) startErroring(reason sobek.Value) {
+	// 1. Assert: stream.[[storedError]] is undefined.
+	// 2. Assert: stream.[[state]] is "writable".
+	if stream.state != WritableStreamStateWritable {
+		common.Throw(stream.runtime, newError(AssertionError, "stream is not writable"))
+	}
+
+	// 3. Let controller be stream.[[controller]].
+	// 4. Assert: controller is not undefined.
+	controller := stream.controller
+
+	// 5. Set stream.[[state]] to "erroring".
+	stream.state = WritableStreamStateErroring
+
+	// 6. Set stream.[[storedError]] to reason.
+	stream.storedError = reason
+
+	// 7. Let writer be stream.[[writer]].
+	// 8. If writer is not undefined, perform ! WritableStreamDefaultWriterEnsureReadyPromiseRejected(writer, reason).
+	if stream.writer != nil {
+		stream.writer.ensureReadyPromiseRejected(reason)
+	}
+
+	// 9. If ! WritableStreamHasOperationMarkedInFlight(stream) is false and controller.[[started]] is true,
+	// perform ! WritableStreamFinishErroring(stream).
+	if !stream.hasOperationMarkedInFlight() && controller.started {
+		stream.finishErroring()
+	}
+}
+
+// updateBackpressure implements the specification's [WritableStreamUpdateBackpressure()] abstract operation.
+//
+// [WritableStreamUpdateBackpressure()]: https://streams.spec.whatwg.org/#writable-stream-update-backpressure
+func (stream *WritableStream) updateBackpressure(backpressure bool) {
+	// 1. Assert: stream.[[state]] is "writable".
+	// 2. Assert: ! WritableStreamCloseQueuedOrInFlight(stream) is false.
+	// 3. Let writer be stream.[[writer]].
+	writer := stream.writer
+
+	// 4. If writer is not undefined and backpressure is not stream.[[backpressure]],
+	if writer != nil && backpressure != stream.backpressure {
+		if backpressure {
+			// 4.1. If backpressure is true, set writer.[[readyPromise]] to a new promise.
+			writer.readyPromise = newPromiseCapability(stream.runtime)
+		} else {
+			// 4.2. Otherwise,
+			// 4.2.1. Assert: backpressure is false.
+			// 4.2.2. Resolve writer.[[readyPromise]] with undefined.
+			writer.readyPromise.resolve(sobek.Undefined())
+		}
+	}
+
+	// 5. Set stream.[[backpressure]] to backpressure.
+	stream.backpressure = backpressure
+}
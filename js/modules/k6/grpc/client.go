@@ -205,6 +205,9 @@ func buildTLSConfigFromMap(parentConfig *tls.Config, tlsConfigMap map[string]int
 }
 
 // Connect is a block dial to the gRPC server at the given address (host:port)
+// With the poolSize param, it opens that many connections, which are used in
+// round-robin, and each of them balances its calls across the addresses of a
+// dns:/// target with the loadBalancing policy.
 func (c *Client) Connect(addr string, params sobek.Value) (bool, error) {
 	state := c.vu.State()
 	if state == nil {
@@ -216,41 +219,25 @@ func (c *Client) Connect(addr string, params sobek.Value) (bool, error) {
 		return false, fmt.Errorf("invalid grpc.connect() parameters: %w", err)
 	}
 
-	opts := grpcext.DefaultOptions(c.vu.State)
-
-	var tcred credentials.TransportCredentials
+	var tlsCfg *tls.Config
 	if !p.IsPlaintext {
-		tlsCfg := state.TLSConfig.Clone()
+		tlsCfg = state.TLSConfig.Clone()
 		if len(p.TLS) > 0 {
 			if tlsCfg, err = buildTLSConfigFromMap(tlsCfg, p.TLS); err != nil {
 				return false, err
 			}
 		}
-		tlsCfg.NextProtos = []string{"h2"}
-
-		tcred = credentials.NewTLS(tlsCfg)
-	} else {
-		tcred = insecure.NewCredentials()
-	}
-	opts = append(opts, grpc.WithTransportCredentials(tcred))
-
-	if ua := state.Options.UserAgent; ua.Valid {
-		opts = append(opts, grpc.WithUserAgent(ua.ValueOrZero()))
 	}
 
 	ctx, cancel := context.WithTimeout(c.vu.Context(), p.Timeout)
 	defer cancel()
 
-	if p.MaxReceiveSize > 0 {
-		opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(p.MaxReceiveSize))))
-	}
-
-	if p.MaxSendSize > 0 {
-		opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(int(p.MaxSendSize))))
-	}
-
 	c.addr = addr
-	c.conn, err = grpcext.Dial(ctx, addr, opts...)
+	if p.Protocol == grpcext.ProtocolGRPC {
+		c.conn, err = c.dial(ctx, addr, p, tlsCfg)
+	} else {
+		c.conn, err = c.dialWeb(addr, p, tlsCfg)
+	}
 	if err != nil {
 		return false, err
 	}
@@ -273,6 +260,61 @@ func (c *Client) Connect(addr string, params sobek.Value) (bool, error) {
 	return true, err
 }
 
+func (c *Client) dial(ctx context.Context, addr string, p *connectParams, tlsCfg *tls.Config) (*grpcext.Conn, error) {
+	opts := grpcext.DefaultOptions(c.vu.State)
+	if p.NetworkConditions != nil {
+		opts = append(opts, grpcext.WithNetworkConditions(c.vu.State, p.NetworkConditions))
+	}
+
+	var tcred credentials.TransportCredentials
+	if tlsCfg != nil {
+		tlsCfg.NextProtos = []string{"h2"}
+
+		tcred = credentials.NewTLS(tlsCfg)
+	} else {
+		tcred = insecure.NewCredentials()
+	}
+	opts = append(opts, grpc.WithTransportCredentials(tcred))
+
+	if ua := c.vu.State().Options.UserAgent; ua.Valid {
+		opts = append(opts, grpc.WithUserAgent(ua.ValueOrZero()))
+	}
+
+	if p.MaxReceiveSize > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(p.MaxReceiveSize))))
+	}
+
+	if p.MaxSendSize > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(int(p.MaxSendSize))))
+	}
+
+	if p.LoadBalancing != "" {
+		opts = append(opts, grpcext.WithLoadBalancing(p.LoadBalancing))
+	}
+
+	if p.Keepalive != nil {
+		opts = append(opts, grpc.WithKeepaliveParams(*p.Keepalive))
+	}
+
+	return grpcext.DialPool(ctx, addr, int(p.PoolSize), opts...)
+}
+
+// dialWeb returns a connection with the gRPC-Web or the Connect protocol,
+// which only connects to the server with the first call.
+func (c *Client) dialWeb(addr string, p *connectParams, tlsCfg *tls.Config) (*grpcext.Conn, error) {
+	if tlsCfg != nil {
+		tlsCfg.NextProtos = []string{"h2", "http/1.1"}
+	}
+	return grpcext.DialWeb(c.vu.State, addr, grpcext.WebOptions{
+		Protocol:          p.Protocol,
+		TLSConfig:         tlsCfg,
+		UserAgent:         c.vu.State().Options.UserAgent.ValueOrZero(),
+		NetworkConditions: p.NetworkConditions,
+		MaxReceiveSize:    int(p.MaxReceiveSize),
+		MaxSendSize:       int(p.MaxSendSize),
+	})
+}
+
 // Invoke creates and calls a unary RPC by fully qualified method name
 func (c *Client) Invoke(
 	method string,
@@ -310,6 +310,32 @@ func TestClient(t *testing.T) {
 				client.invoke("grpc.testing.TestService/EmptyCall", {}, { timeout: 2000 })`,
 			},
 		},
+		{
+			name: "InvokePool",
+			initString: codeBlock{code: `
+				var client = new grpc.Client();
+				client.load([], "../../../../lib/testutils/httpmultibin/grpc_testing/test.proto");`},
+			setup: func(tb *httpmultibin.HTTPMultiBin) {
+				tb.GRPCStub.EmptyCallFunc = func(context.Context, *grpc_testing.Empty) (*grpc_testing.Empty, error) {
+					return &grpc_testing.Empty{}, nil
+				}
+			},
+			vuString: codeBlock{
+				code: `
+				client.connect("GRPCBIN_ADDR", { poolSize: 3, loadBalancing: "round_robin", keepalive: { time: "1m" } });
+				for (var i = 0; i < 5; i++) {
+					var resp = client.invoke("grpc.testing.TestService/EmptyCall", {})
+					if (resp.status !== grpc.StatusOK) {
+						throw new Error("unexpected error: " + JSON.stringify(resp.error) + "or status: " + resp.status)
+					}
+				}
+				client.close()`,
+				asserts: func(t *testing.T, rb *httpmultibin.HTTPMultiBin, samples chan metrics.SampleContainer, _ error) {
+					samplesBuf := metrics.GetBufferedSamples(samples)
+					assertMetricEmitted(t, metrics.GRPCReqDurationName, samplesBuf, rb.Replacer.Replace("GRPCBIN_ADDR/grpc.testing.TestService/EmptyCall"))
+				},
+			},
+		},
 		{
 			name: "InvokeDiscardResponseMessage",
 			initString: codeBlock{
@@ -10,8 +10,10 @@ import (
 	"go.k6.io/k6/js/common"
 	"go.k6.io/k6/js/modules"
 	"go.k6.io/k6/lib"
+	"go.k6.io/k6/lib/netext/grpcext"
 	"go.k6.io/k6/lib/types"
 	"go.k6.io/k6/metrics"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -124,6 +126,7 @@ func (p *callParams) SetSystemTags(state *lib.State, addr string, methodName str
 
 // connectParams is the parameters that can be passed to a gRPC connect call.
 type connectParams struct {
+	Protocol              string
 	IsPlaintext           bool
 	UseReflectionProtocol bool
 	ReflectionMetadata    metadata.MD
@@ -131,10 +134,15 @@ type connectParams struct {
 	MaxReceiveSize        int64
 	MaxSendSize           int64
 	TLS                   map[string]interface{}
+	NetworkConditions     *types.NetworkConditions
+	PoolSize              int64
+	LoadBalancing         string
+	Keepalive             *keepalive.ClientParameters
 }
 
 func newConnectParams(vu modules.VU, input sobek.Value) (*connectParams, error) { //nolint:gocognit
 	result := &connectParams{
+		Protocol:              grpcext.ProtocolGRPC,
 		IsPlaintext:           false,
 		UseReflectionProtocol: false,
 		Timeout:               time.Minute,
@@ -154,6 +162,14 @@ func newConnectParams(vu modules.VU, input sobek.Value) (*connectParams, error)
 		v := params.Get(k).Export()
 
 		switch k {
+		case "protocol":
+			protocol, ok := v.(string)
+			if !ok || (protocol != grpcext.ProtocolGRPC && protocol != grpcext.ProtocolGRPCWeb &&
+				protocol != grpcext.ProtocolConnect) {
+				return result, fmt.Errorf("invalid protocol value: '%#v', it needs to be %q, %q or %q",
+					v, grpcext.ProtocolGRPC, grpcext.ProtocolGRPCWeb, grpcext.ProtocolConnect)
+			}
+			result.Protocol = protocol
 		case "plaintext":
 			var ok bool
 			result.IsPlaintext, ok = v.(bool)
@@ -201,14 +217,73 @@ func newConnectParams(vu modules.VU, input sobek.Value) (*connectParams, error)
 			if err := parseConnectTLSParam(result, v); err != nil {
 				return result, err
 			}
+		case "network":
+			nc, err := types.NetworkConditionsFromValue(v)
+			if err != nil {
+				return result, err
+			}
+			result.NetworkConditions = nc
+		case "poolSize":
+			var ok bool
+			result.PoolSize, ok = v.(int64)
+			if !ok || result.PoolSize < 1 {
+				return result, fmt.Errorf("invalid poolSize value: '%#v', it needs to be a positive integer", v)
+			}
+		case "loadBalancing":
+			policy, ok := v.(string)
+			if !ok || (policy != grpcext.LoadBalancingPickFirst && policy != grpcext.LoadBalancingRoundRobin) {
+				return result, fmt.Errorf("invalid loadBalancing value: '%#v', it needs to be %q or %q",
+					v, grpcext.LoadBalancingPickFirst, grpcext.LoadBalancingRoundRobin)
+			}
+			result.LoadBalancing = policy
+		case "keepalive":
+			ka, err := parseConnectKeepaliveParam(v)
+			if err != nil {
+				return result, err
+			}
+			result.Keepalive = ka
 		default:
 			return result, fmt.Errorf("unknown connect param: %q", k)
 		}
 	}
 
+	if result.Protocol != grpcext.ProtocolGRPC &&
+		(result.PoolSize > 0 || result.LoadBalancing != "" || result.Keepalive != nil) {
+		return result, fmt.Errorf("the poolSize, loadBalancing and keepalive params aren't supported by the %q protocol",
+			result.Protocol)
+	}
+
 	return result, nil
 }
 
+func parseConnectKeepaliveParam(v interface{}) (*keepalive.ClientParameters, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid keepalive value: '%#v', expected (optional) keys: "+
+			"time, timeout and permitWithoutStream", v)
+	}
+	ka := &keepalive.ClientParameters{}
+	for k, v := range m {
+		var err error
+		switch k {
+		case "time":
+			ka.Time, err = types.GetDurationValue(v)
+		case "timeout":
+			ka.Timeout, err = types.GetDurationValue(v)
+		case "permitWithoutStream":
+			if ka.PermitWithoutStream, ok = v.(bool); !ok {
+				err = errors.New("it needs to be boolean")
+			}
+		default:
+			return nil, fmt.Errorf("unknown keepalive param: %q", k)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid keepalive %s value: %w", k, err)
+		}
+	}
+	return ka, nil
+}
+
 func parseConnectTLSParam(params *connectParams, v interface{}) error {
 	var ok bool
 	params.TLS, ok = v.(map[string]interface{})
@@ -13,6 +13,7 @@ import (
 	"go.k6.io/k6/js/modulestest"
 	"go.k6.io/k6/lib"
 	"go.k6.io/k6/metrics"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 	"gopkg.in/guregu/null.v3"
 )
@@ -217,3 +218,129 @@ func newParamsTestRuntime(t *testing.T, paramsJSON string) (*modulestest.Runtime
 
 	return testRuntime, params
 }
+
+func TestConnectParamsProtocolParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name     string
+		JSON     string
+		Protocol string
+		Err      string
+	}{
+		{
+			Name:     "Empty",
+			JSON:     `{}`,
+			Protocol: "grpc",
+		},
+		{
+			Name:     "GRPCWeb",
+			JSON:     `{ protocol: "grpc-web" }`,
+			Protocol: "grpc-web",
+		},
+		{
+			Name:     "Connect",
+			JSON:     `{ protocol: "connect" }`,
+			Protocol: "connect",
+		},
+		{
+			Name: "Invalid",
+			JSON: `{ protocol: "http" }`,
+			Err:  `invalid protocol value: '"http"', it needs to be "grpc", "grpc-web" or "connect"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			testRuntime, params := newParamsTestRuntime(t, tc.JSON)
+
+			p, err := newConnectParams(testRuntime.VU, params)
+			if tc.Err != "" {
+				require.ErrorContains(t, err, tc.Err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.Protocol, p.Protocol)
+		})
+	}
+}
+
+func TestConnectParamsPoolParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		Name          string
+		JSON          string
+		PoolSize      int64
+		LoadBalancing string
+		Keepalive     *keepalive.ClientParameters
+		Err           string
+	}{
+		{
+			Name: "Empty",
+			JSON: `{}`,
+		},
+		{
+			Name:          "Full",
+			JSON:          `{ poolSize: 4, loadBalancing: "round_robin", keepalive: { time: "30s", timeout: 5000, permitWithoutStream: true } }`,
+			PoolSize:      4,
+			LoadBalancing: "round_robin",
+			Keepalive: &keepalive.ClientParameters{
+				Time:                30 * time.Second,
+				Timeout:             5 * time.Second,
+				PermitWithoutStream: true,
+			},
+		},
+		{
+			Name: "InvalidPoolSize",
+			JSON: `{ poolSize: 0 }`,
+			Err:  `invalid poolSize value: '0', it needs to be a positive integer`,
+		},
+		{
+			Name: "InvalidLoadBalancing",
+			JSON: `{ loadBalancing: "random" }`,
+			Err:  `invalid loadBalancing value: '"random"', it needs to be "pick_first" or "round_robin"`,
+		},
+		{
+			Name: "InvalidKeepaliveTime",
+			JSON: `{ keepalive: { time: "often" } }`,
+			Err:  `invalid keepalive time value`,
+		},
+		{
+			Name: "UnknownKeepaliveParam",
+			JSON: `{ keepalive: { interval: "1s" } }`,
+			Err:  `unknown keepalive param: "interval"`,
+		},
+		{
+			Name: "UnsupportedProtocol",
+			JSON: `{ protocol: "grpc-web", poolSize: 2 }`,
+			Err:  `the poolSize, loadBalancing and keepalive params aren't supported by the "grpc-web" protocol`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			testRuntime, params := newParamsTestRuntime(t, tc.JSON)
+
+			p, err := newConnectParams(testRuntime.VU, params)
+			if tc.Err != "" {
+				require.ErrorContains(t, err, tc.Err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.PoolSize, p.PoolSize)
+			assert.Equal(t, tc.LoadBalancing, p.LoadBalancing)
+			assert.Equal(t, tc.Keepalive, p.Keepalive)
+		})
+	}
+}
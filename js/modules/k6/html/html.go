@@ -313,7 +313,7 @@ func (s Selection) Val() sobek.Value {
 	case InputTagName:
 		val, exists := s.sel.Attr("value")
 		if !exists {
-			inputType, _ := s.sel.Attr("type")
+			inputType := strings.ToLower(s.sel.AttrOr("type", ""))
 			if inputType == "radio" || inputType == "checkbox" {
 				val = "on"
 			} else {
@@ -341,6 +341,10 @@ func (s Selection) Val() sobek.Value {
 			return s.rt.ToValue(selected.Map(func(_ int, opt *goquery.Selection) string { return valueOrHTML(opt) }))
 		}
 
+		if selected.Length() == 0 {
+			// like in the browsers, the first option is selected by default
+			selected = s.sel.First().Find("option:not([disabled])").First()
+		}
 		return s.rt.ToValue(valueOrHTML(selected))
 
 	default:
@@ -238,6 +238,30 @@ func TestParseHTML(t *testing.T) {
 				assert.Equal(t, "Lorem ipsum dolor sit amet", v.Export())
 			}
 		})
+		t.Run("Defaults", func(t *testing.T) {
+			rt := getTestRuntimeWithDoc(t, `
+				<select id="no_selected"><option value="a" disabled>A</option><option>B</option></select>
+				<select id="no_options"></select>
+				<select id="multi_no_selected" multiple><option>A</option></select>
+				<input id="upper_checkbox" type="CHECKBOX" />
+				<input id="no_value" type="text" />
+			`)
+			cases := map[string]interface{}{
+				// the first enabled option is the default one, it used to be ""
+				"#no_selected":       "B",
+				"#no_options":        "",
+				"#multi_no_selected": []string{},
+				// the type is case-insensitive, it used to be ""
+				"#upper_checkbox": "on",
+				"#no_value":       "",
+			}
+			for selector, expected := range cases {
+				v, err := rt.RunString(`doc.find("` + selector + `").val()`)
+				if assert.NoError(t, err, selector) {
+					assert.Equal(t, expected, v.Export(), selector)
+				}
+			}
+		})
 	})
 	t.Run("Children", func(t *testing.T) {
 		t.Parallel()
@@ -2,9 +2,11 @@ package html
 
 import (
 	neturl "net/url"
+	"strings"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/grafana/sobek"
+	gohtml "golang.org/x/net/html"
 )
 
 type FormValue struct {
@@ -12,29 +14,31 @@ type FormValue struct {
 	Value sobek.Value
 }
 
+const submittableSelector = "input,select,textarea,keygen"
+
+// SerializeArray returns the names and values of the controls that a browser
+// would submit, either of the forms of the selection, or of the controls in it.
 func (s Selection) SerializeArray() []FormValue {
-	submittableSelector := "input,select,textarea,keygen"
 	var formElements *goquery.Selection
 	if s.sel.Is("form") {
-		formElements = s.sel.Find(submittableSelector)
+		formElements = formControls(s.sel.Filter("form"))
 	} else {
 		formElements = s.sel.Filter(submittableSelector)
 	}
 
 	formElements = formElements.FilterFunction(func(_ int, sel *goquery.Selection) bool {
 		name := sel.AttrOr("name", "")
-		inputType := sel.AttrOr("type", "")
-		disabled := sel.AttrOr("disabled", "")
-		checked := sel.AttrOr("checked", "")
+		inputType := strings.ToLower(sel.AttrOr("type", ""))
+		_, checked := sel.Attr("checked")
 
 		return name != "" && // Must have a non-empty name
-			disabled != "disabled" && // Must not be disabled
+			!isDisabled(sel.Nodes[0]) && // Must not be disabled
 			inputType != "submit" && // Must not be a button
 			inputType != "button" &&
 			inputType != "reset" &&
 			inputType != "image" && // Must not be an image or file
 			inputType != "file" &&
-			(checked == "checked" ||
+			(checked ||
 				(inputType != "checkbox" && inputType != "radio")) // Must be checked if it is an checkbox or radio
 	})
 
@@ -47,6 +51,73 @@ func (s Selection) SerializeArray() []FormValue {
 	return result
 }
 
+// formControls returns the controls of the forms, in the document order, which
+// are their descendants and the elements associated with them by the form
+// attribute.
+func formControls(forms *goquery.Selection) *goquery.Selection {
+	root := forms.Nodes[0]
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	doc := goquery.NewDocumentFromNode(root)
+
+	return doc.Find(submittableSelector).FilterFunction(func(_ int, sel *goquery.Selection) bool {
+		owner := formOwner(doc, sel)
+		for _, form := range forms.Nodes {
+			if owner == form {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// formOwner returns the form of the control, which is the one with the id of
+// its form attribute if it has one, or its closest form ancestor.
+func formOwner(doc *goquery.Document, sel *goquery.Selection) *gohtml.Node {
+	if id, ok := sel.Attr("form"); ok {
+		form := doc.Find("form").FilterFunction(func(_ int, form *goquery.Selection) bool {
+			return form.AttrOr("id", "") == id
+		})
+		if form.Length() == 0 {
+			return nil
+		}
+		return form.Nodes[0]
+	}
+	for p := sel.Nodes[0].Parent; p != nil; p = p.Parent {
+		if p.Type == gohtml.ElementNode && p.Data == FormTagName {
+			return p
+		}
+	}
+	return nil
+}
+
+// isDisabled returns whether the control has the disabled attribute, or is in
+// a disabled fieldset, outside of its first legend.
+func isDisabled(node *gohtml.Node) bool {
+	if getHTMLAttr(node, "disabled") != nil {
+		return true
+	}
+	for child, p := node, node.Parent; p != nil; child, p = p, p.Parent {
+		if p.Type != gohtml.ElementNode || p.Data != FieldSetTagName || getHTMLAttr(p, "disabled") == nil {
+			continue
+		}
+		if child.Type != gohtml.ElementNode || child.Data != LegendTagName || firstLegend(p) != child {
+			return true
+		}
+	}
+	return false
+}
+
+func firstLegend(fieldset *gohtml.Node) *gohtml.Node {
+	for c := fieldset.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == gohtml.ElementNode && c.Data == LegendTagName {
+			return c
+		}
+	}
+	return nil
+}
+
 func (s Selection) SerializeObject() map[string]sobek.Value {
 	formValues := s.SerializeArray()
 	result := make(map[string]sobek.Value)
@@ -66,9 +137,9 @@ func (s Selection) Serialize() string {
 		value := formValue.Value.Export()
 		switch v := value.(type) {
 		case string:
-			urlValues.Set(formValue.Name, v)
+			urlValues.Add(formValue.Name, v)
 		case []string:
-			urlValues[formValue.Name] = v
+			urlValues[formValue.Name] = append(urlValues[formValue.Name], v...)
 		}
 	}
 	return urlValues.Encode()
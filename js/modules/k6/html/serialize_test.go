@@ -182,4 +182,70 @@ func TestSerialize(t *testing.T) {
 				"&textarea=Lorem+ipsum+dolor+sit+amet", url)
 		}
 	})
+
+	t.Run("Legacy", func(t *testing.T) {
+		t.Parallel()
+		// the attribute values which were required before, and the cases whose
+		// results were changed to match the browsers
+		rt := getTestRuntimeWithDoc(t, `
+<form>
+	<input type="text" name="disabled_value" value="no" disabled="disabled" />
+	<input type="text" name="disabled_empty" value="no" disabled="" />
+	<input type="checkbox" name="checked_value" checked="checked" />
+	<input type="checkbox" name="checked_empty" checked="" />
+	<input type="text" name="repeated" value="1" />
+	<input type="text" name="repeated" value="2" />
+	<select name="default"><option>first</option><option>second</option></select>
+</form>
+`)
+
+		v, err := rt.RunString(`doc.find("form").serialize()`)
+		if assert.NoError(t, err) {
+			// disabled="" used to be submitted, checked="" used to be left out,
+			// only the last value of the repeated names was kept and the select
+			// without a selected option used to be submitted as empty
+			assert.Equal(t, "checked_empty=on"+
+				"&checked_value=on"+
+				"&default=first"+
+				"&repeated=1"+
+				"&repeated=2", v.String())
+		}
+	})
+
+	t.Run("Browser", func(t *testing.T) {
+		t.Parallel()
+		rt := getTestRuntimeWithDoc(t, `
+<form id="login" action="/login" method="post">
+	<input type="hidden" name="token" value="abc" />
+	<input type="checkbox" name="tag" value="a" checked />
+	<input type="checkbox" name="tag" value="b" checked />
+	<input type="checkbox" name="tag" value="c" />
+	<input type="CHECKBOX" name="remember" checked />
+	<input type="text" name="disabled" value="no" disabled />
+	<select name="country">
+		<option value="se" disabled>Sweden</option>
+		<option value="no">Norway</option>
+		<option value="dk">Denmark</option>
+	</select>
+	<fieldset disabled>
+		<legend><input type="text" name="legend" value="yes" /></legend>
+		<input type="text" name="fieldset" value="no" />
+	</fieldset>
+	<input type="submit" name="submit" value="Log in" />
+</form>
+<input type="text" name="outside" value="yes" form="login" />
+<input type="text" name="other" value="no" />
+`)
+
+		v, err := rt.RunString(`doc.find("form").serialize()`)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "country=no"+
+				"&legend=yes"+
+				"&outside=yes"+
+				"&remember=on"+
+				"&tag=a"+
+				"&tag=b"+
+				"&token=abc", v.String())
+		}
+	})
 }
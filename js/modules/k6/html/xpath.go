@@ -0,0 +1,186 @@
+package html
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antchfx/xpath"
+	gohtml "golang.org/x/net/html"
+)
+
+// EvaluateXPath evaluates the XPath 1.0 expression with each node of the
+// selection as the context node, and it's exposed to JS as xpath(). Node-sets
+// are returned as a selection, where the attributes are text nodes with their
+// values, and the other results, like the ones of count() or string(), are
+// returned as they are, evaluated with the first node.
+func (s Selection) EvaluateXPath(expr string) (interface{}, error) {
+	compiled, err := xpath.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XPath expression %q: %w", expr, err)
+	}
+	if len(s.sel.Nodes) == 0 {
+		return s.emptySelection(), nil
+	}
+
+	var nodes []*gohtml.Node
+	for i, node := range s.sel.Nodes {
+		switch result := compiled.Evaluate(newNodeNavigator(node)).(type) {
+		case *xpath.NodeIterator:
+			for result.MoveNext() {
+				nodes = append(nodes, result.Current().(*nodeNavigator).node()) //nolint:forcetypeassert
+			}
+		default:
+			if i == 0 {
+				return result, nil
+			}
+		}
+	}
+	return Selection{s.rt, s.emptySelection().sel.AddNodes(nodes...), s.URL}, nil
+}
+
+// nodeNavigator is an xpath.NodeNavigator of the HTML nodes.
+type nodeNavigator struct {
+	root, curr *gohtml.Node
+	// attr is the index of the current attribute of curr, or -1
+	attr int
+}
+
+func newNodeNavigator(node *gohtml.Node) *nodeNavigator {
+	root := node
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	return &nodeNavigator{root: root, curr: node, attr: -1}
+}
+
+// node returns the current node, or a text node with the value of the current
+// attribute.
+func (n *nodeNavigator) node() *gohtml.Node {
+	if n.attr == -1 {
+		return n.curr
+	}
+	return &gohtml.Node{Type: gohtml.TextNode, Data: n.curr.Attr[n.attr].Val}
+}
+
+func (n *nodeNavigator) NodeType() xpath.NodeType {
+	switch n.curr.Type {
+	case gohtml.DocumentNode:
+		return xpath.RootNode
+	case gohtml.TextNode:
+		return xpath.TextNode
+	case gohtml.ElementNode:
+		if n.attr != -1 {
+			return xpath.AttributeNode
+		}
+		return xpath.ElementNode
+	default:
+		return xpath.CommentNode
+	}
+}
+
+func (n *nodeNavigator) LocalName() string {
+	if n.attr != -1 {
+		return n.curr.Attr[n.attr].Key
+	}
+	return n.curr.Data
+}
+
+func (n *nodeNavigator) Prefix() string {
+	return ""
+}
+
+func (n *nodeNavigator) Value() string {
+	switch n.curr.Type {
+	case gohtml.CommentNode, gohtml.TextNode:
+		return n.curr.Data
+	case gohtml.ElementNode:
+		if n.attr != -1 {
+			return n.curr.Attr[n.attr].Val
+		}
+	}
+
+	var sb strings.Builder
+	var text func(*gohtml.Node)
+	text = func(node *gohtml.Node) {
+		if node.Type == gohtml.TextNode {
+			sb.WriteString(node.Data)
+		}
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			text(c)
+		}
+	}
+	text(n.curr)
+	return sb.String()
+}
+
+func (n *nodeNavigator) Copy() xpath.NodeNavigator {
+	c := *n
+	return &c
+}
+
+func (n *nodeNavigator) MoveToRoot() {
+	n.curr, n.attr = n.root, -1
+}
+
+func (n *nodeNavigator) MoveToParent() bool {
+	if n.attr != -1 {
+		n.attr = -1
+		return true
+	}
+	if n.curr.Parent == nil {
+		return false
+	}
+	n.curr = n.curr.Parent
+	return true
+}
+
+func (n *nodeNavigator) MoveToNextAttribute() bool {
+	if n.curr.Type != gohtml.ElementNode || n.attr >= len(n.curr.Attr)-1 {
+		return false
+	}
+	n.attr++
+	return true
+}
+
+func (n *nodeNavigator) MoveToChild() bool {
+	if n.attr != -1 || n.curr.FirstChild == nil {
+		return false
+	}
+	n.curr = n.curr.FirstChild
+	return true
+}
+
+func (n *nodeNavigator) MoveToFirst() bool {
+	if n.attr != -1 || n.curr.PrevSibling == nil {
+		return false
+	}
+	for n.curr.PrevSibling != nil {
+		n.curr = n.curr.PrevSibling
+	}
+	return true
+}
+
+func (n *nodeNavigator) MoveToNext() bool {
+	if n.attr != -1 || n.curr.NextSibling == nil {
+		return false
+	}
+	n.curr = n.curr.NextSibling
+	return true
+}
+
+func (n *nodeNavigator) MoveToPrevious() bool {
+	if n.attr != -1 || n.curr.PrevSibling == nil {
+		return false
+	}
+	n.curr = n.curr.PrevSibling
+	return true
+}
+
+func (n *nodeNavigator) MoveTo(other xpath.NodeNavigator) bool {
+	node, ok := other.(*nodeNavigator)
+	if !ok || node.root != n.root {
+		return false
+	}
+	*n = *node
+	return true
+}
@@ -0,0 +1,110 @@
+package html
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testXPathHTML = `
+<html>
+<body>
+	<form id="form1" action="/login.aspx" method="post">
+		<input type="hidden" name="__VIEWSTATE" id="__VIEWSTATE" value="dDwtMTA4MzE0MjEwNTs7Pg==" />
+		<input type="hidden" name="__EVENTVALIDATION" value="/wEWAgL+raDpAgKM54rGBg==" />
+		<input type="text" name="user" />
+	</form>
+	<ul>
+		<li class="a">first</li>
+		<li class="b">second <b>bold</b></li>
+		<li class="a">third</li>
+	</ul>
+</body>
+</html>
+`
+
+func TestXPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name, script string
+		expected     interface{}
+	}{
+		{
+			name:     "Attribute",
+			script:   `doc.xpath("//input[@id='__VIEWSTATE']/@value").text()`,
+			expected: "dDwtMTA4MzE0MjEwNTs7Pg==",
+		},
+		{
+			name:     "Attributes",
+			script:   `doc.xpath("//input[@type='hidden']/@name").toArray().map(function(s) { return s.text() }).join(",")`,
+			expected: "__VIEWSTATE,__EVENTVALIDATION",
+		},
+		{
+			name:     "Elements",
+			script:   `doc.xpath("//li[@class='a']").size()`,
+			expected: int64(2),
+		},
+		{
+			name:     "Position",
+			script:   `doc.xpath("//ul/li[last()]").text()`,
+			expected: "third",
+		},
+		{
+			name:     "Text",
+			script:   `doc.xpath("//li[contains(., 'bold')]/text()").text()`,
+			expected: "second ",
+		},
+		{
+			name:     "Relative",
+			script:   `doc.find("ul").xpath("./li[2]/b").text()`,
+			expected: "bold",
+		},
+		{
+			name:     "Parent",
+			script:   `doc.find("b").xpath("../@class").text()`,
+			expected: "b",
+		},
+		{
+			name:     "Selection",
+			script:   `doc.xpath("//form").attr("action")`,
+			expected: "/login.aspx",
+		},
+		{
+			name:     "Count",
+			script:   `doc.xpath("count(//li)")`,
+			expected: int64(3),
+		},
+		{
+			name:     "String",
+			script:   `doc.xpath("string(//input[@name='user']/@type)")`,
+			expected: "text",
+		},
+		{
+			name:     "Empty",
+			script:   `doc.xpath("//table").size()`,
+			expected: int64(0),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rt := getTestRuntimeWithDoc(t, testXPathHTML)
+
+			v, err := rt.RunString(tc.script)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, v.Export())
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		rt := getTestRuntimeWithDoc(t, testXPathHTML)
+
+		_, err := rt.RunString(`doc.xpath("//li[")`)
+		assert.ErrorContains(t, err, `invalid XPath expression "//li["`)
+	})
+}
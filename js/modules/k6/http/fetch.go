@@ -0,0 +1,733 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/sobek"
+	"gopkg.in/guregu/null.v3"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/js/modules"
+	"go.k6.io/k6/js/modules/k6/experimental/streams"
+	"go.k6.io/k6/lib/netext/httpext"
+)
+
+// FetchModule is the global module object type of the WHATWG Fetch API
+// implementation. Its exports are available as globals in all scripts, and
+// the requests it makes go through the same code paths as the ones made with
+// k6/http, so they are affected by the same options and emit the same metrics.
+type FetchModule struct{}
+
+// FetchModuleInstance represents an instance of the Fetch API for every VU.
+type FetchModuleInstance struct {
+	vu       modules.VU
+	client   *Client
+	internal *sobek.Symbol
+	exports  *sobek.Object
+
+	headersCtor, requestCtor, responseCtor, abortControllerCtor, abortSignalCtor *sobek.Object
+}
+
+var (
+	_ modules.Module   = &FetchModule{}
+	_ modules.Instance = &FetchModuleInstance{}
+)
+
+// NewFetch returns a pointer to a new Fetch API module.
+func NewFetch() *FetchModule {
+	return &FetchModule{}
+}
+
+// NewModuleInstance returns a Fetch API module instance for each VU.
+func (*FetchModule) NewModuleInstance(vu modules.VU) modules.Instance {
+	rt := vu.Runtime()
+	httpInstance := &ModuleInstance{vu: vu}
+	mi := &FetchModuleInstance{
+		vu: vu,
+		client: &Client{
+			moduleInstance:   httpInstance,
+			responseCallback: defaultExpectedStatuses.match,
+		},
+		internal: sobek.NewSymbol("k6 fetch internal"),
+		exports:  rt.NewObject(),
+	}
+
+	mi.headersCtor = rt.ToValue(mi.newHeaders).ToObject(rt)
+	mi.requestCtor = rt.ToValue(mi.newRequest).ToObject(rt)
+	mi.responseCtor = rt.ToValue(mi.newResponse).ToObject(rt)
+	mi.abortControllerCtor = rt.ToValue(mi.newAbortController).ToObject(rt)
+	mi.abortSignalCtor = rt.ToValue(mi.newAbortSignal).ToObject(rt)
+	mi.defineHeadersPrototype()
+	mi.defineRequestPrototype()
+	mi.defineResponsePrototype()
+	mi.defineAbortControllerPrototype()
+	mi.defineAbortSignalPrototype()
+	mi.defineMethods(mi.responseCtor, map[string]interface{}{
+		"json":     mi.responseJSON,
+		"error":    mi.responseError,
+		"redirect": mi.responseRedirect,
+	})
+	mi.defineMethods(mi.abortSignalCtor, map[string]interface{}{
+		"abort": mi.abortSignalAbort,
+	})
+
+	mustExport := func(name string, value interface{}) {
+		if err := mi.exports.Set(name, value); err != nil {
+			common.Throw(rt, err)
+		}
+	}
+	mustExport("fetch", mi.fetch)
+	mustExport("Headers", mi.headersCtor)
+	mustExport("Request", mi.requestCtor)
+	mustExport("Response", mi.responseCtor)
+	mustExport("AbortController", mi.abortControllerCtor)
+	mustExport("AbortSignal", mi.abortSignalCtor)
+
+	return mi
+}
+
+// Exports returns the JS values this module exports.
+func (mi *FetchModuleInstance) Exports() modules.Exports {
+	return modules.Exports{Default: mi.exports}
+}
+
+// setInternal links the given object to the Go value that implements it.
+func (mi *FetchModuleInstance) setInternal(obj *sobek.Object, v interface{}) {
+	err := obj.DefineDataPropertySymbol(
+		mi.internal, mi.vu.Runtime().ToValue(v), sobek.FLAG_FALSE, sobek.FLAG_FALSE, sobek.FLAG_FALSE)
+	if err != nil {
+		common.Throw(mi.vu.Runtime(), err)
+	}
+}
+
+// getInternal returns the Go value that implements the given object, if any.
+func (mi *FetchModuleInstance) getInternal(v sobek.Value) interface{} {
+	obj, ok := v.(*sobek.Object)
+	if !ok {
+		return nil
+	}
+	internal := obj.GetSymbol(mi.internal)
+	if internal == nil {
+		return nil
+	}
+	return internal.Export()
+}
+
+// internalOf returns the Go value of type T that implements the given object,
+// which the prototype methods are called on. It throws a TypeError if there
+// isn't one, e.g. if a method is called on another object.
+func internalOf[T any](mi *FetchModuleInstance, v sobek.Value) T {
+	internal, ok := mi.getInternal(v).(T)
+	if !ok {
+		panic(mi.vu.Runtime().NewTypeError("Illegal invocation"))
+	}
+	return internal
+}
+
+// newObjectOf returns a new object, which is an instance of the given
+// constructor, without calling it.
+func (mi *FetchModuleInstance) newObjectOf(ctor *sobek.Object) *sobek.Object {
+	rt := mi.vu.Runtime()
+	obj := rt.NewObject()
+	if err := obj.SetPrototype(mi.prototypeOf(ctor)); err != nil {
+		common.Throw(rt, err)
+	}
+	return obj
+}
+
+func (mi *FetchModuleInstance) prototypeOf(ctor *sobek.Object) *sobek.Object {
+	return ctor.Get("prototype").ToObject(mi.vu.Runtime())
+}
+
+// defineMethods defines the given methods on the given object, which is either
+// a constructor, for the static methods, or a prototype.
+func (mi *FetchModuleInstance) defineMethods(obj *sobek.Object, methods map[string]interface{}) {
+	rt := mi.vu.Runtime()
+	for name, method := range methods {
+		err := obj.DefineDataProperty(name, rt.ToValue(method), sobek.FLAG_TRUE, sobek.FLAG_FALSE, sobek.FLAG_TRUE)
+		if err != nil {
+			common.Throw(rt, err)
+		}
+	}
+}
+
+// defineGetters defines the given read-only accessor properties on the given
+// prototype. The getters are called with the object they're accessed on.
+func (mi *FetchModuleInstance) defineGetters(
+	proto *sobek.Object, getters map[string]func(sobek.FunctionCall) sobek.Value,
+) {
+	rt := mi.vu.Runtime()
+	for name, getter := range getters {
+		err := proto.DefineAccessorProperty(name, rt.ToValue(getter), nil, sobek.FLAG_FALSE, sobek.FLAG_TRUE)
+		if err != nil {
+			common.Throw(rt, err)
+		}
+	}
+}
+
+// fetch implements the global fetch() function.
+func (mi *FetchModuleInstance) fetch(input sobek.Value, init sobek.Value) (*sobek.Promise, error) {
+	state := mi.vu.State()
+	if state == nil {
+		return nil, ErrHTTPForbiddenInInitContext
+	}
+	rt := mi.vu.Runtime()
+	p, resolve, reject := rt.NewPromise()
+
+	req, jsErr := mi.parseFetchRequest(input, init)
+	if jsErr != nil {
+		reject(jsErr)
+		return p, nil
+	}
+	if req.signal != nil && req.signal.aborted {
+		reject(req.signal.reason)
+		return p, nil
+	}
+
+	var body interface{}
+	if req.body.data != nil {
+		body = req.body.data
+		req.body.used = true
+	}
+	preq, err := mi.client.parseRequest(req.method, rt.ToValue(req.url), body, nil)
+	if err != nil {
+		reject(rt.NewTypeError("fetch failed: %s", err))
+		return p, nil
+	}
+	for name, values := range req.headers.toHTTPHeader() {
+		if strings.EqualFold(name, "host") {
+			preq.Req.Host = values[0]
+		}
+		preq.Req.Header[name] = values
+	}
+	preq.ResponseType = httpext.ResponseTypeBinary
+	preq.Throw = true
+	if req.redirect != "follow" {
+		preq.Redirects = null.IntFrom(0)
+	}
+
+	ctx, cancel := context.WithCancel(mi.vu.Context())
+	unsubscribe := func() {}
+	if req.signal != nil {
+		unsubscribe = req.signal.subscribe(cancel)
+	}
+
+	mi.client.makeRequestAsync(ctx, state, preq, func(resp *httpext.Response, err error) {
+		unsubscribe()
+		cancel()
+		switch {
+		case req.signal != nil && req.signal.aborted:
+			reject(req.signal.reason)
+		case err != nil:
+			reject(rt.NewTypeError("fetch failed: %s", err))
+		case req.redirect == "error" && resp.Status >= 300 && resp.Status < 400:
+			reject(rt.NewTypeError("fetch failed: unexpected redirect to %s", resp.Headers["Location"]))
+		default:
+			resolve(mi.responseFromHTTPext(req, resp))
+		}
+	})
+
+	return p, nil
+}
+
+// responseFromHTTPext returns a Response object for the given k6 response.
+func (mi *FetchModuleInstance) responseFromHTTPext(req *fetchRequest, resp *httpext.Response) *sobek.Object {
+	headers := &fetchHeaders{}
+	for name, values := range resp.RawHeaders {
+		for _, value := range values {
+			headers.list = append(headers.list, [2]string{strings.ToLower(name), value})
+		}
+	}
+	headers.immutable = true
+
+	data, _ := resp.Body.([]byte)
+	if data == nil {
+		data = []byte{}
+	}
+	res := &fetchResponse{
+		typ:        "basic",
+		status:     resp.Status,
+		statusText: strings.TrimSpace(strings.TrimPrefix(resp.StatusText, strconv.Itoa(resp.Status))),
+		headers:    headers,
+		body:       fetchBody{data: data},
+		url:        resp.URL,
+		redirected: resp.URL != req.url,
+	}
+	return mi.responseObject(res)
+}
+
+// fetchBody implements the body of the Request and Response objects. A nil
+// data means that there is no body.
+type fetchBody struct {
+	data []byte
+	used bool
+
+	// stream is the ReadableStream exposed as the body property, which is
+	// only created the first time it's accessed.
+	stream    *streams.ReadableStream
+	streamObj *sobek.Object
+}
+
+// isDisturbed returns whether the body has been read, either with one of the
+// body methods or through its stream.
+func (b *fetchBody) isDisturbed() bool {
+	if b.data == nil {
+		return false
+	}
+	return b.used || (b.stream != nil && streams.IsReadableStreamDisturbed(b.stream))
+}
+
+// isUnusable returns whether the body can't be read anymore, because it has
+// already been read or its stream is locked to a reader.
+func (b *fetchBody) isUnusable() bool {
+	return b.isDisturbed() || (b.stream != nil && streams.IsReadableStreamLocked(b.stream))
+}
+
+// markUsed marks the body as read. Its stream, if it was already created, is
+// locked, same as reading it fully would do.
+func (b *fetchBody) markUsed() {
+	if b.data == nil {
+		return
+	}
+	b.used = true
+	if b.stream != nil && !streams.IsReadableStreamLocked(b.stream) {
+		b.stream.GetReader(nil)
+	}
+}
+
+// extractBody returns the bytes of the given body init value and its default
+// content type.
+func (mi *FetchModuleInstance) extractBody(v sobek.Value) ([]byte, string) {
+	rt := mi.vu.Runtime()
+	if common.IsNullish(v) {
+		return nil, ""
+	}
+	switch data := v.Export().(type) {
+	case string:
+		return []byte(data), "text/plain;charset=UTF-8"
+	case sobek.ArrayBuffer:
+		return append([]byte{}, data.Bytes()...), ""
+	}
+
+	// typed arrays and data views
+	obj := v.ToObject(rt)
+	if buffer, ok := obj.Get("buffer").Export().(sobek.ArrayBuffer); ok {
+		offset, length := obj.Get("byteOffset").ToInteger(), obj.Get("byteLength").ToInteger()
+		return append([]byte{}, buffer.Bytes()[offset:offset+length]...), ""
+	}
+	return []byte(v.String()), "text/plain;charset=UTF-8"
+}
+
+// bodyOf returns the body of the given Request or Response object.
+func (mi *FetchModuleInstance) bodyOf(v sobek.Value) *fetchBody {
+	switch internal := mi.getInternal(v).(type) {
+	case *fetchRequest:
+		return &internal.body
+	case *fetchResponse:
+		return &internal.body
+	default:
+		panic(mi.vu.Runtime().NewTypeError("Illegal invocation"))
+	}
+}
+
+// defineBodyMethods defines the methods and properties shared by the Request
+// and Response prototypes for reading their body.
+func (mi *FetchModuleInstance) defineBodyMethods(proto *sobek.Object) {
+	rt := mi.vu.Runtime()
+
+	consume := func(call sobek.FunctionCall, convert func([]byte) (interface{}, error)) sobek.Value {
+		p, resolve, reject := rt.NewPromise()
+		body := mi.bodyOf(call.This)
+		if body.isUnusable() {
+			reject(rt.NewTypeError("body has already been used"))
+			return rt.ToValue(p)
+		}
+		body.markUsed()
+		res, err := convert(body.data)
+		if err != nil {
+			reject(err)
+		} else {
+			resolve(res)
+		}
+		return rt.ToValue(p)
+	}
+
+	mi.defineGetters(proto, map[string]func(sobek.FunctionCall) sobek.Value{
+		"bodyUsed": func(call sobek.FunctionCall) sobek.Value {
+			return rt.ToValue(mi.bodyOf(call.This).isDisturbed())
+		},
+		"body": func(call sobek.FunctionCall) sobek.Value {
+			body := mi.bodyOf(call.This)
+			if body.data == nil {
+				return sobek.Null()
+			}
+			if body.streamObj == nil {
+				body.streamObj = streams.NewReadableStreamFromReader(mi.vu, bytes.NewReader(body.data))
+				body.stream, _ = body.streamObj.Export().(*streams.ReadableStream)
+				if body.used {
+					// The body was already read with one of the body methods.
+					body.stream.GetReader(nil)
+				}
+			}
+			return body.streamObj
+		},
+	})
+	mi.defineMethods(proto, map[string]interface{}{
+		"text": func(call sobek.FunctionCall) sobek.Value {
+			return consume(call, func(b []byte) (interface{}, error) { return string(b), nil })
+		},
+		"arrayBuffer": func(call sobek.FunctionCall) sobek.Value {
+			return consume(call, func(b []byte) (interface{}, error) {
+				return rt.NewArrayBuffer(append([]byte{}, b...)), nil
+			})
+		},
+		"bytes": func(call sobek.FunctionCall) sobek.Value {
+			return consume(call, func(b []byte) (interface{}, error) {
+				ctor, _ := sobek.AssertConstructor(rt.Get("Uint8Array"))
+				return ctor(nil, rt.ToValue(rt.NewArrayBuffer(append([]byte{}, b...))))
+			})
+		},
+		"json": func(call sobek.FunctionCall) sobek.Value {
+			return consume(call, func(b []byte) (interface{}, error) {
+				parse, _ := sobek.AssertFunction(rt.Get("JSON").ToObject(rt).Get("parse"))
+				return parse(sobek.Undefined(), rt.ToValue(string(b)))
+			})
+		},
+	})
+}
+
+// fetchRequest implements the WHATWG Request interface.
+type fetchRequest struct {
+	method   string
+	url      string
+	headers  *fetchHeaders
+	body     fetchBody
+	signal   *abortSignal
+	redirect string
+
+	signalObj  *sobek.Object
+	headersObj *sobek.Object
+}
+
+// requestInit contains the supported members of the RequestInit dictionary.
+type requestInit struct {
+	method, redirect, body, headers, signal sobek.Value
+}
+
+func (mi *FetchModuleInstance) getRequestInit(init sobek.Value) requestInit {
+	ri := requestInit{}
+	if common.IsNullish(init) {
+		return ri
+	}
+	obj := init.ToObject(mi.vu.Runtime())
+	get := func(name string) sobek.Value {
+		if v := obj.Get(name); v != nil && !sobek.IsUndefined(v) {
+			return v
+		}
+		return nil
+	}
+	ri.method, ri.redirect, ri.body = get("method"), get("redirect"), get("body")
+	ri.headers, ri.signal = get("headers"), get("signal")
+	return ri
+}
+
+// parseFetchRequest creates a new request from the arguments of the Request
+// constructor and fetch(). It returns the errors as JS values, since they may
+// need to be used to reject a promise.
+func (mi *FetchModuleInstance) parseFetchRequest(input, init sobek.Value) (req *fetchRequest, err sobek.Value) {
+	rt := mi.vu.Runtime()
+	defer func() {
+		if r := recover(); r != nil {
+			var exception *sobek.Exception
+			switch e := r.(type) {
+			case sobek.Value:
+				err = e
+			case error:
+				if !errors.As(e, &exception) {
+					panic(r)
+				}
+				err = exception.Value()
+			default:
+				panic(r)
+			}
+			req = nil
+		}
+	}()
+
+	req = &fetchRequest{method: "GET", redirect: "follow", headers: &fetchHeaders{}}
+	if source, ok := mi.getInternal(input).(*fetchRequest); ok {
+		if source.body.isUnusable() {
+			panic(rt.NewTypeError("the body of the input Request has already been used"))
+		}
+		req.method, req.url, req.redirect = source.method, source.url, source.redirect
+		req.headers = source.headers.clone()
+		req.headers.immutable = false
+		req.body = fetchBody{data: source.body.data}
+		req.signal, req.signalObj = source.signal, source.signalObj
+		source.body.markUsed()
+	} else {
+		u, parseErr := url.Parse(input.String())
+		if parseErr != nil || !u.IsAbs() {
+			panic(rt.NewTypeError("invalid URL '%s'", input.String()))
+		}
+		if u.User != nil {
+			panic(rt.NewTypeError("request URLs can't include credentials"))
+		}
+		req.url = u.String()
+	}
+
+	ri := mi.getRequestInit(init)
+	if ri.method != nil {
+		req.method = normalizeMethod(rt, ri.method.String())
+	}
+	if ri.redirect != nil {
+		switch redirect := ri.redirect.String(); redirect {
+		case "follow", "error", "manual":
+			req.redirect = redirect
+		default:
+			panic(rt.NewTypeError("invalid redirect mode '%s'", redirect))
+		}
+	}
+	if ri.signal != nil && !sobek.IsNull(ri.signal) {
+		req.signal = mi.toAbortSignal(ri.signal)
+		if req.signal == nil {
+			panic(rt.NewTypeError("the signal needs to be an AbortSignal"))
+		}
+		req.signalObj = ri.signal.ToObject(rt)
+	}
+	if ri.headers != nil {
+		req.headers = &fetchHeaders{}
+		mi.fillHeaders(req.headers, ri.headers)
+	}
+	if ri.body != nil {
+		data, contentType := mi.extractBody(ri.body)
+		req.body = fetchBody{data: data}
+		if _, ok := req.headers.get("content-type"); !ok && contentType != "" {
+			req.headers.append(rt, "content-type", contentType)
+		}
+	}
+	if req.body.data != nil && (req.method == "GET" || req.method == "HEAD") {
+		panic(rt.NewTypeError("requests with a %s method can't have a body", req.method))
+	}
+	if req.signalObj == nil {
+		req.signalObj, req.signal = mi.newAbortSignalObject()
+	}
+	return req, nil
+}
+
+// normalizeMethod validates the given method and uppercases it, if it's one of
+// the standard ones.
+func normalizeMethod(rt *sobek.Runtime, method string) string {
+	switch upper := strings.ToUpper(method); upper {
+	case "CONNECT", "TRACE", "TRACK":
+		panic(rt.NewTypeError("the %s method is forbidden", upper))
+	case "DELETE", "GET", "HEAD", "OPTIONS", "POST", "PUT", "PATCH":
+		return upper
+	}
+	if method == "" || strings.ContainsFunc(method, func(r rune) bool {
+		return r <= ' ' || r >= 0x7f || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r)
+	}) {
+		panic(rt.NewTypeError("invalid method '%s'", method))
+	}
+	return method
+}
+
+// newRequest is the constructor of the Request objects.
+func (mi *FetchModuleInstance) newRequest(call sobek.ConstructorCall) *sobek.Object {
+	req, err := mi.parseFetchRequest(call.Argument(0), call.Argument(1))
+	if err != nil {
+		panic(err)
+	}
+	mi.initRequestObject(call.This, req)
+	return nil
+}
+
+func (mi *FetchModuleInstance) initRequestObject(obj *sobek.Object, req *fetchRequest) {
+	mi.setInternal(obj, req)
+	req.headersObj = mi.headersObject(req.headers)
+}
+
+// defineRequestPrototype defines the methods and properties of the Request
+// objects on their prototype.
+func (mi *FetchModuleInstance) defineRequestPrototype() {
+	rt := mi.vu.Runtime()
+	proto := mi.prototypeOf(mi.requestCtor)
+	this := func(call sobek.FunctionCall) *fetchRequest {
+		return internalOf[*fetchRequest](mi, call.This)
+	}
+
+	mi.defineGetters(proto, map[string]func(sobek.FunctionCall) sobek.Value{
+		"method":   func(call sobek.FunctionCall) sobek.Value { return rt.ToValue(this(call).method) },
+		"url":      func(call sobek.FunctionCall) sobek.Value { return rt.ToValue(this(call).url) },
+		"headers":  func(call sobek.FunctionCall) sobek.Value { return this(call).headersObj },
+		"redirect": func(call sobek.FunctionCall) sobek.Value { return rt.ToValue(this(call).redirect) },
+		"signal":   func(call sobek.FunctionCall) sobek.Value { return this(call).signalObj },
+	})
+	mi.defineBodyMethods(proto)
+	mi.defineMethods(proto, map[string]interface{}{
+		"clone": func(call sobek.FunctionCall) sobek.Value {
+			req := this(call)
+			if req.body.isUnusable() {
+				panic(rt.NewTypeError("can't clone a Request whose body has already been used"))
+			}
+			clone := *req
+			clone.headers = req.headers.clone()
+			clone.body = fetchBody{data: req.body.data}
+			cloneObj := mi.newObjectOf(mi.requestCtor)
+			mi.initRequestObject(cloneObj, &clone)
+			return cloneObj
+		},
+	})
+}
+
+// fetchResponse implements the WHATWG Response interface.
+type fetchResponse struct {
+	typ        string
+	status     int
+	statusText string
+	headers    *fetchHeaders
+	body       fetchBody
+	url        string
+	redirected bool
+
+	headersObj *sobek.Object
+}
+
+// responseInit contains the supported members of the ResponseInit dictionary.
+type responseInit struct {
+	Status     *int        `js:"status"`
+	StatusText string      `js:"statusText"`
+	Headers    sobek.Value `js:"headers"`
+}
+
+// newFetchResponse creates a new response from the arguments of the Response
+// constructor.
+func (mi *FetchModuleInstance) newFetchResponse(body []byte, contentType string, init sobek.Value) *fetchResponse {
+	rt := mi.vu.Runtime()
+	ri := responseInit{}
+	if !common.IsNullish(init) {
+		if err := rt.ExportTo(init, &ri); err != nil {
+			panic(rt.NewTypeError("invalid response init: %s", err))
+		}
+	}
+	res := &fetchResponse{typ: "default", status: 200, headers: &fetchHeaders{}, body: fetchBody{data: body}}
+	if ri.Status != nil {
+		if *ri.Status < 200 || *ri.Status > 599 {
+			panic(newRangeError(rt, fmt.Sprintf("the status %d is outside of the [200, 599] range", *ri.Status)))
+		}
+		res.status = *ri.Status
+	}
+	res.statusText = ri.StatusText
+	mi.fillHeaders(res.headers, ri.Headers)
+
+	if body != nil {
+		switch res.status {
+		case 204, 205, 304:
+			panic(rt.NewTypeError("responses with status %d can't have a body", res.status))
+		}
+		if _, ok := res.headers.get("content-type"); !ok && contentType != "" {
+			res.headers.append(rt, "content-type", contentType)
+		}
+	}
+	return res
+}
+
+// newResponse is the constructor of the Response objects.
+func (mi *FetchModuleInstance) newResponse(call sobek.ConstructorCall) *sobek.Object {
+	body, contentType := mi.extractBody(call.Argument(0))
+	mi.initResponseObject(call.This, mi.newFetchResponse(body, contentType, call.Argument(1)))
+	return nil
+}
+
+// responseJSON implements Response.json().
+func (mi *FetchModuleInstance) responseJSON(data sobek.Value, init sobek.Value) *sobek.Object {
+	rt := mi.vu.Runtime()
+	stringify, _ := sobek.AssertFunction(rt.Get("JSON").ToObject(rt).Get("stringify"))
+	serialized, err := stringify(sobek.Undefined(), data)
+	if err != nil {
+		panic(err)
+	}
+	if sobek.IsUndefined(serialized) {
+		panic(rt.NewTypeError("the data isn't JSON-serializable"))
+	}
+	return mi.responseObject(mi.newFetchResponse([]byte(serialized.String()), "application/json", init))
+}
+
+// responseError implements Response.error().
+func (mi *FetchModuleInstance) responseError() *sobek.Object {
+	return mi.responseObject(&fetchResponse{typ: "error", headers: &fetchHeaders{immutable: true}})
+}
+
+// responseRedirect implements Response.redirect().
+func (mi *FetchModuleInstance) responseRedirect(location string, status sobek.Value) *sobek.Object {
+	rt := mi.vu.Runtime()
+	u, err := url.Parse(location)
+	if err != nil || !u.IsAbs() {
+		panic(rt.NewTypeError("invalid URL '%s'", location))
+	}
+	code := 302
+	if !common.IsNullish(status) {
+		code = int(status.ToInteger())
+	}
+	switch code {
+	case 301, 302, 303, 307, 308:
+	default:
+		panic(newRangeError(rt, fmt.Sprintf("invalid redirect status %d", code)))
+	}
+	headers := &fetchHeaders{list: [][2]string{{"location", u.String()}}, immutable: true}
+	return mi.responseObject(&fetchResponse{typ: "default", status: code, headers: headers})
+}
+
+// responseObject returns a new Response object wrapping the given response.
+func (mi *FetchModuleInstance) responseObject(res *fetchResponse) *sobek.Object {
+	obj := mi.newObjectOf(mi.responseCtor)
+	mi.initResponseObject(obj, res)
+	return obj
+}
+
+func (mi *FetchModuleInstance) initResponseObject(obj *sobek.Object, res *fetchResponse) {
+	mi.setInternal(obj, res)
+	res.headersObj = mi.headersObject(res.headers)
+}
+
+// defineResponsePrototype defines the methods and properties of the Response
+// objects on their prototype.
+func (mi *FetchModuleInstance) defineResponsePrototype() {
+	rt := mi.vu.Runtime()
+	proto := mi.prototypeOf(mi.responseCtor)
+	this := func(call sobek.FunctionCall) *fetchResponse {
+		return internalOf[*fetchResponse](mi, call.This)
+	}
+
+	mi.defineGetters(proto, map[string]func(sobek.FunctionCall) sobek.Value{
+		"type":       func(call sobek.FunctionCall) sobek.Value { return rt.ToValue(this(call).typ) },
+		"url":        func(call sobek.FunctionCall) sobek.Value { return rt.ToValue(this(call).url) },
+		"redirected": func(call sobek.FunctionCall) sobek.Value { return rt.ToValue(this(call).redirected) },
+		"status":     func(call sobek.FunctionCall) sobek.Value { return rt.ToValue(this(call).status) },
+		"ok": func(call sobek.FunctionCall) sobek.Value {
+			status := this(call).status
+			return rt.ToValue(status >= 200 && status < 300)
+		},
+		"statusText": func(call sobek.FunctionCall) sobek.Value { return rt.ToValue(this(call).statusText) },
+		"headers":    func(call sobek.FunctionCall) sobek.Value { return this(call).headersObj },
+	})
+	mi.defineBodyMethods(proto)
+	mi.defineMethods(proto, map[string]interface{}{
+		"clone": func(call sobek.FunctionCall) sobek.Value {
+			res := this(call)
+			if res.body.isUnusable() {
+				panic(rt.NewTypeError("can't clone a Response whose body has already been used"))
+			}
+			clone := *res
+			clone.headers = res.headers.clone()
+			clone.body = fetchBody{data: res.body.data}
+			return mi.responseObject(&clone)
+		},
+	})
+}
@@ -0,0 +1,213 @@
+package http
+
+import (
+	"github.com/grafana/sobek"
+
+	"go.k6.io/k6/js/common"
+)
+
+// abortSignal implements the WHATWG AbortSignal interface. Besides the JS
+// event listeners, Go code can subscribe to it, e.g. to cancel a request.
+type abortSignal struct {
+	obj     *sobek.Object
+	aborted bool
+	reason  sobek.Value
+
+	onabort     sobek.Value
+	listeners   []sobek.Value
+	goListeners map[int]func()
+	nextID      int
+}
+
+// subscribe registers a callback that's called when the signal is aborted and
+// returns a function that unsubscribes it.
+func (s *abortSignal) subscribe(f func()) (unsubscribe func()) {
+	if s.goListeners == nil {
+		s.goListeners = make(map[int]func())
+	}
+	id := s.nextID
+	s.nextID++
+	s.goListeners[id] = f
+	return func() { delete(s.goListeners, id) }
+}
+
+// abort aborts the signal with the given reason, or an AbortError if the
+// reason is undefined, and notifies all of the listeners.
+func (mi *FetchModuleInstance) abort(s *abortSignal, reason sobek.Value) {
+	if s.aborted {
+		return
+	}
+	rt := mi.vu.Runtime()
+	if reason == nil || sobek.IsUndefined(reason) {
+		reason = newDOMException(rt, "AbortError", "The operation was aborted.")
+	}
+	s.aborted = true
+	s.reason = reason
+
+	for _, f := range s.goListeners {
+		f()
+	}
+	s.goListeners = nil
+
+	event := rt.NewObject()
+	mustSet := func(name string, value interface{}) {
+		if err := event.Set(name, value); err != nil {
+			common.Throw(rt, err)
+		}
+	}
+	mustSet("type", "abort")
+	mustSet("target", s.obj)
+
+	listeners := s.listeners
+	if fn, ok := sobek.AssertFunction(s.onabort); ok {
+		if _, err := fn(s.obj, event); err != nil {
+			panic(err)
+		}
+	}
+	for _, listener := range listeners {
+		fn, _ := sobek.AssertFunction(listener)
+		if _, err := fn(s.obj, event); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// toAbortSignal returns the signal wrapped by the given value, if it's an
+// AbortSignal object.
+func (mi *FetchModuleInstance) toAbortSignal(v sobek.Value) *abortSignal {
+	s, _ := mi.getInternal(v).(*abortSignal)
+	return s
+}
+
+// newAbortSignalObject returns a new AbortSignal object.
+func (mi *FetchModuleInstance) newAbortSignalObject() (*sobek.Object, *abortSignal) {
+	s := &abortSignal{reason: sobek.Undefined(), onabort: sobek.Null()}
+	s.obj = mi.newObjectOf(mi.abortSignalCtor)
+	mi.setInternal(s.obj, s)
+	return s.obj, s
+}
+
+// defineAbortSignalPrototype defines the methods and properties of the
+// AbortSignal objects on their prototype.
+func (mi *FetchModuleInstance) defineAbortSignalPrototype() {
+	rt := mi.vu.Runtime()
+	proto := mi.prototypeOf(mi.abortSignalCtor)
+	this := func(call sobek.FunctionCall) *abortSignal {
+		return internalOf[*abortSignal](mi, call.This)
+	}
+
+	mi.defineGetters(proto, map[string]func(sobek.FunctionCall) sobek.Value{
+		"aborted": func(call sobek.FunctionCall) sobek.Value { return rt.ToValue(this(call).aborted) },
+		"reason":  func(call sobek.FunctionCall) sobek.Value { return this(call).reason },
+	})
+	err := proto.DefineAccessorProperty("onabort",
+		rt.ToValue(func(call sobek.FunctionCall) sobek.Value { return this(call).onabort }),
+		rt.ToValue(func(call sobek.FunctionCall) sobek.Value {
+			this(call).onabort = call.Argument(0)
+			return sobek.Undefined()
+		}),
+		sobek.FLAG_FALSE, sobek.FLAG_TRUE)
+	if err != nil {
+		common.Throw(rt, err)
+	}
+	mi.defineMethods(proto, map[string]interface{}{
+		"addEventListener": func(call sobek.FunctionCall) sobek.Value {
+			s, listener := this(call), call.Argument(1)
+			if _, ok := sobek.AssertFunction(listener); !ok || call.Argument(0).String() != "abort" {
+				return sobek.Undefined()
+			}
+			for _, l := range s.listeners {
+				if l.SameAs(listener) {
+					return sobek.Undefined()
+				}
+			}
+			s.listeners = append(s.listeners, listener)
+			return sobek.Undefined()
+		},
+		"removeEventListener": func(call sobek.FunctionCall) sobek.Value {
+			s, listener := this(call), call.Argument(1)
+			for i, l := range s.listeners {
+				if call.Argument(0).String() == "abort" && l.SameAs(listener) {
+					s.listeners = append(s.listeners[:i:i], s.listeners[i+1:]...)
+					break
+				}
+			}
+			return sobek.Undefined()
+		},
+		"throwIfAborted": func(call sobek.FunctionCall) sobek.Value {
+			if s := this(call); s.aborted {
+				panic(s.reason)
+			}
+			return sobek.Undefined()
+		},
+	})
+}
+
+// newAbortController is the constructor of the AbortController objects, which
+// are implemented by the signal they control.
+func (mi *FetchModuleInstance) newAbortController(call sobek.ConstructorCall) *sobek.Object {
+	_, signal := mi.newAbortSignalObject()
+	mi.setInternal(call.This, &abortController{signal: signal})
+	return nil
+}
+
+// abortController implements the WHATWG AbortController interface.
+type abortController struct {
+	signal *abortSignal
+}
+
+// defineAbortControllerPrototype defines the methods and properties of the
+// AbortController objects on their prototype.
+func (mi *FetchModuleInstance) defineAbortControllerPrototype() {
+	proto := mi.prototypeOf(mi.abortControllerCtor)
+	this := func(call sobek.FunctionCall) *abortController {
+		return internalOf[*abortController](mi, call.This)
+	}
+
+	mi.defineGetters(proto, map[string]func(sobek.FunctionCall) sobek.Value{
+		"signal": func(call sobek.FunctionCall) sobek.Value { return this(call).signal.obj },
+	})
+	mi.defineMethods(proto, map[string]interface{}{
+		"abort": func(call sobek.FunctionCall) sobek.Value {
+			mi.abort(this(call).signal, call.Argument(0))
+			return sobek.Undefined()
+		},
+	})
+}
+
+// newAbortSignal is the constructor of AbortSignal, which can't be used
+// directly, the signals are created by AbortController or the static methods.
+func (mi *FetchModuleInstance) newAbortSignal(_ sobek.ConstructorCall) *sobek.Object {
+	panic(mi.vu.Runtime().NewTypeError("Illegal constructor"))
+}
+
+// abortSignalAbort implements AbortSignal.abort(), which returns an already
+// aborted signal.
+func (mi *FetchModuleInstance) abortSignalAbort(reason sobek.Value) *sobek.Object {
+	obj, signal := mi.newAbortSignalObject()
+	mi.abort(signal, reason)
+	return obj
+}
+
+// newDOMException returns an Error object with the given DOMException name.
+func newDOMException(rt *sobek.Runtime, name, message string) *sobek.Object {
+	ctor, _ := sobek.AssertConstructor(rt.Get("Error"))
+	obj, err := ctor(nil, rt.ToValue(message))
+	if err != nil {
+		common.Throw(rt, err)
+	}
+	if err = obj.Set("name", name); err != nil {
+		common.Throw(rt, err)
+	}
+	return obj
+}
+
+// newRangeError returns a RangeError object with the given message.
+func newRangeError(rt *sobek.Runtime, message string) *sobek.Object {
+	ctor, _ := sobek.AssertConstructor(rt.Get("RangeError"))
+	obj, err := ctor(nil, rt.ToValue(message))
+	if err != nil {
+		common.Throw(rt, err)
+	}
+	return obj
+}
@@ -0,0 +1,279 @@
+package http
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/grafana/sobek"
+	"golang.org/x/net/http/httpguts"
+
+	"go.k6.io/k6/js/common"
+)
+
+// fetchHeaders implements the WHATWG Headers interface. The header names are
+// kept lowercased and in insertion order, as the spec requires.
+type fetchHeaders struct {
+	list      [][2]string
+	immutable bool
+}
+
+func normalizeHeaderValue(value string) string {
+	return strings.Trim(value, " \t\r\n")
+}
+
+func (h *fetchHeaders) validate(rt *sobek.Runtime, name, value string) {
+	if h.immutable {
+		panic(rt.NewTypeError("Headers are immutable"))
+	}
+	if !httpguts.ValidHeaderFieldName(name) {
+		panic(rt.NewTypeError("invalid header name '%s'", name))
+	}
+	if !httpguts.ValidHeaderFieldValue(value) {
+		panic(rt.NewTypeError("invalid value for header '%s'", name))
+	}
+}
+
+func (h *fetchHeaders) append(rt *sobek.Runtime, name, value string) {
+	value = normalizeHeaderValue(value)
+	h.validate(rt, name, value)
+	h.list = append(h.list, [2]string{strings.ToLower(name), value})
+}
+
+func (h *fetchHeaders) set(rt *sobek.Runtime, name, value string) {
+	value = normalizeHeaderValue(value)
+	h.validate(rt, name, value)
+	name = strings.ToLower(name)
+	for i, header := range h.list {
+		if header[0] == name {
+			h.list[i][1] = value
+			h.deleteFrom(name, i+1)
+			return
+		}
+	}
+	h.list = append(h.list, [2]string{name, value})
+}
+
+func (h *fetchHeaders) delete(rt *sobek.Runtime, name string) {
+	h.validate(rt, name, "")
+	h.deleteFrom(strings.ToLower(name), 0)
+}
+
+func (h *fetchHeaders) deleteFrom(name string, start int) {
+	list := h.list[:start]
+	for _, header := range h.list[start:] {
+		if header[0] != name {
+			list = append(list, header)
+		}
+	}
+	h.list = list
+}
+
+func (h *fetchHeaders) get(name string) (string, bool) {
+	name = strings.ToLower(name)
+	var values []string
+	for _, header := range h.list {
+		if header[0] == name {
+			values = append(values, header[1])
+		}
+	}
+	return strings.Join(values, ", "), values != nil
+}
+
+func (h *fetchHeaders) getSetCookie() []string {
+	values := []string{}
+	for _, header := range h.list {
+		if header[0] == "set-cookie" {
+			values = append(values, header[1])
+		}
+	}
+	return values
+}
+
+// sorted returns the headers sorted by name and combined, except for the
+// Set-Cookie ones, as they should be iterated over.
+func (h *fetchHeaders) sorted() [][2]string {
+	names := make([]string, 0, len(h.list))
+	seen := make(map[string]bool, len(h.list))
+	for _, header := range h.list {
+		if !seen[header[0]] {
+			seen[header[0]] = true
+			names = append(names, header[0])
+		}
+	}
+	sort.Strings(names)
+
+	result := make([][2]string, 0, len(names))
+	for _, name := range names {
+		if name == "set-cookie" {
+			for _, value := range h.getSetCookie() {
+				result = append(result, [2]string{name, value})
+			}
+			continue
+		}
+		value, _ := h.get(name)
+		result = append(result, [2]string{name, value})
+	}
+	return result
+}
+
+func (h *fetchHeaders) clone() *fetchHeaders {
+	return &fetchHeaders{list: append([][2]string{}, h.list...), immutable: h.immutable}
+}
+
+// toHTTPHeader returns the headers in the format used by net/http.
+func (h *fetchHeaders) toHTTPHeader() http.Header {
+	header := make(http.Header, len(h.list))
+	for _, kv := range h.list {
+		header.Add(kv[0], kv[1])
+	}
+	return header
+}
+
+// fill adds the headers from the given init value, which can be another
+// Headers object, an array of name-value pairs or a record.
+func (mi *FetchModuleInstance) fillHeaders(h *fetchHeaders, init sobek.Value) {
+	rt := mi.vu.Runtime()
+	if common.IsNullish(init) {
+		return
+	}
+	if other := mi.toHeaders(init); other != nil {
+		for _, header := range other.list {
+			h.append(rt, header[0], header[1])
+		}
+		return
+	}
+
+	obj := init.ToObject(rt)
+	if obj.ClassName() == "Array" {
+		var pairs [][]string
+		if err := rt.ExportTo(init, &pairs); err != nil {
+			panic(rt.NewTypeError("invalid headers: %s", err))
+		}
+		for _, pair := range pairs {
+			if len(pair) != 2 {
+				panic(rt.NewTypeError("header pairs need to contain exactly two elements"))
+			}
+			h.append(rt, pair[0], pair[1])
+		}
+		return
+	}
+	for _, key := range obj.Keys() {
+		h.append(rt, key, obj.Get(key).String())
+	}
+}
+
+// newHeaders is the constructor of the Headers objects.
+func (mi *FetchModuleInstance) newHeaders(call sobek.ConstructorCall) *sobek.Object {
+	h := &fetchHeaders{}
+	mi.fillHeaders(h, call.Argument(0))
+	mi.initHeadersObject(call.This, h)
+	return nil
+}
+
+// headersObject returns a new Headers object wrapping the given headers.
+func (mi *FetchModuleInstance) headersObject(h *fetchHeaders) *sobek.Object {
+	obj := mi.newObjectOf(mi.headersCtor)
+	mi.initHeadersObject(obj, h)
+	return obj
+}
+
+// toHeaders returns the headers wrapped by the given value, if it's a Headers
+// object.
+func (mi *FetchModuleInstance) toHeaders(v sobek.Value) *fetchHeaders {
+	h, _ := mi.getInternal(v).(*fetchHeaders)
+	return h
+}
+
+func (mi *FetchModuleInstance) initHeadersObject(obj *sobek.Object, h *fetchHeaders) {
+	mi.setInternal(obj, h)
+}
+
+// defineHeadersPrototype defines the methods of the Headers objects on their
+// prototype.
+func (mi *FetchModuleInstance) defineHeadersPrototype() {
+	rt := mi.vu.Runtime()
+	proto := mi.prototypeOf(mi.headersCtor)
+	this := func(call sobek.FunctionCall) *fetchHeaders {
+		return internalOf[*fetchHeaders](mi, call.This)
+	}
+
+	iterator := func(kind string) func(sobek.FunctionCall) sobek.Value {
+		return func(call sobek.FunctionCall) sobek.Value {
+			sorted := this(call).sorted()
+			items := make([]interface{}, len(sorted))
+			for i, header := range sorted {
+				switch kind {
+				case "keys":
+					items[i] = header[0]
+				case "values":
+					items[i] = header[1]
+				default:
+					items[i] = rt.NewArray(header[0], header[1])
+				}
+			}
+			return arrayIterator(rt, items)
+		}
+	}
+
+	mi.defineMethods(proto, map[string]interface{}{
+		"append": func(call sobek.FunctionCall) sobek.Value {
+			this(call).append(rt, call.Argument(0).String(), call.Argument(1).String())
+			return sobek.Undefined()
+		},
+		"set": func(call sobek.FunctionCall) sobek.Value {
+			this(call).set(rt, call.Argument(0).String(), call.Argument(1).String())
+			return sobek.Undefined()
+		},
+		"delete": func(call sobek.FunctionCall) sobek.Value {
+			this(call).delete(rt, call.Argument(0).String())
+			return sobek.Undefined()
+		},
+		"get": func(call sobek.FunctionCall) sobek.Value {
+			if value, ok := this(call).get(call.Argument(0).String()); ok {
+				return rt.ToValue(value)
+			}
+			return sobek.Null()
+		},
+		"has": func(call sobek.FunctionCall) sobek.Value {
+			_, ok := this(call).get(call.Argument(0).String())
+			return rt.ToValue(ok)
+		},
+		"getSetCookie": func(call sobek.FunctionCall) sobek.Value {
+			return rt.ToValue(this(call).getSetCookie())
+		},
+		"forEach": func(call sobek.FunctionCall) sobek.Value {
+			h := this(call)
+			callback, ok := sobek.AssertFunction(call.Argument(0))
+			if !ok {
+				panic(rt.NewTypeError("the callback needs to be a function"))
+			}
+			for _, header := range h.sorted() {
+				_, err := callback(call.Argument(1), rt.ToValue(header[1]), rt.ToValue(header[0]), call.This)
+				if err != nil {
+					panic(err)
+				}
+			}
+			return sobek.Undefined()
+		},
+		"entries": iterator("entries"),
+		"keys":    iterator("keys"),
+		"values":  iterator("values"),
+	})
+	if err := proto.DefineDataPropertySymbol(
+		sobek.SymIterator, rt.ToValue(iterator("entries")), sobek.FLAG_TRUE, sobek.FLAG_FALSE, sobek.FLAG_TRUE,
+	); err != nil {
+		common.Throw(rt, err)
+	}
+}
+
+// arrayIterator returns an iterator over the given items.
+func arrayIterator(rt *sobek.Runtime, items []interface{}) sobek.Value {
+	arr := rt.NewArray(items...)
+	values, _ := sobek.AssertFunction(arr.Get("values"))
+	it, err := values(arr)
+	if err != nil {
+		common.Throw(rt, err)
+	}
+	return it
+}
@@ -0,0 +1,296 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.k6.io/k6/metrics"
+)
+
+func newFetchTestCase(t *testing.T) *httpTestCase {
+	ts := newTestCase(t)
+	rt := ts.runtime.VU.Runtime()
+	exports := NewFetch().NewModuleInstance(ts.runtime.VU).Exports().Default
+	for _, name := range []string{"fetch", "Headers", "Request", "Response", "AbortController", "AbortSignal"} {
+		require.NoError(t, rt.Set(name, rt.ToValue(exports).ToObject(rt).Get(name)))
+	}
+	return ts
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GET", func(t *testing.T) {
+		t.Parallel()
+		ts := newFetchTestCase(t)
+		sr := ts.tb.Replacer.Replace
+
+		_, err := ts.runtime.RunOnEventLoop(wrapInAsyncLambda(sr(`
+			var res = await fetch("HTTPBIN_URL/get", { headers: { "X-Test": "a" } });
+			if (!res.ok || res.status !== 200 || res.statusText !== "OK") {
+				throw new Error("unexpected status: " + res.status + " " + res.statusText);
+			}
+			if (!res.headers.get("content-type").startsWith("application/json")) {
+				throw new Error("unexpected content type: " + res.headers.get("content-type"));
+			}
+			var body = await res.json();
+			if (body.headers["X-Test"][0] !== "a" || body.headers["User-Agent"][0] !== "TestUserAgent") {
+				throw new Error("unexpected request headers: " + JSON.stringify(body.headers));
+			}
+			if (!res.bodyUsed) { throw new Error("the body should've been used"); }
+			try {
+				await res.text();
+				throw new Error("reading the body twice should fail");
+			} catch (e) {
+				if (!(e instanceof TypeError)) { throw e; }
+			}
+		`)))
+		require.NoError(t, err)
+
+		var found bool
+		for _, sc := range metrics.GetBufferedSamples(ts.samples) {
+			for _, s := range sc.GetSamples() {
+				if s.Metric.Name == metrics.HTTPReqsName {
+					found = true
+					assert.Equal(t, sr("HTTPBIN_URL/get"), s.Tags.Map()["url"])
+				}
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("POST", func(t *testing.T) {
+		t.Parallel()
+		ts := newFetchTestCase(t)
+		sr := ts.tb.Replacer.Replace
+
+		_, err := ts.runtime.RunOnEventLoop(wrapInAsyncLambda(sr(`
+			var req = new Request("HTTPBIN_URL/post", { method: "post", body: new Uint8Array([104, 105]) });
+			var res = await fetch(req, { headers: { "Content-Type": "application/octet-stream" } });
+			var body = await res.json();
+			if (body.data !== "hi") {
+				throw new Error("unexpected body: " + JSON.stringify(body));
+			}
+			res = await fetch("HTTPBIN_URL/post", { method: "POST", body: "text" });
+			body = await res.json();
+			if (body.data !== "text" || body.headers["Content-Type"][0] !== "text/plain;charset=UTF-8") {
+				throw new Error("unexpected body: " + JSON.stringify(body));
+			}
+		`)))
+		require.NoError(t, err)
+	})
+
+	t.Run("Redirects", func(t *testing.T) {
+		t.Parallel()
+		ts := newFetchTestCase(t)
+		sr := ts.tb.Replacer.Replace
+
+		_, err := ts.runtime.RunOnEventLoop(wrapInAsyncLambda(sr(`
+			var res = await fetch("HTTPBIN_URL/redirect/1");
+			if (!res.redirected || res.url !== "HTTPBIN_URL/get") {
+				throw new Error("unexpected redirect: " + res.url);
+			}
+			res = await fetch("HTTPBIN_URL/redirect/1", { redirect: "manual" });
+			if (res.redirected || res.status !== 302) {
+				throw new Error("unexpected status: " + res.status);
+			}
+			try {
+				await fetch("HTTPBIN_URL/redirect/1", { redirect: "error" });
+				throw new Error("the redirect should've failed");
+			} catch (e) {
+				if (!(e instanceof TypeError)) { throw e; }
+			}
+		`)))
+		require.NoError(t, err)
+	})
+
+	t.Run("Abort", func(t *testing.T) {
+		t.Parallel()
+		ts := newFetchTestCase(t)
+		sr := ts.tb.Replacer.Replace
+		ts.tb.Mux.HandleFunc("/fetch-slow", func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(10 * time.Second):
+			case <-r.Context().Done():
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+
+		_, err := ts.runtime.RunOnEventLoop(wrapInAsyncLambda(sr(`
+			var controller = new AbortController();
+			var events = 0;
+			controller.signal.addEventListener("abort", () => events++);
+			var p = fetch("HTTPBIN_URL/fetch-slow", { signal: controller.signal });
+			controller.abort();
+			try {
+				await p;
+				throw new Error("the request should've been aborted");
+			} catch (e) {
+				if (e.name !== "AbortError" || events !== 1) { throw e; }
+			}
+			try {
+				await fetch("HTTPBIN_URL/get", { signal: AbortSignal.abort("reason") });
+				throw new Error("the request should've been aborted");
+			} catch (e) {
+				if (e !== "reason") { throw e; }
+			}
+		`)))
+		require.NoError(t, err)
+	})
+
+	t.Run("MultiValueHeaders", func(t *testing.T) {
+		t.Parallel()
+		ts := newFetchTestCase(t)
+		sr := ts.tb.Replacer.Replace
+		ts.tb.Mux.HandleFunc("/fetch-cookies", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Add("Set-Cookie", "a=1")
+			w.Header().Add("Set-Cookie", "b=2")
+			w.WriteHeader(http.StatusOK)
+		})
+
+		_, err := ts.runtime.RunOnEventLoop(wrapInAsyncLambda(sr(`
+			var res = await fetch("HTTPBIN_URL/fetch-cookies");
+			var cookies = JSON.stringify(res.headers.getSetCookie());
+			if (cookies !== '["a=1","b=2"]') {
+				throw new Error("unexpected cookies: " + cookies);
+			}
+		`)))
+		require.NoError(t, err)
+	})
+
+	t.Run("InitContext", func(t *testing.T) {
+		t.Parallel()
+		runtime, _ := getTestModuleInstance(t)
+		rt := runtime.VU.Runtime()
+		exports := NewFetch().NewModuleInstance(runtime.VU).Exports().Default
+		require.NoError(t, rt.Set("fetch", rt.ToValue(exports).ToObject(rt).Get("fetch")))
+
+		_, err := rt.RunString(`fetch("https://example.com")`)
+		require.ErrorContains(t, err, ErrHTTPForbiddenInInitContext.Error())
+	})
+}
+
+func TestFetchObjects(t *testing.T) {
+	t.Parallel()
+
+	runtime, _ := getTestModuleInstance(t)
+	rt := runtime.VU.Runtime()
+	exports := NewFetch().NewModuleInstance(runtime.VU).Exports().Default
+	for _, name := range []string{"Headers", "Request", "Response", "AbortController", "AbortSignal"} {
+		require.NoError(t, rt.Set(name, rt.ToValue(exports).ToObject(rt).Get(name)))
+	}
+
+	_, err := runtime.RunOnEventLoop(wrapInAsyncLambda(`
+		var h = new Headers([["B", "1"], ["a", "2"], ["set-cookie", "x=1"], ["Set-Cookie", "y=2"]]);
+		h.append("b", "3");
+		var entries = JSON.stringify([...h]);
+		if (entries !== '[["a","2"],["b","1, 3"],["set-cookie","x=1"],["set-cookie","y=2"]]') {
+			throw new Error("unexpected entries: " + entries);
+		}
+		if (!(h instanceof Headers) || h.get("missing") !== null || h.getSetCookie().length !== 2) {
+			throw new Error("unexpected headers");
+		}
+
+		var res = Response.json({ a: 1 }, { status: 201, headers: { "X-A": "b" } });
+		if (!(res instanceof Response) || res.status !== 201 || res.headers.get("content-type") !== "application/json") {
+			throw new Error("unexpected response");
+		}
+		var clone = res.clone();
+		if ((await res.json()).a !== 1 || await clone.text() !== '{"a":1}') {
+			throw new Error("unexpected response body");
+		}
+		if (Response.redirect("https://example.com/", 301).headers.get("location") !== "https://example.com/") {
+			throw new Error("unexpected redirect");
+		}
+		try {
+			Response.redirect("https://example.com/").headers.set("a", "b");
+			throw new Error("the headers should be immutable");
+		} catch (e) {
+			if (!(e instanceof TypeError)) { throw e; }
+		}
+
+		var streamed = new Response("abc");
+		if (streamed.body !== streamed.body || streamed.bodyUsed) {
+			throw new Error("the body stream should be created once, without using the body");
+		}
+		var reader = streamed.body.getReader();
+		try {
+			await streamed.text();
+			throw new Error("reading a locked body should fail");
+		} catch (e) {
+			if (!(e instanceof TypeError)) { throw e; }
+		}
+		var chunk = await reader.read();
+		if (chunk.done || !streamed.bodyUsed) {
+			throw new Error("reading the body stream should use the body");
+		}
+
+		var req = new Request("https://example.com/", { method: "put", body: "a" });
+		if (req.method !== "PUT" || req.headers.get("content-type") !== "text/plain;charset=UTF-8" || req.signal.aborted) {
+			throw new Error("unexpected request");
+		}
+		var bytes = await req.clone().bytes();
+		if (!(bytes instanceof Uint8Array) || bytes[0] !== 97) {
+			throw new Error("unexpected request body");
+		}
+	`))
+	require.NoError(t, err)
+
+	cases := map[string]string{
+		"GET with body":       `new Request("https://example.com/", { body: "a" })`,
+		"forbidden method":    `new Request("https://example.com/", { method: "connect" })`,
+		"relative URL":        `new Request("/path")`,
+		"invalid header name": `new Headers({ "a b": "c" })`,
+		"null body status":    `new Response("a", { status: 204 })`,
+		"signal constructor":  `new AbortSignal()`,
+	}
+	for name, code := range cases {
+		_, err := rt.RunString(code)
+		assert.ErrorContains(t, err, "TypeError", name)
+	}
+	_, err = rt.RunString(`new Response(null, { status: 100 })`)
+	assert.ErrorContains(t, err, "RangeError: the status 100 is outside of the [200, 599] range")
+	_, err = rt.RunString(`Response.redirect("https://example.com/", 200)`)
+	assert.ErrorContains(t, err, "RangeError: invalid redirect status 200")
+}
+
+func TestFetchPrototypes(t *testing.T) {
+	t.Parallel()
+
+	runtime, _ := getTestModuleInstance(t)
+	rt := runtime.VU.Runtime()
+	exports := NewFetch().NewModuleInstance(runtime.VU).Exports().Default
+	for _, name := range []string{"Headers", "Request", "Response", "AbortController", "AbortSignal"} {
+		require.NoError(t, rt.Set(name, rt.ToValue(exports).ToObject(rt).Get(name)))
+	}
+
+	_, err := rt.RunString(`
+		var a = new Response("a"), b = new Response("b");
+		if (a.text !== b.text || Object.keys(a).length !== 0 || !Response.prototype.hasOwnProperty("text")) {
+			throw new Error("the methods should be defined on the prototype");
+		}
+		var h = new Headers({ a: "1" });
+		if (h.get !== Headers.prototype.get || [...h].length !== 1) {
+			throw new Error("the Headers methods should be defined on the prototype");
+		}
+		var controller = new AbortController();
+		if (!("signal" in AbortController.prototype) || controller.signal !== controller.signal) {
+			throw new Error("the signal should be a prototype getter returning the same object");
+		}
+	`)
+	require.NoError(t, err)
+
+	cases := map[string]string{
+		"method on another object": `Response.prototype.text.call({})`,
+		"getter on the prototype":  `Response.prototype.status`,
+		"headers of a request":     `Headers.prototype.get.call(new Request("https://example.com/"), "a")`,
+	}
+	for name, code := range cases {
+		_, err := rt.RunString(code)
+		assert.ErrorContains(t, err, "TypeError: Illegal invocation", name)
+	}
+}
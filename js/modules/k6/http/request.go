@@ -2,6 +2,7 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"mime/multipart"
@@ -16,6 +17,7 @@ import (
 	"gopkg.in/guregu/null.v3"
 
 	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/lib"
 	"go.k6.io/k6/lib/netext/httpext"
 	"go.k6.io/k6/lib/types"
 )
@@ -104,27 +106,38 @@ func (c *Client) asyncRequest(method string, url sobek.Value, args ...sobek.Valu
 		return p, nil
 	}
 
+	c.makeRequestAsync(c.moduleInstance.vu.Context(), state, req, func(resp *httpext.Response, err error) {
+		if err != nil {
+			reject(err)
+			return
+		}
+		c.processResponse(resp, req.ResponseType)
+		resolve(c.responseFromHTTPext(resp))
+	})
+
+	return p, nil
+}
+
+// makeRequestAsync makes the given request off the event loop, and then calls
+// done with its result back on it.
+func (c *Client) makeRequestAsync(
+	ctx context.Context, state *lib.State, req *httpext.ParsedHTTPRequest,
+	done func(*httpext.Response, error),
+) {
 	callback := c.moduleInstance.vu.RegisterCallback()
 
 	go func() {
-		resp, err := httpext.MakeRequest(c.moduleInstance.vu.Context(), state, req)
+		resp, err := httpext.MakeRequest(ctx, state, req)
 		callback(func() error {
-			if err != nil {
-				reject(err)
-				return nil //nolint:nilerr // we want to reject the promise in this case
-			}
-			c.processResponse(resp, req.ResponseType)
-			resolve(c.responseFromHTTPext(resp))
+			done(resp, err)
 			return nil
 		})
 	}()
-
-	return p, nil
 }
 
-// processResponse stores the body as an ArrayBuffer if indicated by
-// respType. This is done here instead of in httpext.readResponseBody to avoid
-// a reverse dependency on js/common or sobek.
+// processResponse stores the body as an ArrayBuffer or a ReadableStream if
+// indicated by respType. This is done here instead of in
+// httpext.readResponseBody to avoid a reverse dependency on js/common or sobek.
 func (c *Client) processResponse(resp *httpext.Response, respType httpext.ResponseType) {
 	if respType == httpext.ResponseTypeBinary && resp.Body != nil {
 		b, ok := resp.Body.([]byte)
@@ -133,6 +146,11 @@ func (c *Client) processResponse(resp *httpext.Response, respType httpext.Respon
 		}
 		resp.Body = c.moduleInstance.vu.Runtime().NewArrayBuffer(b)
 	}
+	if respType == httpext.ResponseTypeStream && resp.BodyStream != nil {
+		stream := &responseStream{ReadCloser: resp.BodyStream}
+		resp.BodyStream = stream
+		resp.Body = c.newReadableBodyStream(stream)
+	}
 }
 
 func (c *Client) responseFromHTTPext(resp *httpext.Response) *Response {
@@ -361,6 +379,24 @@ func (c *Client) parseRequest(
 				}
 			case "redirects":
 				result.Redirects = null.IntFrom(params.Get(k).ToInteger())
+			case "network":
+				if common.IsNullish(params.Get(k)) {
+					continue
+				}
+				nc, err := types.NetworkConditionsFromValue(params.Get(k).Export())
+				if err != nil {
+					return nil, err
+				}
+				result.NetworkConditions = nc
+			case "proxy":
+				if common.IsNullish(params.Get(k)) {
+					continue
+				}
+				proxy, err := types.ProxyFromValue(params.Get(k).Export())
+				if err != nil {
+					return nil, err
+				}
+				result.Proxy = proxy
 			case "tags":
 				if err := common.ApplyCustomUserTags(rt, &result.TagsAndMeta, params.Get(k)); err != nil {
 					return nil, fmt.Errorf("invalid HTTP request metric tags: %w", err)
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v3"
+
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/lib/types"
+	"go.k6.io/k6/metrics"
+)
+
+func TestRequestHostLimits(t *testing.T) {
+	t.Parallel()
+	ts := newTestCase(t)
+
+	var inFlight, maxInFlight int64
+	ts.tb.Mux.HandleFunc("/limited", func(w http.ResponseWriter, _ *http.Request) {
+		current := atomic.AddInt64(&inFlight, 1)
+		defer atomic.AddInt64(&inFlight, -1)
+		for {
+			prev := atomic.LoadInt64(&maxInFlight)
+			if current <= prev || atomic.CompareAndSwapInt64(&maxInFlight, prev, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	limits, err := types.NewNullHostLimits(map[string]types.HostLimit{
+		ts.tb.Replacer.Replace("HTTPBIN_DOMAIN"): {MaxConcurrent: null.IntFrom(2)},
+		"*.example.com":                          {RPS: null.IntFrom(1)},
+	})
+	require.NoError(t, err)
+	ts.runtime.VU.State().HostLimiter = lib.NewHostLimiter(limits)
+
+	_, err = ts.runtime.VU.Runtime().RunString(ts.tb.Replacer.Replace(`
+		var url = "HTTPBIN_URL/limited";
+		var responses = http.batch([url, url, url, url, url, url]);
+		responses.forEach(function(res) {
+			if (res.status !== 200) {
+				throw new Error("unexpected status " + res.status);
+			}
+		});
+		http.get("HTTPBIN_IP_URL/get");
+	`))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), atomic.LoadInt64(&maxInFlight))
+
+	var limited int
+	for _, sample := range metrics.GetBufferedSamples(ts.samples) {
+		for _, s := range sample.GetSamples() {
+			if s.Metric.Name != metrics.HTTPReqLimitedDurationName {
+				continue
+			}
+			limited++
+			host, _ := s.Tags.Get("host")
+			assert.Equal(t, ts.tb.Replacer.Replace("HTTPBIN_DOMAIN"), host)
+		}
+	}
+	assert.Equal(t, 6, limited)
+}
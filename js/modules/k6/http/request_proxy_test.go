@@ -0,0 +1,114 @@
+package http
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.k6.io/k6/lib/netext"
+	"go.k6.io/k6/lib/types"
+)
+
+// newForwardProxy returns a plain HTTP forward proxy for the test case, which
+// records the requests it forwards and their Proxy-Authorization header. It
+// also makes the transport of the test case use the proxy from the context.
+func newForwardProxy(t *testing.T, ts *httpTestCase) (*httptest.Server, func() []string) {
+	forward := ts.tb.HTTPTransport.Clone()
+	ts.tb.HTTPTransport.Proxy = netext.ProxyFromContext
+
+	var mx sync.Mutex
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mx.Lock()
+		requests = append(requests, r.URL.Path+" "+r.Header.Get("Proxy-Authorization"))
+		mx.Unlock()
+
+		outReq, err := http.NewRequestWithContext(r.Context(), r.Method, r.URL.String(), r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		resp, err := forward.RoundTrip(outReq)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer func() { _ = resp.Body.Close() }()
+		w.Header().Set("X-Proxied", "true")
+		w.WriteHeader(resp.StatusCode)
+		_, _ = io.Copy(w, resp.Body)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() []string {
+		mx.Lock()
+		defer mx.Unlock()
+		return append([]string{}, requests...)
+	}
+}
+
+func TestRequestProxy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Param", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestCase(t)
+		proxy, requests := newForwardProxy(t, ts)
+		require.NoError(t, ts.runtime.VU.Runtime().Set("proxyURL", proxy.URL))
+
+		_, err := ts.runtime.VU.Runtime().RunString(ts.tb.Replacer.Replace(`
+			var res = http.get("HTTPBIN_URL/get", {
+				proxy: { url: proxyURL, username: "user", password: "pass" },
+			});
+			if (res.headers["X-Proxied"] !== "true") {
+				throw new Error("the request wasn't proxied");
+			}
+			res = http.get("HTTPBIN_URL/headers");
+			if (res.headers["X-Proxied"] !== undefined) {
+				throw new Error("the request without a proxy was proxied");
+			}
+		`))
+		require.NoError(t, err)
+		auth := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:pass"))
+		assert.Equal(t, []string{"/get " + auth}, requests())
+	})
+
+	t.Run("State", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestCase(t)
+		proxy, requests := newForwardProxy(t, ts)
+		state := ts.runtime.VU.State()
+		var err error
+		state.Proxy, err = types.NewProxy(proxy.URL, []string{"*.example.com"})
+		require.NoError(t, err)
+
+		_, err = ts.runtime.VU.Runtime().RunString(ts.tb.Replacer.Replace(`
+			var res = http.get("HTTPBIN_URL/get");
+			if (res.headers["X-Proxied"] !== "true") {
+				throw new Error("the request wasn't proxied");
+			}
+			res = http.get("HTTPBIN_URL/headers", { proxy: "" });
+			if (res.headers["X-Proxied"] !== undefined) {
+				throw new Error("the request without a proxy was proxied");
+			}
+		`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/get "}, requests())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestCase(t)
+
+		_, err := ts.runtime.VU.Runtime().RunString(`
+			http.get("http://example.com", { proxy: "ftp://proxy:21" });
+		`)
+		assert.ErrorContains(t, err, "unsupported proxy scheme 'ftp'")
+	})
+}
@@ -13,6 +13,7 @@ import (
 
 	"go.k6.io/k6/js/common"
 	"go.k6.io/k6/js/modules/k6/html"
+	"go.k6.io/k6/js/modules/k6/internal/jsonschema"
 	"go.k6.io/k6/lib/netext/httpext"
 )
 
@@ -71,43 +72,98 @@ func (res *Response) JSON(selector ...string) sobek.Value {
 		common.Throw(rt, err)
 	}
 
-	hasSelector := len(selector) > 0
-	if res.cachedJSON == nil || hasSelector { //nolint:nestif
-		var v interface{}
-
+	if len(selector) > 0 {
 		body, err := common.ToBytes(res.Body)
 		if err != nil {
 			common.Throw(rt, err)
 		}
 
-		if hasSelector {
-			if !res.validatedJSON {
-				if !gjson.ValidBytes(body) {
-					return sobek.Undefined()
-				}
-				res.validatedJSON = true
+		if !res.validatedJSON {
+			if !gjson.ValidBytes(body) {
+				return sobek.Undefined()
 			}
+			res.validatedJSON = true
+		}
 
-			result := gjson.GetBytes(body, selector[0])
+		result := gjson.GetBytes(body, selector[0])
 
-			if !result.Exists() {
-				return sobek.Undefined()
-			}
-			return rt.ToValue(result.Value())
+		if !result.Exists() {
+			return sobek.Undefined()
 		}
+		return rt.ToValue(result.Value())
+	}
 
-		if err := json.Unmarshal(body, &v); err != nil {
-			var syntaxError *json.SyntaxError
-			if errors.As(err, &syntaxError) {
-				err = checkErrorInJSON(body, int(syntaxError.Offset), err)
-			}
+	v, err := res.parsedJSON()
+	if err != nil {
+		common.Throw(rt, err)
+	}
+
+	return rt.ToValue(v)
+}
+
+// parsedJSON returns the body parsed as JSON, which is cached so that it's
+// parsed only once.
+func (res *Response) parsedJSON() (interface{}, error) {
+	if res.cachedJSON != nil {
+		return res.cachedJSON, nil
+	}
+
+	body, err := common.ToBytes(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		var syntaxError *json.SyntaxError
+		if errors.As(err, &syntaxError) {
+			err = checkErrorInJSON(body, int(syntaxError.Offset), err)
+		}
+		return nil, err
+	}
+	res.validatedJSON = true
+	res.cachedJSON = v
+
+	return v, nil
+}
+
+// ValidateJSON validates the body parsed as JSON against the schema, which is
+// either a validator compiled in the init context with the
+// k6/experimental/jsonschema module, or a schema which is then compiled on each
+// call. The parsed body is shared with [Response.JSON], and a body which isn't
+// JSON is reported as invalid.
+func (res *Response) ValidateJSON(schema sobek.Value) *jsonschema.Result {
+	mi := res.client.moduleInstance
+	rt := mi.vu.Runtime()
+
+	if common.IsNullish(schema) {
+		common.Throw(rt, errors.New("validateJSON() requires a schema"))
+	}
+
+	validator, ok := schema.Export().(*jsonschema.Validator)
+	if !ok {
+		var err error
+		validator, err = jsonschema.NewValidator(mi.vu, schema.Export())
+		if err != nil {
 			common.Throw(rt, err)
 		}
-		res.validatedJSON = true
-		res.cachedJSON = v
 	}
 
-	return rt.ToValue(res.cachedJSON)
+	if res.Body == nil {
+		result := jsonschema.NewResult(errors.New("the body is null" +
+			" - this likely was because of a request error getting the response"))
+		validator.Report(result)
+		return result
+	}
+
+	v, err := res.parsedJSON()
+	if err != nil {
+		result := jsonschema.NewResult(err)
+		validator.Report(result)
+		return result
+	}
+
+	return validator.ValidateValue(v)
 }
 
 func checkErrorInJSON(input []byte, offset int, err error) error {
@@ -0,0 +1,244 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/grafana/sobek"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/js/modules/k6/experimental/streams"
+)
+
+// streamChunkSize is the maximum size of the chunks read from streamed
+// response bodies.
+const streamChunkSize = 64 * 1024
+
+// responseStream wraps the body of a response with the stream responseType,
+// which can be consumed only once, either through its ReadableStream or with
+// the Response.consumeBody() helper.
+type responseStream struct {
+	io.ReadCloser
+	consumer string
+}
+
+func (s *responseStream) claim(consumer string) error {
+	if s.consumer != "" && s.consumer != consumer {
+		return fmt.Errorf("the response body stream is already consumed by %s", s.consumer)
+	}
+	s.consumer = consumer
+	return nil
+}
+
+// newReadableBodyStream returns a ReadableStream of Uint8Array chunks, which
+// reads the response body off the event loop, only when data is requested.
+func (c *Client) newReadableBodyStream(stream *responseStream) *sobek.Object {
+	vu := c.moduleInstance.vu
+	rt := vu.Runtime()
+
+	source := rt.NewObject()
+	mustSet := func(name string, value interface{}) {
+		if err := source.Set(name, value); err != nil {
+			common.Throw(rt, err)
+		}
+	}
+	mustSet("pull", func(controller *sobek.Object) *sobek.Promise {
+		p, resolve, reject := rt.NewPromise()
+		if err := stream.claim("its ReadableStream"); err != nil {
+			reject(rt.NewTypeError("%s", err))
+			return p
+		}
+
+		callback := vu.RegisterCallback()
+		go func() {
+			buf := make([]byte, streamChunkSize)
+			n, err := stream.Read(buf)
+			callback(func() error {
+				if n > 0 {
+					if _, enqueueErr := callMethod(controller, "enqueue", newUint8Array(rt, buf[:n])); enqueueErr != nil {
+						reject(enqueueErr)
+						return nil
+					}
+				}
+				switch {
+				case err == nil:
+				case errors.Is(err, io.EOF):
+					if _, closeErr := callMethod(controller, "close"); closeErr != nil {
+						reject(closeErr)
+						return nil
+					}
+				default:
+					reject(err)
+					return nil
+				}
+				resolve(sobek.Undefined())
+				return nil
+			})
+		}()
+		return p
+	})
+	mustSet("cancel", func(sobek.Value) error {
+		return stream.Close()
+	})
+
+	strategy := rt.NewObject()
+	// nothing is read in advance, so consumeBody() can still be used
+	if err := strategy.Set("highWaterMark", 0); err != nil {
+		common.Throw(rt, err)
+	}
+	return streams.NewReadableStreamFromSource(vu, source, strategy)
+}
+
+func callMethod(obj *sobek.Object, name string, args ...sobek.Value) (sobek.Value, error) {
+	method, ok := sobek.AssertFunction(obj.Get(name))
+	if !ok {
+		return nil, fmt.Errorf("%s is not a function", name)
+	}
+	return method(obj, args...)
+}
+
+func newUint8Array(rt *sobek.Runtime, data []byte) sobek.Value {
+	ctor, _ := sobek.AssertConstructor(rt.Get("Uint8Array"))
+	arr, err := ctor(nil, rt.ToValue(rt.NewArrayBuffer(append([]byte{}, data...))))
+	if err != nil {
+		common.Throw(rt, err)
+	}
+	return arr
+}
+
+// ConsumeBody reads the whole body of a response with the stream
+// responseType, without buffering it in memory. Each chunk can be passed to
+// the update() method of a hasher, e.g. from crypto.createHash(), and each
+// line to an onLine callback, which can return false to stop reading. The body
+// is read off the event loop, and the returned promise resolves to the number
+// of bytes and lines that were read.
+func (res *Response) ConsumeBody(options sobek.Value) (*sobek.Promise, error) {
+	vu := res.client.moduleInstance.vu
+	rt := vu.Runtime()
+	stream, ok := res.BodyStream.(*responseStream)
+	if !ok {
+		return nil, errors.New(`consumeBody() can only be used with the "stream" responseType`)
+	}
+	if err := stream.claim("consumeBody()"); err != nil {
+		return nil, err
+	}
+
+	c := &bodyConsumer{rt: rt}
+	if !common.IsNullish(options) {
+		opts := options.ToObject(rt)
+		if v := opts.Get("hasher"); !common.IsNullish(v) {
+			c.hasher = v.ToObject(rt)
+		}
+		if v := opts.Get("onLine"); !common.IsNullish(v) {
+			if c.onLine, ok = sobek.AssertFunction(v); !ok {
+				return nil, errors.New("onLine needs to be a function")
+			}
+		}
+	}
+
+	p, resolve, reject := rt.NewPromise()
+	var readNext func()
+	readNext = func() {
+		callback := vu.RegisterCallback()
+		go func() {
+			buf := make([]byte, streamChunkSize)
+			n, readErr := stream.Read(buf)
+			callback(func() error {
+				err := c.process(buf[:n])
+				switch {
+				case err != nil:
+				case errors.Is(readErr, io.EOF):
+					err = c.flush()
+				case readErr != nil:
+					err = readErr
+				case !c.stopped:
+					readNext()
+					return nil
+				}
+
+				closeErr := stream.Close()
+				if err == nil {
+					err = closeErr
+				}
+				if err != nil {
+					reject(err)
+					return nil
+				}
+				resolve(c.result())
+				return nil
+			})
+		}()
+	}
+	readNext()
+
+	return p, nil
+}
+
+// bodyConsumer passes the chunks of a response body read by consumeBody() to
+// its hasher and its onLine callback, on the event loop.
+type bodyConsumer struct {
+	rt     *sobek.Runtime
+	hasher *sobek.Object
+	onLine sobek.Callable
+
+	bytesRead, lines int64
+	pending          []byte
+	stopped          bool
+}
+
+func (c *bodyConsumer) process(chunk []byte) error {
+	c.bytesRead += int64(len(chunk))
+	if len(chunk) > 0 && c.hasher != nil {
+		data := c.rt.NewArrayBuffer(append([]byte{}, chunk...))
+		if _, err := callMethod(c.hasher, "update", c.rt.ToValue(data)); err != nil {
+			return err
+		}
+	}
+	for c.onLine != nil && !c.stopped {
+		i := bytes.IndexByte(chunk, '\n')
+		if i < 0 {
+			c.pending = append(c.pending, chunk...)
+			break
+		}
+		line := chunk[:i]
+		if len(c.pending) > 0 {
+			line = append(c.pending, line...)
+			c.pending = c.pending[:0]
+		}
+		chunk = chunk[i+1:]
+		if err := c.emitLine(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flush emits the last line, if the body doesn't end with a line break.
+func (c *bodyConsumer) flush() error {
+	if c.onLine == nil || c.stopped || len(c.pending) == 0 {
+		return nil
+	}
+	return c.emitLine(c.pending)
+}
+
+func (c *bodyConsumer) emitLine(line []byte) error {
+	c.lines++
+	v, err := c.onLine(sobek.Undefined(), c.rt.ToValue(string(bytes.TrimSuffix(line, []byte{'\r'}))))
+	if err == nil && v != nil && v.StrictEquals(c.rt.ToValue(false)) {
+		c.stopped = true
+	}
+	return err
+}
+
+func (c *bodyConsumer) result() *sobek.Object {
+	result := c.rt.NewObject()
+	if err := result.Set("bytes", c.bytesRead); err != nil {
+		common.Throw(c.rt, err)
+	}
+	if err := result.Set("lines", c.lines); err != nil {
+		common.Throw(c.rt, err)
+	}
+	return result
+}
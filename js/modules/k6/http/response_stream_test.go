@@ -0,0 +1,133 @@
+package http
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.k6.io/k6/js/modules/k6/crypto"
+)
+
+func TestResponseStream(t *testing.T) {
+	t.Parallel()
+
+	var lines strings.Builder
+	for i := 0; i < 20000; i++ {
+		_, _ = fmt.Fprintf(&lines, "line %d\r\n", i)
+	}
+	body := lines.String()
+	sum := sha256.Sum256([]byte(body))
+
+	newStreamTestCase := func(t *testing.T) *httpTestCase {
+		ts := newTestCase(t)
+		ts.tb.Mux.HandleFunc("/stream-lines", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Encoding", "gzip")
+			gw := gzip.NewWriter(w)
+			_, _ = gw.Write([]byte(body))
+			_ = gw.Close()
+		})
+		rt := ts.runtime.VU.Runtime()
+		require.NoError(t, rt.Set("crypto", crypto.New().NewModuleInstance(ts.runtime.VU).Exports().Named))
+		require.NoError(t, rt.Set("expectedHash", hex.EncodeToString(sum[:])))
+		return ts
+	}
+
+	t.Run("consumeBody", func(t *testing.T) {
+		t.Parallel()
+		ts := newStreamTestCase(t)
+
+		_, err := ts.runtime.RunOnEventLoop(wrapInAsyncLambda(ts.tb.Replacer.Replace(`
+			var res = http.get("HTTPBIN_URL/stream-lines", { responseType: "stream" });
+			var hasher = crypto.createHash("sha256");
+			var last;
+			var result = await res.consumeBody({ hasher: hasher, onLine: (line) => { last = line; } });
+			if (result.bytes !== ` + fmt.Sprint(len(body)) + ` || result.lines !== 20000 || last !== "line 19999") {
+				throw new Error("unexpected result: " + JSON.stringify(result) + " " + last);
+			}
+			if (hasher.digest("hex") !== expectedHash) {
+				throw new Error("unexpected hash");
+			}
+
+			res = http.get("HTTPBIN_URL/stream-lines", { responseType: "stream" });
+			var count = 0;
+			result = await res.consumeBody({ onLine: () => ++count < 10 });
+			if (result.lines !== 10) {
+				throw new Error("unexpected number of lines: " + result.lines);
+			}
+		`)))
+		require.NoError(t, err)
+	})
+
+	t.Run("consumeBodyDoesNotBlock", func(t *testing.T) {
+		t.Parallel()
+		ts := newStreamTestCase(t)
+		release := make(chan struct{})
+		ts.tb.Mux.HandleFunc("/stream-slow", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("first\n"))
+			w.(http.Flusher).Flush()
+			<-release
+			_, _ = w.Write([]byte("second\n"))
+		})
+		rt := ts.runtime.VU.Runtime()
+		require.NoError(t, rt.Set("release", func() { close(release) }))
+
+		_, err := ts.runtime.RunOnEventLoop(wrapInAsyncLambda(ts.tb.Replacer.Replace(`
+			var res = http.get("HTTPBIN_URL/stream-slow", { responseType: "stream" });
+			var lines = [];
+			var p = res.consumeBody({ onLine: (line) => { lines.push(line); } });
+			// the rest of the body is only sent after consumeBody() has returned
+			release();
+			var result = await p;
+			if (result.lines !== 2 || lines.join() !== "first,second") {
+				throw new Error("unexpected result: " + JSON.stringify(result) + " " + lines);
+			}
+		`)))
+		require.NoError(t, err)
+	})
+
+	t.Run("ReadableStream", func(t *testing.T) {
+		t.Parallel()
+		ts := newStreamTestCase(t)
+
+		_, err := ts.runtime.RunOnEventLoop(wrapInAsyncLambda(ts.tb.Replacer.Replace(`
+			var res = await http.asyncRequest("GET", "HTTPBIN_URL/stream-lines", null, { responseType: "stream" });
+			var reader = res.body.getReader();
+			var hasher = crypto.createHash("sha256");
+			var total = 0;
+			while (true) {
+				var { done, value } = await reader.read();
+				if (done) { break; }
+				if (!(value instanceof Uint8Array)) { throw new Error("unexpected chunk type"); }
+				total += value.length;
+				hasher.update(value.buffer);
+			}
+			if (total !== ` + fmt.Sprint(len(body)) + ` || hasher.digest("hex") !== expectedHash) {
+				throw new Error("unexpected body: " + total);
+			}
+			try {
+				res.consumeBody();
+				throw new Error("consuming the body twice should fail");
+			} catch (e) {
+				if (!e.toString().includes("already consumed by its ReadableStream")) { throw e; }
+			}
+		`)))
+		require.NoError(t, err)
+	})
+
+	t.Run("NotStream", func(t *testing.T) {
+		t.Parallel()
+		ts := newStreamTestCase(t)
+
+		_, err := ts.runtime.VU.Runtime().RunString(ts.tb.Replacer.Replace(`
+			http.get("HTTPBIN_URL/get").consumeBody();
+		`))
+		assert.ErrorContains(t, err, `consumeBody() can only be used with the "stream" responseType`)
+	})
+}
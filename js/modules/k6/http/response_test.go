@@ -244,6 +244,61 @@ func TestResponse(t *testing.T) {
 		assertRequestMetricsEmitted(t, metrics.GetBufferedSamples(samples), "GET", sr("HTTPBIN_URL/json"), 200, "")
 	})
 
+	t.Run("ValidateJSON", func(t *testing.T) {
+		_, err := rt.RunString(sr(`
+			var res = http.request("GET", "HTTPBIN_URL/json");
+			var schema = {
+				type: "object",
+				required: ["glossary"],
+				properties: {
+					glossary: {
+						type: "object",
+						properties: {
+							GlossDiv: { properties: { title: { type: "string" } } },
+							friends: { type: "array", items: { required: ["first", "last"] } },
+						},
+					},
+				},
+			};
+
+			var result = res.validateJSON(schema);
+			if (!result.valid || result.errors.length !== 0) {
+				throw new Error("Expected the body to be valid, but got: " + JSON.stringify(result.errors));
+			}
+
+			result = res.validateJSON({ properties: { glossary: { properties: { GlossDiv: { properties: { title: { type: "number" } } } } } } });
+			if (result.valid || result.errors.length !== 1) {
+				throw new Error("Expected the body to be invalid, but got: " + JSON.stringify(result));
+			}
+			if (result.errors[0].path !== "/glossary/GlossDiv/title" ||
+				result.errors[0].schemaPath !== "/properties/glossary/properties/GlossDiv/properties/title/type") {
+				throw new Error("Unexpected error: " + JSON.stringify(result.errors[0]));
+			}
+
+			result = http.request("GET", "HTTPBIN_URL/html").validateJSON(schema);
+			if (result.valid || result.errors[0].message.indexOf("cannot parse json") === -1) {
+				throw new Error("Expected the HTML body to be invalid, but got: " + JSON.stringify(result));
+			}
+		`))
+		require.NoError(t, err)
+
+		var validations []float64
+		for _, sc := range metrics.GetBufferedSamples(samples) {
+			for _, s := range sc.GetSamples() {
+				if s.Metric.Name == metrics.JSONSchemaFailedName {
+					validations = append(validations, s.Value)
+				}
+			}
+		}
+		assert.Equal(t, []float64{0, 1, 1}, validations)
+
+		t.Run("InvalidSchema", func(t *testing.T) {
+			_, err := rt.RunString(sr(`http.request("GET", "HTTPBIN_URL/json").validateJSON({ type: 42 });`))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid schema")
+		})
+	})
+
 	t.Run("SubmitForm", func(t *testing.T) {
 		t.Run("withoutArgs", func(t *testing.T) {
 			_, err := rt.RunString(sr(`
@@ -0,0 +1,359 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/grafana/sobek"
+	"github.com/mstoykov/k6-taskqueue-lib/taskqueue"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/js/modules"
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/lib/netext/httpext"
+	"go.k6.io/k6/lib/types"
+	"go.k6.io/k6/metrics"
+)
+
+// ErrSSEInInitContext is returned when http.sse() is used in the init context.
+var ErrSSEInInitContext = common.NewInitContextError("using Server-Sent Events in the init context is not supported")
+
+// The ready states of an EventSource, as in the browsers.
+const (
+	sseConnecting = iota
+	sseOpen
+	sseClosed
+)
+
+// defaultSSERetry is the reconnection time until the server sets one with
+// the retry field.
+const defaultSSERetry = 3 * time.Second
+
+// maxSSELineSize limits the size of the lines of the event streams, so a
+// broken stream can't use all the memory.
+const maxSSELineSize = 16 * 1024 * 1024
+
+// EventSource is the Server-Sent Events client given to the handler of
+// http.sse(). Its events are dispatched on the event loop of the VU, which
+// waits for it until it's closed, either by the script, by the server with a
+// response which isn't an event stream, or after the last reconnection.
+type EventSource struct {
+	URL         string `js:"url"`
+	ReadyState  int    `js:"readyState"`
+	LastEventID string `js:"lastEventId"`
+
+	client      *Client
+	vu          modules.VU
+	req         *httpext.ParsedHTTPRequest
+	tagsAndMeta metrics.TagsAndMeta
+	listeners   map[string][]sobek.Callable
+	tq          *taskqueue.TaskQueue
+	ctx         context.Context //nolint:containedctx
+	cancel      context.CancelFunc
+
+	// only used by the goroutine of the connections
+	retry         time.Duration
+	maxReconnects int64
+	lastEventID   string
+}
+
+// sse opens a Server-Sent Events stream with a GET request, which accepts the
+// params of the other requests, along with the initial reconnection time as
+// retry and the maxReconnects limit, unlimited by default. The handler is
+// called with the EventSource before connecting, to add the listeners of its
+// events. Unless the timeout param is set, the streams have no timeout.
+func (c *Client) sse(url sobek.Value, params sobek.Value, handler sobek.Value) (*EventSource, error) {
+	vu := c.moduleInstance.vu
+	state := vu.State()
+	if state == nil {
+		return nil, ErrSSEInInitContext
+	}
+	setup, ok := sobek.AssertFunction(handler)
+	if !ok {
+		return nil, errors.New("the http.sse() handler must be a function")
+	}
+
+	req, err := c.parseRequest(http.MethodGet, url, nil, params)
+	if err != nil {
+		return nil, err
+	}
+	req.ResponseType = httpext.ResponseTypeStream
+	if req.Req.Header.Get("Accept") == "" {
+		req.Req.Header.Set("Accept", httpext.SSEContentType)
+	}
+	if req.Req.Header.Get("Cache-Control") == "" {
+		req.Req.Header.Set("Cache-Control", "no-cache")
+	}
+
+	ctx, cancel := context.WithCancel(vu.Context())
+	es := &EventSource{
+		URL:           req.URL.URL,
+		client:        c,
+		vu:            vu,
+		req:           req,
+		tagsAndMeta:   sseTagsAndMeta(state, req),
+		listeners:     make(map[string][]sobek.Callable),
+		ctx:           ctx,
+		cancel:        cancel,
+		retry:         defaultSSERetry,
+		maxReconnects: -1,
+	}
+	if err := es.parseParams(params); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if _, err := setup(sobek.Undefined(), vu.Runtime().ToValue(es)); err != nil {
+		cancel()
+		return nil, err
+	}
+	if es.ReadyState == sseClosed { // closed by the handler
+		return es, nil
+	}
+
+	es.tq = taskqueue.New(vu.RegisterCallback)
+	go es.run()
+	return es, nil
+}
+
+func (es *EventSource) parseParams(params sobek.Value) error {
+	es.req.Timeout = math.MaxInt64
+	if common.IsNullish(params) {
+		return nil
+	}
+	rt := es.vu.Runtime()
+	obj := params.ToObject(rt)
+	for _, k := range obj.Keys() {
+		v := obj.Get(k)
+		switch k {
+		case "timeout":
+			t, err := types.GetDurationValue(v.Export())
+			if err != nil {
+				return fmt.Errorf("invalid timeout value: %w", err)
+			}
+			es.req.Timeout = t
+		case "retry":
+			t, err := types.GetDurationValue(v.Export())
+			if err != nil {
+				return fmt.Errorf("invalid retry value: %w", err)
+			}
+			es.retry = t
+		case "maxReconnects":
+			es.maxReconnects = v.ToInteger()
+		}
+	}
+	return nil
+}
+
+// sseTagsAndMeta returns the tags and metadata of the event metrics, with the
+// name and url system tags set like for the HTTP requests.
+func sseTagsAndMeta(state *lib.State, req *httpext.ParsedHTTPRequest) metrics.TagsAndMeta {
+	tagsAndMeta := req.TagsAndMeta.Clone()
+	enabledTags := state.Options.SystemTags
+	if name, ok := tagsAndMeta.Tags.Get(metrics.TagName.String()); ok {
+		tagsAndMeta.SetSystemTagOrMetaIfEnabled(enabledTags, metrics.TagURL, name)
+		return tagsAndMeta
+	}
+	name := req.URL.Clean()
+	if req.URL.Name != "" && req.URL.Name != name {
+		name = req.URL.Name
+	}
+	tagsAndMeta.SetSystemTagOrMetaIfEnabled(enabledTags, metrics.TagName, name)
+	tagsAndMeta.SetSystemTagOrMetaIfEnabled(enabledTags, metrics.TagURL, name)
+	return tagsAndMeta
+}
+
+// On adds a listener for the given event, which can be open, error, message
+// for the events without a type, or the type of the named events.
+func (es *EventSource) On(event string, handler sobek.Value) error {
+	listener, ok := sobek.AssertFunction(handler)
+	if !ok {
+		return fmt.Errorf("the listener of the %q event must be a function", event)
+	}
+	es.listeners[event] = append(es.listeners[event], listener)
+	return nil
+}
+
+// Close closes the connection, and stops the reconnections. No events are
+// dispatched after it.
+func (es *EventSource) Close() {
+	es.ReadyState = sseClosed
+	es.cancel()
+}
+
+func (es *EventSource) run() {
+	defer func() {
+		es.cancel()
+		es.tq.Queue(func() error {
+			es.ReadyState = sseClosed
+			return nil
+		})
+		es.tq.Close()
+	}()
+
+	state := es.vu.State()
+	for reconnects := int64(0); ; reconnects++ {
+		reconnect, err := es.connect(state)
+		if es.ctx.Err() != nil {
+			return
+		}
+		es.dispatchError(err)
+		if !reconnect || (es.maxReconnects >= 0 && reconnects >= es.maxReconnects) {
+			return
+		}
+
+		es.queue(func() error {
+			es.ReadyState = sseConnecting
+			return nil
+		})
+		select {
+		case <-time.After(es.retry):
+		case <-es.ctx.Done():
+			return
+		}
+	}
+}
+
+// connect makes a request and dispatches the events of its stream, until it
+// ends. It returns whether the connection can be reestablished, and why it
+// ended.
+func (es *EventSource) connect(state *lib.State) (bool, error) {
+	if es.lastEventID != "" {
+		es.req.Req.Header.Set("Last-Event-ID", es.lastEventID)
+	} else {
+		// the ID can be reset by the events of the previous connection
+		es.req.Req.Header.Del("Last-Event-ID")
+	}
+	start := time.Now()
+	resp, err := httpext.MakeRequest(es.ctx, state, es.req)
+	if err != nil {
+		return true, err
+	}
+	if resp.Error != "" {
+		return true, errors.New(resp.Error)
+	}
+	stream := resp.BodyStream
+	defer func() { _ = stream.Close() }()
+
+	if resp.Status != http.StatusOK {
+		return false, fmt.Errorf("unexpected response status %s", resp.StatusText)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Headers["Content-Type"]); mediaType != httpext.SSEContentType {
+		return false, fmt.Errorf("unexpected response Content-Type %q, it must be %q",
+			resp.Headers["Content-Type"], httpext.SSEContentType)
+	}
+
+	es.queue(func() error {
+		es.ReadyState = sseOpen
+		rt := es.vu.Runtime()
+		event := rt.NewObject()
+		if err := event.Set("type", "open"); err != nil {
+			return err
+		}
+		if err := event.Set("response", es.client.responseFromHTTPext(resp)); err != nil {
+			return err
+		}
+		return es.dispatch("open", event)
+	})
+
+	reader := httpext.NewSSEReader(stream, es.lastEventID)
+	reader.MaxLineSize = maxSSELineSize
+	defer func() {
+		es.lastEventID = reader.LastEventID()
+		if retry := reader.Retry(); retry > 0 {
+			es.retry = retry
+		}
+	}()
+	for first := true; ; first = false {
+		event, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return true, errors.New("the event stream was closed by the server")
+		}
+		if err != nil {
+			return true, err
+		}
+		es.pushEventSamples(start, first)
+		es.queueEvent(event)
+	}
+}
+
+func (es *EventSource) pushEventSamples(start time.Time, first bool) {
+	state := es.vu.State()
+	now := time.Now()
+	samples := []metrics.Sample{{
+		TimeSeries: metrics.TimeSeries{Metric: state.BuiltinMetrics.SSEEvents, Tags: es.tagsAndMeta.Tags},
+		Time:       now,
+		Metadata:   es.tagsAndMeta.Metadata,
+		Value:      1,
+	}}
+	if first {
+		samples = append(samples, metrics.Sample{
+			TimeSeries: metrics.TimeSeries{Metric: state.BuiltinMetrics.SSETimeToFirstEvent, Tags: es.tagsAndMeta.Tags},
+			Time:       now,
+			Metadata:   es.tagsAndMeta.Metadata,
+			Value:      metrics.D(now.Sub(start)),
+		})
+	}
+	metrics.PushIfNotDone(es.vu.Context(), state.Samples, metrics.Samples(samples))
+}
+
+func (es *EventSource) queueEvent(e *httpext.SSEEvent) {
+	es.queue(func() error {
+		es.LastEventID = e.LastEventID
+		rt := es.vu.Runtime()
+		event := rt.NewObject()
+		for k, v := range map[string]string{"type": e.Type, "data": e.Data, "lastEventId": e.LastEventID} {
+			if err := event.Set(k, v); err != nil {
+				return err
+			}
+		}
+		return es.dispatch(e.Type, event)
+	})
+}
+
+func (es *EventSource) dispatchError(err error) {
+	es.queue(func() error {
+		rt := es.vu.Runtime()
+		event := rt.NewObject()
+		if err := event.Set("type", "error"); err != nil {
+			return err
+		}
+		if err := event.Set("error", err.Error()); err != nil {
+			return err
+		}
+		if len(es.listeners["error"]) == 0 {
+			es.vu.State().Logger.WithError(err).Warn("Server-Sent Events error without an error listener")
+		}
+		return es.dispatch("error", event)
+	})
+}
+
+// queue queues the task on the event loop, unless the EventSource is closed
+// by then.
+func (es *EventSource) queue(task func() error) {
+	es.tq.Queue(func() error {
+		if es.ReadyState == sseClosed {
+			return nil
+		}
+		return task()
+	})
+}
+
+func (es *EventSource) dispatch(eventType string, event sobek.Value) error {
+	for _, listener := range es.listeners[eventType] {
+		if _, err := listener(sobek.Undefined(), event); err != nil {
+			es.Close()
+			return err
+		}
+		if es.ReadyState == sseClosed {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.k6.io/k6/metrics"
+)
+
+func TestSSE(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Reconnect", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestCase(t)
+
+		var mu sync.Mutex
+		var lastEventIDs []string
+		ts.tb.Mux.HandleFunc("/sse", func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+			mu.Unlock()
+			w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+			if r.Header.Get("Last-Event-ID") == "" {
+				_, _ = fmt.Fprint(w, ": hi\nretry: 10\nid: 1\ndata: hello\n\nevent: update\ndata: {\"a\":1}\n\n")
+				return
+			}
+			_, _ = fmt.Fprint(w, "data: again\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		})
+
+		_, err := ts.runtime.RunOnEventLoop(ts.tb.Replacer.Replace(`
+			var events = [];
+			var states = [];
+			var source = http.sse("HTTPBIN_URL/sse", { tags: { tag: "sse" } }, (es) => {
+				states.push(es.readyState);
+				es.on("open", (e) => events.push("open " + e.response.status));
+				es.on("message", (e) => {
+					events.push("message " + e.data + " " + e.lastEventId);
+					if (e.data === "again") {
+						es.close();
+						states.push(es.readyState);
+					}
+				});
+				es.on("update", (e) => events.push("update " + JSON.parse(e.data).a + " " + es.lastEventId));
+				es.on("error", (e) => {
+					events.push("error " + e.error);
+					states.push(es.readyState);
+				});
+			});
+			if (source.url !== "HTTPBIN_URL/sse") {
+				throw new Error("unexpected url " + source.url);
+			}
+		`))
+		require.NoError(t, err)
+
+		rt := ts.runtime.VU.Runtime()
+		assert.Equal(t, []any{
+			"open 200",
+			"message hello 1",
+			"update 1 1",
+			"error the event stream was closed by the server",
+			"open 200",
+			"message again 1",
+		}, rt.Get("events").Export())
+		assert.Equal(t, []any{int64(0), int64(1), int64(2)}, rt.Get("states").Export())
+		assert.Equal(t, []string{"", "1"}, lastEventIDs)
+
+		counts := map[string]int{}
+		for _, sc := range metrics.GetBufferedSamples(ts.samples) {
+			for _, s := range sc.GetSamples() {
+				if s.Metric.Name != metrics.SSEEventsName && s.Metric.Name != metrics.SSETimeToFirstEventName {
+					continue
+				}
+				counts[s.Metric.Name]++
+				assert.Equal(t, map[string]string{
+					"tag":   "sse",
+					"name":  ts.tb.Replacer.Replace("HTTPBIN_URL/sse"),
+					"url":   ts.tb.Replacer.Replace("HTTPBIN_URL/sse"),
+					"group": "",
+				}, s.Tags.Map())
+			}
+		}
+		assert.Equal(t, map[string]int{
+			metrics.SSEEventsName:           3,
+			metrics.SSETimeToFirstEventName: 2,
+		}, counts)
+	})
+
+	t.Run("MaxReconnects", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestCase(t)
+
+		ts.tb.Mux.HandleFunc("/sse-once", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = fmt.Fprint(w, "data: once\n\n")
+		})
+
+		_, err := ts.runtime.RunOnEventLoop(ts.tb.Replacer.Replace(`
+			var events = [];
+			http.sse("HTTPBIN_URL/sse-once", { retry: 1, maxReconnects: 2 }, (es) => {
+				es.on("message", (e) => events.push(e.data));
+				es.on("error", () => events.push("error"));
+			});
+		`))
+		require.NoError(t, err)
+		assert.Equal(t, []any{"once", "error", "once", "error", "once", "error"},
+			ts.runtime.VU.Runtime().Get("events").Export())
+	})
+
+	t.Run("ResetLastEventID", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestCase(t)
+
+		var mu sync.Mutex
+		var lastEventIDs [][]string
+		ts.tb.Mux.HandleFunc("/sse-reset", func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			lastEventIDs = append(lastEventIDs, r.Header.Values("Last-Event-ID"))
+			mu.Unlock()
+			w.Header().Set("Content-Type", "text/event-stream")
+			if r.Header.Get("Last-Event-ID") == "" {
+				_, _ = fmt.Fprint(w, "id: 1\ndata: set\n\n")
+				return
+			}
+			_, _ = fmt.Fprint(w, "id\ndata: reset\n\n")
+		})
+
+		_, err := ts.runtime.RunOnEventLoop(ts.tb.Replacer.Replace(`
+			http.sse("HTTPBIN_URL/sse-reset", { retry: 1, maxReconnects: 2 }, () => {});
+		`))
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{nil, {"1"}, nil}, lastEventIDs)
+	})
+
+	t.Run("NotAnEventStream", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestCase(t)
+
+		_, err := ts.runtime.RunOnEventLoop(ts.tb.Replacer.Replace(`
+			var events = [];
+			var source = http.sse("HTTPBIN_URL/get", null, (es) => {
+				es.on("open", () => events.push("open"));
+				es.on("error", (e) => events.push(e.error));
+			});
+		`))
+		require.NoError(t, err)
+		rt := ts.runtime.VU.Runtime()
+		assert.Equal(t, []any{`unexpected response Content-Type "application/json; encoding=utf-8", it must be "text/event-stream"`},
+			rt.Get("events").Export())
+		v, err := rt.RunString(`source.readyState`)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), v.Export())
+	})
+
+	t.Run("ListenerError", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestCase(t)
+
+		ts.tb.Mux.HandleFunc("/sse-forever", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = fmt.Fprint(w, "data: boom\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		})
+
+		_, err := ts.runtime.RunOnEventLoop(ts.tb.Replacer.Replace(`
+			http.sse("HTTPBIN_URL/sse-forever", null, (es) => {
+				es.on("message", (e) => { throw new Error(e.data); });
+			});
+		`))
+		require.ErrorContains(t, err, "boom")
+	})
+
+	t.Run("InvalidHandler", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestCase(t)
+
+		_, err := ts.runtime.VU.Runtime().RunString(ts.tb.Replacer.Replace(`
+			http.sse("HTTPBIN_URL/get", null, "handler");
+		`))
+		require.ErrorContains(t, err, "the http.sse() handler must be a function")
+	})
+}
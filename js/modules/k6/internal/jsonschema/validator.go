@@ -0,0 +1,167 @@
+// Package jsonschema validates JSON values against JSON Schemas. It's shared by
+// the k6/experimental/jsonschema module and Response.validateJSON of k6/http.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/grafana/sobek"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/js/modules"
+	"go.k6.io/k6/metrics"
+)
+
+// schemaURL is the URL of the compiled schemas, which their local references
+// are resolved against.
+const schemaURL = "schema.json"
+
+// Validator validates JSON values against a compiled schema.
+type Validator struct {
+	schema *jsonschema.Schema
+
+	vu modules.VU
+}
+
+// NewValidator compiles the schema, which can be a JSON string or a value that
+// can be marshaled to it, and returns a [Validator] for it, which reports its
+// validations to the json_schema_failed metric.
+//
+// Only the local references of the schema are supported, so that compiling it
+// never reads files or makes requests.
+func NewValidator(vu modules.VU, schema any) (*Validator, error) {
+	doc, ok := schema.(string)
+	if !ok {
+		b, err := json.Marshal(schema)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema; reason: %w", err)
+		}
+		doc = string(b)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	compiler.LoadURL = func(s string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("loading the %q schema is not supported, only local references are", s)
+	}
+	if err := compiler.AddResource(schemaURL, bytes.NewReader([]byte(doc))); err != nil {
+		return nil, fmt.Errorf("invalid schema; reason: %w", err)
+	}
+
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema; reason: %w", err)
+	}
+
+	return &Validator{schema: compiled, vu: vu}, nil
+}
+
+// Result is the result of a validation.
+type Result struct {
+	// Valid indicates whether the value is valid.
+	Valid bool `js:"valid"`
+
+	// Errors holds the errors of the invalid value.
+	Errors []ValidationError `js:"errors"`
+}
+
+// ValidationError is an error of a validation.
+type ValidationError struct {
+	// Path is the JSON pointer of the invalid value.
+	Path string `js:"path"`
+
+	// SchemaPath is the JSON pointer of the failing keyword of the schema.
+	SchemaPath string `js:"schemaPath"`
+
+	// Message describes the error.
+	Message string `js:"message"`
+}
+
+// Validate validates the JS value, which is converted to JSON first.
+func (v *Validator) Validate(value sobek.Value) (*Result, error) {
+	var data any
+	if !common.IsNullish(value) {
+		b, err := json.Marshal(value.Export())
+		if err != nil {
+			return nil, fmt.Errorf("the value can't be converted to JSON; reason: %w", err)
+		}
+		if err := json.Unmarshal(b, &data); err != nil {
+			return nil, err
+		}
+	}
+
+	return v.ValidateValue(data), nil
+}
+
+// ValidateValue validates the value, as decoded by json.Unmarshal, and reports
+// the result to the json_schema_failed metric when it is called in the VU
+// context.
+func (v *Validator) ValidateValue(data any) *Result {
+	result := NewResult(v.schema.Validate(data))
+	v.Report(result)
+	return result
+}
+
+// NewResult returns the result of a validation from its error, which can be
+// a validation error or an error preventing the validation.
+func NewResult(err error) *Result {
+	result := &Result{Valid: err == nil, Errors: []ValidationError{}}
+
+	var validationErr *jsonschema.ValidationError
+	switch {
+	case err == nil:
+	case errors.As(err, &validationErr):
+		result.Errors = appendLeaves(result.Errors, validationErr)
+	default:
+		result.Errors = append(result.Errors, ValidationError{Message: err.Error()})
+	}
+
+	return result
+}
+
+// appendLeaves appends the leaves of the tree of errors, which are the ones
+// describing the actual failures.
+func appendLeaves(errs []ValidationError, err *jsonschema.ValidationError) []ValidationError {
+	if len(err.Causes) == 0 {
+		return append(errs, ValidationError{
+			Path:       err.InstanceLocation,
+			SchemaPath: err.KeywordLocation,
+			Message:    err.Message,
+		})
+	}
+	for _, cause := range err.Causes {
+		errs = appendLeaves(errs, cause)
+	}
+	return errs
+}
+
+// Report emits a sample of the json_schema_failed metric for the result, when it
+// is called in the VU context.
+func (v *Validator) Report(result *Result) {
+	state := v.vu.State()
+	if state == nil {
+		return
+	}
+
+	var value float64
+	if !result.Valid {
+		value = 1
+	}
+
+	ctm := state.Tags.GetCurrentValues()
+	metrics.PushIfNotDone(v.vu.Context(), state.Samples, metrics.Sample{
+		TimeSeries: metrics.TimeSeries{
+			Metric: state.BuiltinMetrics.JSONSchemaFailed,
+			Tags:   ctm.Tags,
+		},
+		Time:     time.Now(),
+		Metadata: ctm.Metadata,
+		Value:    value,
+	})
+}
@@ -59,6 +59,7 @@ func (mi *K6) Exports() modules.Exports {
 	return modules.Exports{
 		Named: map[string]interface{}{
 			"check":      mi.Check,
+			"expect":     mi.Expect,
 			"fail":       mi.Fail,
 			"group":      mi.Group,
 			"randomSeed": mi.RandomSeed,
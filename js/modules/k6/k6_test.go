@@ -2,6 +2,7 @@ package k6
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -369,6 +370,138 @@ func TestCheckTags(t *testing.T) {
 	}, sample.Tags.Map())
 }
 
+func TestExpect(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Matchers", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			script string
+			pass   bool
+		}{
+			{`k6.expect(200).toBe(200)`, true},
+			{`k6.expect("200").toBe(200)`, false},
+			{`k6.expect({ a: [1, { b: "c" }] }).toEqual({ a: [1.0, { b: "c" }] })`, true},
+			{`k6.expect({ a: 1 }).toEqual({ a: 1, b: 2 })`, false},
+			{`k6.expect("abc").toBeTruthy()`, true},
+			{`k6.expect(0).toBeTruthy()`, false},
+			{`k6.expect(250).toBeBetween(200, 299)`, true},
+			{`k6.expect(404).toBeBetween(200, 299)`, false},
+			{`k6.expect(12.5).toBeGreaterThan(12)`, true},
+			{`k6.expect("13").toBeGreaterThan(12)`, false},
+			{`k6.expect(99).toBeLessThan(100)`, true},
+			{`k6.expect(null).toBeLessThan(100)`, false},
+			{`k6.expect("Hello, World").toMatch(/^hello/i)`, true},
+			{`k6.expect("Hello, World").toMatch("^World")`, false},
+			{`k6.expect('{"user": {"roles": ["admin"]}}').toHaveJSONPath("user.roles.0", "admin")`, true},
+			{`k6.expect({ user: { id: 1 } }).toHaveJSONPath("user.id")`, true},
+			{`k6.expect({ user: { id: 1 } }).toHaveJSONPath("user.id", 2)`, false},
+			{`k6.expect({ user: { id: 1 } }).toHaveJSONPath("user.name")`, false},
+			{`k6.expect("<html>").toHaveJSONPath("user")`, false},
+		}
+
+		for _, tc := range testCases {
+			tc := tc
+			t.Run(tc.script, func(t *testing.T) {
+				t.Parallel()
+				rt := testCaseRuntime(t)
+
+				v, err := rt.testRuntime.RunOnEventLoop(tc.script)
+				require.NoError(t, err)
+				assert.Equal(t, tc.pass, v.Export())
+
+				bufSamples := metrics.GetBufferedSamples(rt.samples)
+				require.Len(t, bufSamples, 1)
+				sample, ok := bufSamples[0].(metrics.Sample)
+				require.True(t, ok)
+
+				assert.Equal(t, rt.testRuntime.VU.State().BuiltinMetrics.Checks, sample.Metric)
+				if tc.pass {
+					assert.Equal(t, float64(1), sample.Value)
+					assert.Empty(t, sample.Metadata)
+				} else {
+					assert.Equal(t, float64(0), sample.Value)
+					assert.NotEmpty(t, sample.Metadata[lib.CheckFailureReasonMetadata])
+					assert.NotEmpty(t, sample.Metadata[lib.CheckActualValueMetadata])
+				}
+			})
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		t.Parallel()
+		tc := testCaseRuntime(t)
+
+		v, err := tc.testRuntime.RunOnEventLoop(`k6.expect(404, "status is 200", { a: 1 }).toBe(200)`)
+		require.NoError(t, err)
+		assert.Equal(t, false, v.Export())
+
+		bufSamples := metrics.GetBufferedSamples(tc.samples)
+		require.Len(t, bufSamples, 1)
+		sample, ok := bufSamples[0].(metrics.Sample)
+		require.True(t, ok)
+
+		assert.NotZero(t, sample.Time)
+		assert.Equal(t, float64(0), sample.Value)
+		assert.Equal(t, map[string]string{
+			"group": "",
+			"check": "status is 200",
+			"a":     "1",
+		}, sample.Tags.Map())
+		assert.Equal(t, map[string]string{
+			lib.CheckFailureReasonMetadata: "expected 404 to be 200",
+			lib.CheckActualValueMetadata:   "404",
+		}, sample.Metadata)
+	})
+
+	t.Run("DefaultName", func(t *testing.T) {
+		t.Parallel()
+		tc := testCaseRuntime(t)
+
+		_, err := tc.testRuntime.RunOnEventLoop(`k6.expect("a::b").toBe("a::b")`)
+		require.NoError(t, err)
+
+		bufSamples := metrics.GetBufferedSamples(tc.samples)
+		require.Len(t, bufSamples, 1)
+		name, ok := bufSamples[0].GetSamples()[0].Tags.Get("check")
+		require.True(t, ok)
+		assert.Equal(t, `toBe("a:b")`, name)
+	})
+
+	t.Run("TruncatedActualValue", func(t *testing.T) {
+		t.Parallel()
+		tc := testCaseRuntime(t)
+
+		_, err := tc.testRuntime.RunOnEventLoop(`k6.expect("x".repeat(200), "short").toBe("")`)
+		require.NoError(t, err)
+
+		bufSamples := metrics.GetBufferedSamples(tc.samples)
+		require.Len(t, bufSamples, 1)
+		actual := bufSamples[0].GetSamples()[0].Metadata[lib.CheckActualValueMetadata]
+		assert.Equal(t, `"`+strings.Repeat("x", 99)+"...", actual)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		tc := testCaseRuntime(t)
+
+		_, err := tc.testRuntime.RunOnEventLoop(`k6.expect(1, "a::b").toBe(1)`)
+		assert.ErrorContains(t, err, "group and check names may not contain '::'")
+	})
+
+	t.Run("InitContext", func(t *testing.T) {
+		t.Parallel()
+		testRuntime := modulestest.NewRuntime(t)
+		m, ok := New().NewModuleInstance(testRuntime.VU).(*K6)
+		require.True(t, ok)
+		require.NoError(t, testRuntime.VU.RuntimeField.Set("k6", m.Exports().Named))
+
+		_, err := testRuntime.VU.Runtime().RunString(`k6.expect(1).toBe(1)`)
+		assert.ErrorContains(t, err, "Using expect() in the init context is not supported")
+	})
+}
+
 type testCase struct {
 	samples     chan metrics.SampleContainer
 	testRuntime *modulestest.Runtime
@@ -0,0 +1,307 @@
+package socket
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/grafana/sobek"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/js/modules"
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/metrics"
+)
+
+// Conn is a connected socket, as it's exposed to JS.
+type Conn struct {
+	vu          modules.VU
+	state       *lib.State
+	network     string
+	host        string
+	tagsAndMeta metrics.TagsAndMeta
+
+	// raw is the connection made by the dialer, conn can be a TLS connection
+	// over it. readMu serializes the reads and guards the reader, while connMu
+	// guards conn, which are both replaced by startTLS().
+	raw    net.Conn
+	readMu sync.Mutex
+	connMu sync.RWMutex
+	conn   net.Conn
+	reader *bufio.Reader
+
+	// The end of the last write that wasn't followed by a read yet, in Unix
+	// nanoseconds, for tcp_request_duration.
+	lastWrite atomic.Int64
+	closed    atomic.Bool
+	stopClose func() bool
+}
+
+func newConn(vu modules.VU, network, host string, conn net.Conn, tagsAndMeta metrics.TagsAndMeta) *Conn {
+	c := &Conn{
+		vu:          vu,
+		state:       vu.State(),
+		network:     network,
+		host:        host,
+		tagsAndMeta: tagsAndMeta,
+		raw:         conn,
+		conn:        conn,
+	}
+	if network == networkTCP {
+		c.reader = bufio.NewReader(conn)
+	}
+	// the connections don't outlive the iteration that made them
+	c.stopClose = context.AfterFunc(vu.Context(), func() { _ = c.Close() })
+	return c
+}
+
+// LocalAddress returns the local address of the connection.
+func (c *Conn) LocalAddress() string {
+	return c.raw.LocalAddr().String()
+}
+
+// RemoteAddress returns the remote address of the connection.
+func (c *Conn) RemoteAddress() string {
+	return c.raw.RemoteAddr().String()
+}
+
+// Write writes the given string or ArrayBuffer to the connection. For UDP,
+// each write is sent as a single datagram. It returns a promise, which is
+// resolved when all the data is written.
+func (c *Conn) Write(data sobek.Value) *sobek.Promise {
+	promise, resolve, reject := c.vu.Runtime().NewPromise()
+	b, err := common.ToBytes(data.Export())
+	if err != nil {
+		reject(fmt.Errorf("invalid write() data: %w", err))
+		return promise
+	}
+	b = append([]byte{}, b...) // the ArrayBuffer may be changed in the meantime
+
+	callback := c.vu.RegisterCallback()
+	go func() {
+		err := c.write(b)
+		callback(func() error {
+			if err != nil {
+				reject(err)
+				return nil //nolint:nilerr // the error is returned through the promise
+			}
+			resolve(sobek.Undefined())
+			return nil
+		})
+	}()
+	return promise
+}
+
+func (c *Conn) write(b []byte) error {
+	if c.closed.Load() {
+		return errClosed
+	}
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
+	if _, err := conn.Write(b); err != nil {
+		return err
+	}
+	c.lastWrite.Store(time.Now().UnixNano())
+	return nil
+}
+
+// Read reads from the connection. By default, it returns the data which is
+// available, up to maxBytes, or for UDP the next datagram. TCP connections can
+// also read until a delimiter, which is included in the result, or exactly
+// the given size. It returns a promise of an ArrayBuffer, or of a string with
+// the utf-8 encoding, which is resolved with null if the connection was closed
+// by the remote host.
+func (c *Conn) Read(params sobek.Value) *sobek.Promise {
+	rt := c.vu.Runtime()
+	promise, resolve, reject := rt.NewPromise()
+	p, err := parseReadParams(rt, c.network, params)
+	if err != nil {
+		reject(fmt.Errorf("invalid read() parameters: %w", err))
+		return promise
+	}
+
+	callback := c.vu.RegisterCallback()
+	go func() {
+		data, err := c.read(p)
+		callback(func() error {
+			switch {
+			case errors.Is(err, io.EOF) && len(data) == 0:
+				resolve(sobek.Null())
+			case err != nil:
+				reject(err)
+			case p.text:
+				resolve(string(data))
+			default:
+				resolve(rt.NewArrayBuffer(data))
+			}
+			return nil
+		})
+	}()
+	return promise
+}
+
+func (c *Conn) read(p *readParams) ([]byte, error) {
+	if c.closed.Load() {
+		return nil, errClosed
+	}
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+	c.connMu.RLock()
+	conn := c.conn
+	c.connMu.RUnlock()
+
+	var deadline time.Time
+	if p.timeout > 0 {
+		deadline = time.Now().Add(p.timeout)
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	var err error
+	switch {
+	case c.reader == nil: // UDP
+		buf := make([]byte, p.maxBytes)
+		var n int
+		n, err = conn.Read(buf)
+		data = buf[:n]
+	case p.until != nil:
+		data, err = readUntil(c.reader, p.until, p.maxBytes)
+	case p.size > 0:
+		data = make([]byte, p.size)
+		var n int
+		n, err = io.ReadFull(c.reader, data)
+		data = data[:n]
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			err = fmt.Errorf("the connection was closed after %d of %d bytes: %w", n, p.size, io.EOF)
+		}
+	default:
+		buf := make([]byte, p.maxBytes)
+		var n int
+		n, err = c.reader.Read(buf)
+		data = buf[:n]
+	}
+	if err != nil {
+		return data, err
+	}
+	c.emitRequestDuration()
+	return data, nil
+}
+
+// readUntil reads until the delimiter, which is included in the result.
+func readUntil(r *bufio.Reader, delim []byte, maxBytes int) ([]byte, error) {
+	var data []byte
+	last := delim[len(delim)-1]
+	for {
+		chunk, err := r.ReadSlice(last)
+		data = append(data, chunk...)
+		if err == nil && bytes.HasSuffix(data, delim) {
+			return data, nil
+		}
+		if len(data) > maxBytes {
+			return nil, fmt.Errorf("the delimiter wasn't found in the first %d bytes", maxBytes)
+		}
+		if err != nil && !errors.Is(err, bufio.ErrBufferFull) {
+			if errors.Is(err, io.EOF) && len(data) > 0 {
+				return nil, fmt.Errorf("the connection was closed before the delimiter: %w", io.EOF)
+			}
+			return nil, err
+		}
+	}
+}
+
+// emitRequestDuration emits the duration from the end of the last write to
+// the end of the read that followed it, if there was one.
+func (c *Conn) emitRequestDuration() {
+	if c.network != networkTCP {
+		return
+	}
+	lastWrite := c.lastWrite.Swap(0)
+	if lastWrite == 0 {
+		return
+	}
+	now := time.Now()
+	metrics.PushIfNotDone(c.vu.Context(), c.state.Samples, metrics.Sample{
+		TimeSeries: metrics.TimeSeries{Metric: c.state.BuiltinMetrics.TCPRequestDuration, Tags: c.tagsAndMeta.Tags},
+		Time:       now,
+		Metadata:   c.tagsAndMeta.Metadata,
+		Value:      metrics.D(now.Sub(time.Unix(0, lastWrite))),
+	})
+}
+
+// StartTLS upgrades the TCP connection to TLS, e.g. after a STARTTLS command
+// of the protocol. It returns a promise, which is resolved when the handshake
+// is done.
+func (c *Conn) StartTLS(params sobek.Value) *sobek.Promise {
+	rt := c.vu.Runtime()
+	promise, resolve, reject := rt.NewPromise()
+	if c.network != networkTCP {
+		reject(errors.New("TLS is supported only for TCP connections"))
+		return promise
+	}
+	p, err := parseTLSParams(rt, params)
+	if err != nil {
+		reject(fmt.Errorf("invalid startTLS() parameters: %w", err))
+		return promise
+	}
+
+	callback := c.vu.RegisterCallback()
+	go func() {
+		err := c.startTLS(p)
+		callback(func() error {
+			if err != nil {
+				reject(err)
+				return nil //nolint:nilerr // the error is returned through the promise
+			}
+			resolve(sobek.Undefined())
+			return nil
+		})
+	}()
+	return promise
+}
+
+func (c *Conn) startTLS(p *tlsParams) error {
+	if c.closed.Load() {
+		return errClosed
+	}
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+	if c.reader.Buffered() > 0 {
+		return errors.New("the received data must be read before the TLS upgrade")
+	}
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	tlsConn, err := handshake(c.vu.Context(), c.conn, newTLSConfig(c.state.TLSConfig, c.host, p))
+	if err != nil {
+		c.closed.Store(true)
+		return err
+	}
+	c.conn = tlsConn
+	c.reader = bufio.NewReader(tlsConn)
+	return nil
+}
+
+// Close closes the connection. Any pending reads and writes are rejected.
+func (c *Conn) Close() error {
+	if c.closed.Swap(true) {
+		return nil
+	}
+	c.stopClose()
+	// a TLS upgrade holds connMu during its handshake, which is aborted by
+	// closing the underlying connection
+	if !c.connMu.TryLock() {
+		_ = c.raw.Close()
+		c.connMu.Lock()
+	}
+	defer c.connMu.Unlock()
+	return c.conn.Close()
+}
@@ -0,0 +1,168 @@
+package socket
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/grafana/sobek"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/js/modules"
+	"go.k6.io/k6/lib/types"
+	"go.k6.io/k6/metrics"
+)
+
+// defaultMaxBytes is the default maximum size of the data returned by a read.
+const defaultMaxBytes = 64 * 1024
+
+// connectParams are the parameters of connect().
+type connectParams struct {
+	tagsAndMeta metrics.TagsAndMeta
+	timeout     time.Duration
+	tls         *tlsParams
+}
+
+// tlsParams are the parameters of the TLS connections, from the tls parameter
+// of connect() or the ones of startTLS().
+type tlsParams struct {
+	serverName            string
+	insecureSkipTLSVerify bool
+}
+
+// readParams are the parameters of read().
+type readParams struct {
+	until    []byte
+	size     int
+	maxBytes int
+	timeout  time.Duration
+	text     bool
+}
+
+func parseConnectParams(vu modules.VU, network string, input sobek.Value) (*connectParams, error) {
+	result := &connectParams{
+		tagsAndMeta: vu.State().Tags.GetCurrentValues(),
+	}
+	if common.IsNullish(input) {
+		return result, nil
+	}
+
+	rt := vu.Runtime()
+	params := input.ToObject(rt)
+	for _, k := range params.Keys() {
+		v := params.Get(k)
+		switch k {
+		case "tags":
+			if err := common.ApplyCustomUserTags(rt, &result.tagsAndMeta, v); err != nil {
+				return nil, fmt.Errorf("metric tags: %w", err)
+			}
+		case "timeout":
+			var err error
+			if result.timeout, err = types.GetDurationValue(v.Export()); err != nil {
+				return nil, fmt.Errorf("invalid timeout value: %w", err)
+			}
+		case "tls":
+			if network != networkTCP {
+				return nil, errors.New("TLS is supported only for TCP connections")
+			}
+			if common.IsNullish(v) {
+				continue
+			}
+			if _, isObject := v.(*sobek.Object); !isObject {
+				if v.ToBoolean() {
+					result.tls = &tlsParams{}
+				}
+				continue
+			}
+			var err error
+			if result.tls, err = parseTLSParams(rt, v); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unknown param: %q", k)
+		}
+	}
+	return result, nil
+}
+
+func parseTLSParams(rt *sobek.Runtime, input sobek.Value) (*tlsParams, error) {
+	result := &tlsParams{}
+	if common.IsNullish(input) {
+		return result, nil
+	}
+	params := input.ToObject(rt)
+	for _, k := range params.Keys() {
+		v := params.Get(k)
+		switch k {
+		case "serverName":
+			result.serverName = v.String()
+		case "insecureSkipTLSVerify":
+			result.insecureSkipTLSVerify = v.ToBoolean()
+		default:
+			return nil, fmt.Errorf("unknown TLS param: %q", k)
+		}
+	}
+	return result, nil
+}
+
+func parseReadParams(rt *sobek.Runtime, network string, input sobek.Value) (*readParams, error) {
+	result := &readParams{maxBytes: defaultMaxBytes}
+	if common.IsNullish(input) {
+		return result, nil
+	}
+
+	params := input.ToObject(rt)
+	for _, k := range params.Keys() {
+		v := params.Get(k)
+		switch k {
+		case "until":
+			if network != networkTCP {
+				return nil, errors.New("reading until a delimiter is supported only for TCP connections")
+			}
+			until, err := common.ToBytes(v.Export())
+			if err != nil {
+				return nil, fmt.Errorf("invalid until value: %w", err)
+			}
+			if len(until) == 0 {
+				return nil, errors.New("the until delimiter can't be empty")
+			}
+			result.until = until
+		case "size":
+			if network != networkTCP {
+				return nil, errors.New("reading a fixed size is supported only for TCP connections")
+			}
+			result.size = int(v.ToInteger())
+			if result.size <= 0 {
+				return nil, errors.New("the size must be positive")
+			}
+		case "maxBytes":
+			result.maxBytes = int(v.ToInteger())
+			if result.maxBytes <= 0 {
+				return nil, errors.New("maxBytes must be positive")
+			}
+		case "timeout":
+			var err error
+			if result.timeout, err = types.GetDurationValue(v.Export()); err != nil {
+				return nil, fmt.Errorf("invalid timeout value: %w", err)
+			}
+		case "encoding":
+			switch encoding := v.String(); encoding {
+			case "utf-8":
+				result.text = true
+			case "binary":
+				result.text = false
+			default:
+				return nil, fmt.Errorf("unsupported encoding %q, it must be \"utf-8\" or \"binary\"", encoding)
+			}
+		default:
+			return nil, fmt.Errorf("unknown param: %q", k)
+		}
+	}
+	if result.until != nil && result.size > 0 {
+		return nil, errors.New("until and size can't be used together")
+	}
+	if result.size > result.maxBytes {
+		return nil, fmt.Errorf("the size %d is larger than maxBytes %d", result.size, result.maxBytes)
+	}
+	return result, nil
+}
@@ -0,0 +1,175 @@
+// Package socket implements the k6/net/tcp and k6/net/udp modules, which
+// provide raw sockets for testing custom protocols.
+package socket
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/grafana/sobek"
+
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/js/modules"
+	"go.k6.io/k6/metrics"
+)
+
+const (
+	networkTCP = "tcp"
+	networkUDP = "udp"
+)
+
+type (
+	// RootModule is the global module instance that will create module
+	// instances for each VU.
+	RootModule struct {
+		network string
+	}
+
+	// ModuleInstance represents an instance of the module for every VU.
+	ModuleInstance struct {
+		vu      modules.VU
+		network string
+	}
+)
+
+var (
+	_ modules.Module   = &RootModule{}
+	_ modules.Instance = &ModuleInstance{}
+)
+
+// NewTCP returns the root module of k6/net/tcp.
+func NewTCP() *RootModule {
+	return &RootModule{network: networkTCP}
+}
+
+// NewUDP returns the root module of k6/net/udp.
+func NewUDP() *RootModule {
+	return &RootModule{network: networkUDP}
+}
+
+// NewModuleInstance implements the modules.Module interface to return
+// a new instance for each VU.
+func (r *RootModule) NewModuleInstance(vu modules.VU) modules.Instance {
+	return &ModuleInstance{vu: vu, network: r.network}
+}
+
+// Exports returns the exports of the module.
+func (mi *ModuleInstance) Exports() modules.Exports {
+	return modules.Exports{
+		Named: map[string]interface{}{
+			"connect": mi.Connect,
+		},
+	}
+}
+
+// Connect connects to the given address with the dialer of the VU, so the
+// blocked hostnames, the hosts overrides and the local IPs of the options
+// apply. It returns a promise of the connection.
+func (mi *ModuleInstance) Connect(addr string, params sobek.Value) *sobek.Promise {
+	promise, resolve, reject := mi.vu.Runtime().NewPromise()
+
+	state := mi.vu.State()
+	if state == nil {
+		reject(common.NewInitContextError("connecting in the init context is not supported"))
+		return promise
+	}
+	p, err := parseConnectParams(mi.vu, mi.network, params)
+	if err != nil {
+		reject(fmt.Errorf("invalid connect() parameters: %w", err))
+		return promise
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		reject(fmt.Errorf("invalid address %q: %w", addr, err))
+		return promise
+	}
+	p.tagsAndMeta.SetSystemTagOrMetaIfEnabled(state.Options.SystemTags, metrics.TagHost, host)
+
+	callback := mi.vu.RegisterCallback()
+	go func() {
+		vuCtx := mi.vu.Context()
+		ctx := vuCtx
+		if p.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, p.timeout)
+			defer cancel()
+		}
+		start := time.Now()
+		conn, err := state.Dialer.DialContext(ctx, mi.network, addr)
+		if err == nil && p.tls != nil {
+			conn, err = handshake(ctx, conn, newTLSConfig(state.TLSConfig, host, p.tls))
+		}
+		end := time.Now()
+
+		if err == nil && state.Options.SystemTags.Has(metrics.TagIP) {
+			if ip, _, splitErr := net.SplitHostPort(conn.RemoteAddr().String()); splitErr == nil {
+				p.tagsAndMeta.SetSystemTagOrMeta(metrics.TagIP, ip)
+			}
+		}
+		if err == nil && mi.network == networkTCP {
+			metrics.PushIfNotDone(vuCtx, state.Samples, metrics.Sample{
+				TimeSeries: metrics.TimeSeries{Metric: state.BuiltinMetrics.TCPConnectDuration, Tags: p.tagsAndMeta.Tags},
+				Time:       end,
+				Metadata:   p.tagsAndMeta.Metadata,
+				Value:      metrics.D(end.Sub(start)),
+			})
+		}
+
+		// the callback isn't called if the iteration ends in the meantime, so
+		// the connection is closed before it's queued, not only by newConn()
+		stopClose := func() bool { return true }
+		if err == nil {
+			stopClose = context.AfterFunc(vuCtx, func() { _ = conn.Close() })
+		}
+		callback(func() error {
+			if err == nil && !stopClose() {
+				err = errClosed
+			}
+			if err != nil {
+				reject(err)
+				return nil //nolint:nilerr // the error is returned through the promise
+			}
+			resolve(newConn(mi.vu, mi.network, host, conn, p.tagsAndMeta))
+			return nil
+		})
+	}()
+
+	return promise
+}
+
+// handshake executes the TLS handshake of a client over the connection, and
+// closes it if the handshake fails.
+func handshake(ctx context.Context, conn net.Conn, config *tls.Config) (net.Conn, error) {
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("TLS handshake failed: %w", err)
+	}
+	return tlsConn, nil
+}
+
+// newTLSConfig returns the TLS configuration of the VU, for the given server
+// and with the given parameters.
+func newTLSConfig(base *tls.Config, host string, p *tlsParams) *tls.Config {
+	var config *tls.Config
+	if base != nil {
+		config = base.Clone()
+	} else {
+		config = &tls.Config{MinVersion: tls.VersionTLS12} //nolint:gosec // the VU always has a TLS config
+	}
+	config.NextProtos = nil
+	config.ServerName = host
+	if p.serverName != "" {
+		config.ServerName = p.serverName
+	}
+	if p.insecureSkipTLSVerify {
+		config.InsecureSkipVerify = true
+	}
+	return config
+}
+
+var errClosed = errors.New("the connection is closed")
@@ -0,0 +1,362 @@
+package socket
+
+import (
+	"bufio"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.k6.io/k6/js/compiler"
+	"go.k6.io/k6/js/modulestest"
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/lib/netext"
+	"go.k6.io/k6/lib/testutils/mockresolver"
+	"go.k6.io/k6/lib/types"
+	"go.k6.io/k6/metrics"
+)
+
+type testState struct {
+	*modulestest.Runtime
+	dialer  *netext.Dialer
+	samples chan metrics.SampleContainer
+}
+
+func newTestState(t *testing.T) *testState {
+	t.Helper()
+	runtime := modulestest.NewRuntime(t)
+	err := runtime.SetupModuleSystem(map[string]any{
+		"k6/net/tcp": NewTCP(),
+		"k6/net/udp": NewUDP(),
+	}, nil, compiler.New(runtime.VU.InitEnv().Logger))
+	require.NoError(t, err)
+	_, err = runtime.VU.Runtime().RunString(`
+		var tcp = require("k6/net/tcp");
+		var udp = require("k6/net/udp");
+	`)
+	require.NoError(t, err)
+
+	registry := runtime.VU.InitEnv().Registry
+	dialer := netext.NewDialer(net.Dialer{}, netext.NewResolver(
+		net.LookupIP, 0, types.DNSfirst, types.DNSpreferIPv4))
+	samples := make(chan metrics.SampleContainer, 100)
+	runtime.MoveToVUContext(&lib.State{
+		Options:        lib.Options{SystemTags: &metrics.DefaultSystemTagSet},
+		BuiltinMetrics: runtime.BuiltinMetrics,
+		Logger:         logrus.New(),
+		Dialer:         dialer,
+		TLSConfig:      &tls.Config{MinVersion: tls.VersionTLS12},
+		Samples:        samples,
+		Tags:           lib.NewVUStateTags(registry.RootTagSet()),
+	})
+	return &testState{Runtime: runtime, dialer: dialer, samples: samples}
+}
+
+func (ts *testState) run(t *testing.T, code string) {
+	t.Helper()
+	_, err := ts.RunOnEventLoop("(async () => {\n" + code + "\n})()")
+	require.NoError(t, err)
+}
+
+// listenTCP starts a TCP server which handles each connection with serve.
+func listenTCP(t *testing.T, serve func(net.Conn)) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				serve(conn)
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func echo(conn net.Conn) {
+	_, _ = io.Copy(conn, conn)
+}
+
+func testCertificates(t *testing.T) []tls.Certificate {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(nil)
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv.TLS.Certificates
+}
+
+func TestTCP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Echo", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestState(t)
+		addr := listenTCP(t, echo)
+		require.NoError(t, ts.VU.Runtime().Set("addr", addr))
+
+		ts.run(t, `
+			const conn = await tcp.connect(addr, { tags: { service: "echo" } });
+			await conn.write("hello\nworld\n");
+			const line = await conn.read({ until: "\n", encoding: "utf-8" });
+			if (line !== "hello\n") {
+				throw new Error("unexpected line " + JSON.stringify(line));
+			}
+			const word = await conn.read({ size: 5, encoding: "utf-8" });
+			if (word !== "world") {
+				throw new Error("unexpected word " + JSON.stringify(word));
+			}
+			await conn.write(new Uint8Array([1, 2, 3]).buffer);
+			const data = new Uint8Array(await conn.read({ until: new Uint8Array([2, 3]).buffer }));
+			if (data.join(",") !== "10,1,2,3") {
+				throw new Error("unexpected data " + data.join(","));
+			}
+			if (conn.remoteAddress() !== addr) {
+				throw new Error("unexpected remote address " + conn.remoteAddress());
+			}
+			conn.close();
+			let err;
+			try {
+				await conn.write("closed");
+			} catch (e) {
+				err = e;
+			}
+			if (!err || !String(err).includes("the connection is closed")) {
+				throw new Error("unexpected error " + err);
+			}
+		`)
+
+		metricSamples := map[string]int{}
+		for _, sc := range metrics.GetBufferedSamples(ts.samples) {
+			for _, s := range sc.GetSamples() {
+				metricSamples[s.Metric.Name]++
+				service, _ := s.Tags.Get("service")
+				assert.Equal(t, "echo", service)
+				host, _ := s.Tags.Get("host")
+				assert.Equal(t, "127.0.0.1", host)
+			}
+		}
+		assert.Equal(t, map[string]int{
+			metrics.TCPConnectDurationName: 1,
+			metrics.TCPRequestDurationName: 2,
+		}, metricSamples)
+		assert.Equal(t, int64(len("hello\nworld\n")+3), ts.dialer.BytesWritten)
+	})
+
+	t.Run("ReadAfterRemoteClose", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestState(t)
+		addr := listenTCP(t, func(conn net.Conn) {
+			_, _ = conn.Write([]byte("bye"))
+		})
+		require.NoError(t, ts.VU.Runtime().Set("addr", addr))
+
+		ts.run(t, `
+			const conn = await tcp.connect(addr);
+			let data = await conn.read({ encoding: "utf-8" });
+			if (data !== "bye") {
+				throw new Error("unexpected data " + JSON.stringify(data));
+			}
+			data = await conn.read();
+			if (data !== null) {
+				throw new Error("unexpected data after close " + data);
+			}
+		`)
+	})
+
+	t.Run("StartTLS", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestState(t)
+		certs := testCertificates(t)
+		addr := listenTCP(t, func(conn net.Conn) {
+			line, err := bufio.NewReader(conn).ReadString('\n')
+			if err != nil || line != "STARTTLS\n" {
+				return
+			}
+			_, _ = conn.Write([]byte("OK\n"))
+			tlsConn := tls.Server(conn, &tls.Config{Certificates: certs, MinVersion: tls.VersionTLS12})
+			echo(tlsConn)
+		})
+		require.NoError(t, ts.VU.Runtime().Set("addr", addr))
+
+		ts.run(t, `
+			const conn = await tcp.connect(addr);
+			await conn.write("STARTTLS\n");
+			const resp = await conn.read({ until: "\n", encoding: "utf-8" });
+			if (resp !== "OK\n") {
+				throw new Error("unexpected response " + JSON.stringify(resp));
+			}
+			await conn.startTLS({ insecureSkipTLSVerify: true });
+			await conn.write("secret");
+			const data = await conn.read({ size: 6, encoding: "utf-8" });
+			if (data !== "secret") {
+				throw new Error("unexpected data " + JSON.stringify(data));
+			}
+			conn.close();
+		`)
+	})
+
+	t.Run("CloseDuringStartTLS", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestState(t)
+		addr := listenTCP(t, func(conn net.Conn) {
+			// the TLS handshake is never answered
+			_, _ = io.Copy(io.Discard, conn)
+		})
+		require.NoError(t, ts.VU.Runtime().Set("addr", addr))
+
+		ts.run(t, `
+			const conn = await tcp.connect(addr);
+			const upgrade = conn.startTLS({ insecureSkipTLSVerify: true });
+			conn.close();
+			let err;
+			try {
+				await upgrade;
+			} catch (e) {
+				err = e;
+			}
+			if (!err) {
+				throw new Error("the TLS upgrade should've failed");
+			}
+		`)
+	})
+
+	t.Run("TLS", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestState(t)
+		certs := testCertificates(t)
+		addr := listenTCP(t, func(conn net.Conn) {
+			echo(tls.Server(conn, &tls.Config{Certificates: certs, MinVersion: tls.VersionTLS12}))
+		})
+		require.NoError(t, ts.VU.Runtime().Set("addr", addr))
+
+		ts.run(t, `
+			let err;
+			try {
+				await tcp.connect(addr, { tls: true });
+			} catch (e) {
+				err = e;
+			}
+			if (!err || !String(err).includes("TLS handshake failed")) {
+				throw new Error("unexpected error " + err);
+			}
+
+			const conn = await tcp.connect(addr, { tls: { insecureSkipTLSVerify: true } });
+			await conn.write("ping");
+			const data = await conn.read({ size: 4, encoding: "utf-8" });
+			if (data !== "ping") {
+				throw new Error("unexpected data " + JSON.stringify(data));
+			}
+			conn.close();
+		`)
+	})
+
+	t.Run("Dialer", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestState(t)
+		addr := listenTCP(t, echo)
+		_, port, err := net.SplitHostPort(addr)
+		require.NoError(t, err)
+		ts.dialer.Resolver = mockresolver.New(map[string][]net.IP{"echo.k6.test": {net.ParseIP("127.0.0.1")}})
+		ts.dialer.BlockedHostnames, err = types.NewHostnameTrie([]string{"*.blocked.test"})
+		require.NoError(t, err)
+		require.NoError(t, ts.VU.Runtime().Set("port", port))
+
+		ts.run(t, `
+			const conn = await tcp.connect("echo.k6.test:" + port);
+			conn.close();
+			let err;
+			try {
+				await tcp.connect("api.blocked.test:" + port);
+			} catch (e) {
+				err = e;
+			}
+			if (!err || !String(err).includes("is in a blocked pattern")) {
+				throw new Error("unexpected error " + err);
+			}
+		`)
+	})
+
+	t.Run("InvalidParams", func(t *testing.T) {
+		t.Parallel()
+		ts := newTestState(t)
+		addr := listenTCP(t, echo)
+		require.NoError(t, ts.VU.Runtime().Set("addr", addr))
+
+		ts.run(t, `
+			async function expectError(promise, msg) {
+				try {
+					await promise;
+				} catch (e) {
+					if (!String(e).includes(msg)) {
+						throw new Error("unexpected error " + e);
+					}
+					return;
+				}
+				throw new Error("expected an error with " + msg);
+			}
+			await expectError(tcp.connect("localhost"), "invalid address");
+			await expectError(tcp.connect(addr, { foo: 1 }), 'unknown param: "foo"');
+			const conn = await tcp.connect(addr);
+			await expectError(conn.read({ until: "" }), "the until delimiter can't be empty");
+			await expectError(conn.read({ until: "\n", size: 1 }), "until and size can't be used together");
+			await expectError(conn.read({ encoding: "latin1" }), 'unsupported encoding "latin1"');
+			await expectError(conn.read({ timeout: "10ms" }), "i/o timeout");
+			conn.close();
+		`)
+	})
+}
+
+func TestUDP(t *testing.T) {
+	t.Parallel()
+	ts := newTestState(t)
+
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = server.Close() })
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := server.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = server.WriteTo(buf[:n], addr)
+		}
+	}()
+	require.NoError(t, ts.VU.Runtime().Set("addr", server.LocalAddr().String()))
+
+	ts.run(t, `
+		const conn = await udp.connect(addr);
+		await conn.write("first");
+		await conn.write("second");
+		const first = await conn.read({ encoding: "utf-8", timeout: "5s" });
+		const second = await conn.read({ encoding: "utf-8", timeout: "5s" });
+		if (first !== "first" || second !== "second") {
+			throw new Error("unexpected datagrams " + first + ", " + second);
+		}
+		let err;
+		try {
+			await udp.connect(addr, { tls: true });
+		} catch (e) {
+			err = e;
+		}
+		if (!err || !String(err).includes("TLS is supported only for TCP connections")) {
+			throw new Error("unexpected error " + err);
+		}
+		conn.close();
+	`)
+
+	assert.Equal(t, int64(len("first")+len("second")), ts.dialer.BytesWritten)
+	assert.Empty(t, metrics.GetBufferedSamples(ts.samples))
+}
@@ -23,6 +23,8 @@ import (
 	"go.k6.io/k6/js/modules"
 	httpModule "go.k6.io/k6/js/modules/k6/http"
 	"go.k6.io/k6/lib"
+	"go.k6.io/k6/lib/netext"
+	"go.k6.io/k6/lib/types"
 	"go.k6.io/k6/metrics"
 )
 
@@ -105,6 +107,8 @@ type wsConnectArgs struct {
 	enableCompression bool
 	cookieJar         *cookiejar.Jar
 	tagsAndMeta       *metrics.TagsAndMeta
+	networkConditions *types.NetworkConditions
+	proxy             *types.Proxy
 }
 
 const writeWait = 10 * time.Second
@@ -259,7 +263,7 @@ func (mi *WS) dial(
 		// Pass a custom net.DialContext function to websocket.Dialer that will substitute
 		// the underlying net.Conn with our own tracked netext.Conn
 		NetDialContext:    state.Dialer.DialContext,
-		Proxy:             http.ProxyFromEnvironment,
+		Proxy:             netext.ProxyFromContext,
 		TLSClientConfig:   tlsConfig,
 		EnableCompression: args.enableCompression,
 	}
@@ -269,7 +273,11 @@ func (mi *WS) dial(
 	}
 
 	connStart := time.Now()
-	conn, httpResponse, dialErr := wsd.DialContext(ctx, url, args.headers)
+	dialCtx := netext.WithNetworkConditions(ctx, args.networkConditions)
+	if args.proxy != nil {
+		dialCtx = netext.WithProxy(dialCtx, args.proxy)
+	}
+	conn, httpResponse, dialErr := wsd.DialContext(dialCtx, url, args.headers)
 	connEnd := time.Now()
 
 	if state.Options.SystemTags.Has(metrics.TagIP) && conn.RemoteAddr() != nil {
@@ -645,10 +653,12 @@ func parseConnectArgs(state *lib.State, rt *sobek.Runtime, args ...sobek.Value)
 	headers.Set("User-Agent", state.Options.UserAgent.String)
 	tagsAndMeta := state.Tags.GetCurrentValues()
 	parsedArgs := &wsConnectArgs{
-		setupFn:     setupFn,
-		headers:     headers,
-		cookieJar:   state.CookieJar,
-		tagsAndMeta: &tagsAndMeta,
+		setupFn:           setupFn,
+		headers:           headers,
+		cookieJar:         state.CookieJar,
+		tagsAndMeta:       &tagsAndMeta,
+		networkConditions: state.NetworkConditions,
+		proxy:             state.Proxy,
 	}
 
 	if sobek.IsUndefined(paramsV) || sobek.IsNull(paramsV) {
@@ -699,6 +709,24 @@ func parseConnectArgs(state *lib.State, rt *sobek.Runtime, args ...sobek.Value)
 			}
 
 			parsedArgs.enableCompression = true
+		case "network":
+			if common.IsNullish(params.Get(k)) {
+				continue
+			}
+			nc, err := types.NetworkConditionsFromValue(params.Get(k).Export())
+			if err != nil {
+				return nil, err
+			}
+			parsedArgs.networkConditions = nc
+		case "proxy":
+			if common.IsNullish(params.Get(k)) {
+				continue
+			}
+			proxy, err := types.ProxyFromValue(params.Get(k).Export())
+			if err != nil {
+				return nil, err
+			}
+			parsedArgs.proxy = proxy
 		}
 	}
 
@@ -14,6 +14,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/grafana/sobek"
@@ -54,6 +55,7 @@ type Runner struct {
 	// TODO: Remove ActualResolver, it's a hack to simplify mocking in tests.
 	ActualResolver netext.MultiResolver
 	RPSLimit       *rate.Limiter
+	HostLimiter    *lib.HostLimiter
 	RunTags        *metrics.TagSet
 
 	console    *console
@@ -190,8 +192,15 @@ func (r *Runner) newVU(
 		})
 		tlsConfig.NameToCertificate = nameToCert
 	}
+	var tlsSessions *netext.SessionCache
+	if resumption := r.Bundle.Options.TLSSessionResumption; resumption.Bool {
+		tlsSessions = netext.NewSessionCache(0)
+		tlsConfig.ClientSessionCache = tlsSessions
+	} else if resumption.Valid {
+		tlsConfig.SessionTicketsDisabled = true
+	}
 	transport := &http.Transport{
-		Proxy:               http.ProxyFromEnvironment,
+		Proxy:               netext.ProxyFromContext,
 		TLSClientConfig:     tlsConfig,
 		DialContext:         dialer.DialContext,
 		DisableCompression:  true,
@@ -221,6 +230,7 @@ func (r *Runner) newVU(
 		Dialer:         dialer,
 		CookieJar:      cookieJar,
 		TLSConfig:      tlsConfig,
+		TLSSessions:    tlsSessions,
 		Console:        r.console,
 		BufferPool:     r.BufferPool,
 		Samples:        samplesOut,
@@ -235,6 +245,7 @@ func (r *Runner) newVU(
 		TLSConfig:      vu.TLSConfig,
 		CookieJar:      cookieJar,
 		RPSLimit:       vu.Runner.RPSLimit,
+		HostLimiter:    vu.Runner.HostLimiter,
 		BufferPool:     vu.BufferPool,
 		VUID:           vu.ID,
 		VUIDGlobal:     vu.IDGlobal,
@@ -243,8 +254,10 @@ func (r *Runner) newVU(
 		BuiltinMetrics: r.preInitState.BuiltinMetrics,
 		TracerProvider: r.preInitState.TracerProvider,
 		Usage:          r.preInitState.Usage,
+		Proxy:          vu.Runner.Bundle.Options.Proxy.Proxy,
 	}
 	vu.moduleVUImpl.state = vu.state
+	dialer.OnLookup = vu.emitDNSLookupSamples
 	_ = vu.Runtime.Set("console", vu.Console)
 
 	return vu, nil
@@ -438,6 +451,7 @@ func (r *Runner) SetOptions(opts lib.Options) error {
 	if rps := opts.RPS; rps.Valid && rps.Int64 > 0 {
 		r.RPSLimit = rate.NewLimiter(rate.Limit(rps.Int64), 1)
 	}
+	r.HostLimiter = lib.NewHostLimiter(opts.HostLimits)
 
 	// TODO: validate that all exec values are either nil or valid exported methods (or HTTP requests in the future)
 
@@ -492,8 +506,15 @@ func (r *Runner) setResolver(dns types.DNSConfig) error {
 	if !dnsPol.Valid {
 		dnsPol = types.DefaultDNSConfig().Policy
 	}
+	actualResolver := r.ActualResolver
+	if len(dns.Servers) > 0 || len(dns.Overrides) > 0 {
+		actualResolver, err = netext.NewServersResolver(dns, r.ActualResolver)
+		if err != nil {
+			return err
+		}
+	}
 	r.Resolver = netext.NewResolver(
-		r.ActualResolver, ttl, dnsSel.DNSSelect, dnsPol.DNSPolicy)
+		actualResolver, ttl, dnsSel.DNSSelect, dnsPol.DNSPolicy)
 
 	return nil
 }
@@ -604,9 +625,11 @@ type VU struct {
 	Dialer    *netext.Dialer
 	CookieJar *cookiejar.Jar
 	TLSConfig *tls.Config
-	ID        uint64 // local to the current instance
-	IDGlobal  uint64 // global across all instances
-	iteration int64
+	// TLSSessions is nil if the TLS session resumption is disabled.
+	TLSSessions *netext.SessionCache
+	ID          uint64 // local to the current instance
+	IDGlobal    uint64 // global across all instances
+	iteration   int64
 
 	Console    *console
 	BufferPool *lib.BufferPool
@@ -618,6 +641,8 @@ type VU struct {
 	state *lib.State
 	// count of iterations executed by this VU in each scenario
 	scenarioIter map[string]uint64
+	// iterTimeout is the maxIterationDuration timer of the running iteration, if any
+	iterTimeout *iterationTimeout
 }
 
 // Verify that interfaces are implemented
@@ -642,6 +667,37 @@ func (u *VU) GetID() uint64 {
 	return u.ID
 }
 
+// emitDNSLookupSamples emits the duration and the result of a DNS lookup made
+// by the dialer of the VU, tagged with the looked up host.
+func (u *VU) emitDNSLookupSamples(ctx context.Context, host string, start time.Time, err error) {
+	end := time.Now()
+	ctm := u.state.Tags.GetCurrentValues()
+	ctm.SetSystemTagOrMetaIfEnabled(u.state.Options.SystemTags, metrics.TagHost, host)
+	tags := ctm.Tags
+	failed := 0.0
+	if err != nil {
+		failed = 1
+	}
+	metrics.PushIfNotDone(ctx, u.state.Samples, metrics.ConnectedSamples{
+		Samples: []metrics.Sample{
+			{
+				TimeSeries: metrics.TimeSeries{Metric: u.state.BuiltinMetrics.DNSLookupDuration, Tags: tags},
+				Time:       end,
+				Metadata:   ctm.Metadata,
+				Value:      metrics.D(end.Sub(start)),
+			},
+			{
+				TimeSeries: metrics.TimeSeries{Metric: u.state.BuiltinMetrics.DNSLookupFailed, Tags: tags},
+				Time:       end,
+				Metadata:   ctm.Metadata,
+				Value:      failed,
+			},
+		},
+		Tags: tags,
+		Time: end,
+	})
+}
+
 // Activate the VU so it will be able to run code.
 func (u *VU) Activate(params *lib.VUActivationParams) lib.ActiveVU {
 	u.Runtime.ClearInterrupt()
@@ -686,6 +742,11 @@ func (u *VU) Activate(params *lib.VUActivationParams) lib.ActiveVU {
 	u.state.GetScenarioVUIter = func() uint64 {
 		return u.scenarioIter[params.Scenario]
 	}
+	u.state.NetworkConditions = params.NetworkConditions
+	u.state.Proxy = params.Proxy
+	if u.state.Proxy == nil {
+		u.state.Proxy = u.Runner.Bundle.Options.Proxy.Proxy
+	}
 
 	avu := &ActiveVU{
 		VU:                       u,
@@ -769,8 +830,32 @@ func (u *ActiveVU) RunOnce() error {
 
 	u.emitAndWaitEvent(&event.Event{Type: event.IterStart, Data: eventIterData})
 
+	if u.MaxIterationDuration > 0 {
+		u.iterTimeout = u.startIterationTimeout(cancel)
+	}
+
 	// Call the exported function.
 	_, isFullIteration, totalTime, err := u.runFn(ctx, true, fn, cancel, u.setupData)
+	if u.iterTimeout != nil && u.iterTimeout.timedOut {
+		err = &lib.IterationTimeoutError{Duration: u.MaxIterationDuration}
+		if u.RunContext.Err() == nil {
+			// Don't let the interrupt leak into the next iteration
+			u.Runtime.ClearInterrupt()
+		}
+
+		ctm := u.state.Tags.GetCurrentValues()
+		ctm.SetSystemTagOrMetaIfEnabled(u.state.Options.SystemTags, metrics.TagCause, "timeout")
+		metrics.PushIfNotDone(u.RunContext, u.state.Samples, metrics.Sample{
+			TimeSeries: metrics.TimeSeries{
+				Metric: u.Runner.preInitState.BuiltinMetrics.IterationsInterrupted,
+				Tags:   ctm.Tags,
+			},
+			Time:     time.Now(),
+			Metadata: ctm.Metadata,
+			Value:    1,
+		})
+	}
+	u.iterTimeout = nil
 	if err != nil {
 		var x *sobek.InterruptedError
 		if errors.As(err, &x) {
@@ -799,6 +884,45 @@ func (u *ActiveVU) RunOnce() error {
 	return err
 }
 
+// iterationTimeout interrupts the current iteration of a VU if it exceeds the
+// maxIterationDuration of its scenario.
+type iterationTimeout struct {
+	timer *time.Timer
+	fired chan struct{}
+	// finished is set by whichever comes first of the iteration's end and the
+	// timer firing, so an iteration that has already ended isn't interrupted.
+	finished atomic.Bool
+	timedOut bool
+}
+
+// startIterationTimeout starts a timer that interrupts the JS runtime and
+// cancels the iteration context (so that any Go code blocked on it unwinds)
+// once the maxIterationDuration is exceeded.
+func (u *ActiveVU) startIterationTimeout(cancel func()) *iterationTimeout {
+	it := &iterationTimeout{fired: make(chan struct{})}
+	it.timer = time.AfterFunc(u.MaxIterationDuration, func() {
+		if !it.finished.CompareAndSwap(false, true) {
+			return
+		}
+		defer close(it.fired)
+		u.Runtime.Interrupt(&lib.IterationTimeoutError{Duration: u.MaxIterationDuration})
+		cancel()
+	})
+	return it
+}
+
+// stop marks the iteration as finished and stops the timer. If the timer has
+// already fired, it waits for the interruption to be fully handled and records
+// that the iteration timed out.
+func (it *iterationTimeout) stop() {
+	if it.finished.CompareAndSwap(false, true) {
+		it.timer.Stop()
+		return
+	}
+	<-it.fired
+	it.timedOut = true
+}
+
 func (u *ActiveVU) emitAndWaitEvent(evt *event.Event) {
 	waitDone := u.moduleVUImpl.events.local.Emit(evt)
 	waitCtx, waitCancel := context.WithTimeout(u.RunContext, 30*time.Minute)
@@ -825,6 +949,12 @@ func (u *VU) runFn(
 	}
 
 	opts := &u.Runner.Bundle.Options
+	if opts.TLSFullHandshakePerIteration.Bool {
+		if u.TLSSessions != nil {
+			u.TLSSessions.Reset()
+		}
+		u.Transport.CloseIdleConnections()
+	}
 
 	if opts.SystemTags.Has(metrics.TagIter) {
 		u.state.Tags.Modify(func(tagsAndMeta *metrics.TagsAndMeta) {
@@ -841,6 +971,11 @@ func (u *VU) runFn(
 		v, err = fn(sobek.Undefined(), args...) // Actually run the JS script
 		return err
 	})
+	if isDefault && u.iterTimeout != nil {
+		// This has to happen before checking whether the iteration was a full
+		// one, so that both agree on whether it timed out.
+		u.iterTimeout.stop()
+	}
 
 	select {
 	case <-ctx.Done():
@@ -866,6 +1001,9 @@ func (u *VU) runFn(
 	builtinMetrics := u.Runner.preInitState.BuiltinMetrics
 	ctm := u.state.Tags.GetCurrentValues()
 	u.state.Samples <- u.Dialer.IOSamples(endTime, ctm, builtinMetrics)
+	for _, closed := range u.Dialer.ConnPool.ClosedSamples(endTime, ctm, u.state.Options.SystemTags, builtinMetrics) {
+		u.state.Samples <- closed
+	}
 
 	if isFullIteration && isDefault {
 		u.state.Samples <- iterationSamples(startTime, endTime, ctm, builtinMetrics)
@@ -46,6 +46,7 @@ import (
 	"go.k6.io/k6/lib/testutils/httpmultibin"
 	"go.k6.io/k6/lib/testutils/httpmultibin/grpc_testing"
 	"go.k6.io/k6/lib/testutils/mockoutput"
+	"go.k6.io/k6/lib/testutils/mockresolver"
 	"go.k6.io/k6/lib/types"
 	"go.k6.io/k6/metrics"
 	"go.k6.io/k6/output"
@@ -1103,6 +1104,160 @@ func TestVUIntegrationBlockHostnamesScript(t *testing.T) {
 	}
 }
 
+func TestVUIntegrationDNSLookupMetrics(t *testing.T) {
+	t.Parallel()
+	tb := httpmultibin.NewHTTPMultiBin(t)
+
+	r, err := getSimpleRunner(t, "/script.js", tb.Replacer.Replace(`
+		var http = require("k6/http");
+		exports.default = function() {
+			http.get("http://dns.k6.test:HTTPBIN_PORT/get");
+			http.get("http://missing.k6.test:HTTPBIN_PORT/get");
+		}
+	`))
+	require.NoError(t, err)
+	r.Resolver = mockresolver.New(map[string][]net.IP{"dns.k6.test": {net.ParseIP("127.0.0.1")}})
+	r.Bundle.Options.SystemTags = &metrics.DefaultSystemTagSet
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	samples := make(chan metrics.SampleContainer, 100)
+	initVU, err := r.NewVU(ctx, 1, 1, samples)
+	require.NoError(t, err)
+	vu := initVU.Activate(&lib.VUActivationParams{RunContext: ctx})
+	require.NoError(t, vu.RunOnce())
+	close(samples)
+
+	lookups := map[string]float64{}
+	durations := 0
+	for sc := range samples {
+		for _, s := range sc.GetSamples() {
+			host, _ := s.Tags.Get("host")
+			switch s.Metric.Name {
+			case metrics.DNSLookupFailedName:
+				lookups[host] = s.Value
+			case metrics.DNSLookupDurationName:
+				durations++
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"dns.k6.test": 0, "missing.k6.test": 1}, lookups)
+	assert.Equal(t, 2, durations)
+}
+
+func TestVUIntegrationHTTPConnPoolMetrics(t *testing.T) {
+	t.Parallel()
+	tb := httpmultibin.NewHTTPMultiBin(t)
+
+	r, err := getSimpleRunner(t, "/script.js", tb.Replacer.Replace(`
+		var http = require("k6/http");
+		exports.default = function() {
+			http.get("HTTPBIN_URL/get");
+			http.get("HTTPBIN_URL/get");
+			http.get("HTTP2BIN_URL/get");
+		}
+	`))
+	require.NoError(t, err)
+	require.NoError(t, r.SetOptions(lib.Options{
+		Throw:                 null.BoolFrom(true),
+		Hosts:                 types.NullHosts{Trie: tb.Dialer.Hosts},
+		InsecureSkipTLSVerify: null.BoolFrom(true),
+		NoVUConnectionReuse:   null.BoolFrom(true),
+		SystemTags:            &metrics.DefaultSystemTagSet,
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	samples := make(chan metrics.SampleContainer, 100)
+	initVU, err := r.NewVU(ctx, 1, 1, samples)
+	require.NoError(t, err)
+	vu := initVU.Activate(&lib.VUActivationParams{RunContext: ctx})
+	require.NoError(t, vu.RunOnce())
+	close(samples)
+
+	type hostMetric struct{ host, metric string }
+	got := map[hostMetric][]float64{}
+	for sc := range samples {
+		for _, s := range sc.GetSamples() {
+			switch s.Metric.Name {
+			case metrics.HTTPConnsOpenedName, metrics.HTTPConnsClosedName, metrics.HTTPReqConnReusedName,
+				metrics.HTTPIdleConnsName, metrics.HTTP2ConnStreamsName:
+				host, _ := s.Tags.Get("host")
+				got[hostMetric{host, s.Metric.Name}] = append(got[hostMetric{host, s.Metric.Name}], s.Value)
+			}
+		}
+	}
+	httpHost, http2Host := tb.Replacer.Replace("HTTPBIN_DOMAIN"), tb.Replacer.Replace("HTTP2BIN_DOMAIN")
+	assert.Equal(t, map[hostMetric][]float64{
+		{httpHost, metrics.HTTPConnsOpenedName}:    {1, 0},
+		{httpHost, metrics.HTTPReqConnReusedName}:  {0, 1},
+		{httpHost, metrics.HTTPIdleConnsName}:      {1, 1},
+		{httpHost, metrics.HTTPConnsClosedName}:    {1},
+		{http2Host, metrics.HTTPConnsOpenedName}:   {1},
+		{http2Host, metrics.HTTPReqConnReusedName}: {0},
+		{http2Host, metrics.HTTPIdleConnsName}:     {1},
+		{http2Host, metrics.HTTPConnsClosedName}:   {1},
+		{http2Host, metrics.HTTP2ConnStreamsName}:  {1},
+	}, got)
+}
+
+func TestVUIntegrationTLSSessionResumption(t *testing.T) {
+	t.Parallel()
+	tb := httpmultibin.NewHTTPMultiBin(t)
+
+	testCases := map[string]struct {
+		opts       lib.Options
+		expResumed bool
+	}{
+		"Default":  {opts: lib.Options{}},
+		"Enabled":  {opts: lib.Options{TLSSessionResumption: null.BoolFrom(true)}, expResumed: true},
+		"Disabled": {opts: lib.Options{TLSSessionResumption: null.BoolFrom(false)}},
+		"FullHandshake": {opts: lib.Options{
+			TLSSessionResumption:         null.BoolFrom(true),
+			TLSFullHandshakePerIteration: null.BoolFrom(true),
+		}},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r, err := getSimpleRunner(t, "/script.js", tb.Replacer.Replace(`
+				var http = require("k6/http");
+				exports.default = function() {
+					var res = http.get("HTTPSBIN_IP_URL/get");
+					if (res.tls_alpn !== "h2" && res.tls_alpn !== "http/1.1") {
+						throw new Error("unexpected ALPN protocol: " + res.tls_alpn);
+					}
+					var expResumed = __ITER > 0 && `+fmt.Sprint(tc.expResumed)+`;
+					if (res.tls_resumed !== expResumed) {
+						throw new Error("unexpected resumption in iteration " + __ITER + ": " + res.tls_resumed);
+					}
+				}
+			`))
+			require.NoError(t, err)
+			require.NoError(t, r.SetOptions(r.GetOptions().Apply(tc.opts).Apply(lib.Options{
+				Throw:                 null.BoolFrom(true),
+				InsecureSkipTLSVerify: null.BoolFrom(true),
+				NoVUConnectionReuse:   null.BoolFrom(true),
+			})))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			samples := make(chan metrics.SampleContainer, 100)
+			go func() {
+				for range samples {
+				}
+			}()
+			initVU, err := r.NewVU(ctx, 1, 1, samples)
+			require.NoError(t, err)
+			vu := initVU.Activate(&lib.VUActivationParams{RunContext: ctx})
+			for i := 0; i < 3; i++ {
+				require.NoError(t, vu.RunOnce())
+			}
+		})
+	}
+}
+
 func TestVUIntegrationHosts(t *testing.T) {
 	t.Parallel()
 	tb := httpmultibin.NewHTTPMultiBin(t)
@@ -2293,6 +2448,100 @@ func TestMinIterationDurationIsCancellable(t *testing.T) {
 	}
 }
 
+func TestMaxIterationDurationInterruptsIteration(t *testing.T) {
+	t.Parallel()
+
+	r, err := getSimpleRunner(t, "/script.js", `
+			exports.options = { systemTags: ["cause"] };
+			exports.default = function() {
+				if (__ITER == 0) { while(true) {} }
+			};
+		`)
+	require.NoError(t, err)
+
+	ch := make(chan metrics.SampleContainer, 1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	initVU, err := r.NewVU(ctx, 1, 1, ch)
+	require.NoError(t, err)
+
+	vu := initVU.Activate(&lib.VUActivationParams{
+		RunContext:           ctx,
+		MaxIterationDuration: 100 * time.Millisecond,
+	})
+
+	err = vu.RunOnce()
+	var timeoutErr *lib.IterationTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, 100*time.Millisecond, timeoutErr.Duration)
+
+	// the next iteration shouldn't be affected by the previous interruption
+	require.NoError(t, vu.RunOnce())
+
+	var interrupted, iterations float64
+	for _, sc := range metrics.GetBufferedSamples(ch) {
+		for _, sample := range sc.GetSamples() {
+			switch sample.Metric.Name {
+			case metrics.IterationsInterruptedName:
+				interrupted += sample.Value
+				cause, ok := sample.Tags.Get("cause")
+				require.True(t, ok)
+				assert.Equal(t, "timeout", cause)
+			case metrics.IterationsName:
+				iterations += sample.Value
+			}
+		}
+	}
+	assert.Equal(t, 1.0, interrupted)
+	assert.Equal(t, 1.0, iterations)
+}
+
+func TestMaxIterationDurationNotExceeded(t *testing.T) {
+	t.Parallel()
+
+	r, err := getSimpleRunner(t, "/script.js", `
+			exports.options = { systemTags: ["scenario"] };
+			exports.default = function() {
+				if (__ITER == 1) { while(true) {} }
+			};
+		`)
+	require.NoError(t, err)
+
+	ch := make(chan metrics.SampleContainer, 1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	initVU, err := r.NewVU(ctx, 1, 1, ch)
+	require.NoError(t, err)
+
+	vu := initVU.Activate(&lib.VUActivationParams{
+		RunContext:           ctx,
+		MaxIterationDuration: 50 * time.Millisecond,
+	})
+
+	require.NoError(t, vu.RunOnce())
+	// the timer of the finished iteration must not interrupt anything anymore
+	time.Sleep(100 * time.Millisecond)
+
+	var timeoutErr *lib.IterationTimeoutError
+	require.ErrorAs(t, vu.RunOnce(), &timeoutErr)
+
+	var interrupted, iterations float64
+	for _, sc := range metrics.GetBufferedSamples(ch) {
+		for _, sample := range sc.GetSamples() {
+			switch sample.Metric.Name {
+			case metrics.IterationsInterruptedName:
+				interrupted += sample.Value
+				_, ok := sample.Tags.Get("cause")
+				assert.False(t, ok, "the cause system tag is disabled")
+			case metrics.IterationsName:
+				iterations += sample.Value
+			}
+		}
+	}
+	assert.Equal(t, 1.0, interrupted)
+	assert.Equal(t, 1.0, iterations)
+}
+
 func TestForceHTTP1Feature(t *testing.T) {
 	t.Parallel()
 	cases := map[string]struct {
@@ -97,6 +97,21 @@ func summarizeMetricsToObject(data *lib.Summary, options lib.Options, setupData
 	}
 	m["metrics"] = metricsData
 
+	if len(data.Scenarios) > 0 {
+		scenariosData := make(map[string]interface{}, len(data.Scenarios))
+		for name, outcome := range data.Scenarios {
+			thresholds := make(map[string]interface{}, len(outcome.Thresholds))
+			for metricName, ok := range outcome.Thresholds {
+				thresholds[metricName] = map[string]interface{}{"ok": ok}
+			}
+			scenariosData[name] = map[string]interface{}{
+				"stopped":    outcome.Stopped,
+				"thresholds": thresholds,
+			}
+		}
+		m["scenarios"] = scenariosData
+	}
+
 	var setupDataI interface{}
 	if setupData != nil {
 		if err := json.Unmarshal(setupData, &setupDataI); err != nil {
@@ -127,6 +142,14 @@ func exportGroup(group *lib.Group) map[string]interface{} {
 			"passes": check.Passes,
 			"fails":  check.Fails,
 		}
+
+		// The details are only known for the failures of expect() assertions,
+		// so they are omitted for the rest of the checks.
+		if failures := check.Failures(); !failures.First.IsZero() {
+			checks[i]["first_failure"] = failures.First.UTC().Format(time.RFC3339Nano)
+			checks[i]["failure_reasons"] = append([]string{}, failures.Reasons...)
+			checks[i]["actual_values"] = append([]string{}, failures.ActualValues...)
+		}
 	}
 
 	return map[string]interface{}{
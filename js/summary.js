@@ -91,11 +91,28 @@ function summarizeCheck(indent, check, decorate) {
     ' / ' +
     failMark +
     ' ' +
-    check.fails,
+    check.fails +
+    summarizeCheckFailures(indent, check),
     palette.red
   )
 }
 
+function summarizeCheckFailures(indent, check) {
+  var details = (check.failure_reasons || []).slice()
+  if (check.actual_values && check.actual_values.length > 0) {
+    details.push('actual values: ' + check.actual_values.join(', '))
+  }
+  if (check.first_failure) {
+    details.push('first failure at ' + check.first_failure)
+  }
+
+  var result = ''
+  for (var i = 0; i < details.length; i++) {
+    result += '\n' + indent + ' ' + detailsPrefix + '  ' + details[i]
+  }
+  return result
+}
+
 function summarizeGroup(indent, group, decorate) {
   var result = []
   if (group.name != '') {
@@ -381,6 +398,30 @@ function summarizeMetrics(options, data, decorate) {
   return result
 }
 
+function summarizeScenarios(indent, data, decorate) {
+  var result = []
+  var names = Object.keys(data.scenarios || {}).sort()
+  for (var name of names) {
+    var scenario = data.scenarios[name]
+    var ok = true
+    forEach(scenario.thresholds, function (metricName, threshold) {
+      if (!threshold.ok) {
+        ok = false
+        return true // break
+      }
+    })
+    var line = indent + (ok ? succMark : failMark) + ' scenario ' + name
+    if (scenario.stopped) {
+      line += ' (stopped early by thresholds)'
+    }
+    result.push(decorate(line, ok ? palette.green : palette.red))
+  }
+  if (result.length > 0) {
+    result.unshift('')
+  }
+  return result
+}
+
 function generateTextSummary(data, options) {
   var mergedOpts = Object.assign({}, defaultOptions, data.options, options)
   var lines = []
@@ -405,6 +446,10 @@ function generateTextSummary(data, options) {
   )
 
   Array.prototype.push.apply(lines, summarizeMetrics(mergedOpts, data, decorate))
+  Array.prototype.push.apply(
+    lines,
+    summarizeScenarios(mergedOpts.indent + '    ', data, decorate)
+  )
 
   return lines.join('\n')
 }
@@ -133,6 +133,68 @@ func TestTextSummaryWithSubMetrics(t *testing.T) {
 	assert.Equal(t, "\n"+expected+"\n", string(summaryOut))
 }
 
+func TestSummaryWithCheckFailures(t *testing.T) {
+	t.Parallel()
+
+	rootG, err := lib.NewGroup("", nil)
+	require.NoError(t, err)
+	check, err := rootG.Check("status is 200")
+	require.NoError(t, err)
+	check.Passes = 1
+	check.Fails = 2
+	firstFailure := time.Date(2024, time.May, 6, 7, 8, 9, 0, time.UTC)
+	check.AddFailure(firstFailure.Add(time.Second), "expected 500 to be 200", "500")
+	check.AddFailure(firstFailure, "expected 404 to be 200", "404")
+
+	summary := &lib.Summary{
+		Metrics:         map[string]*metrics.Metric{},
+		RootGroup:       rootG,
+		TestRunDuration: time.Second,
+	}
+
+	handleSummary := func(t *testing.T, script string) map[string]io.Reader {
+		t.Helper()
+		runner, err := getSimpleRunner(t, "/script.js", script,
+			lib.RuntimeOptions{CompatibilityMode: null.NewString("base", true)})
+		require.NoError(t, err)
+
+		result, err := runner.HandleSummary(context.Background(), summary)
+		require.NoError(t, err)
+		return result
+	}
+
+	result := handleSummary(t, `exports.default = function() {/* we don't run this, metrics are mocked */};`)
+	require.NotNil(t, result["stdout"])
+	summaryOut, err := io.ReadAll(result["stdout"])
+	require.NoError(t, err)
+	assert.Contains(t, string(summaryOut), "     ✗ status is 200\n"+
+		"      ↳  33% — ✓ 1 / ✗ 2\n"+
+		"      ↳  expected 500 to be 200\n"+
+		"      ↳  expected 404 to be 200\n"+
+		"      ↳  actual values: 500, 404\n"+
+		"      ↳  first failure at 2024-05-06T07:08:09Z\n")
+
+	result = handleSummary(t, `
+		exports.default = function() {/* we don't run this, metrics are mocked */};
+		exports.handleSummary = function(data) {
+			return {'check.json': JSON.stringify(data.root_group.checks[0])};
+		};
+	`)
+	require.NotNil(t, result["check.json"])
+	checkData, err := io.ReadAll(result["check.json"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "status is 200",
+		"path": "::status is 200",
+		"id": "`+check.ID+`",
+		"passes": 1,
+		"fails": 2,
+		"first_failure": "2024-05-06T07:08:09Z",
+		"failure_reasons": ["expected 500 to be 200", "expected 404 to be 200"],
+		"actual_values": ["500", "404"]
+	}`, string(checkData))
+}
+
 func createTestMetrics(t *testing.T) (map[string]*metrics.Metric, *lib.Group) {
 	registry := metrics.NewRegistry()
 	testMetrics := make(map[string]*metrics.Metric)
@@ -80,6 +80,11 @@ type ExecutionState struct {
 
 	ExecutionTuple *ExecutionTuple // TODO Rename, possibly move
 
+	// SharedStore, if set, is used for the mutable state that's shared between
+	// the VUs of all k6 instances. It's set by the scheduler when the
+	// execution.Controller of a distributed test run implements it.
+	SharedStore SharedStore
+
 	// vus is the shared channel buffer that contains all of the VUs that have
 	// been initialized and aren't currently being used by a executor.
 	//
@@ -196,6 +201,63 @@ type ExecutionState struct {
 	pauseStateLock      sync.RWMutex
 	totalPausedDuration time.Duration // only modified behind the lock
 	resumeNotify        chan struct{}
+
+	// Per-scenario controls, lazily created the first time they are needed by
+	// either the executor of a scenario or whatever wants to control it.
+	scenarioControlsLock sync.Mutex
+	scenarioControls     map[string]*ScenarioControl
+}
+
+// ScenarioControl holds the state used for controlling a single scenario
+// independently from the rest of the test run. Apart from the atomics, its
+// fields are guarded by the ExecutionState's scenarioControlsLock.
+//
+// Executors can get it once with GetScenarioControl() and use its methods on
+// every iteration, without looking it up in the shared map each time.
+type ScenarioControl struct {
+	stopNotify chan struct{}
+	stopReason error
+
+	// closed when the scenario isn't paused, same as the test-wide one; it's
+	// only replaced behind the lock, but can be loaded without it
+	resumeNotify atomic.Pointer[chan struct{}]
+	paused       bool
+
+	started, ended        bool
+	activeVUs             int64
+	fullIterations        uint64
+	interruptedIterations uint64
+}
+
+// ResumeNotify returns a channel that is closed when the scenario isn't
+// paused. Since a scenario could be paused again after being resumed, the
+// channel needs to be requested again before each wait.
+func (sc *ScenarioControl) ResumeNotify() <-chan struct{} {
+	return *sc.resumeNotify.Load()
+}
+
+// AddFullIterations increments the number of full iterations of the scenario
+// by the supplied amount.
+func (sc *ScenarioControl) AddFullIterations(count uint64) uint64 {
+	return atomic.AddUint64(&sc.fullIterations, count)
+}
+
+// AddInterruptedIterations increments the number of partial iterations of
+// the scenario by the supplied amount.
+func (sc *ScenarioControl) AddInterruptedIterations(count uint64) uint64 {
+	return atomic.AddUint64(&sc.interruptedIterations, count)
+}
+
+// ScenarioStats contains the current state of a single scenario.
+type ScenarioStats struct {
+	Started, Ended, Paused, Stopped bool
+
+	// ActiveVUs is the number of VUs that are currently activated by the
+	// scenario's executor. For the externally-controlled executor, these are
+	// the VUs that are started and not paused.
+	ActiveVUs             int64
+	FullIterations        uint64
+	InterruptedIterations uint64
 }
 
 // NewExecutionState initializes all of the pointers in the ExecutionState
@@ -230,6 +292,7 @@ func NewExecutionState(
 		pauseStateLock:             sync.RWMutex{},
 		totalPausedDuration:        0, // Accessed only behind the pauseStateLock
 		resumeNotify:               resumeNotify,
+		scenarioControls:           make(map[string]*ScenarioControl),
 	}
 }
 
@@ -547,3 +610,178 @@ func (es *ExecutionState) ReturnVU(vu InitializedVU, wasActive bool) {
 		es.ModCurrentlyActiveVUsCount(-1)
 	}
 }
+
+// GetScenarioControl returns the controls for the given scenario, creating
+// them if they don't exist yet.
+func (es *ExecutionState) GetScenarioControl(name string) *ScenarioControl {
+	es.scenarioControlsLock.Lock()
+	defer es.scenarioControlsLock.Unlock()
+
+	sc, ok := es.scenarioControls[name]
+	if !ok {
+		resumeNotify := make(chan struct{})
+		close(resumeNotify)
+		sc = &ScenarioControl{stopNotify: make(chan struct{})}
+		sc.resumeNotify.Store(&resumeNotify)
+		es.scenarioControls[name] = sc
+	}
+	return sc
+}
+
+// StopScenario gracefully stops the scenario with the given name, without
+// affecting any of the other scenarios. Its executor won't start any new
+// iterations and any iterations in progress will have the scenario's
+// gracefulStop period to finish. Stopping an already stopped scenario is a
+// no-op and an error is returned if there's no such scenario in the test.
+func (es *ExecutionState) StopScenario(name string, reason error) error {
+	if err := es.checkScenarioExists(name); err != nil {
+		return err
+	}
+
+	sc := es.GetScenarioControl(name)
+	es.scenarioControlsLock.Lock()
+	defer es.scenarioControlsLock.Unlock()
+	select {
+	case <-sc.stopNotify:
+		// already stopped
+	default:
+		sc.stopReason = reason
+		close(sc.stopNotify)
+	}
+	return nil
+}
+
+// ScenarioStopNotify returns a channel that will be closed when the scenario
+// with the given name is stopped via StopScenario().
+func (es *ExecutionState) ScenarioStopNotify(name string) <-chan struct{} {
+	return es.GetScenarioControl(name).stopNotify
+}
+
+// GetScenarioStopReason returns whether the scenario with the given name was
+// stopped early, and the reason that was given for stopping it.
+func (es *ExecutionState) GetScenarioStopReason(name string) (bool, error) {
+	sc := es.GetScenarioControl(name)
+	es.scenarioControlsLock.Lock()
+	defer es.scenarioControlsLock.Unlock()
+	select {
+	case <-sc.stopNotify:
+		return true, sc.stopReason
+	default:
+		return false, nil
+	}
+}
+
+func (es *ExecutionState) checkScenarioExists(name string) error {
+	if es.Test == nil {
+		return nil
+	}
+	if _, ok := es.Test.Options.Scenarios[name]; !ok {
+		return fmt.Errorf("scenario '%s' doesn't exist", name)
+	}
+	return nil
+}
+
+// PauseScenario pauses the scenario with the given name. Its VUs won't start
+// any new iterations until the scenario is resumed, though any iterations in
+// progress won't be interrupted. The scenario's duration isn't extended by
+// the time it spent paused, and arrival-rate executors will drop the
+// iterations they can't start because of it.
+func (es *ExecutionState) PauseScenario(name string) error {
+	if err := es.checkScenarioExists(name); err != nil {
+		return err
+	}
+	sc := es.GetScenarioControl(name)
+	es.scenarioControlsLock.Lock()
+	defer es.scenarioControlsLock.Unlock()
+	if sc.paused {
+		return fmt.Errorf("scenario '%s' is already paused", name)
+	}
+	sc.paused = true
+	resumeNotify := make(chan struct{})
+	sc.resumeNotify.Store(&resumeNotify)
+	return nil
+}
+
+// ResumeScenario resumes the scenario with the given name, if it was paused
+// with PauseScenario().
+func (es *ExecutionState) ResumeScenario(name string) error {
+	if err := es.checkScenarioExists(name); err != nil {
+		return err
+	}
+	sc := es.GetScenarioControl(name)
+	es.scenarioControlsLock.Lock()
+	defer es.scenarioControlsLock.Unlock()
+	if !sc.paused {
+		return fmt.Errorf("scenario '%s' isn't paused", name)
+	}
+	sc.paused = false
+	close(*sc.resumeNotify.Load())
+	return nil
+}
+
+// ScenarioResumeNotify returns a channel that is closed when the scenario with
+// the given name isn't paused. Since a scenario could be paused again after
+// being resumed, the channel needs to be requested again before each wait.
+func (es *ExecutionState) ScenarioResumeNotify(name string) <-chan struct{} {
+	return es.GetScenarioControl(name).ResumeNotify()
+}
+
+// MarkScenarioStarted records that the executor of the given scenario started.
+func (es *ExecutionState) MarkScenarioStarted(name string) {
+	sc := es.GetScenarioControl(name)
+	es.scenarioControlsLock.Lock()
+	defer es.scenarioControlsLock.Unlock()
+	sc.started = true
+}
+
+// MarkScenarioEnded records that the executor of the given scenario finished.
+func (es *ExecutionState) MarkScenarioEnded(name string) {
+	sc := es.GetScenarioControl(name)
+	es.scenarioControlsLock.Lock()
+	defer es.scenarioControlsLock.Unlock()
+	sc.ended = true
+}
+
+// ModScenarioActiveVUsCount changes the number of the currently active VUs
+// of the given scenario and returns the new value.
+func (es *ExecutionState) ModScenarioActiveVUsCount(name string, mod int64) int64 {
+	return atomic.AddInt64(&es.GetScenarioControl(name).activeVUs, mod)
+}
+
+// AddScenarioFullIterations increments the number of full iterations of the
+// given scenario by the supplied amount.
+func (es *ExecutionState) AddScenarioFullIterations(name string, count uint64) uint64 {
+	return es.GetScenarioControl(name).AddFullIterations(count)
+}
+
+// AddScenarioInterruptedIterations increments the number of partial
+// iterations of the given scenario by the supplied amount.
+func (es *ExecutionState) AddScenarioInterruptedIterations(name string, count uint64) uint64 {
+	return es.GetScenarioControl(name).AddInterruptedIterations(count)
+}
+
+// GetScenarioStats returns the current state of the scenario with the given
+// name, or an error if there is no such scenario in the test.
+func (es *ExecutionState) GetScenarioStats(name string) (ScenarioStats, error) {
+	if err := es.checkScenarioExists(name); err != nil {
+		return ScenarioStats{}, err
+	}
+	sc := es.GetScenarioControl(name)
+	es.scenarioControlsLock.Lock()
+	defer es.scenarioControlsLock.Unlock()
+
+	stats := ScenarioStats{
+		Started:               sc.started,
+		Ended:                 sc.ended,
+		Paused:                sc.paused,
+		ActiveVUs:             atomic.LoadInt64(&sc.activeVUs),
+		FullIterations:        atomic.LoadUint64(&sc.fullIterations),
+		InterruptedIterations: atomic.LoadUint64(&sc.interruptedIterations),
+	}
+	select {
+	case <-sc.stopNotify:
+		stats.Stopped = true
+	default:
+	}
+	return stats, nil
+}
@@ -12,6 +12,7 @@ import (
 	"go.k6.io/k6/lib"
 	"go.k6.io/k6/lib/consts"
 	"go.k6.io/k6/lib/types"
+	"go.k6.io/k6/metrics"
 )
 
 // DefaultGracefulStopValue is the graceful top value for all executors, unless
@@ -34,6 +35,22 @@ type BaseConfig struct {
 	Tags         map[string]string    `json:"tags"`
 	Options      *lib.ScenarioOptions `json:"options,omitempty"`
 
+	MaxIterationDuration types.NullDuration `json:"maxIterationDuration"`
+	RecycleVUOnTimeout   null.Bool          `json:"recycleVUOnTimeout"`
+
+	// Network conditions, e.g. latency and bandwidth limits, that are
+	// emulated for all connections made by the VUs of this scenario.
+	Network *types.NetworkConditions `json:"network,omitempty"`
+
+	// Proxy used for the requests of the VUs of this scenario, instead of the
+	// global one.
+	Proxy *types.Proxy `json:"proxy,omitempty"`
+
+	// Thresholds that are evaluated only against the metrics emitted by this
+	// scenario. If one with abortOnFail is crossed, only this scenario is
+	// stopped, while the rest of the test continues.
+	Thresholds map[string]metrics.Thresholds `json:"thresholds,omitempty"`
+
 	// TODO: future extensions like distribution, others?
 }
 
@@ -69,6 +86,12 @@ func (bc BaseConfig) Validate() (result []error) {
 	if bc.GracefulStop.Duration < 0 {
 		result = append(result, errors.New("the gracefulStop timeout can't be negative"))
 	}
+	if bc.MaxIterationDuration.Duration < 0 {
+		result = append(result, errors.New("the maxIterationDuration can't be negative"))
+	}
+	if bc.RecycleVUOnTimeout.Bool && bc.MaxIterationDuration.Duration <= 0 {
+		result = append(result, errors.New("recycleVUOnTimeout requires a positive maxIterationDuration"))
+	}
 	return result
 }
 
@@ -98,6 +121,24 @@ func (bc BaseConfig) GetGracefulStop() time.Duration {
 	return bc.GracefulStop.TimeDuration()
 }
 
+// GetMaxIterationDuration returns how long a single iteration is allowed to
+// run before it's interrupted. Zero means that iterations are never timed out.
+func (bc BaseConfig) GetMaxIterationDuration() time.Duration {
+	return bc.MaxIterationDuration.TimeDuration()
+}
+
+// GetNetworkConditions returns the network conditions emulated for the
+// connections of this scenario, if any.
+func (bc BaseConfig) GetNetworkConditions() *types.NetworkConditions {
+	return bc.Network
+}
+
+// GetProxy returns the proxy used for the requests of this scenario, if it
+// overrides the global one.
+func (bc BaseConfig) GetProxy() *types.Proxy {
+	return bc.Proxy
+}
+
 // GetEnv returns any specific environment key=value pairs that
 // are configured for the executor.
 func (bc BaseConfig) GetEnv() map[string]string {
@@ -118,6 +159,13 @@ func (bc BaseConfig) GetScenarioOptions() *lib.ScenarioOptions {
 	return bc.Options
 }
 
+var _ lib.ScenarioThresholdsConfig = BaseConfig{}
+
+// GetThresholds returns the thresholds that apply only to this scenario.
+func (bc BaseConfig) GetThresholds() map[string]metrics.Thresholds {
+	return bc.Thresholds
+}
+
 // GetTags returns any custom tags configured for the scenario.
 func (bc BaseConfig) GetTags() map[string]string {
 	return bc.Tags
@@ -140,6 +188,15 @@ func (bc BaseConfig) getBaseInfo(facts ...string) string {
 	if bc.GracefulStop.Duration > 0 {
 		facts = append(facts, fmt.Sprintf("gracefulStop: %s", bc.GracefulStop.Duration))
 	}
+	if bc.MaxIterationDuration.Duration > 0 {
+		facts = append(facts, fmt.Sprintf("maxIterationDuration: %s", bc.MaxIterationDuration.Duration))
+	}
+	if bc.Network != nil {
+		facts = append(facts, "network emulation")
+	}
+	if bc.Proxy != nil && bc.Proxy.URL != nil {
+		facts = append(facts, fmt.Sprintf("proxy: %s", bc.Proxy))
+	}
 	if len(facts) == 0 {
 		return ""
 	}
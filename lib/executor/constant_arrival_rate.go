@@ -212,7 +212,8 @@ func (car ConstantArrivalRate) Run(parentCtx context.Context, out chan<- metrics
 
 	returnedVUs := make(chan struct{})
 	waitOnProgressChannel := make(chan struct{})
-	startTime, maxDurationCtx, regDurationCtx, cancel := getDurationContexts(parentCtx, duration, gracefulStop)
+	startTime, maxDurationCtx, regDurationCtx, cancel := getDurationContexts(
+		parentCtx, duration, gracefulStop, car.executionState.ScenarioStopNotify(car.config.Name))
 	defer func() {
 		cancel()
 		<-waitOnProgressChannel
@@ -273,13 +274,14 @@ func (car ConstantArrivalRate) Run(parentCtx context.Context, out chan<- metrics
 		activeVUsWg.Done()
 	}
 
-	runIterationBasic := getIterationRunner(car.executionState, car.logger)
+	runIterationBasic := getIterationRunner(car.executionState, car.config.Name, car.logger)
 	activateVU := func(initVU lib.InitializedVU) lib.ActiveVU {
 		activeVUsWg.Add(1)
-		activeVU := initVU.Activate(getVUActivationParams(
-			maxDurationCtx, car.config.BaseConfig, returnVU,
-			car.nextIterationCounters,
-		))
+		activeVU := activateVU(car.executionState, car.logger, car.config.BaseConfig, initVU,
+			getVUActivationParams(
+				maxDurationCtx, car.config.BaseConfig, returnVU,
+				car.nextIterationCounters,
+			))
 		atomic.AddUint64(&activeVUsCount, 1)
 		vusPool.AddVU(maxDurationCtx, activeVU, runIterationBasic)
 		return activeVU
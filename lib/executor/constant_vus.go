@@ -128,7 +128,8 @@ func (clv ConstantVUs) Run(parentCtx context.Context, _ chan<- metrics.SampleCon
 	gracefulStop := clv.config.GetGracefulStop()
 
 	waitOnProgressChannel := make(chan struct{})
-	startTime, maxDurationCtx, regDurationCtx, cancel := getDurationContexts(parentCtx, duration, gracefulStop)
+	startTime, maxDurationCtx, regDurationCtx, cancel := getDurationContexts(
+		parentCtx, duration, gracefulStop, clv.executionState.ScenarioStopNotify(clv.config.Name))
 	defer func() {
 		cancel()
 		<-waitOnProgressChannel
@@ -168,7 +169,7 @@ func (clv ConstantVUs) Run(parentCtx context.Context, _ chan<- metrics.SampleCon
 	defer activeVUs.Wait()
 
 	regDurationDone := regDurationCtx.Done()
-	runIteration := getIterationRunner(clv.executionState, clv.logger)
+	runIteration := getIterationRunner(clv.executionState, clv.config.Name, clv.logger)
 
 	returnVU := func(u lib.InitializedVU) {
 		clv.executionState.ReturnVU(u, true)
@@ -179,7 +180,7 @@ func (clv ConstantVUs) Run(parentCtx context.Context, _ chan<- metrics.SampleCon
 		ctx, cancel := context.WithCancel(maxDurationCtx)
 		defer cancel()
 
-		activeVU := initVU.Activate(
+		activeVU := activateVU(clv.executionState, clv.logger, clv.config.BaseConfig, initVU,
 			getVUActivationParams(ctx, clv.config.BaseConfig, returnVU, clv.nextIterationCounters))
 
 		for {
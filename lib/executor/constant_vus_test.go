@@ -2,7 +2,9 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -52,3 +54,70 @@ func TestConstantVUsRun(t *testing.T) {
 	})
 	assert.Equal(t, uint64(50), totalIters)
 }
+
+func TestConstantVUsStopScenario(t *testing.T) {
+	t.Parallel()
+
+	runner := simpleRunner(func(_ context.Context, _ *lib.State) error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+
+	config := getTestConstantVUsConfig()
+	config.Name = "spike"
+	config.Duration = types.NullDurationFrom(10 * time.Second)
+	options := lib.Options{Scenarios: lib.ScenarioConfigs{"spike": config}}
+	test := setupExecutorTest(t, "", "", options, runner, config)
+	defer test.cancel()
+
+	assert.Error(t, test.state.StopScenario("unknown", nil))
+	time.AfterFunc(200*time.Millisecond, func() {
+		assert.NoError(t, test.state.StopScenario("spike", errors.New("threshold crossed")))
+	})
+
+	start := time.Now()
+	require.NoError(t, test.executor.Run(test.ctx, nil))
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	stopped, reason := test.state.GetScenarioStopReason("spike")
+	assert.True(t, stopped)
+	assert.EqualError(t, reason, "threshold crossed")
+	assert.Positive(t, test.state.GetFullIterationCount())
+}
+
+func TestConstantVUsPauseScenario(t *testing.T) {
+	t.Parallel()
+
+	var iterations int64
+	runner := simpleRunner(func(_ context.Context, _ *lib.State) error {
+		atomic.AddInt64(&iterations, 1)
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+
+	config := getTestConstantVUsConfig()
+	config.Name = "paused"
+	config.VUs = null.IntFrom(2)
+	options := lib.Options{Scenarios: lib.ScenarioConfigs{"paused": config}}
+	test := setupExecutorTest(t, "", "", options, runner, config)
+	defer test.cancel()
+
+	require.NoError(t, test.state.PauseScenario("paused"))
+	time.AfterFunc(500*time.Millisecond, func() {
+		stats, err := test.state.GetScenarioStats("paused")
+		assert.NoError(t, err)
+		assert.True(t, stats.Paused)
+		assert.Equal(t, int64(2), stats.ActiveVUs)
+		assert.Zero(t, atomic.LoadInt64(&iterations))
+		assert.NoError(t, test.state.ResumeScenario("paused"))
+	})
+
+	require.NoError(t, test.executor.Run(test.ctx, nil))
+
+	stats, err := test.state.GetScenarioStats("paused")
+	require.NoError(t, err)
+	assert.False(t, stats.Paused)
+	assert.Zero(t, stats.ActiveVUs)
+	assert.Positive(t, stats.FullIterations)
+	assert.Equal(t, uint64(atomic.LoadInt64(&iterations)), stats.FullIterations) //nolint:gosec
+}
@@ -105,6 +105,18 @@ var configMapTestCases = []configMapTestCase{
 	{`{"aname": {"executor": "constant-vus", "vus": 10, "duration": "10s", "startTime": "-10s"}}`, exp{validationError: true}},
 	{`{"aname": {"executor": "constant-vus", "vus": 10, "duration": "10s", "exec": ""}}`, exp{validationError: true}},
 	{`{"aname": {"executor": "constant-vus", "vus": 10, "duration": "10s", "gracefulStop": "-2s"}}`, exp{validationError: true}},
+	{`{"aname": {"executor": "constant-vus", "vus": 10, "duration": "10s", "maxIterationDuration": "-2s"}}`, exp{validationError: true}},
+	{`{"aname": {"executor": "constant-vus", "vus": 10, "duration": "10s", "recycleVUOnTimeout": true}}`, exp{validationError: true}},
+	{
+		`{"aname": {"executor": "constant-vus", "vus": 10, "duration": "10s", "maxIterationDuration": "2s", "recycleVUOnTimeout": true}}`,
+		exp{custom: func(t *testing.T, cm lib.ScenarioConfigs) {
+			assert.Empty(t, cm.Validate())
+			cfg, ok := cm["aname"].(ConstantVUsConfig)
+			require.True(t, ok)
+			assert.Equal(t, 2*time.Second, cfg.GetMaxIterationDuration())
+			assert.True(t, cfg.RecycleVUOnTimeout.Bool)
+		}},
+	},
 	// ramping-vus
 	{
 		`{"varloops": {"executor": "ramping-vus", "startVUs": 20, "gracefulStop": "15s", "gracefulRampDown": "10s",
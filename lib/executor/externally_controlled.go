@@ -100,6 +100,11 @@ func (mec ExternallyControlledConfig) Validate() []error {
 			"gracefulStop is not supported by the externally controlled executor",
 		))
 	}
+	if mec.RecycleVUOnTimeout.Bool {
+		errors = append(errors, fmt.Errorf(
+			"recycleVUOnTimeout is not supported by the externally controlled executor",
+		))
+	}
 	return errors
 }
 
@@ -331,11 +336,13 @@ func (rs *externallyControlledRunState) newManualVUHandle(
 	getVU := func() (lib.InitializedVU, error) {
 		wg.Add(1)
 		state.ModCurrentlyActiveVUsCount(+1)
+		state.ModScenarioActiveVUsCount(rs.executor.config.Name, +1)
 		atomic.AddInt64(rs.activeVUsCount, +1)
 		return initVU, nil
 	}
 	returnVU := func(_ lib.InitializedVU) {
 		state.ModCurrentlyActiveVUsCount(-1)
+		state.ModScenarioActiveVUsCount(rs.executor.config.Name, -1)
 		atomic.AddInt64(rs.activeVUsCount, -1)
 		wg.Done()
 	}
@@ -523,7 +530,7 @@ func (mex *ExternallyControlled) Run(parentCtx context.Context, _ chan<- metrics
 		currentlyPaused: false,
 		activeVUsCount:  new(int64),
 		maxVUs:          new(int64),
-		runIteration:    getIterationRunner(mex.executionState, mex.logger),
+		runIteration:    getIterationRunner(mex.executionState, mex.config.Name, mex.logger),
 	}
 	ss.ProgressFn = runState.progressFn
 
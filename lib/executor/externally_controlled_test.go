@@ -35,7 +35,9 @@ func TestExternallyControlledRun(t *testing.T) {
 		return nil
 	})
 
-	test := setupExecutorTest(t, "", "", lib.Options{}, runner, getTestExternallyControlledConfig())
+	config := getTestExternallyControlledConfig()
+	options := lib.Options{Scenarios: lib.ScenarioConfigs{config.Name: config}}
+	test := setupExecutorTest(t, "", "", options, runner, config)
 	defer test.cancel()
 
 	var (
@@ -68,8 +70,11 @@ func TestExternallyControlledRun(t *testing.T) {
 
 	var resultVUCount [][]int64
 	snapshot := func() {
-		resultVUCount = append(resultVUCount,
-			[]int64{test.state.GetCurrentlyActiveVUsCount(), test.state.GetInitializedVUsCount()})
+		stats, err := test.state.GetScenarioStats(config.Name)
+		assert.NoError(t, err)
+		resultVUCount = append(resultVUCount, []int64{
+			test.state.GetCurrentlyActiveVUsCount(), test.state.GetInitializedVUsCount(), stats.ActiveVUs,
+		})
 	}
 
 	wg.Add(1)
@@ -105,5 +110,5 @@ func TestExternallyControlledRun(t *testing.T) {
 	wg.Wait()
 	require.NoError(t, <-errCh)
 	assert.InDelta(t, 48, int(atomic.LoadUint64(doneIters)), 2)
-	assert.Equal(t, [][]int64{{2, 10}, {4, 10}, {8, 20}, {4, 10}, {0, 10}}, resultVUCount)
+	assert.Equal(t, [][]int64{{2, 10, 2}, {4, 10, 4}, {8, 20, 8}, {4, 10, 4}, {0, 10, 0}}, resultVUCount)
 }
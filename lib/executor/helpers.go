@@ -98,13 +98,28 @@ func handleInterrupt(ctx context.Context, err error) bool {
 
 // getIterationRunner is a helper function that returns an iteration executor
 // closure. It takes care of updating the execution state statistics and
-// warning messages. And returns whether a full iteration was finished or not
+// warning messages. And returns whether a full iteration was finished or not.
+// If the scenario is paused, it waits for it to be resumed before starting the
+// iteration.
 //
 // TODO: emit the end-of-test iteration metrics here (https://github.com/k6io/k6/issues/1250)
 func getIterationRunner(
-	executionState *lib.ExecutionState, logger *logrus.Entry,
+	executionState *lib.ExecutionState, scenario string, logger *logrus.Entry,
 ) func(context.Context, lib.ActiveVU) bool {
+	// Resolved once, so the per-iteration bookkeeping below doesn't need to
+	// go through the lock guarding the controls of all scenarios.
+	scenarioControl := executionState.GetScenarioControl(scenario)
+	addInterrupted := func() {
+		executionState.AddInterruptedIterations(1)
+		scenarioControl.AddInterruptedIterations(1)
+	}
 	return func(ctx context.Context, vu lib.ActiveVU) bool {
+		select {
+		case <-scenarioControl.ResumeNotify():
+		case <-ctx.Done():
+			return false
+		}
+
 		err := vu.RunOnce()
 
 		// TODO: track (non-ramp-down) errors from script iterations as a metric,
@@ -114,12 +129,19 @@ func getIterationRunner(
 		select {
 		case <-ctx.Done():
 			// Don't log errors or emit iterations metrics from cancelled iterations
-			executionState.AddInterruptedIterations(1)
+			addInterrupted()
 			return false
 		default:
 			if err != nil {
 				if handleInterrupt(ctx, err) {
-					executionState.AddInterruptedIterations(1)
+					addInterrupted()
+					return false
+				}
+
+				var timeoutErr *lib.IterationTimeoutError
+				if errors.As(err, &timeoutErr) {
+					logger.Warn(err.Error())
+					addInterrupted()
 					return false
 				}
 
@@ -135,6 +157,7 @@ func getIterationRunner(
 
 			// TODO: move emission of end-of-iteration metrics here?
 			executionState.AddFullIterations(1)
+			scenarioControl.AddFullIterations(1)
 			return true
 		}
 	}
@@ -165,20 +188,52 @@ func getIterationRunner(
 //   - If the whole test is aborted, the parent context will be cancelled, so
 //     that will also cancel these contexts, thus the "general abort" case is
 //     handled transparently.
-func getDurationContexts(parentCtx context.Context, regularDuration, gracefulStop time.Duration) (
+//   - If the scenario is stopped early (i.e. stopNotify is closed), the
+//     regDurationCtx is cancelled immediately and the maxDurationCtx is
+//     cancelled after the graceful stop period, same as if the regular
+//     duration had ended at that moment.
+func getDurationContexts(
+	parentCtx context.Context, regularDuration, gracefulStop time.Duration, stopNotify <-chan struct{},
+) (
 	startTime time.Time, maxDurationCtx, regDurationCtx context.Context, maxDurationCancel func(),
 ) {
 	startTime = time.Now()
 	maxEndTime := startTime.Add(regularDuration + gracefulStop)
 
 	maxDurationCtx, maxDurationCancel = context.WithDeadline(parentCtx, maxEndTime)
-	if gracefulStop == 0 {
-		return startTime, maxDurationCtx, maxDurationCtx, maxDurationCancel
+	regDurationCancel := maxDurationCancel
+	regDurationCtx = maxDurationCtx
+	if gracefulStop > 0 {
+		regDurationCtx, regDurationCancel = context.WithDeadline(maxDurationCtx, startTime.Add(regularDuration))
 	}
-	regDurationCtx, _ = context.WithDeadline(maxDurationCtx, startTime.Add(regularDuration)) //nolint:govet
+	go stopEarlyOnNotify(maxDurationCtx, stopNotify, gracefulStop, regDurationCancel, maxDurationCancel)
 	return startTime, maxDurationCtx, regDurationCtx, maxDurationCancel
 }
 
+// stopEarlyOnNotify waits for the scenario to be stopped early and then
+// cancels the regular and max duration contexts, the latter after the graceful
+// stop period. It returns as soon as the max duration context is done. A nil
+// stopNotify means that the scenario can't be stopped early.
+func stopEarlyOnNotify(
+	maxDurationCtx context.Context, stopNotify <-chan struct{}, gracefulStop time.Duration,
+	regDurationCancel, maxDurationCancel func(),
+) {
+	select {
+	case <-maxDurationCtx.Done():
+		return
+	case <-stopNotify:
+	}
+	regDurationCancel()
+
+	timer := time.NewTimer(gracefulStop)
+	defer timer.Stop()
+	select {
+	case <-maxDurationCtx.Done():
+	case <-timer.C:
+		maxDurationCancel()
+	}
+}
+
 // trackProgress is a helper function that monitors certain end-events in an
 // executor and updates its progressbar accordingly.
 func trackProgress(
@@ -260,5 +315,31 @@ func getVUActivationParams(
 		Tags:                     conf.GetTags(),
 		DeactivateCallback:       deactivateCallback,
 		GetNextIterationCounters: nextIterationCounters,
+		MaxIterationDuration:     conf.GetMaxIterationDuration(),
+		NetworkConditions:        conf.GetNetworkConditions(),
+		Proxy:                    conf.GetProxy(),
+	}
+}
+
+// activateVU activates the given VU with the supplied params. If the scenario
+// is configured with recycleVUOnTimeout, the returned ActiveVU will replace the
+// underlying VU with a freshly initialized one whenever a timed out iteration
+// doesn't unwind in a reasonable amount of time.
+func activateVU(
+	es *lib.ExecutionState, logger *logrus.Entry, conf BaseConfig,
+	initVU lib.InitializedVU, params *lib.VUActivationParams,
+) lib.ActiveVU {
+	es.ModScenarioActiveVUsCount(conf.Name, +1)
+	deactivateCallback := params.DeactivateCallback
+	params.DeactivateCallback = func(u lib.InitializedVU) {
+		es.ModScenarioActiveVUsCount(conf.Name, -1)
+		if deactivateCallback != nil {
+			deactivateCallback(u)
+		}
+	}
+
+	if !conf.RecycleVUOnTimeout.Bool || params.MaxIterationDuration <= 0 {
+		return initVU.Activate(params)
 	}
+	return newRecyclingVU(es, logger, initVU, params, DefaultVUUnwindTimeout)
 }
@@ -139,7 +139,8 @@ func (pvi PerVUIterations) Run(parentCtx context.Context, out chan<- metrics.Sam
 	gracefulStop := pvi.config.GetGracefulStop()
 
 	waitOnProgressChannel := make(chan struct{})
-	startTime, maxDurationCtx, regDurationCtx, cancel := getDurationContexts(parentCtx, duration, gracefulStop)
+	startTime, maxDurationCtx, regDurationCtx, cancel := getDurationContexts(
+		parentCtx, duration, gracefulStop, pvi.executionState.ScenarioStopNotify(pvi.config.Name))
 	defer func() {
 		cancel()
 		<-waitOnProgressChannel
@@ -192,7 +193,7 @@ func (pvi PerVUIterations) Run(parentCtx context.Context, out chan<- metrics.Sam
 	defer activeVUs.Wait()
 
 	regDurationDone := regDurationCtx.Done()
-	runIteration := getIterationRunner(pvi.executionState, pvi.logger)
+	runIteration := getIterationRunner(pvi.executionState, pvi.config.Name, pvi.logger)
 
 	returnVU := func(u lib.InitializedVU) {
 		pvi.executionState.ReturnVU(u, true)
@@ -206,7 +207,7 @@ func (pvi PerVUIterations) Run(parentCtx context.Context, out chan<- metrics.Sam
 		defer cancel()
 
 		vuID := initVU.GetID()
-		activeVU := initVU.Activate(
+		activeVU := activateVU(pvi.executionState, pvi.logger, pvi.config.BaseConfig, initVU,
 			getVUActivationParams(ctx, pvi.config.BaseConfig, returnVU,
 				pvi.nextIterationCounters))
 
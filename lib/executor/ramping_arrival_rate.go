@@ -326,7 +326,8 @@ func (varr RampingArrivalRate) Run(parentCtx context.Context, out chan<- metrics
 
 	returnedVUs := make(chan struct{})
 	waitOnProgressChannel := make(chan struct{})
-	startTime, maxDurationCtx, regDurationCtx, cancel := getDurationContexts(parentCtx, duration, gracefulStop)
+	startTime, maxDurationCtx, regDurationCtx, cancel := getDurationContexts(
+		parentCtx, duration, gracefulStop, varr.executionState.ScenarioStopNotify(varr.config.Name))
 
 	vusPool := newActiveVUPool(varr.executionState)
 
@@ -393,11 +394,11 @@ func (varr RampingArrivalRate) Run(parentCtx context.Context, out chan<- metrics
 		activeVUsWg.Done()
 	}
 
-	runIterationBasic := getIterationRunner(varr.executionState, varr.logger)
+	runIterationBasic := getIterationRunner(varr.executionState, varr.config.Name, varr.logger)
 
 	activateVU := func(initVU lib.InitializedVU) lib.ActiveVU {
 		activeVUsWg.Add(1)
-		activeVU := initVU.Activate(
+		activeVU := activateVU(varr.executionState, varr.logger, varr.config.BaseConfig, initVU,
 			getVUActivationParams(
 				maxDurationCtx, varr.config.BaseConfig, returnVU,
 				varr.nextIterationCounters))
@@ -498,8 +498,9 @@ func (vlv *RampingVUs) Run(ctx context.Context, _ chan<- metrics.SampleContainer
 		return fmt.Errorf("%s expected graceful end offset at %s to be final", vlv.config.GetName(), maxDuration)
 	}
 	waitOnProgressChannel := make(chan struct{})
+	stopNotify := vlv.executionState.ScenarioStopNotify(vlv.config.Name)
 	startTime, maxDurationCtx, regularDurationCtx, cancel := getDurationContexts(
-		ctx, regularDuration, maxDuration-regularDuration,
+		ctx, regularDuration, maxDuration-regularDuration, stopNotify,
 	)
 	defer func() {
 		cancel()
@@ -522,7 +523,7 @@ func (vlv *RampingVUs) Run(ctx context.Context, _ chan<- metrics.SampleContainer
 		maxVUs:         maxVUs,
 		activeVUsCount: new(int64),
 		started:        startTime,
-		runIteration:   getIterationRunner(vlv.executionState, vlv.logger),
+		runIteration:   getIterationRunner(vlv.executionState, vlv.config.Name, vlv.logger),
 	}
 
 	progressFn := runState.makeProgressFn(regularDuration)
@@ -546,11 +547,31 @@ func (vlv *RampingVUs) Run(ctx context.Context, _ chan<- metrics.SampleContainer
 		handleNewMaxAllowedVUs = runState.maxAllowedVUsHandlerStrategy()
 		handleNewScheduledVUs  = runState.scheduledVUsHandlerStrategy()
 	)
+	stepsCtx, stepsCancel := context.WithCancel(ctx)
+	defer stepsCancel()
+	go func() {
+		select {
+		case <-stopNotify:
+			stepsCancel()
+		case <-stepsCtx.Done():
+		}
+	}()
 	handledGracefulSteps := runState.iterateSteps(
-		ctx,
+		stepsCtx,
 		handleNewMaxAllowedVUs,
 		handleNewScheduledVUs,
 	)
+	select {
+	case <-stopNotify:
+		// The scenario was stopped early, so we gracefully stop all VUs; any
+		// that are still running when the gracefulStop expires will be
+		// interrupted by the cancellation of maxDurationCtx.
+		for _, vuHandle := range runState.vuHandles {
+			vuHandle.gracefulStop()
+		}
+		return nil
+	default:
+	}
 	go runState.runRemainingGracefulSteps(
 		ctx,
 		handleNewMaxAllowedVUs,
@@ -612,6 +633,10 @@ func (rs *rampingVUsRunState) runLoopsIfPossible(ctx context.Context, cancel fun
 		rs.vuHandles[i] = newStoppedVUHandle(
 			ctx, getVU, returnVU, rs.executor.nextIterationCounters,
 			&rs.executor.config.BaseConfig, rs.executor.logger.WithField("vuNum", i))
+		rs.vuHandles[i].activate = func(initVU lib.InitializedVU, params *lib.VUActivationParams) lib.ActiveVU {
+			return activateVU(
+				rs.executor.executionState, rs.executor.logger, rs.executor.config.BaseConfig, initVU, params)
+		}
 		go rs.vuHandles[i].runLoopsIfPossible(rs.runIteration) //nolint:contextcheck
 	}
 }
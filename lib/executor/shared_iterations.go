@@ -171,7 +171,8 @@ func (si SharedIterations) Run(parentCtx context.Context, out chan<- metrics.Sam
 	gracefulStop := si.config.GetGracefulStop()
 
 	waitOnProgressChannel := make(chan struct{})
-	startTime, maxDurationCtx, regDurationCtx, cancel := getDurationContexts(parentCtx, duration, gracefulStop)
+	startTime, maxDurationCtx, regDurationCtx, cancel := getDurationContexts(
+		parentCtx, duration, gracefulStop, si.executionState.ScenarioStopNotify(si.config.Name))
 	defer func() {
 		cancel()
 		<-waitOnProgressChannel
@@ -229,7 +230,7 @@ func (si SharedIterations) Run(parentCtx context.Context, out chan<- metrics.Sam
 	}()
 
 	regDurationDone := regDurationCtx.Done()
-	runIteration := getIterationRunner(si.executionState, si.logger)
+	runIteration := getIterationRunner(si.executionState, si.config.Name, si.logger)
 
 	returnVU := func(u lib.InitializedVU) {
 		si.executionState.ReturnVU(u, true)
@@ -240,8 +241,8 @@ func (si SharedIterations) Run(parentCtx context.Context, out chan<- metrics.Sam
 		ctx, cancel := context.WithCancel(maxDurationCtx)
 		defer cancel()
 
-		activeVU := initVU.Activate(getVUActivationParams(
-			ctx, si.config.BaseConfig, returnVU, si.nextIterationCounters))
+		activeVU := activateVU(si.executionState, si.logger, si.config.BaseConfig, initVU,
+			getVUActivationParams(ctx, si.config.BaseConfig, returnVU, si.nextIterationCounters))
 
 		for {
 			select {
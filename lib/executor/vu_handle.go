@@ -75,6 +75,10 @@ type vuHandle struct {
 	nextIterationCounters func() (uint64, uint64)
 	config                *BaseConfig
 
+	// activate is used for activating the VUs retrieved with getVU. It can be
+	// replaced by executors that support recycling VUs, see activateVU().
+	activate func(lib.InitializedVU, *lib.VUActivationParams) lib.ActiveVU
+
 	initVU       lib.InitializedVU
 	activeVU     lib.ActiveVU
 	canStartIter chan struct{}
@@ -101,6 +105,9 @@ func newStoppedVUHandle(
 		getVU:                 getVU,
 		nextIterationCounters: nextIterationCounters,
 		config:                config,
+		activate: func(initVU lib.InitializedVU, params *lib.VUActivationParams) lib.ActiveVU {
+			return initVU.Activate(params)
+		},
 
 		canStartIter: make(chan struct{}),
 		state:        stopped,
@@ -130,7 +137,7 @@ func (vh *vuHandle) start() (err error) {
 			return err
 		}
 
-		vh.activeVU = vh.initVU.Activate(getVUActivationParams(
+		vh.activeVU = vh.activate(vh.initVU, getVUActivationParams(
 			vh.ctx, *vh.config, vh.returnVU, vh.nextIterationCounters))
 		close(vh.canStartIter)
 		vh.changeState(starting)
@@ -0,0 +1,106 @@
+package executor
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"go.k6.io/k6/lib"
+)
+
+// DefaultVUUnwindTimeout is how long we wait for an iteration that exceeded its
+// maxIterationDuration to actually return, before we give up on its VU and
+// replace it with a new one, if the scenario has enabled recycleVUOnTimeout.
+var DefaultVUUnwindTimeout = 5 * time.Second //nolint:gochecknoglobals
+
+// recyclingVU is an ActiveVU that runs every iteration of the underlying VU in
+// a separate goroutine. If an iteration doesn't return within its
+// maxIterationDuration plus the unwind timeout, for example because it's stuck
+// in some Go code that doesn't respect context cancellation, the underlying VU
+// is discarded and a brand new one is initialized through the execution
+// state's InitVUFunc, i.e. the same path the scheduler uses for unplanned VUs.
+//
+// The discarded VU is never returned to the global VU buffer, so its stuck
+// goroutine can't affect other iterations or scenarios if it ever unwinds.
+//
+// RunOnce() is not safe for concurrent use, same as for any other ActiveVU.
+type recyclingVU struct {
+	executionState *lib.ExecutionState
+	logger         *logrus.Entry
+	params         *lib.VUActivationParams
+	unwindTimeout  time.Duration
+
+	current   lib.ActiveVU
+	abandoned *atomic.Bool
+}
+
+var _ lib.ActiveVU = &recyclingVU{}
+
+func newRecyclingVU(
+	es *lib.ExecutionState, logger *logrus.Entry, initVU lib.InitializedVU,
+	params *lib.VUActivationParams, unwindTimeout time.Duration,
+) *recyclingVU {
+	rv := &recyclingVU{
+		executionState: es,
+		logger:         logger,
+		params:         params,
+		unwindTimeout:  unwindTimeout,
+	}
+	rv.activate(initVU)
+	return rv
+}
+
+// activate activates the given VU with a copy of the original params, whose
+// deactivation callback is only propagated if the VU wasn't abandoned.
+func (rv *recyclingVU) activate(initVU lib.InitializedVU) {
+	abandoned := new(atomic.Bool)
+	params := *rv.params
+	params.DeactivateCallback = func(u lib.InitializedVU) {
+		if abandoned.Load() {
+			rv.executionState.ModInitializedVUsCount(-1)
+			return
+		}
+		if rv.params.DeactivateCallback != nil {
+			rv.params.DeactivateCallback(u)
+		}
+	}
+	rv.current = initVU.Activate(&params)
+	rv.abandoned = abandoned
+}
+
+// RunOnce runs a single iteration of the current VU, replacing it if the
+// iteration doesn't unwind after it has timed out.
+func (rv *recyclingVU) RunOnce() error {
+	result := make(chan error, 1)
+	current := rv.current
+	go func() {
+		result <- current.RunOnce()
+	}()
+
+	timer := time.NewTimer(rv.params.MaxIterationDuration + rv.unwindTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-result:
+		return err
+	case <-timer.C:
+	}
+
+	rv.logger.Warnf(
+		"A VU didn't unwind %s after its iteration exceeded the maxIterationDuration, replacing it with a new one",
+		rv.unwindTimeout,
+	)
+	// The new VU should be initialized even if the run context is already done,
+	// since its deactivation is what returns a VU to the executor.
+	newVU, err := rv.executionState.InitializeNewVU(context.WithoutCancel(rv.params.RunContext), rv.logger)
+	if err != nil {
+		rv.logger.WithError(err).Error("Could not initialize a replacement VU, waiting for the stuck one")
+		return <-result
+	}
+	rv.abandoned.Store(true)
+	rv.activate(newVU)
+
+	return &lib.IterationTimeoutError{Duration: rv.params.MaxIterationDuration}
+}
@@ -0,0 +1,96 @@
+package executor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v3"
+
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/lib/types"
+)
+
+func TestMaxIterationDurationInterruptsIterations(t *testing.T) {
+	t.Parallel()
+
+	runner := simpleRunner(func(ctx context.Context, _ *lib.State) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	config := PerVUIterationsConfig{
+		BaseConfig: BaseConfig{
+			MaxIterationDuration: types.NullDurationFrom(50 * time.Millisecond),
+		},
+		VUs:         null.IntFrom(2),
+		Iterations:  null.IntFrom(3),
+		MaxDuration: types.NullDurationFrom(10 * time.Second),
+	}
+	test := setupExecutorTest(t, "", "", lib.Options{}, runner, config)
+	defer test.cancel()
+
+	require.NoError(t, test.executor.Run(test.ctx, nil))
+	assert.Equal(t, uint64(6), test.state.GetPartialIterationCount())
+	assert.Equal(t, uint64(0), test.state.GetFullIterationCount())
+}
+
+func TestRecyclingVUReplacesStuckVU(t *testing.T) {
+	t.Parallel()
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+	var calls int64
+	runner := simpleRunner(func(_ context.Context, _ *lib.State) error {
+		if atomic.AddInt64(&calls, 1) == 1 {
+			<-stuck // deliberately ignore the context
+		}
+		return nil
+	})
+
+	config := PerVUIterationsConfig{
+		BaseConfig: BaseConfig{
+			MaxIterationDuration: types.NullDurationFrom(50 * time.Millisecond),
+			RecycleVUOnTimeout:   null.BoolFrom(true),
+		},
+		VUs:         null.IntFrom(1),
+		Iterations:  null.IntFrom(1),
+		MaxDuration: types.NullDurationFrom(10 * time.Second),
+	}
+	test := setupExecutorTest(t, "", "", lib.Options{}, runner, config)
+	defer test.cancel()
+
+	initVU, err := test.state.GetPlannedVU(test.executor.GetLogger(), true)
+	require.NoError(t, err)
+	initializedVUs := test.state.GetInitializedVUsCount()
+
+	ctx, cancel := context.WithCancel(test.ctx)
+	defer cancel()
+	returnedVUs := make(chan lib.InitializedVU, 2)
+	params := getVUActivationParams(ctx, config.BaseConfig, func(u lib.InitializedVU) {
+		returnedVUs <- u
+	}, mockNextIterations)
+	rv := newRecyclingVU(test.state, test.executor.GetLogger(), initVU, params, 50*time.Millisecond)
+
+	var timeoutErr *lib.IterationTimeoutError
+	require.ErrorAs(t, rv.RunOnce(), &timeoutErr)
+	assert.Equal(t, initializedVUs+1, test.state.GetInitializedVUsCount())
+
+	require.NoError(t, rv.RunOnce())
+	cancel()
+
+	select {
+	case u := <-returnedVUs:
+		assert.NotEqual(t, initVU.GetID(), u.GetID())
+	case <-time.After(time.Second):
+		t.Fatal("the replacement VU wasn't returned")
+	}
+	select {
+	case u := <-returnedVUs:
+		t.Fatalf("the stuck VU %d shouldn't have been returned", u.GetID())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -93,6 +93,25 @@ type ExecutorConfig interface {
 	HasWork(*ExecutionTuple) bool
 }
 
+// ScenarioThresholdsConfig is an optional interface for executor configs that
+// support thresholds scoped to their scenario. It's not part of ExecutorConfig,
+// so that the executor configs of extensions don't have to implement it.
+type ScenarioThresholdsConfig interface {
+	// Returns the thresholds which are scoped to the scenario, i.e. evaluated
+	// only against the metric samples tagged with its name.
+	GetThresholds() map[string]metrics.Thresholds
+}
+
+// GetScenarioThresholds returns the thresholds scoped to the scenario of the
+// given executor config, or nil if it doesn't support them.
+func GetScenarioThresholds(config ExecutorConfig) map[string]metrics.Thresholds {
+	thresholdsConfig, ok := config.(ScenarioThresholdsConfig)
+	if !ok {
+		return nil
+	}
+	return thresholdsConfig.GetThresholds()
+}
+
 // ScenarioOptions are options specific to a scenario. These include k6 browser
 // options, which are validated by the browser module, and not by k6 core.
 type ScenarioOptions struct {
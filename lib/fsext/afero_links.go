@@ -44,6 +44,12 @@ func NewOsFs() Fs {
 	return afero.NewOsFs()
 }
 
+// NewBasePathFs returns a Fs restricted to the provided path of the wrapped one,
+// where all the paths are relative to it and can't escape it.
+func NewBasePathFs(fs Fs, path string) Fs {
+	return afero.NewBasePathFs(fs, path)
+}
+
 // Exists checks if the provided path exists on the filesystem
 func Exists(fs Fs, path string) (bool, error) {
 	return afero.Exists(fs, path)
@@ -1,7 +1,12 @@
 package lib
 
 import (
+	"context"
 	"sync"
+
+	"golang.org/x/time/rate"
+
+	"go.k6.io/k6/lib/types"
 )
 
 // SlotLimiter can restrict the concurrent execution of tasks to the given `slots` limit
@@ -68,3 +73,70 @@ func (l *MultiSlotLimiter) Slot(s string) SlotLimiter {
 	}
 	return ll
 }
+
+// HostLimiter restricts the rate and the concurrency of the requests to the
+// hosts which match the patterns of the hostLimits option. The same instance
+// is shared by all VUs, so the limits apply to all of their requests.
+type HostLimiter struct {
+	limits types.NullHostLimits
+	hosts  map[string]hostLimiter
+}
+
+type hostLimiter struct {
+	rps   *rate.Limiter
+	slots SlotLimiter
+}
+
+// NewHostLimiter returns a HostLimiter for the given limits, or nil if there
+// aren't any.
+func NewHostLimiter(limits types.NullHostLimits) *HostLimiter {
+	if !limits.Valid || len(limits.Limits) == 0 {
+		return nil
+	}
+	l := &HostLimiter{limits: limits, hosts: make(map[string]hostLimiter, len(limits.Limits))}
+	for pattern, limit := range limits.Limits {
+		var hl hostLimiter
+		if limit.RPS.Valid {
+			hl.rps = rate.NewLimiter(rate.Limit(limit.RPS.Int64), 1)
+		}
+		if limit.MaxConcurrent.Valid {
+			hl.slots = NewSlotLimiter(int(limit.MaxConcurrent.Int64))
+		}
+		l.hosts[pattern] = hl
+	}
+	return l
+}
+
+// Wait blocks until a request to the host is allowed by its limit, if it has
+// one, or the context is done. It returns whether the host is limited and a
+// function that must be called when the request is finished, to free its slot.
+// The returned function can be called more than once.
+func (l *HostLimiter) Wait(ctx context.Context, host string) (limited bool, done func(), err error) {
+	noop := func() {}
+	if l == nil {
+		return false, noop, nil
+	}
+	pattern, _, ok := l.limits.Match(host)
+	if !ok {
+		return false, noop, nil
+	}
+	hl := l.hosts[pattern]
+	// the rate is waited for first, so requests that are waiting for it don't
+	// hold a slot that other requests could've used in the meantime
+	if hl.rps != nil {
+		if err := hl.rps.Wait(ctx); err != nil {
+			return true, noop, err
+		}
+	}
+	if hl.slots == nil {
+		return true, noop, nil
+	}
+	select {
+	case <-hl.slots:
+	case <-ctx.Done():
+		return true, noop, ctx.Err()
+	}
+	var once sync.Once
+	done = func() { once.Do(hl.slots.End) }
+	return true, done, nil
+}
@@ -1,12 +1,18 @@
 package lib
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v3"
+
+	"go.k6.io/k6/lib/types"
 )
 
 func TestSlotLimiterSingleSlot(t *testing.T) {
@@ -121,3 +127,58 @@ func TestMultiSlotLimiter(t *testing.T) {
 		assert.NotNil(t, l.Slot("dtest"))
 	})
 }
+
+func TestHostLimiter(t *testing.T) {
+	t.Parallel()
+	limits, err := types.NewNullHostLimits(map[string]types.HostLimit{
+		"slots.k6.io":  {MaxConcurrent: null.IntFrom(1)},
+		"*.rate.k6.io": {RPS: null.IntFrom(1)},
+		"both.k6.io":   {RPS: null.IntFrom(1), MaxConcurrent: null.IntFrom(1)},
+	})
+	require.NoError(t, err)
+	l := NewHostLimiter(limits)
+
+	limited, done, err := l.Wait(context.Background(), "k6.io")
+	require.NoError(t, err)
+	assert.False(t, limited)
+	done()
+
+	limited, done, err = l.Wait(context.Background(), "slots.k6.io")
+	require.NoError(t, err)
+	assert.True(t, limited)
+
+	// the only slot is taken, so the next request waits until it's freed
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err = l.Wait(ctx, "slots.k6.io")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	done()
+	done() // it's safe to free the slot more than once
+	_, done2, err := l.Wait(context.Background(), "slots.k6.io")
+	require.NoError(t, err)
+	done2()
+
+	// the burst of the rate limit is a single request
+	_, _, err = l.Wait(context.Background(), "api.rate.k6.io")
+	require.NoError(t, err)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err = l.Wait(ctx, "web.rate.k6.io")
+	assert.Error(t, err)
+
+	// the rate is waited for before the slot, which isn't held in the meantime
+	_, done, err = l.Wait(context.Background(), "both.k6.io")
+	require.NoError(t, err)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err = l.Wait(ctx, "both.k6.io")
+	assert.ErrorContains(t, err, "rate: Wait")
+	done()
+
+	var nilLimiter *HostLimiter
+	limited, _, err = nilLimiter.Wait(context.Background(), "slots.k6.io")
+	require.NoError(t, err)
+	assert.False(t, limited)
+	assert.Nil(t, NewHostLimiter(types.NullHostLimits{}))
+}
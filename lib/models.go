@@ -205,6 +205,70 @@ type Check struct {
 	// Counters for how many times this check has passed and failed respectively.
 	Passes int64 `json:"passes"`
 	Fails  int64 `json:"fails"`
+
+	failures      CheckFailures
+	failuresMutex sync.Mutex
+}
+
+// The metadata of the check samples that describe their failures, which are
+// set by the assertions of the js/modules/k6.K6.Expect() function.
+const (
+	CheckFailureReasonMetadata = "check_failure_reason"
+	CheckActualValueMetadata   = "check_actual_value"
+)
+
+// MaxCheckFailureDetails is the maximum number of distinct failure reasons and
+// actual values that are kept for a check.
+const MaxCheckFailureDetails = 5
+
+// CheckFailures holds the details of the failures of a check.
+type CheckFailures struct {
+	// First is the time of the earliest failure.
+	First time.Time
+
+	// Reasons and ActualValues hold the distinct failure reasons and actual
+	// values reported by the assertions, in the order they were first seen.
+	Reasons      []string
+	ActualValues []string
+}
+
+// AddFailure records the details of a failure of the check, the reason and
+// actual value of which may be empty when they are not known.
+// This is safe to call from multiple goroutines simultaneously.
+func (c *Check) AddFailure(t time.Time, reason, actual string) {
+	c.failuresMutex.Lock()
+	defer c.failuresMutex.Unlock()
+
+	if c.failures.First.IsZero() || t.Before(c.failures.First) {
+		c.failures.First = t
+	}
+	c.failures.Reasons = appendFailureDetail(c.failures.Reasons, reason)
+	c.failures.ActualValues = appendFailureDetail(c.failures.ActualValues, actual)
+}
+
+// Failures returns a copy of the details of the failures of the check.
+// This is safe to call from multiple goroutines simultaneously.
+func (c *Check) Failures() CheckFailures {
+	c.failuresMutex.Lock()
+	defer c.failuresMutex.Unlock()
+
+	return CheckFailures{
+		First:        c.failures.First,
+		Reasons:      append([]string(nil), c.failures.Reasons...),
+		ActualValues: append([]string(nil), c.failures.ActualValues...),
+	}
+}
+
+func appendFailureDetail(details []string, detail string) []string {
+	if detail == "" || len(details) >= MaxCheckFailureDetails {
+		return details
+	}
+	for _, d := range details {
+		if d == detail {
+			return details
+		}
+	}
+	return append(details, detail)
 }
 
 // NewCheck creates a new check with the given name and parent group. The group may not be nil.
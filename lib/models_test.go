@@ -2,11 +2,13 @@ package lib
 
 import (
 	"encoding/json"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/guregu/null.v3"
 
 	"go.k6.io/k6/lib/types"
@@ -73,3 +75,27 @@ func TestDataRaces(t *testing.T) {
 		assert.Equal(t, group1, group2, "Groups are the same")
 	})
 }
+
+func TestCheckFailures(t *testing.T) {
+	t.Parallel()
+
+	group, err := NewGroup("", nil)
+	require.NoError(t, err)
+	check, err := group.Check("status is 200")
+	require.NoError(t, err)
+	assert.Equal(t, CheckFailures{}, check.Failures())
+
+	now := time.Now()
+	check.AddFailure(now, "expected 404 to be 200", "404")
+	check.AddFailure(now.Add(-time.Second), "expected 500 to be 200", "500")
+	check.AddFailure(now.Add(time.Second), "expected 404 to be 200", "404")
+	check.AddFailure(now, "", "")
+	for i := 0; i < MaxCheckFailureDetails; i++ {
+		check.AddFailure(now, "", strconv.Itoa(i))
+	}
+
+	failures := check.Failures()
+	assert.Equal(t, now.Add(-time.Second), failures.First)
+	assert.Equal(t, []string{"expected 404 to be 200", "expected 500 to be 200"}, failures.Reasons)
+	assert.Equal(t, []string{"404", "500", "0", "1", "2"}, failures.ActualValues)
+}
@@ -0,0 +1,138 @@
+package netext
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"go.k6.io/k6/metrics"
+)
+
+// ConnPool keeps track of the connections of a Dialer that are used for HTTP
+// requests, so the opening, the reuse and the closing of the connections can
+// be reported per host. Its zero value is ready to use.
+type ConnPool struct {
+	mu     sync.Mutex
+	conns  map[*Conn]*pooledConn
+	closed map[string]int64
+}
+
+type pooledConn struct {
+	host    string
+	streams int
+}
+
+// Acquire marks the start of a request to the given host over the connection.
+// It returns whether the connection is used for the first time and the number
+// of the requests in progress over it, including this one. Connections which
+// weren't made by a Dialer aren't tracked.
+func (p *ConnPool) Acquire(conn net.Conn, host string) (opened bool, streams int) {
+	c := unwrapConn(conn)
+	if c == nil {
+		return false, 1
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conns == nil {
+		p.conns = make(map[*Conn]*pooledConn)
+	}
+	pc, ok := p.conns[c]
+	if !ok {
+		select {
+		case <-c.closed:
+			// don't track a connection that was closed in the meantime
+			return true, 1
+		default:
+		}
+		pc = &pooledConn{host: host}
+		p.conns[c] = pc
+	}
+	pc.streams++
+	return !ok, pc.streams
+}
+
+// Release marks the end of a request over the connection.
+func (p *ConnPool) Release(conn net.Conn) {
+	c := unwrapConn(conn)
+	if c == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pc, ok := p.conns[c]; ok && pc.streams > 0 {
+		pc.streams--
+	}
+}
+
+// IdleConns returns the number of the open connections to the host without
+// requests in progress over them.
+func (p *ConnPool) IdleConns(host string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	idle := 0
+	for _, pc := range p.conns {
+		if pc.host == host && pc.streams == 0 {
+			idle++
+		}
+	}
+	return idle
+}
+
+// ClosedSamples returns samples with the number of the connections that were
+// closed since its last call, tagged with their host, and zeros them out.
+func (p *ConnPool) ClosedSamples(
+	sampleTime time.Time, ctm metrics.TagsAndMeta, enabledTags *metrics.SystemTagSet,
+	builtinMetrics *metrics.BuiltinMetrics,
+) []metrics.SampleContainer {
+	p.mu.Lock()
+	closed := p.closed
+	p.closed = nil
+	p.mu.Unlock()
+
+	containers := make([]metrics.SampleContainer, 0, len(closed))
+	for host, count := range closed {
+		hostCtm := ctm.Clone()
+		hostCtm.SetSystemTagOrMetaIfEnabled(enabledTags, metrics.TagHost, host)
+		containers = append(containers, metrics.ConnectedSamples{
+			Samples: []metrics.Sample{{
+				TimeSeries: metrics.TimeSeries{Metric: builtinMetrics.HTTPConnsClosed, Tags: hostCtm.Tags},
+				Time:       sampleTime,
+				Metadata:   hostCtm.Metadata,
+				Value:      float64(count),
+			}},
+			Tags: hostCtm.Tags,
+			Time: sampleTime,
+		})
+	}
+	return containers
+}
+
+// remove stops tracking the connection, because it was closed.
+func (p *ConnPool) remove(c *Conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pc, ok := p.conns[c]
+	if !ok {
+		return
+	}
+	delete(p.conns, c)
+	if p.closed == nil {
+		p.closed = make(map[string]int64)
+	}
+	p.closed[pc.host]++
+}
+
+// unwrapConn returns the Conn under the given connection, if there is one.
+func unwrapConn(conn net.Conn) *Conn {
+	for {
+		switch c := conn.(type) {
+		case *Conn:
+			return c
+		case *tls.Conn:
+			conn = c.NetConn()
+		default:
+			return nil
+		}
+	}
+}
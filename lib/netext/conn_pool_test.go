@@ -0,0 +1,59 @@
+package netext
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.k6.io/k6/metrics"
+)
+
+func TestConnPool(t *testing.T) {
+	t.Parallel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	dialer := NewDialer(net.Dialer{}, newResolver())
+	conn, err := dialer.DialContext(context.Background(), "tcp", listener.Addr().String())
+	require.NoError(t, err)
+	pool := &dialer.ConnPool
+
+	opened, streams := pool.Acquire(conn, "k6.test")
+	assert.True(t, opened)
+	assert.Equal(t, 1, streams)
+	opened, streams = pool.Acquire(conn, "k6.test")
+	assert.False(t, opened)
+	assert.Equal(t, 2, streams)
+	assert.Equal(t, 0, pool.IdleConns("k6.test"))
+
+	pool.Release(conn)
+	pool.Release(conn)
+	assert.Equal(t, 1, pool.IdleConns("k6.test"))
+	assert.Equal(t, 0, pool.IdleConns("other.k6.test"))
+
+	// connections which weren't made by a dialer aren't tracked
+	opened, _ = pool.Acquire(&net.TCPConn{}, "k6.test")
+	assert.False(t, opened)
+
+	registry := metrics.NewRegistry()
+	builtinMetrics := metrics.RegisterBuiltinMetrics(registry)
+	ctm := metrics.TagsAndMeta{Tags: registry.RootTagSet()}
+	enabledTags := metrics.NewSystemTagSet(metrics.TagHost)
+	assert.Empty(t, pool.ClosedSamples(time.Now(), ctm, enabledTags, builtinMetrics))
+
+	require.NoError(t, conn.Close())
+	assert.Equal(t, 0, pool.IdleConns("k6.test"))
+	containers := pool.ClosedSamples(time.Now(), ctm, enabledTags, builtinMetrics)
+	require.Len(t, containers, 1)
+	samples := containers[0].GetSamples()
+	require.Len(t, samples, 1)
+	assert.Equal(t, builtinMetrics.HTTPConnsClosed, samples[0].Metric)
+	assert.Equal(t, map[string]string{"host": "k6.test"}, samples[0].Tags.Map())
+	assert.Equal(t, 1.0, samples[0].Value)
+	assert.Empty(t, pool.ClosedSamples(time.Now(), ctm, enabledTags, builtinMetrics))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -23,6 +24,14 @@ type Dialer struct {
 	BlockedHostnames *types.HostnameTrie
 	Hosts            *types.Hosts
 
+	// OnLookup, if set, is called after each DNS lookup of the dialed hosts
+	// that actually queried the DNS servers, i.e. not for the ones answered
+	// from the cache of the resolver.
+	OnLookup func(ctx context.Context, host string, start time.Time, err error)
+
+	// ConnPool tracks the connections of the dialer that are used for HTTP.
+	ConnPool ConnPool
+
 	BytesRead    int64
 	BytesWritten int64
 }
@@ -57,7 +66,7 @@ func (b BlockedHostError) Error() string {
 
 // DialContext wraps the net.Dialer.DialContext and handles the k6 specifics
 func (d *Dialer) DialContext(ctx context.Context, proto, addr string) (net.Conn, error) {
-	dialAddr, err := d.getDialAddr(addr)
+	dialAddr, err := d.getDialAddr(ctx, addr)
 	if err != nil {
 		return nil, err
 	}
@@ -65,8 +74,20 @@ func (d *Dialer) DialContext(ctx context.Context, proto, addr string) (net.Conn,
 	if err != nil {
 		return nil, err
 	}
-	conn = &Conn{conn, &d.BytesRead, &d.BytesWritten}
-	return conn, err
+	c := newConn(conn, &d.BytesRead, &d.BytesWritten)
+	c.pool = &d.ConnPool
+	if nc := GetNetworkConditions(ctx); nc != nil {
+		c.setNetworkConditions(nc)
+		// emulate the latency of establishing the connection
+		if err = c.sleep(c.emulator.Load().latency()); err == nil {
+			err = ctx.Err()
+		}
+		if err != nil {
+			_ = c.Close()
+			return nil, err
+		}
+	}
+	return c, nil
 }
 
 // IOSamples returns samples for data send and received since it last call and zeros out.
@@ -98,8 +119,8 @@ func (d *Dialer) IOSamples(
 	})
 }
 
-func (d *Dialer) getDialAddr(addr string) (string, error) {
-	remote, err := d.findRemote(addr)
+func (d *Dialer) getDialAddr(ctx context.Context, addr string) (string, error) {
+	remote, err := d.findRemote(ctx, addr)
 	if err != nil {
 		return "", err
 	}
@@ -113,7 +134,7 @@ func (d *Dialer) getDialAddr(addr string) (string, error) {
 	return remote.String(), nil
 }
 
-func (d *Dialer) findRemote(addr string) (*types.Host, error) {
+func (d *Dialer) findRemote(ctx context.Context, addr string) (*types.Host, error) {
 	host, port, err := net.SplitHostPort(addr)
 	if err != nil {
 		return nil, err
@@ -137,18 +158,31 @@ func (d *Dialer) findRemote(addr string) (*types.Host, error) {
 		return types.NewHost(ip, port)
 	}
 
-	ip, err = d.Resolver.LookupIP(host)
+	lookupStart := time.Now()
+	ip, queried, err := d.lookupIP(host)
+	if err == nil && ip == nil {
+		err = fmt.Errorf("lookup %s: no such host", host)
+	}
+	if d.OnLookup != nil && queried {
+		d.OnLookup(ctx, host, lookupStart, err)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	if ip == nil {
-		return nil, fmt.Errorf("lookup %s: no such host", host)
-	}
-
 	return types.NewHost(ip, port)
 }
 
+// lookupIP resolves the given host, and reports whether the DNS servers were
+// queried for it. Resolvers that can't tell are assumed to always query them.
+func (d *Dialer) lookupIP(host string) (net.IP, bool, error) {
+	if r, ok := d.Resolver.(queryingResolver); ok {
+		return r.lookupIPQueried(host)
+	}
+	ip, err := d.Resolver.LookupIP(host)
+	return ip, true, err
+}
+
 func (d *Dialer) getConfiguredHost(addr, host, port string) (*types.Host, error) {
 	if remote := d.Hosts.Match(addr); remote != nil {
 		return remote, nil
@@ -173,25 +207,54 @@ func (d *Dialer) getConfiguredHost(addr, host, port string) (*types.Host, error)
 	return nil, nil //nolint:nilnil
 }
 
-// Conn wraps net.Conn and keeps track of sent and received data size
+// Conn wraps net.Conn and keeps track of sent and received data size. It
+// also emulates the network conditions that are set for it, if any.
 type Conn struct {
 	net.Conn
 
 	BytesRead, BytesWritten *int64
+
+	emulator  atomic.Pointer[networkEmulator]
+	pool      *ConnPool
+	closed    chan struct{}
+	closeOnce sync.Once
 }
 
-func (c *Conn) Read(b []byte) (int, error) {
-	n, err := c.Conn.Read(b)
+func newConn(conn net.Conn, bytesRead, bytesWritten *int64) *Conn {
+	return &Conn{Conn: conn, BytesRead: bytesRead, BytesWritten: bytesWritten, closed: make(chan struct{})}
+}
+
+func (c *Conn) Read(b []byte) (n int, err error) {
+	if e := c.emulator.Load(); e != nil {
+		n, err = c.emulatedRead(e, b)
+	} else {
+		n, err = c.Conn.Read(b)
+	}
 	if n > 0 {
 		atomic.AddInt64(c.BytesRead, int64(n))
 	}
 	return n, err
 }
 
-func (c *Conn) Write(b []byte) (int, error) {
-	n, err := c.Conn.Write(b)
+func (c *Conn) Write(b []byte) (n int, err error) {
+	if e := c.emulator.Load(); e != nil {
+		n, err = c.emulatedWrite(e, b)
+	} else {
+		n, err = c.Conn.Write(b)
+	}
 	if n > 0 {
 		atomic.AddInt64(c.BytesWritten, int64(n))
 	}
 	return n, err
 }
+
+// Close closes the connection and interrupts any emulated delays.
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		if c.pool != nil {
+			c.pool.remove(c)
+		}
+	})
+	return c.Conn.Close()
+}
@@ -1,9 +1,12 @@
 package netext
 
 import (
+	"context"
 	"net"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.k6.io/k6/lib"
@@ -62,7 +65,7 @@ func TestDialerAddr(t *testing.T) {
 
 		t.Run(tc.address, func(t *testing.T) {
 			t.Parallel()
-			addr, err := dialer.getDialAddr(tc.address)
+			addr, err := dialer.getDialAddr(context.Background(), tc.address)
 
 			if tc.expErr != "" {
 				require.EqualError(t, err, tc.expErr)
@@ -101,7 +104,7 @@ func TestDialerAddrBlockHostnamesStar(t *testing.T) {
 
 		t.Run(tc.address, func(t *testing.T) {
 			t.Parallel()
-			addr, err := dialer.getDialAddr(tc.address)
+			addr, err := dialer.getDialAddr(context.Background(), tc.address)
 
 			if tc.expErr != "" {
 				require.EqualError(t, err, tc.expErr)
@@ -113,6 +116,31 @@ func TestDialerAddrBlockHostnamesStar(t *testing.T) {
 	}
 }
 
+func TestDialerOnLookup(t *testing.T) {
+	t.Parallel()
+
+	queries := 0
+	resolve := func(host string) ([]net.IP, error) {
+		queries++
+		return []net.IP{net.ParseIP("1.2.3.4")}, nil
+	}
+	resolver := NewResolver(resolve, time.Minute, types.DNSfirst, types.DNSany)
+	dialer := NewDialer(net.Dialer{}, resolver)
+	var lookups []string
+	dialer.OnLookup = func(_ context.Context, host string, _ time.Time, err error) {
+		require.NoError(t, err)
+		lookups = append(lookups, host)
+	}
+
+	for _, address := range []string{"example.com:80", "example.com:443", "1.2.3.4:80", "k6.io:80"} {
+		_, err := dialer.getDialAddr(context.Background(), address)
+		require.NoError(t, err)
+	}
+	// the second lookup of example.com is answered from the cache
+	assert.Equal(t, 2, queries)
+	assert.Equal(t, []string{"example.com", "k6.io"}, lookups)
+}
+
 // Benchmarks /etc/hosts like hostname mapping
 func BenchmarkDialerHosts(b *testing.B) {
 	hosts, err := types.NewHosts(map[string]types.Host{
@@ -134,7 +162,7 @@ func BenchmarkDialerHosts(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		for _, tc := range tcs {
 			//nolint:gosec,errcheck
-			dialer.getDialAddr(tc)
+			dialer.getDialAddr(context.Background(), tc)
 		}
 	}
 }
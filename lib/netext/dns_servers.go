@@ -0,0 +1,186 @@
+package netext
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.k6.io/k6/lib/types"
+)
+
+// dnsQueryTimeout is the maximum duration of a lookup with a single server.
+const dnsQueryTimeout = 10 * time.Second
+
+// NewServersResolver returns a MultiResolver which queries the DNS servers
+// of the given configuration, with their transports, instead of the ones of
+// the system. The servers are tried in order, until one of them answers.
+// Hosts without configured servers are resolved with the fallback.
+func NewServersResolver(conf types.DNSConfig, fallback MultiResolver) (MultiResolver, error) {
+	return newServersResolver(conf, fallback, &http.Client{Timeout: dnsQueryTimeout})
+}
+
+func newServersResolver(conf types.DNSConfig, fallback MultiResolver, dohClient *http.Client) (MultiResolver, error) {
+	trie, err := conf.OverridesTrie()
+	if err != nil {
+		return nil, err
+	}
+	overrides := make(map[string][]*net.Resolver, len(conf.Overrides))
+	for pattern, servers := range conf.Overrides {
+		overrides[strings.ToLower(pattern)] = newServerResolvers(servers, dohClient)
+	}
+	defaults := newServerResolvers(conf.Servers, dohClient)
+
+	return func(host string) ([]net.IP, error) {
+		resolvers := defaults
+		if pattern, found := trie.Contains(host); found {
+			resolvers = overrides[pattern]
+		}
+		if len(resolvers) == 0 {
+			return fallback(host)
+		}
+
+		var errs []error
+		for _, r := range resolvers {
+			ctx, cancel := context.WithTimeout(context.Background(), dnsQueryTimeout)
+			ips, err := r.LookupIP(ctx, "ip", host)
+			cancel()
+			if err == nil {
+				return ips, nil
+			}
+			var dnsErr *net.DNSError
+			if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+				return nil, err
+			}
+			errs = append(errs, err)
+		}
+		return nil, errors.Join(errs...)
+	}, nil
+}
+
+func newServerResolvers(servers []types.DNSServer, dohClient *http.Client) []*net.Resolver {
+	resolvers := make([]*net.Resolver, len(servers))
+	for i, server := range servers {
+		resolvers[i] = newServerResolver(server, dohClient)
+	}
+	return resolvers
+}
+
+// newServerResolver returns a resolver that queries only the given server. The
+// address of the system servers, which is passed to the Dial function, is
+// ignored. Connections that aren't a net.PacketConn are used with the
+// length-prefixed messages of DNS over TCP.
+func newServerResolver(server types.DNSServer, dohClient *http.Client) *net.Resolver {
+	dialer := &net.Dialer{}
+	var dial func(ctx context.Context, network, _ string) (net.Conn, error)
+	switch server.Transport {
+	case types.DNSTransportUDP:
+		dial = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			// the resolver retries over TCP when the response is truncated
+			return dialer.DialContext(ctx, network, server.Address)
+		}
+	case types.DNSTransportTCP:
+		dial = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "tcp", server.Address)
+		}
+	case types.DNSTransportTLS:
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{MinVersion: tls.VersionTLS12}}
+		dial = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return tlsDialer.DialContext(ctx, "tcp", server.Address)
+		}
+	case types.DNSTransportHTTPS:
+		dial = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return &dohConn{ctx: ctx, client: dohClient, url: server.Address}, nil
+		}
+	}
+	return &net.Resolver{PreferGo: true, Dial: dial}
+}
+
+// dohConn is a net.Conn which sends each length-prefixed DNS message written
+// to it as a DNS-over-HTTPS request, and returns the length-prefixed response
+// message when it's read.
+type dohConn struct {
+	ctx      context.Context //nolint:containedctx
+	client   *http.Client
+	url      string
+	deadline time.Time
+
+	wbuf, rbuf bytes.Buffer
+}
+
+var _ net.Conn = &dohConn{}
+
+func (c *dohConn) Write(b []byte) (int, error) {
+	c.wbuf.Write(b)
+	for c.wbuf.Len() >= 2 {
+		size := int(binary.BigEndian.Uint16(c.wbuf.Bytes()[:2]))
+		if c.wbuf.Len() < 2+size {
+			break
+		}
+		msg := c.wbuf.Next(2 + size)[2:]
+		resp, err := c.exchange(msg)
+		if err != nil {
+			return 0, err
+		}
+		if len(resp) > 0xffff {
+			return 0, errors.New("the DNS-over-HTTPS response is too large")
+		}
+		_ = binary.Write(&c.rbuf, binary.BigEndian, uint16(len(resp))) //nolint:gosec
+		c.rbuf.Write(resp)
+	}
+	return len(b), nil
+}
+
+func (c *dohConn) exchange(msg []byte) ([]byte, error) {
+	ctx := c.ctx
+	if !c.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, c.deadline)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(msg))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the DNS-over-HTTPS server responded with status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 0xffff+1))
+}
+
+func (c *dohConn) Read(b []byte) (int, error) {
+	if c.rbuf.Len() == 0 {
+		return 0, io.EOF
+	}
+	return c.rbuf.Read(b)
+}
+
+func (c *dohConn) Close() error                     { return nil }
+func (c *dohConn) LocalAddr() net.Addr              { return dohAddr(c.url) }
+func (c *dohConn) RemoteAddr() net.Addr             { return dohAddr(c.url) }
+func (c *dohConn) SetReadDeadline(time.Time) error  { return nil }
+func (c *dohConn) SetWriteDeadline(time.Time) error { return nil }
+
+func (c *dohConn) SetDeadline(t time.Time) error {
+	c.deadline = t
+	return nil
+}
+
+type dohAddr string
+
+func (a dohAddr) Network() string { return "https" }
+func (a dohAddr) String() string  { return string(a) }
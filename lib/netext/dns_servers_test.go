@@ -0,0 +1,199 @@
+package netext
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.k6.io/k6/lib/types"
+)
+
+// dnsAnswer returns a response to the given DNS query, which answers the A
+// questions with the given IP and all the others without records.
+func dnsAnswer(query []byte, ip net.IP) []byte {
+	if len(query) < 12 {
+		return nil
+	}
+	end := 12
+	for end < len(query) && query[end] != 0 {
+		end += int(query[end]) + 1
+	}
+	end += 5 // the terminating label, the type and the class
+	if end > len(query) {
+		return nil
+	}
+	question := query[12:end]
+	qtype := binary.BigEndian.Uint16(question[len(question)-4:])
+
+	resp := make([]byte, 12, 64)
+	copy(resp, query[:2])
+	binary.BigEndian.PutUint16(resp[2:], 0x8180) // response, recursion available
+	binary.BigEndian.PutUint16(resp[4:], 1)
+	resp = append(resp, question...)
+	if qtype == 1 {
+		binary.BigEndian.PutUint16(resp[6:], 1)
+		resp = append(resp, 0xc0, 0x0c, 0, 1, 0, 1, 0, 0, 0, 60, 0, 4)
+		resp = append(resp, ip.To4()...)
+	}
+	return resp
+}
+
+func startUDPDNSServer(t *testing.T, ip net.IP) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = conn.WriteTo(dnsAnswer(buf[:n], ip), addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func startTCPDNSServer(t *testing.T, ip net.IP) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				for {
+					var size uint16
+					if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+						return
+					}
+					query := make([]byte, size)
+					if _, err := io.ReadFull(conn, query); err != nil {
+						return
+					}
+					resp := dnsAnswer(query, ip)
+					_ = binary.Write(conn, binary.BigEndian, uint16(len(resp))) //nolint:gosec
+					_, _ = conn.Write(resp)
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func startDoHServer(t *testing.T, ip net.IP) (string, *http.Client) {
+	t.Helper()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/dns-message" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		query, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/dns-message")
+		_, _ = w.Write(dnsAnswer(query, ip))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL + "/dns-query", srv.Client()
+}
+
+func TestServersResolver(t *testing.T) {
+	t.Parallel()
+
+	parseServers := func(t *testing.T, servers ...string) []types.DNSServer {
+		result := make([]types.DNSServer, len(servers))
+		for i, s := range servers {
+			var err error
+			result[i], err = types.ParseDNSServer(s)
+			require.NoError(t, err)
+		}
+		return result
+	}
+	fallback := func(host string) ([]net.IP, error) {
+		return nil, errors.New("unexpected fallback lookup of " + host)
+	}
+
+	testCases := map[string]func(t *testing.T, ip net.IP) (string, *http.Client){
+		"UDP": func(t *testing.T, ip net.IP) (string, *http.Client) {
+			return startUDPDNSServer(t, ip), nil
+		},
+		"TCP": func(t *testing.T, ip net.IP) (string, *http.Client) {
+			return "tcp://" + startTCPDNSServer(t, ip), nil
+		},
+		"HTTPS": startDoHServer,
+	}
+	for name, startServer := range testCases {
+		startServer := startServer
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ip := net.ParseIP("192.0.2.10")
+			server, client := startServer(t, ip)
+			conf := types.DNSConfig{Servers: parseServers(t, server)}
+			resolve, err := newServersResolver(conf, fallback, client)
+			require.NoError(t, err)
+
+			ips, err := resolve("test.k6.example")
+			require.NoError(t, err)
+			require.Len(t, ips, 1)
+			assert.True(t, ip.Equal(ips[0]))
+		})
+	}
+
+	t.Run("Overrides", func(t *testing.T) {
+		t.Parallel()
+		conf := types.DNSConfig{
+			Servers: parseServers(t, startUDPDNSServer(t, net.ParseIP("192.0.2.1"))),
+			Overrides: map[string][]types.DNSServer{
+				"*.Internal": parseServers(t, "tcp://"+startTCPDNSServer(t, net.ParseIP("192.0.2.2"))),
+				"system.k6":  nil,
+			},
+		}
+		resolve, err := NewServersResolver(conf, func(string) ([]net.IP, error) {
+			return []net.IP{net.ParseIP("192.0.2.3")}, nil
+		})
+		require.NoError(t, err)
+
+		for host, exp := range map[string]string{
+			"api.k6.example":  "192.0.2.1",
+			"api.internal":    "192.0.2.2",
+			"system.k6":       "192.0.2.3",
+			"other.system.k6": "192.0.2.1",
+		} {
+			ips, err := resolve(host)
+			require.NoError(t, err, host)
+			require.Len(t, ips, 1, host)
+			assert.Equal(t, exp, ips[0].String(), host)
+		}
+	})
+
+	t.Run("Failover", func(t *testing.T) {
+		t.Parallel()
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		unreachable := listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		conf := types.DNSConfig{
+			Servers: parseServers(t, "tcp://"+unreachable, startUDPDNSServer(t, net.ParseIP("192.0.2.4"))),
+		}
+		resolve, err := NewServersResolver(conf, fallback)
+		require.NoError(t, err)
+		ips, err := resolve("test.k6.example")
+		require.NoError(t, err)
+		require.Len(t, ips, 1)
+		assert.Equal(t, "192.0.2.4", ips[0].String())
+	})
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"go.k6.io/k6/lib"
+	"go.k6.io/k6/lib/netext"
+	"go.k6.io/k6/lib/types"
 	"go.k6.io/k6/metrics"
 
 	protov1 "github.com/golang/protobuf/proto" //nolint:staticcheck,nolintlint // this is the old v1 version
@@ -72,20 +74,36 @@ type Conn struct {
 // DefaultOptions generates an option set
 // with common options for requests from a VU.
 func DefaultOptions(getState func() *lib.State) []grpc.DialOption {
-	dialer := func(ctx context.Context, addr string) (net.Conn, error) {
-		return getState().Dialer.DialContext(ctx, "tcp", addr)
-	}
-
 	//nolint:staticcheck // see https://github.com/grafana/k6/issues/3699
 	return []grpc.DialOption{
 		grpc.WithBlock(),
 		grpc.FailOnNonTempDialError(true),
 		grpc.WithReturnConnectionError(),
 		grpc.WithStatsHandler(statsHandler{getState: getState}),
-		grpc.WithContextDialer(dialer),
+		withDialer(getState, nil),
 	}
 }
 
+// WithNetworkConditions returns a dial option that emulates the given network
+// conditions for the connection, instead of the ones of the VU.
+func WithNetworkConditions(getState func() *lib.State, nc *types.NetworkConditions) grpc.DialOption {
+	return withDialer(getState, nc)
+}
+
+// withDialer returns a dial option that uses the dialer of the VU. The context
+// of gRPC dials isn't derived from the one of the VU, so the network
+// conditions need to be set explicitly. The connections are made directly,
+// without the proxy of the VU, since the dialer only makes TCP connections.
+func withDialer(getState func() *lib.State, nc *types.NetworkConditions) grpc.DialOption {
+	return grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		state := getState()
+		if nc == nil {
+			nc = state.NetworkConditions
+		}
+		return state.Dialer.DialContext(netext.WithNetworkConditions(ctx, nc), "tcp", addr)
+	})
+}
+
 // Dial establish a gRPC connection.
 func Dial(ctx context.Context, addr string, options ...grpc.DialOption) (*Conn, error) {
 	//nolint:staticcheck // see https://github.com/grafana/k6/issues/3699
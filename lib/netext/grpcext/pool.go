@@ -0,0 +1,70 @@
+package grpcext
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+)
+
+// The load balancing policies of the connections, as named by gRPC.
+const (
+	LoadBalancingPickFirst  = "pick_first"
+	LoadBalancingRoundRobin = "round_robin"
+)
+
+// WithLoadBalancing returns a dial option that sets the load balancing policy
+// of the connection across the addresses resolved for its target, which only
+// makes a difference with a resolver returning several addresses, like the
+// dns:/// one.
+func WithLoadBalancing(policy string) grpc.DialOption {
+	return grpc.WithDefaultServiceConfig(`{"loadBalancingConfig":[{"` + policy + `":{}}]}`)
+}
+
+// DialPool establishes size gRPC connections to the address, and returns a
+// connection which spreads the calls and streams over them in round-robin.
+func DialPool(ctx context.Context, addr string, size int, options ...grpc.DialOption) (*Conn, error) {
+	if size <= 1 {
+		return Dial(ctx, addr, options...)
+	}
+
+	pool := &connPool{conns: make([]clientConnCloser, 0, size)}
+	for i := 0; i < size; i++ {
+		conn, err := Dial(ctx, addr, options...)
+		if err != nil {
+			_ = pool.Close()
+			return nil, err
+		}
+		pool.conns = append(pool.conns, conn.raw)
+	}
+	return &Conn{raw: pool}, nil
+}
+
+// connPool is a set of connections used in round-robin.
+type connPool struct {
+	conns []clientConnCloser
+	next  atomic.Uint64
+}
+
+func (p *connPool) pick() clientConnCloser {
+	return p.conns[(p.next.Add(1)-1)%uint64(len(p.conns))]
+}
+
+func (p *connPool) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	return p.pick().Invoke(ctx, method, args, reply, opts...)
+}
+
+func (p *connPool) NewStream(
+	ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return p.pick().NewStream(ctx, desc, method, opts...)
+}
+
+func (p *connPool) Close() error {
+	errs := make([]error, 0, len(p.conns))
+	for _, conn := range p.conns {
+		errs = append(errs, conn.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,80 @@
+package grpcext
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// countingListener counts the accepted connections.
+type countingListener struct {
+	net.Listener
+	accepted atomic.Int64
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		l.accepted.Add(1)
+	}
+	return conn, err
+}
+
+func TestDialPool(t *testing.T) {
+	t.Parallel()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	counting := &countingListener{Listener: lis}
+	srv := grpc.NewServer()
+	go func() { _ = srv.Serve(counting) }()
+	t.Cleanup(srv.Stop)
+
+	//nolint:staticcheck // see https://github.com/grafana/k6/issues/3699
+	conn, err := DialPool(context.Background(), lis.Addr().String(), 3,
+		grpc.WithBlock(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		WithLoadBalancing(LoadBalancingRoundRobin),
+	)
+	require.NoError(t, err)
+	assert.Len(t, conn.raw.(*connPool).conns, 3) //nolint:forcetypeassert
+	assert.Equal(t, int64(3), counting.accepted.Load())
+	require.NoError(t, conn.Close())
+}
+
+func TestConnPoolRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	var calls [3]int
+	conns := make([]clientConnCloser, 0, len(calls))
+	for i := range calls {
+		i := i
+		conns = append(conns, invokemock(func(_, out *dynamicpb.Message, _ ...grpc.CallOption) error {
+			calls[i]++
+			return protojson.Unmarshal([]byte(`{"reply":"text reply"}`), out)
+		}))
+	}
+
+	c := Conn{raw: &connPool{conns: conns}}
+	r := InvokeRequest{
+		Method:           "/hello.HelloService/SayHello",
+		MethodDescriptor: methodFromProto("SayHello"),
+		Message:          []byte(`{"greeting":"text request"}`),
+		Metadata:         metadata.New(nil),
+	}
+	for i := 0; i < 7; i++ {
+		_, err := c.Invoke(context.Background(), r)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, [3]int{3, 2, 2}, calls)
+	require.NoError(t, c.Close())
+}
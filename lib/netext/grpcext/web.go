@@ -0,0 +1,811 @@
+package grpcext
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	protov1 "github.com/golang/protobuf/proto" //nolint:staticcheck,nolintlint // this is the old v1 version
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/lib/netext"
+	"go.k6.io/k6/lib/types"
+	"go.k6.io/k6/metrics"
+)
+
+// The protocols of the gRPC connections.
+const (
+	ProtocolGRPC    = "grpc"
+	ProtocolGRPCWeb = "grpc-web"
+	ProtocolConnect = "connect"
+)
+
+// defaultMaxReceiveSize is the default limit of the received messages, the
+// same as the one of grpc-go.
+const defaultMaxReceiveSize = 4 * 1024 * 1024
+
+// The flags of the message envelopes.
+const (
+	flagCompressed    = 0x01
+	flagConnectEnd    = 0x02
+	flagGRPCWebHeader = 0x80
+)
+
+// WebOptions are the options of the gRPC-Web and Connect connections.
+type WebOptions struct {
+	Protocol string
+	// TLSConfig is nil for plaintext connections.
+	TLSConfig         *tls.Config
+	UserAgent         string
+	NetworkConditions *types.NetworkConditions
+	MaxReceiveSize    int
+	MaxSendSize       int
+}
+
+// DialWeb returns a connection which makes the calls with the gRPC-Web or the
+// Connect protocol over HTTP/1.1, or HTTP/2 when the server negotiates it
+// with TLS. The connections to the server are made by the calls, with the
+// dialer and the proxy of the VU, and the calls are limited by its hostLimits. Client streams are half-duplex: their messages are sent
+// together when the sending side is closed.
+func DialWeb(getState func() *lib.State, addr string, opts WebOptions) (*Conn, error) {
+	if opts.Protocol != ProtocolGRPCWeb && opts.Protocol != ProtocolConnect {
+		return nil, fmt.Errorf("unsupported protocol %q", opts.Protocol)
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+
+	scheme := "https"
+	if opts.TLSConfig == nil {
+		scheme = "http"
+	}
+	transport := &http.Transport{
+		Proxy: netext.ProxyFromContext,
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			state := getState()
+			nc := opts.NetworkConditions
+			if nc == nil {
+				nc = state.NetworkConditions
+			}
+			return state.Dialer.DialContext(netext.WithNetworkConditions(ctx, nc), network, addr)
+		},
+		TLSClientConfig:    opts.TLSConfig,
+		ForceAttemptHTTP2:  opts.TLSConfig != nil,
+		DisableCompression: true,
+	}
+	if opts.MaxReceiveSize <= 0 {
+		opts.MaxReceiveSize = defaultMaxReceiveSize
+	}
+	return &Conn{raw: &webConn{
+		opts:      opts,
+		baseURL:   scheme + "://" + addr,
+		host:      host,
+		transport: transport,
+		client:    &http.Client{Transport: transport},
+		getState:  getState,
+	}}, nil
+}
+
+// webConn implements the gRPC client connections over HTTP.
+type webConn struct {
+	opts      WebOptions
+	baseURL   string
+	host      string
+	transport *http.Transport
+	client    *http.Client
+	getState  func() *lib.State
+}
+
+var _ clientConnCloser = &webConn{}
+
+// Invoke implements the grpc.ClientConnInterface interface.
+func (c *webConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	msg, err := c.marshal(args)
+	if err != nil {
+		return err
+	}
+	call := c.startCall(ctx, method, true, [][]byte{msg})
+	err = call.err
+	if err == nil {
+		err = call.recvMessage(reply)
+		if errors.Is(err, io.EOF) {
+			err = status.Error(codes.Internal, "the response has no message")
+		}
+	}
+	if err == nil {
+		if _, err = call.recv(); err == nil {
+			err = status.Error(codes.Internal, "the response has more than one message")
+		} else if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	call.finish(err)
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case grpc.HeaderCallOption:
+			*o.HeaderAddr = call.header
+		case grpc.TrailerCallOption:
+			*o.TrailerAddr = call.trailer
+		}
+	}
+	return err
+}
+
+// NewStream implements the grpc.ClientConnInterface interface.
+func (c *webConn) NewStream(
+	ctx context.Context, desc *grpc.StreamDesc, method string, _ ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	s := &webStream{
+		conn:          c,
+		ctx:           ctx,
+		method:        method,
+		clientStreams: desc.ClientStreams,
+		started:       make(chan struct{}),
+	}
+	// The reflection is a bidirectional stream, where each request is
+	// answered before the next one is sent, so each request is sent with
+	// its own call instead.
+	if strings.HasSuffix(method, "ServerReflection/ServerReflectionInfo") {
+		s.perMessage = true
+	}
+	return s, nil
+}
+
+// Close closes the idle connections to the server.
+func (c *webConn) Close() error {
+	c.transport.CloseIdleConnections()
+	return nil
+}
+
+func (c *webConn) marshal(m any) ([]byte, error) {
+	var b []byte
+	var err error
+	switch msg := m.(type) {
+	case proto.Message:
+		b, err = proto.Marshal(msg)
+	case protov1.Message:
+		b, err = proto.Marshal(protov1.MessageV2(msg))
+	default:
+		return nil, status.Errorf(codes.Internal, "unsupported message type %T", m)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "can't marshal the message: %s", err)
+	}
+	if c.opts.MaxSendSize > 0 && len(b) > c.opts.MaxSendSize {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"trying to send message larger than max (%d vs. %d)", len(b), c.opts.MaxSendSize)
+	}
+	return b, nil
+}
+
+func unmarshal(b []byte, m any) error {
+	var err error
+	switch msg := m.(type) {
+	case proto.Message:
+		err = proto.Unmarshal(b, msg)
+	case protov1.Message:
+		err = proto.Unmarshal(b, protov1.MessageV2(msg))
+	default:
+		return status.Errorf(codes.Internal, "unsupported message type %T", m)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "can't unmarshal the message: %s", err)
+	}
+	return nil
+}
+
+// webCall is a call made with a single HTTP request, whose response is read
+// with recv() until it returns an error, io.EOF for a successful call.
+type webCall struct {
+	conn    *webConn
+	ctx     context.Context //nolint:containedctx
+	unary   bool
+	start   time.Time
+	err     error
+	resp    *http.Response
+	body    *bufio.Reader
+	header  metadata.MD
+	trailer metadata.MD
+	read    bool
+	ip      string
+
+	// limitDone frees the slot of the call in the hostLimits of the VU.
+	limitDone  func()
+	finishOnce sync.Once
+}
+
+// startCall sends the request of a call with the given messages. The call
+// always needs to be finished, even if it failed to start.
+func (c *webConn) startCall(ctx context.Context, method string, unary bool, msgs [][]byte) *webCall {
+	call := &webCall{
+		conn:    c,
+		ctx:     ctx,
+		unary:   unary && c.opts.Protocol == ProtocolConnect,
+		start:   time.Now(),
+		header:  metadata.New(nil),
+		trailer: metadata.New(nil),
+	}
+
+	var body bytes.Buffer
+	if call.unary {
+		body.Write(msgs[0])
+	} else {
+		for _, msg := range msgs {
+			writeEnvelope(&body, 0, msg)
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+method, &body)
+	if err != nil {
+		call.err = status.Error(codes.Internal, err.Error())
+		return call
+	}
+	c.setRequestHeaders(ctx, req.Header, call.unary)
+
+	state := c.getState()
+	_, call.limitDone, err = state.HostLimiter.Wait(ctx, c.host)
+	if err != nil {
+		call.err = contextError(ctx, err)
+		return call
+	}
+	reqCtx := ctx
+	if state.Proxy != nil {
+		reqCtx = netext.WithProxy(reqCtx, state.Proxy)
+	}
+	req = req.WithContext(httptrace.WithClientTrace(reqCtx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if ip, _, err := net.SplitHostPort(info.Conn.RemoteAddr().String()); err == nil {
+				call.ip = ip
+			}
+		},
+	}))
+
+	call.resp, err = c.client.Do(req) //nolint:bodyclose // the body is closed when the call is finished
+	if err != nil {
+		call.err = contextError(ctx, err)
+		return call
+	}
+	call.body = bufio.NewReader(call.resp.Body)
+	call.err = call.readHeaders()
+	return call
+}
+
+func (c *webConn) setRequestHeaders(ctx context.Context, h http.Header, unary bool) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	for k, vs := range md {
+		for _, v := range vs {
+			if strings.HasSuffix(k, "-bin") {
+				v = base64.StdEncoding.EncodeToString([]byte(v))
+			}
+			h.Add(k, v)
+		}
+	}
+	if c.opts.UserAgent != "" {
+		h.Set("User-Agent", c.opts.UserAgent)
+	}
+	deadline, hasDeadline := ctx.Deadline()
+	switch {
+	case c.opts.Protocol == ProtocolGRPCWeb:
+		h.Set("Content-Type", "application/grpc-web+proto")
+		h.Set("X-Grpc-Web", "1")
+		if hasDeadline {
+			h.Set("Grpc-Timeout", encodeGRPCTimeout(time.Until(deadline)))
+		}
+	case unary:
+		h.Set("Content-Type", "application/proto")
+	default:
+		h.Set("Content-Type", "application/connect+proto")
+	}
+	if c.opts.Protocol == ProtocolConnect {
+		h.Set("Connect-Protocol-Version", "1")
+		if hasDeadline {
+			h.Set("Connect-Timeout-Ms", strconv.FormatInt(max(time.Until(deadline).Milliseconds(), 1), 10))
+		}
+	}
+}
+
+// readHeaders reads the metadata from the response headers, and returns the
+// error of the call if the response headers already have it.
+func (call *webCall) readHeaders() error {
+	resp := call.resp
+	for k, vs := range resp.Header {
+		k = strings.ToLower(k)
+		md := call.header
+		switch {
+		case call.unary && strings.HasPrefix(k, "trailer-"):
+			k, md = strings.TrimPrefix(k, "trailer-"), call.trailer
+		case !call.unary && call.conn.opts.Protocol == ProtocolGRPCWeb && (k == "grpc-status" || k == "grpc-message"):
+			md = call.trailer // a trailers-only response
+		}
+		md.Append(k, decodeMetadataValues(k, vs)...)
+	}
+
+	if call.unary {
+		if resp.StatusCode == http.StatusOK {
+			return nil
+		}
+		return call.readConnectError()
+	}
+	if resp.StatusCode != http.StatusOK {
+		if call.trailer.Get("grpc-status") != nil {
+			return trailerStatus(call.trailer)
+		}
+		return status.Errorf(httpStatusCode(resp.StatusCode), "unexpected HTTP status %s", resp.Status)
+	}
+	if call.trailer.Get("grpc-status") != nil {
+		if err := trailerStatus(call.trailer); err != nil {
+			return err
+		}
+		call.read = true // no messages follow a trailers-only response
+	}
+	return nil
+}
+
+// readConnectError reads the JSON error of an unsuccessful Connect unary call.
+func (call *webCall) readConnectError() error {
+	var connectErr struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	body, err := io.ReadAll(io.LimitReader(call.body, int64(call.conn.opts.MaxReceiveSize)))
+	if err != nil || json.Unmarshal(body, &connectErr) != nil || connectErr.Code == "" {
+		return status.Errorf(httpStatusCode(call.resp.StatusCode), "unexpected HTTP status %s", call.resp.Status)
+	}
+	return status.Error(connectCode(connectErr.Code), connectErr.Message)
+}
+
+// recv reads the next message of the response.
+func (call *webCall) recv() ([]byte, error) {
+	if call.err != nil {
+		return nil, call.err
+	}
+	if call.read {
+		return nil, io.EOF
+	}
+	maxSize := call.conn.opts.MaxReceiveSize
+	if call.unary {
+		call.read = true
+		b, err := io.ReadAll(io.LimitReader(call.body, int64(maxSize)+1))
+		if err != nil {
+			return nil, contextError(call.ctx, err)
+		}
+		if len(b) > maxSize {
+			return nil, status.Errorf(codes.ResourceExhausted,
+				"received message larger than max (%d vs. %d)", len(b), maxSize)
+		}
+		return b, nil
+	}
+
+	var prefix [5]byte
+	if _, err := io.ReadFull(call.body, prefix[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, call.endWithoutTrailers()
+		}
+		return nil, contextError(call.ctx, err)
+	}
+	flags, size := prefix[0], int(binary.BigEndian.Uint32(prefix[1:]))
+	if size > maxSize {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"received message larger than max (%d vs. %d)", size, maxSize)
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(call.body, b); err != nil {
+		return nil, contextError(call.ctx, err)
+	}
+	switch {
+	case flags&flagCompressed != 0:
+		return nil, status.Error(codes.Internal, "compressed messages are not supported")
+	case call.conn.opts.Protocol == ProtocolGRPCWeb && flags&flagGRPCWebHeader != 0:
+		call.read = true
+		if err := call.readGRPCWebTrailers(b); err != nil {
+			return nil, err
+		}
+		return nil, trailerStatus(call.trailer)
+	case call.conn.opts.Protocol == ProtocolConnect && flags&flagConnectEnd != 0:
+		call.read = true
+		return nil, call.readConnectEnd(b)
+	}
+	return b, nil
+}
+
+func (call *webCall) recvMessage(m any) error {
+	b, err := call.recv()
+	if err != nil {
+		return err
+	}
+	return unmarshal(b, m)
+}
+
+// endWithoutTrailers returns the status of a gRPC-Web response which ended
+// without the trailers message, from its HTTP trailers if it had any.
+func (call *webCall) endWithoutTrailers() error {
+	call.read = true
+	if call.conn.opts.Protocol == ProtocolGRPCWeb && call.resp.Trailer.Get("Grpc-Status") != "" {
+		for k, vs := range call.resp.Trailer {
+			k = strings.ToLower(k)
+			call.trailer.Append(k, decodeMetadataValues(k, vs)...)
+		}
+		return trailerStatus(call.trailer)
+	}
+	return status.Error(codes.Internal, "the response ended without a status")
+}
+
+func (call *webCall) readGRPCWebTrailers(b []byte) error {
+	r := textproto.NewReader(bufio.NewReader(io.MultiReader(bytes.NewReader(b), strings.NewReader("\r\n\r\n"))))
+	h, err := r.ReadMIMEHeader()
+	if err != nil {
+		return status.Errorf(codes.Internal, "invalid trailers: %s", err)
+	}
+	for k, vs := range h {
+		k = strings.ToLower(k)
+		call.trailer.Append(k, decodeMetadataValues(k, vs)...)
+	}
+	return nil
+}
+
+func (call *webCall) readConnectEnd(b []byte) error {
+	var end struct {
+		Error *struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+		Metadata map[string][]string `json:"metadata"`
+	}
+	if err := json.Unmarshal(b, &end); err != nil {
+		return status.Errorf(codes.Internal, "invalid end of stream message: %s", err)
+	}
+	for k, vs := range end.Metadata {
+		k = strings.ToLower(k)
+		call.trailer.Append(k, decodeMetadataValues(k, vs)...)
+	}
+	if end.Error != nil {
+		return status.Error(connectCode(end.Error.Code), end.Error.Message)
+	}
+	return io.EOF
+}
+
+// finish closes the response and emits the metrics of the call, once.
+func (call *webCall) finish(err error) {
+	call.finishOnce.Do(func() {
+		end := time.Now()
+		if call.resp != nil {
+			_ = call.resp.Body.Close()
+		}
+		if call.limitDone != nil {
+			call.limitDone()
+		}
+
+		state := call.conn.getState()
+		stateRPC := getRPCState(call.ctx)
+		if stateRPC == nil || stateRPC.tagsAndMeta == nil {
+			ctm := state.Tags.GetCurrentValues()
+			stateRPC = &rpcState{tagsAndMeta: &ctm}
+		}
+		if call.ip != "" && state.Options.SystemTags.Has(metrics.TagIP) {
+			stateRPC.tagsAndMeta.SetSystemTagOrMeta(metrics.TagIP, call.ip)
+		}
+		if state.Options.SystemTags.Has(metrics.TagStatus) {
+			stateRPC.tagsAndMeta.SetSystemTagOrMeta(metrics.TagStatus, strconv.Itoa(int(status.Code(err))))
+		}
+		metrics.PushIfNotDone(call.ctx, state.Samples, metrics.Sample{
+			TimeSeries: metrics.TimeSeries{
+				Metric: state.BuiltinMetrics.GRPCReqDuration,
+				Tags:   stateRPC.tagsAndMeta.Tags,
+			},
+			Time:     end,
+			Metadata: stateRPC.tagsAndMeta.Metadata,
+			Value:    metrics.D(end.Sub(call.start)),
+		})
+	})
+}
+
+// webResult is a received message or the error which ended a call.
+type webResult struct {
+	msg []byte
+	err error
+}
+
+// webStream implements the grpc.ClientStream interface over webCalls.
+type webStream struct {
+	conn          *webConn
+	ctx           context.Context //nolint:containedctx
+	method        string
+	clientStreams bool
+
+	mu      sync.Mutex
+	msgs    [][]byte
+	closed  bool
+	started chan struct{}
+	call    *webCall
+
+	// perMessage streams make a call for each message, and keep their
+	// results until they're received.
+	perMessage bool
+	pending    []webResult
+}
+
+var _ grpc.ClientStream = &webStream{}
+
+// SendMsg implements the grpc.ClientStream interface.
+func (s *webStream) SendMsg(m any) error {
+	msg, err := s.conn.marshal(m)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errors.New("SendMsg called after CloseSend")
+	}
+	if s.perMessage {
+		call := s.conn.startCall(s.ctx, s.method, false, [][]byte{msg})
+		for {
+			b, err := call.recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				call.finish(err)
+				if err != nil {
+					s.pending = append(s.pending, webResult{err: err})
+				}
+				break
+			}
+			s.pending = append(s.pending, webResult{msg: b})
+		}
+		return nil
+	}
+	if !s.clientStreams && len(s.msgs) > 0 {
+		return status.Error(codes.Internal, "the method doesn't stream the requests")
+	}
+	s.msgs = append(s.msgs, msg)
+	return nil
+}
+
+// CloseSend implements the grpc.ClientStream interface. It sends the
+// messages of the stream.
+func (s *webStream) CloseSend() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if !s.perMessage {
+		s.call = s.conn.startCall(s.ctx, s.method, false, s.msgs)
+		s.msgs = nil
+	}
+	close(s.started)
+	return nil
+}
+
+// RecvMsg implements the grpc.ClientStream interface.
+func (s *webStream) RecvMsg(m any) error {
+	if s.perMessage {
+		return s.recvPerMessage(m)
+	}
+	select {
+	case <-s.started:
+	case <-s.ctx.Done():
+		return contextError(s.ctx, s.ctx.Err())
+	}
+	b, err := s.call.recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			s.call.finish(nil)
+		} else {
+			s.call.finish(err)
+		}
+		return err
+	}
+	return unmarshal(b, m)
+}
+
+func (s *webStream) recvPerMessage(m any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.pending) == 0 {
+		if s.closed {
+			return io.EOF
+		}
+		return status.Error(codes.Internal, "no response to receive")
+	}
+	result := s.pending[0]
+	s.pending = s.pending[1:]
+	if result.err != nil {
+		return result.err
+	}
+	return unmarshal(result.msg, m)
+}
+
+// Header implements the grpc.ClientStream interface.
+func (s *webStream) Header() (metadata.MD, error) {
+	select {
+	case <-s.started:
+	case <-s.ctx.Done():
+		return nil, contextError(s.ctx, s.ctx.Err())
+	}
+	if s.call == nil {
+		return metadata.New(nil), nil
+	}
+	return s.call.header, s.call.err
+}
+
+// Trailer implements the grpc.ClientStream interface.
+func (s *webStream) Trailer() metadata.MD {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.call == nil {
+		return nil
+	}
+	return s.call.trailer
+}
+
+// Context implements the grpc.ClientStream interface.
+func (s *webStream) Context() context.Context {
+	return s.ctx
+}
+
+func writeEnvelope(w *bytes.Buffer, flags byte, msg []byte) {
+	var prefix [5]byte
+	prefix[0] = flags
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(msg))) //nolint:gosec // the size is limited by the sender
+	w.Write(prefix[:])
+	w.Write(msg)
+}
+
+// trailerStatus returns the status of the call from its trailers, or io.EOF
+// if it's successful.
+func trailerStatus(trailer metadata.MD) error {
+	values := trailer.Get("grpc-status")
+	if len(values) == 0 {
+		return status.Error(codes.Internal, "the response has no grpc-status")
+	}
+	code, err := strconv.Atoi(values[0])
+	if err != nil {
+		return status.Errorf(codes.Internal, "invalid grpc-status %q", values[0])
+	}
+	if codes.Code(code) == codes.OK { //nolint:gosec // the status codes are small
+		return io.EOF
+	}
+	var msg string
+	if messages := trailer.Get("grpc-message"); len(messages) > 0 {
+		msg = decodeGRPCMessage(messages[0])
+	}
+	return status.Error(codes.Code(code), msg) //nolint:gosec // the status codes are small
+}
+
+// decodeGRPCMessage decodes the percent-encoded grpc-message.
+func decodeGRPCMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		if msg[i] == '%' && i+2 < len(msg) {
+			if v, err := strconv.ParseUint(msg[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(msg[i])
+	}
+	return b.String()
+}
+
+func decodeMetadataValues(key string, values []string) []string {
+	if !strings.HasSuffix(key, "-bin") {
+		return values
+	}
+	decoded := make([]string, 0, len(values))
+	for _, v := range values {
+		b, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			b, err = base64.RawStdEncoding.DecodeString(v)
+		}
+		if err != nil {
+			decoded = append(decoded, v)
+			continue
+		}
+		decoded = append(decoded, string(b))
+	}
+	return decoded
+}
+
+// encodeGRPCTimeout encodes the timeout with the largest precision of the
+// units which fits in the 8 digits allowed by the gRPC protocol.
+func encodeGRPCTimeout(d time.Duration) string {
+	if d <= 0 {
+		return "1n"
+	}
+	units := []struct {
+		unit string
+		size time.Duration
+	}{
+		{"n", time.Nanosecond}, {"u", time.Microsecond}, {"m", time.Millisecond},
+		{"S", time.Second}, {"M", time.Minute}, {"H", time.Hour},
+	}
+	for _, u := range units {
+		if v := (d + u.size - 1) / u.size; v < 1e8 {
+			return strconv.FormatInt(int64(v), 10) + u.unit
+		}
+	}
+	return "99999999H"
+}
+
+// contextError returns the status of an error of the HTTP client.
+func contextError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(ctx.Err(), context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
+
+// httpStatusCode returns the gRPC code for the HTTP status of a response
+// without a gRPC status.
+func httpStatusCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.Internal
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.Unimplemented
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
+
+//nolint:gochecknoglobals
+var connectCodes = map[string]codes.Code{
+	"canceled":            codes.Canceled,
+	"unknown":             codes.Unknown,
+	"invalid_argument":    codes.InvalidArgument,
+	"deadline_exceeded":   codes.DeadlineExceeded,
+	"not_found":           codes.NotFound,
+	"already_exists":      codes.AlreadyExists,
+	"permission_denied":   codes.PermissionDenied,
+	"resource_exhausted":  codes.ResourceExhausted,
+	"failed_precondition": codes.FailedPrecondition,
+	"aborted":             codes.Aborted,
+	"out_of_range":        codes.OutOfRange,
+	"unimplemented":       codes.Unimplemented,
+	"internal":            codes.Internal,
+	"unavailable":         codes.Unavailable,
+	"data_loss":           codes.DataLoss,
+	"unauthenticated":     codes.Unauthenticated,
+}
+
+func connectCode(code string) codes.Code {
+	if c, ok := connectCodes[code]; ok {
+		return c
+	}
+	return codes.Unknown
+}
@@ -0,0 +1,318 @@
+package grpcext
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"gopkg.in/guregu/null.v3"
+
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/lib/netext"
+	"go.k6.io/k6/lib/types"
+	"go.k6.io/k6/metrics"
+)
+
+// webTestServer is a minimal gRPC-Web and Connect server of the hello
+// service, whose SayHello method fails for the "error" greeting.
+func webTestServer(t *testing.T, protocol string) *httptest.Server {
+	t.Helper()
+	sayHello := methodFromProto("SayHello")
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "v", r.Header.Get("X-Metadata"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var greetings []string
+		unary := protocol == ProtocolConnect && r.Header.Get("Content-Type") == "application/proto"
+		payloads := [][]byte{body}
+		if !unary {
+			payloads = nil
+			for len(body) > 0 {
+				size := binary.BigEndian.Uint32(body[1:5])
+				payloads = append(payloads, body[5:5+size])
+				body = body[5+size:]
+			}
+		}
+		for _, payload := range payloads {
+			msg := dynamicpb.NewMessage(sayHello.Input())
+			require.NoError(t, proto.Unmarshal(payload, msg))
+			greetings = append(greetings, msg.Get(sayHello.Input().Fields().ByName("greeting")).String())
+		}
+
+		var replies []string
+		switch r.URL.Path {
+		case "/hello.HelloService/SayHello":
+			replies = []string{"hi " + greetings[0]}
+		case "/hello.HelloService/LotsOfReplies":
+			replies = []string{"1 " + greetings[0], "2 " + greetings[0], "3 " + greetings[0]}
+		case "/hello.HelloService/LotsOfGreetings":
+			replies = []string{strings.Join(greetings, ",")}
+		}
+		failed := greetings[0] == "error"
+
+		encoded := make([][]byte, 0, len(replies))
+		for _, reply := range replies {
+			msg := dynamicpb.NewMessage(sayHello.Output())
+			require.NoError(t, protojson.Unmarshal([]byte(fmt.Sprintf(`{"reply":%q}`, reply)), msg))
+			b, err := proto.Marshal(msg)
+			require.NoError(t, err)
+			encoded = append(encoded, b)
+		}
+
+		w.Header().Set("X-Header", "h")
+		switch {
+		case unary && failed:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":"not_found","message":"no such greeting"}`))
+		case unary:
+			w.Header().Set("Content-Type", "application/proto")
+			w.Header().Set("Trailer-X-Trailer", "t")
+			_, _ = w.Write(encoded[0])
+		case protocol == ProtocolConnect:
+			w.Header().Set("Content-Type", "application/connect+proto")
+			var buf bytes.Buffer
+			end := `{"metadata":{"x-trailer":["t"]}}`
+			if failed {
+				end = `{"error":{"code":"not_found","message":"no such greeting"}}`
+			} else {
+				for _, b := range encoded {
+					writeEnvelope(&buf, 0, b)
+				}
+			}
+			writeEnvelope(&buf, flagConnectEnd, []byte(end))
+			_, _ = w.Write(buf.Bytes())
+		default:
+			w.Header().Set("Content-Type", "application/grpc-web+proto")
+			var buf bytes.Buffer
+			trailers := "grpc-status: 0\r\nx-trailer: t\r\n"
+			if failed {
+				trailers = "grpc-status: 5\r\ngrpc-message: no%20such greeting\r\n"
+			} else {
+				for _, b := range encoded {
+					writeEnvelope(&buf, 0, b)
+				}
+			}
+			writeEnvelope(&buf, flagGRPCWebHeader, []byte(trailers))
+			_, _ = w.Write(buf.Bytes())
+		}
+	}))
+}
+
+func newWebTestConn(t *testing.T, protocol string) (*Conn, chan metrics.SampleContainer) {
+	t.Helper()
+	srv := webTestServer(t, protocol)
+	t.Cleanup(srv.Close)
+
+	registry := metrics.NewRegistry()
+	samples := make(chan metrics.SampleContainer, 100)
+	state := &lib.State{
+		Options: lib.Options{SystemTags: &metrics.DefaultSystemTagSet},
+		Dialer: netext.NewDialer(net.Dialer{}, netext.NewResolver(
+			net.LookupIP, 0, types.DNSfirst, types.DNSpreferIPv4)),
+		BuiltinMetrics: metrics.RegisterBuiltinMetrics(registry),
+		Samples:        samples,
+		Tags:           lib.NewVUStateTags(registry.RootTagSet()),
+	}
+	conn, err := DialWeb(func() *lib.State { return state }, strings.TrimPrefix(srv.URL, "http://"),
+		WebOptions{Protocol: protocol})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, samples
+}
+
+func TestWebInvoke(t *testing.T) {
+	t.Parallel()
+
+	for _, protocol := range []string{ProtocolGRPCWeb, ProtocolConnect} {
+		protocol := protocol
+		t.Run(protocol, func(t *testing.T) {
+			t.Parallel()
+			conn, samples := newWebTestConn(t, protocol)
+
+			req := InvokeRequest{
+				Method:           "/hello.HelloService/SayHello",
+				MethodDescriptor: methodFromProto("SayHello"),
+				Message:          []byte(`{"greeting":"text request"}`),
+				Metadata:         metadata.Pairs("x-metadata", "v"),
+			}
+			res, err := conn.Invoke(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, codes.OK, res.Status)
+			assert.Equal(t, map[string]interface{}{"reply": "hi text request"}, res.Message)
+			assert.Equal(t, []string{"h"}, res.Headers["x-header"])
+			assert.Equal(t, []string{"t"}, res.Trailers["x-trailer"])
+
+			req.Message = []byte(`{"greeting":"error"}`)
+			res, err = conn.Invoke(context.Background(), req)
+			require.NoError(t, err)
+			assert.Equal(t, codes.NotFound, res.Status)
+			assert.Equal(t, "no such greeting", res.Error.(map[string]interface{})["message"]) //nolint:forcetypeassert
+
+			var statuses []string
+			for _, sc := range metrics.GetBufferedSamples(samples) {
+				for _, s := range sc.GetSamples() {
+					assert.Equal(t, metrics.GRPCReqDurationName, s.Metric.Name)
+					status, _ := s.Tags.Get("status")
+					statuses = append(statuses, status)
+				}
+			}
+			assert.Equal(t, []string{"0", "5"}, statuses)
+		})
+	}
+}
+
+func TestWebProxyAndHostLimits(t *testing.T) {
+	t.Parallel()
+	srv := webTestServer(t, ProtocolGRPCWeb)
+	t.Cleanup(srv.Close)
+	srvURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	var proxied []string
+	proxySrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		httputil.NewSingleHostReverseProxy(srvURL).ServeHTTP(w, r)
+	}))
+	t.Cleanup(proxySrv.Close)
+	proxy, err := types.NewProxy(proxySrv.URL, nil)
+	require.NoError(t, err)
+	limits, err := types.NewNullHostLimits(map[string]types.HostLimit{
+		srvURL.Hostname(): {RPS: null.IntFrom(1)},
+	})
+	require.NoError(t, err)
+
+	registry := metrics.NewRegistry()
+	state := &lib.State{
+		Options: lib.Options{SystemTags: &metrics.DefaultSystemTagSet},
+		Dialer: netext.NewDialer(net.Dialer{}, netext.NewResolver(
+			net.LookupIP, 0, types.DNSfirst, types.DNSpreferIPv4)),
+		BuiltinMetrics: metrics.RegisterBuiltinMetrics(registry),
+		Samples:        make(chan metrics.SampleContainer, 100),
+		Tags:           lib.NewVUStateTags(registry.RootTagSet()),
+		Proxy:          proxy,
+		HostLimiter:    lib.NewHostLimiter(limits),
+	}
+	conn, err := DialWeb(func() *lib.State { return state }, srvURL.Host, WebOptions{Protocol: ProtocolGRPCWeb})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	req := InvokeRequest{
+		Method:           "/hello.HelloService/SayHello",
+		MethodDescriptor: methodFromProto("SayHello"),
+		Message:          []byte(`{"greeting":"proxy"}`),
+		Metadata:         metadata.Pairs("x-metadata", "v"),
+	}
+	res, err := conn.Invoke(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, codes.OK, res.Status)
+	assert.Equal(t, []string{srv.URL + "/hello.HelloService/SayHello"}, proxied)
+
+	// the burst of the rate limit is a single call
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	res, err = conn.Invoke(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, codes.Unavailable, res.Status)
+	assert.Len(t, proxied, 1)
+}
+
+func TestWebStream(t *testing.T) {
+	t.Parallel()
+
+	receiveAll := func(t *testing.T, s *Stream) ([]interface{}, error) {
+		t.Helper()
+		var msgs []interface{}
+		for {
+			msg, err := s.ReceiveConverted()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				return msgs, err
+			}
+			msgs = append(msgs, msg)
+		}
+	}
+
+	for _, protocol := range []string{ProtocolGRPCWeb, ProtocolConnect} {
+		protocol := protocol
+		t.Run(protocol, func(t *testing.T) {
+			t.Parallel()
+			conn, samples := newWebTestConn(t, protocol)
+			ctx := context.Background()
+
+			s, err := conn.NewStream(ctx, StreamRequest{
+				Method:           "/hello.HelloService/LotsOfReplies",
+				MethodDescriptor: methodFromProto("LotsOfReplies"),
+				Metadata:         metadata.Pairs("x-metadata", "v"),
+			})
+			require.NoError(t, err)
+			require.NoError(t, s.Send([]byte(`{"greeting":"a"}`)))
+			require.NoError(t, s.CloseSend())
+			msgs, err := receiveAll(t, s)
+			require.NoError(t, err)
+			assert.Equal(t, []interface{}{
+				map[string]interface{}{"reply": "1 a"},
+				map[string]interface{}{"reply": "2 a"},
+				map[string]interface{}{"reply": "3 a"},
+			}, msgs)
+
+			s, err = conn.NewStream(ctx, StreamRequest{
+				Method:           "/hello.HelloService/LotsOfGreetings",
+				MethodDescriptor: methodFromProto("LotsOfGreetings"),
+				Metadata:         metadata.Pairs("x-metadata", "v"),
+			})
+			require.NoError(t, err)
+			require.NoError(t, s.Send([]byte(`{"greeting":"a"}`)))
+			require.NoError(t, s.Send([]byte(`{"greeting":"b"}`)))
+			require.NoError(t, s.CloseSend())
+			msgs, err = receiveAll(t, s)
+			require.NoError(t, err)
+			assert.Equal(t, []interface{}{map[string]interface{}{"reply": "a,b"}}, msgs)
+
+			s, err = conn.NewStream(ctx, StreamRequest{
+				Method:           "/hello.HelloService/LotsOfReplies",
+				MethodDescriptor: methodFromProto("LotsOfReplies"),
+				Metadata:         metadata.Pairs("x-metadata", "v"),
+			})
+			require.NoError(t, err)
+			require.NoError(t, s.Send([]byte(`{"greeting":"error"}`)))
+			require.NoError(t, s.CloseSend())
+			_, err = receiveAll(t, s)
+			require.ErrorContains(t, err, "no such greeting")
+
+			assert.Len(t, metrics.GetBufferedSamples(samples), 3)
+		})
+	}
+}
+
+func TestEncodeGRPCTimeout(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1500000u", encodeGRPCTimeout(1500*time.Millisecond))
+	assert.Equal(t, "720000S", encodeGRPCTimeout(200*time.Hour))
+	assert.Equal(t, "99999999n", encodeGRPCTimeout(99999999))
+	assert.Equal(t, "100000u", encodeGRPCTimeout(100000000))
+	assert.Equal(t, "1n", encodeGRPCTimeout(0))
+}
@@ -78,6 +78,27 @@ func compressBody(algos []CompressionType, body io.ReadCloser) (*bytes.Buffer, s
 	return buf, contentEncoding, body.Close()
 }
 
+// Compress returns the data compressed with the given algorithm.
+func Compress(data []byte, algo CompressionType) ([]byte, error) {
+	buf, _, err := compressBody([]CompressionType{algo}, io.NopCloser(bytes.NewReader(data)))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress returns the data decompressed with the given algorithm.
+func Decompress(data []byte, algo CompressionType) ([]byte, error) {
+	decoder, err := pickDecoder(algo, &readCloser{bytes.NewReader(data)})
+	if err != nil {
+		return nil, err
+	}
+	rc := &readCloser{decoder}
+	defer func() { _ = rc.Close() }()
+
+	return io.ReadAll(rc)
+}
+
 //nolint:gochecknoglobals
 var decompressionErrors = [...]error{
 	zlib.ErrChecksum, zlib.ErrDictionary, zlib.ErrHeader,
@@ -132,38 +153,23 @@ func readResponseBody(
 		return nil, err
 	}
 
-	rc := &readCloser{resp.Body}
 	// Ensure that the entire response body is read and closed, e.g. in case of decoding errors
 	defer func(respBody io.ReadCloser) {
 		_, _ = io.Copy(io.Discard, respBody)
 		_ = respBody.Close()
 	}(resp.Body)
 
-	if (resp.StatusCode >= 100 && resp.StatusCode <= 199) || // 1xx
-		resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified {
-		// for all three of this status code there is always no content
-		// https://www.rfc-editor.org/rfc/rfc9110.html#section-6.4.1-8
-		// this also prevents trying to read
+	if !responseHasBody(resp) {
 		return nil, nil //nolint:nilnil
 	}
-	contentEncodings := strings.Split(resp.Header.Get("Content-Encoding"), ",")
-	// Transparently decompress the body if it's has a content-encoding we
-	// support. If not, simply return it as it is.
-	for i := len(contentEncodings) - 1; i >= 0; i-- {
-		contentEncoding := strings.TrimSpace(contentEncodings[i])
-		if compression, err := CompressionTypeString(contentEncoding); err == nil {
-			decoder, err := pickDecoder(compression, rc)
-			if err != nil {
-				return nil, newDecompressionError(err)
-			}
-
-			rc = &readCloser{decoder}
-		}
+	rc, err := decodeResponseBody(resp)
+	if err != nil {
+		return nil, err
 	}
 
 	buf := state.BufferPool.Get()
 	defer state.BufferPool.Put(buf)
-	_, err := io.Copy(buf, rc.Reader)
+	_, err = io.Copy(buf, rc.Reader)
 	if err != nil {
 		respErr = wrapDecompressionError(err)
 	}
@@ -193,6 +199,34 @@ func readResponseBody(
 	return result, respErr
 }
 
+// responseHasBody returns false for the responses whose status codes mean
+// that they never have content, which also prevents trying to read it.
+// https://www.rfc-editor.org/rfc/rfc9110.html#section-6.4.1-8
+func responseHasBody(resp *http.Response) bool {
+	return (resp.StatusCode < 100 || resp.StatusCode > 199) && // 1xx
+		resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotModified
+}
+
+// decodeResponseBody returns a reader of the response body, which
+// transparently decompresses it if it has a content-encoding we support. If
+// not, it simply returns it as it is.
+func decodeResponseBody(resp *http.Response) (*readCloser, error) {
+	rc := &readCloser{resp.Body}
+	contentEncodings := strings.Split(resp.Header.Get("Content-Encoding"), ",")
+	for i := len(contentEncodings) - 1; i >= 0; i-- {
+		contentEncoding := strings.TrimSpace(contentEncodings[i])
+		if compression, err := CompressionTypeString(contentEncoding); err == nil {
+			decoder, err := pickDecoder(compression, rc)
+			if err != nil {
+				return nil, newDecompressionError(err)
+			}
+
+			rc = &readCloser{decoder}
+		}
+	}
+	return rc, nil
+}
+
 func pickDecoder(compression CompressionType, rc *readCloser) (io.Reader, error) {
 	var decoder io.Reader
 	var err error
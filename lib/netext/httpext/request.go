@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/http"
 	"net/http/cookiejar"
+	"net/http/httptrace"
 	"strconv"
 	"strings"
 	"time"
@@ -18,6 +19,8 @@ import (
 	"gopkg.in/guregu/null.v3"
 
 	"go.k6.io/k6/lib"
+	"go.k6.io/k6/lib/netext"
+	"go.k6.io/k6/lib/types"
 	"go.k6.io/k6/metrics"
 )
 
@@ -51,6 +54,12 @@ type ParsedHTTPRequest struct {
 	ActiveJar        *cookiejar.Jar
 	Cookies          map[string]*HTTPRequestCookie
 	TagsAndMeta      metrics.TagsAndMeta
+
+	// NetworkConditions override the ones of the VU for this request.
+	NetworkConditions *types.NetworkConditions
+
+	// Proxy overrides the one of the VU for this request.
+	Proxy *types.Proxy
 }
 
 // Matches non-compliant io.Closer implementations (e.g. zstd.Decoder)
@@ -180,6 +189,14 @@ func MakeRequest(ctx context.Context, state *lib.State, preq *ParsedHTTPRequest)
 			return nil, err
 		}
 	}
+	limitStart := time.Now()
+	limited, limitDone, err := state.HostLimiter.Wait(ctx, preq.Req.URL.Hostname())
+	if err != nil {
+		return nil, err
+	}
+	if limited {
+		emitLimitedDuration(ctx, state, preq, limitStart)
+	}
 
 	tracerTransport := newTransport(ctx, state, &preq.TagsAndMeta, preq.ResponseCallback)
 	var transport http.RoundTripper = tracerTransport
@@ -259,8 +276,40 @@ func MakeRequest(ctx context.Context, state *lib.State, preq *ParsedHTTPRequest)
 		},
 	}
 
+	// The network conditions are set for new connections when they are dialed,
+	// and for the reused ones when they are picked from the pool.
+	networkConditions := preq.NetworkConditions
+	if networkConditions == nil {
+		networkConditions = state.NetworkConditions
+	}
+	ctx = netext.WithNetworkConditions(ctx, networkConditions)
+	if proxy := preq.Proxy; proxy != nil {
+		ctx = netext.WithProxy(ctx, proxy)
+	} else if state.Proxy != nil {
+		ctx = netext.WithProxy(ctx, state.Proxy)
+	}
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			netext.SetNetworkConditions(info.Conn, networkConditions)
+		},
+	})
+
 	reqCtx, cancelFunc := context.WithTimeout(ctx, preq.Timeout)
-	defer cancelFunc()
+	if limited {
+		// the slot of the host limit is freed along with the request context
+		cancelReq := cancelFunc
+		cancelFunc = func() {
+			cancelReq()
+			limitDone()
+		}
+	}
+	streaming := false
+	defer func() {
+		// the context of streamed responses is canceled when their body is closed
+		if !streaming {
+			cancelFunc()
+		}
+	}()
 	mreq := preq.Req.WithContext(reqCtx)
 	res, resErr := client.Do(mreq)
 
@@ -273,7 +322,11 @@ func MakeRequest(ctx context.Context, state *lib.State, preq *ParsedHTTPRequest)
 		return nil, fmt.Errorf("unsupported response status: %s", res.Status)
 	}
 
-	if resErr == nil {
+	if resErr == nil && preq.ResponseType == ResponseTypeStream {
+		resp.Body = nil
+		resp.BodyStream, resErr = newResponseBodyStream(res, cancelFunc)
+		streaming = resErr == nil
+	} else if resErr == nil {
 		resp.Body, resErr = readResponseBody(state, preq.ResponseType, res, resErr)
 		if resErr != nil && errors.Is(resErr, context.DeadlineExceeded) {
 			// TODO This can be more specific that the timeout happened in the middle of the reading of the body
@@ -301,6 +354,7 @@ func MakeRequest(ctx context.Context, state *lib.State, preq *ParsedHTTPRequest)
 			resp.setTLSInfo(res.TLS)
 		}
 
+		resp.RawHeaders = res.Header
 		resp.Headers = make(map[string]string, len(res.Header))
 		for k, vs := range res.Header {
 			resp.Headers[k] = strings.Join(vs, ", ")
@@ -354,3 +408,17 @@ func SetRequestCookies(req *http.Request, jar *cookiejar.Jar, reqCookies map[str
 		}
 	}
 }
+
+// emitLimitedDuration emits the time that the request waited for the limit of
+// its host, tagged with the tags of the request and the host.
+func emitLimitedDuration(ctx context.Context, state *lib.State, preq *ParsedHTTPRequest, start time.Time) {
+	now := time.Now()
+	ctm := preq.TagsAndMeta.Clone()
+	ctm.SetSystemTagOrMetaIfEnabled(state.Options.SystemTags, metrics.TagHost, preq.Req.URL.Hostname())
+	metrics.PushIfNotDone(ctx, state.Samples, metrics.Sample{
+		TimeSeries: metrics.TimeSeries{Metric: state.BuiltinMetrics.HTTPReqLimitedDuration, Tags: ctm.Tags},
+		Time:       now,
+		Metadata:   ctm.Metadata,
+		Value:      metrics.D(now.Sub(start)),
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"runtime"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -554,3 +555,106 @@ func TestMakeRequestRPSLimit(t *testing.T) {
 		}
 	}
 }
+
+func TestMakeRequestStream(t *testing.T) {
+	t.Parallel()
+
+	body := strings.Repeat("data", 100000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+	samples := make(chan metrics.SampleContainer, 10)
+	registry := metrics.NewRegistry()
+	state := &lib.State{
+		Transport:      server.Client().Transport,
+		Logger:         logrus.New(),
+		Samples:        samples,
+		BuiltinMetrics: metrics.RegisterBuiltinMetrics(registry),
+		Tags:           lib.NewVUStateTags(registry.RootTagSet()),
+	}
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	preq := &ParsedHTTPRequest{
+		Req:          req,
+		URL:          &URL{u: req.URL},
+		Body:         new(bytes.Buffer),
+		Timeout:      10 * time.Second,
+		ResponseType: ResponseTypeStream,
+		TagsAndMeta:  state.Tags.GetCurrentValues(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	res, err := MakeRequest(ctx, state, preq)
+	require.NoError(t, err)
+	assert.Equal(t, 200, res.Status)
+	assert.Nil(t, res.Body)
+	// the metrics are emitted before the body is read
+	assert.Len(t, metrics.GetBufferedSamples(samples), 1)
+
+	data, err := io.ReadAll(res.BodyStream)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(data))
+	require.NoError(t, res.BodyStream.Close())
+}
+
+func TestMakeRequestStreamCloseDuringRead(t *testing.T) {
+	t.Parallel()
+
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		select {
+		case <-unblock:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(unblock)
+	samples := make(chan metrics.SampleContainer, 10)
+	registry := metrics.NewRegistry()
+	state := &lib.State{
+		Transport:      server.Client().Transport,
+		Logger:         logrus.New(),
+		Samples:        samples,
+		BuiltinMetrics: metrics.RegisterBuiltinMetrics(registry),
+		Tags:           lib.NewVUStateTags(registry.RootTagSet()),
+	}
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	preq := &ParsedHTTPRequest{
+		Req:          req,
+		URL:          &URL{u: req.URL},
+		Body:         new(bytes.Buffer),
+		Timeout:      10 * time.Second,
+		ResponseType: ResponseTypeStream,
+		TagsAndMeta:  state.Tags.GetCurrentValues(),
+	}
+
+	res, err := MakeRequest(context.Background(), state, preq)
+	require.NoError(t, err)
+
+	readErr := make(chan error, 1)
+	go func() {
+		_, err := res.BodyStream.Read(make([]byte, 1024))
+		readErr <- err
+	}()
+
+	// the read is blocked until the stream is closed
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, res.BodyStream.Close())
+	select {
+	case err := <-readErr:
+		require.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the read wasn't unblocked by Close()")
+	}
+
+	_, err = res.BodyStream.Read(make([]byte, 1024))
+	require.Error(t, err)
+}
@@ -2,6 +2,8 @@ package httpext
 
 import (
 	"crypto/tls"
+	"io"
+	"net/http"
 
 	"go.k6.io/k6/lib/netext"
 )
@@ -29,6 +31,11 @@ const (
 	// want to  measure, but we don't care about their responses' contents. This is the
 	// default value for all requests if the global discardResponseBodies is enablled.
 	ResponseTypeNone
+	// ResponseTypeStream causes k6 to return the response body as a ResponseBodyStream
+	// that's read incrementally, so big responses don't need to be buffered in memory.
+	// The request metrics are emitted once the response headers are received, so the
+	// time spent reading the body isn't included in them.
+	ResponseTypeStream
 )
 
 // ResponseTimings is a struct to put all timings for a given HTTP response/request
@@ -60,11 +67,16 @@ type Response struct {
 	StatusText     string                   `json:"status_text"`
 	Proto          string                   `json:"proto"`
 	Headers        map[string]string        `json:"headers"`
+	RawHeaders     http.Header              `json:"-" js:"-"`
 	Cookies        map[string][]*HTTPCookie `json:"cookies"`
 	Body           interface{}              `json:"body"`
+	BodyStream     io.ReadCloser            `json:"-" js:"-"`
 	Timings        ResponseTimings          `json:"timings"`
 	TLSVersion     string                   `json:"tls_version"`
 	TLSCipherSuite string                   `json:"tls_cipher_suite"`
+	TLSResumed     bool                     `json:"tls_resumed"`
+	TLSALPN        string                   `json:"tls_alpn" js:"tls_alpn"`
+	TLSCurve       string                   `json:"tls_curve"`
 	OCSP           netext.OCSP              `json:"ocsp"`
 	Error          string                   `json:"error"`
 	ErrorCode      int                      `json:"error_code"`
@@ -82,5 +94,8 @@ func (res *Response) setTLSInfo(tlsState *tls.ConnectionState) {
 	tlsInfo, oscp := netext.ParseTLSConnState(tlsState)
 	res.TLSVersion = tlsInfo.Version
 	res.TLSCipherSuite = tlsInfo.CipherSuite
+	res.TLSResumed = tlsInfo.Resumed
+	res.TLSALPN = tlsInfo.ALPN
+	res.TLSCurve = tlsInfo.Curve
 	res.OCSP = oscp
 }
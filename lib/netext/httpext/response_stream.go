@@ -0,0 +1,87 @@
+package httpext
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// errResponseBodyStreamClosed is returned when reading from a closed stream.
+var errResponseBodyStreamClosed = errors.New("read on closed response body stream")
+
+// ResponseBodyStream is the body of the responses with the stream
+// responseType, which is read incrementally instead of being buffered in
+// memory. It's closed automatically once it's fully read, and the request
+// context (and timeout) stays active until then. Concurrent reads aren't
+// supported, but it can be closed (e.g. when it's canceled from JS) while
+// a read is in progress, which unblocks it.
+type ResponseBodyStream struct {
+	rc     *readCloser
+	body   io.ReadCloser
+	cancel context.CancelFunc
+	closed atomic.Bool
+
+	// readMu is held while reading from the decoders, so they are only
+	// closed once no read is using them anymore.
+	readMu sync.Mutex
+}
+
+var _ io.ReadCloser = &ResponseBodyStream{}
+
+func newResponseBodyStream(resp *http.Response, cancel context.CancelFunc) (*ResponseBodyStream, error) {
+	stream := &ResponseBodyStream{rc: &readCloser{http.NoBody}, body: resp.Body, cancel: cancel}
+	if responseHasBody(resp) {
+		rc, err := decodeResponseBody(resp)
+		if err != nil {
+			_ = stream.Close()
+			return nil, err
+		}
+		stream.rc = rc
+	}
+	return stream, nil
+}
+
+// Read reads the next part of the (decompressed) response body.
+func (s *ResponseBodyStream) Read(p []byte) (int, error) {
+	s.readMu.Lock()
+	if s.closed.Load() {
+		s.readMu.Unlock()
+		return 0, errResponseBodyStreamClosed
+	}
+	n, err := s.rc.Read(p)
+	s.readMu.Unlock()
+	if err == nil {
+		return n, nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = NewK6Error(requestTimeoutErrorCode, requestTimeoutErrorCodeMsg, err)
+	} else if !errors.Is(err, io.EOF) {
+		err = wrapDecompressionError(err)
+	}
+	if closeErr := s.Close(); closeErr != nil && errors.Is(err, io.EOF) {
+		err = wrapDecompressionError(closeErr)
+	}
+	return n, err
+}
+
+// Close releases the connection of the response and cancels its context.
+func (s *ResponseBodyStream) Close() error {
+	if !s.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	// closing the body and canceling the context unblocks any pending read,
+	// so the decoders can be closed as soon as it returns
+	s.cancel()
+	_ = s.body.Close()
+
+	s.readMu.Lock()
+	defer s.readMu.Unlock()
+	if s.rc.Reader == s.body {
+		return nil
+	}
+	// only the decoders are closed here, as they may hold resources
+	return s.rc.Close()
+}
@@ -0,0 +1,148 @@
+package httpext
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SSEContentType is the media type of the Server-Sent Events streams.
+const SSEContentType = "text/event-stream"
+
+// SSEEvent is an event dispatched from a Server-Sent Events stream.
+type SSEEvent struct {
+	// Type is the event type, "message" unless the event field was set.
+	Type string
+	Data string
+	// LastEventID is the last event ID of the stream when the event was
+	// dispatched, which isn't necessarily set by this event.
+	LastEventID string
+}
+
+// SSEReader parses a text/event-stream body, following the WHATWG HTML
+// specification. It isn't safe for concurrent use.
+type SSEReader struct {
+	r *bufio.Reader
+
+	// MaxLineSize limits the size of the lines of the stream, and so of the
+	// fields of the events, if it's positive.
+	MaxLineSize int
+
+	started     bool
+	skipLF      bool
+	lastEventID string
+	retry       time.Duration
+}
+
+// NewSSEReader returns a parser of the Server-Sent Events stream in r. The
+// lastEventID is the one of the previous stream, when reconnecting.
+func NewSSEReader(r io.Reader, lastEventID string) *SSEReader {
+	return &SSEReader{r: bufio.NewReader(r), lastEventID: lastEventID}
+}
+
+// LastEventID returns the last event ID which was set by the stream.
+func (s *SSEReader) LastEventID() string {
+	return s.lastEventID
+}
+
+// Retry returns the last reconnection time which was set by the stream with
+// the retry field, or 0 if it wasn't set.
+func (s *SSEReader) Retry() time.Duration {
+	return s.retry
+}
+
+// Next reads the stream until the next event is dispatched. It returns
+// io.EOF when the stream ends, discarding any incomplete event, as required
+// by the specification.
+func (s *SSEReader) Next() (*SSEEvent, error) {
+	var eventType string
+	var data strings.Builder
+	hasData := false
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 {
+			if !hasData {
+				eventType = ""
+				continue
+			}
+			if eventType == "" {
+				eventType = "message"
+			}
+			return &SSEEvent{
+				Type:        eventType,
+				Data:        strings.TrimSuffix(data.String(), "\n"),
+				LastEventID: s.lastEventID,
+			}, nil
+		}
+		if line[0] == ':' { // a comment
+			continue
+		}
+
+		field, value, _ := bytes.Cut(line, []byte{':'})
+		value = bytes.TrimPrefix(value, []byte{' '})
+		switch string(field) {
+		case "event":
+			eventType = string(value)
+		case "data":
+			data.Write(value)
+			data.WriteByte('\n')
+			hasData = true
+		case "id":
+			if bytes.IndexByte(value, 0) < 0 {
+				s.lastEventID = string(value)
+			}
+		case "retry":
+			// only ASCII digits are valid, which ParseUint enforces with base 10
+			if ms, err := strconv.ParseUint(string(value), 10, 32); err == nil {
+				s.retry = time.Duration(ms) * time.Millisecond
+			}
+		default: // other fields are ignored
+		}
+	}
+}
+
+// readLine reads the next line, which may end with CRLF, LF or CR.
+func (s *SSEReader) readLine() ([]byte, error) {
+	var line []byte
+	for {
+		b, err := s.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if s.skipLF {
+			s.skipLF = false
+			if b == '\n' {
+				continue
+			}
+		}
+		switch b {
+		case '\r':
+			s.skipLF = true
+			return s.trimBOM(line), nil
+		case '\n':
+			return s.trimBOM(line), nil
+		}
+		line = append(line, b)
+		if s.MaxLineSize > 0 && len(line) > s.MaxLineSize {
+			return nil, fmt.Errorf("the event stream has a line longer than %d bytes", s.MaxLineSize)
+		}
+	}
+}
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// trimBOM removes the byte order mark which can start the stream.
+func (s *SSEReader) trimBOM(line []byte) []byte {
+	if s.started {
+		return line
+	}
+	s.started = true
+	return bytes.TrimPrefix(line, utf8BOM)
+}
@@ -0,0 +1,106 @@
+package httpext
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSEReader(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		stream      string
+		events      []SSEEvent
+		lastEventID string
+		retry       time.Duration
+	}{
+		{
+			name:   "simple",
+			stream: "data: hello\n\ndata:world\n\n",
+			events: []SSEEvent{{Type: "message", Data: "hello"}, {Type: "message", Data: "world"}},
+		},
+		{
+			name:   "multiline data",
+			stream: "data: first\ndata\ndata:  third\n\n",
+			events: []SSEEvent{{Type: "message", Data: "first\n\n third"}},
+		},
+		{
+			name:   "named events and comments",
+			stream: ": keep-alive\nevent: update\ndata: {}\n\nevent: skipped\n\ndata: default\n\n",
+			events: []SSEEvent{{Type: "update", Data: "{}"}, {Type: "message", Data: "default"}},
+		},
+		{
+			name:   "ids",
+			stream: "id: 1\ndata: a\n\ndata: b\n\nid: 3\n\nid\ndata: c\n\nid: \x00\ndata: d\n\n",
+			events: []SSEEvent{
+				{Type: "message", Data: "a", LastEventID: "1"},
+				{Type: "message", Data: "b", LastEventID: "1"},
+				{Type: "message", Data: "c"},
+				{Type: "message", Data: "d"},
+			},
+		},
+		{
+			name:   "retry",
+			stream: "retry: 1500\ndata: a\n\nretry: 2s\nretry: -1\n\n",
+			events: []SSEEvent{{Type: "message", Data: "a"}},
+			retry:  1500 * time.Millisecond,
+		},
+		{
+			name:   "line endings and BOM",
+			stream: "\xEF\xBB\xBFdata: crlf\r\n\r\ndata: cr\r\rdata: lf\n\n",
+			events: []SSEEvent{
+				{Type: "message", Data: "crlf"},
+				{Type: "message", Data: "cr"},
+				{Type: "message", Data: "lf"},
+			},
+		},
+		{
+			name:   "incomplete event",
+			stream: "data: complete\n\ndata: incomplete\n",
+			events: []SSEEvent{{Type: "message", Data: "complete"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			r := NewSSEReader(strings.NewReader(tc.stream), "")
+			var events []SSEEvent
+			for {
+				event, err := r.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				events = append(events, *event)
+			}
+			assert.Equal(t, tc.events, events)
+			assert.Equal(t, tc.lastEventID, r.LastEventID())
+			assert.Equal(t, tc.retry, r.Retry())
+		})
+	}
+
+	t.Run("previous last event ID", func(t *testing.T) {
+		t.Parallel()
+		r := NewSSEReader(strings.NewReader("data: a\n\n"), "42")
+		event, err := r.Next()
+		require.NoError(t, err)
+		assert.Equal(t, &SSEEvent{Type: "message", Data: "a", LastEventID: "42"}, event)
+	})
+
+	t.Run("max line size", func(t *testing.T) {
+		t.Parallel()
+		r := NewSSEReader(strings.NewReader("data: "+strings.Repeat("a", 100)+"\n\n"), "")
+		r.MaxLineSize = 64
+		_, err := r.Next()
+		require.ErrorContains(t, err, "longer than 64 bytes")
+	})
+}
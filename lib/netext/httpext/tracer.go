@@ -4,9 +4,11 @@ import (
 	"crypto/tls"
 	"net"
 	"net/http/httptrace"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"go.k6.io/k6/lib/netext"
 	"go.k6.io/k6/metrics"
 	"gopkg.in/guregu/null.v3"
 )
@@ -33,7 +35,15 @@ type Trail struct {
 	ConnReused     bool
 	ConnRemoteAddr net.Addr
 
+	// Connection pool information, only when the tracer has a ConnPool.
+	ConnOpened  bool // The connection was used for the first time.
+	ConnStreams int  // Requests in progress over the connection, including this one.
+
 	Failed null.Bool
+	// TLSMetadata is added to the metadata of the http_req_tls_handshaking
+	// sample, e.g. whether the session was resumed.
+	TLSMetadata map[string]string
+
 	// Populated by SaveSamples()
 	Tags     *metrics.TagSet
 	Metadata map[string]string
@@ -88,7 +98,7 @@ func (tr *Trail) SaveSamples(builtinMetrics *metrics.BuiltinMetrics, ctm *metric
 				Tags:   ctm.Tags,
 			},
 			Time:     tr.EndTime,
-			Metadata: ctm.Metadata,
+			Metadata: tr.tlsHandshakingMetadata(ctm.Metadata),
 			Value:    metrics.D(tr.TLSHandshaking),
 		},
 		{
@@ -121,6 +131,20 @@ func (tr *Trail) SaveSamples(builtinMetrics *metrics.BuiltinMetrics, ctm *metric
 	}...)
 }
 
+func (tr *Trail) tlsHandshakingMetadata(metadata map[string]string) map[string]string {
+	if len(tr.TLSMetadata) == 0 {
+		return metadata
+	}
+	result := make(map[string]string, len(metadata)+len(tr.TLSMetadata))
+	for k, v := range metadata {
+		result[k] = v
+	}
+	for k, v := range tr.TLSMetadata {
+		result[k] = v
+	}
+	return result
+}
+
 // GetSamples implements the metrics.SampleContainer interface.
 func (tr *Trail) GetSamples() []metrics.Sample {
 	return tr.Samples
@@ -156,6 +180,15 @@ type Tracer struct {
 
 	connReused     bool
 	connRemoteAddr net.Addr
+
+	// The pool which tracks the connection, if any, with the host of the request.
+	connPool     *netext.ConnPool
+	connHost     string
+	connMu       sync.Mutex
+	conn         net.Conn
+	connOpened   bool
+	connStreams  int
+	connReleased bool
 }
 
 // Trace returns a premade ClientTrace that calls all of the Tracer's hooks.
@@ -261,6 +294,14 @@ func (t *Tracer) GotConn(info httptrace.GotConnInfo) {
 	t.gotConn = now
 	t.connReused = info.Reused
 	t.connRemoteAddr = info.Conn.RemoteAddr()
+	if t.connPool != nil {
+		t.connMu.Lock()
+		if !t.connReleased {
+			t.conn = info.Conn
+			t.connOpened, t.connStreams = t.connPool.Acquire(info.Conn, t.connHost)
+		}
+		t.connMu.Unlock()
+	}
 
 	// The Go stdlib's http module can start connecting to a remote server, only
 	// to abandon that connection even before it was fully established and reuse
@@ -293,6 +334,21 @@ func (t *Tracer) GotConn(info httptrace.GotConnInfo) {
 	}
 }
 
+// releaseConn marks the end of the request over the connection in the pool.
+// A connection that is obtained after it, e.g. by a cancelled request, isn't
+// acquired at all.
+func (t *Tracer) releaseConn() {
+	if t.connPool == nil {
+		return
+	}
+	t.connMu.Lock()
+	defer t.connMu.Unlock()
+	t.connReleased = true
+	if t.conn != nil {
+		t.connPool.Release(t.conn)
+	}
+}
+
 // WroteRequest is called with the result of writing the
 // request and any body. It may be called multiple times
 // in the case of retried requests.
@@ -315,10 +371,14 @@ func (t *Tracer) GotFirstResponseByte() {
 func (t *Tracer) Done() *Trail {
 	done := time.Now()
 
+	t.connMu.Lock()
 	trail := Trail{
 		ConnReused:     t.connReused,
 		ConnRemoteAddr: t.connRemoteAddr,
+		ConnOpened:     t.connOpened,
+		ConnStreams:    t.connStreams,
 	}
+	t.connMu.Unlock()
 
 	if t.gotConn != 0 && t.getConn != 0 && t.gotConn > t.getConn {
 		trail.Blocked = time.Duration(t.gotConn - t.getConn)
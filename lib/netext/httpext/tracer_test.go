@@ -290,3 +290,22 @@ func TestCancelledRequest(t *testing.T) {
 		}
 	})
 }
+
+func TestTrailTLSMetadata(t *testing.T) {
+	t.Parallel()
+	registry := metrics.NewRegistry()
+	builtinMetrics := metrics.RegisterBuiltinMetrics(registry)
+
+	trail := &Trail{TLSMetadata: map[string]string{"tls_resumed": "true"}}
+	trail.SaveSamples(builtinMetrics, &metrics.TagsAndMeta{
+		Tags:     registry.RootTagSet(),
+		Metadata: map[string]string{"trace_id": "abc"},
+	})
+	for _, s := range trail.GetSamples() {
+		if s.Metric == builtinMetrics.HTTPReqTLSHandshaking {
+			assert.Equal(t, map[string]string{"trace_id": "abc", "tls_resumed": "true"}, s.Metadata)
+		} else {
+			assert.Equal(t, map[string]string{"trace_id": "abc"}, s.Metadata)
+		}
+	}
+}
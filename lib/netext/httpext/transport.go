@@ -122,6 +122,11 @@ func (t *transport) measureAndEmitMetrics(unfReq *unfinishedRequest) *finishedRe
 			tagsAndMeta.SetSystemTagOrMetaIfEnabled(enabledTags, metrics.TagTLSVersion, tlsInfo.Version)
 			tagsAndMeta.SetSystemTagOrMetaIfEnabled(enabledTags, metrics.TagOCSPStatus, oscp.Status)
 			result.tlsInfo = tlsInfo
+			trail.TLSMetadata = map[string]string{
+				"tls_resumed": strconv.FormatBool(tlsInfo.Resumed),
+				"tls_alpn":    tlsInfo.ALPN,
+				"tls_curve":   tlsInfo.Curve,
+			}
 		}
 	}
 	if enabledTags.Has(metrics.TagIP) && trail.ConnRemoteAddr != nil {
@@ -162,9 +167,58 @@ func (t *transport) measureAndEmitMetrics(unfReq *unfinishedRequest) *finishedRe
 		)
 	}
 	metrics.PushIfNotDone(t.ctx, t.state.Samples, trail)
+	t.emitConnPoolSamples(unfReq, trail)
 	return result
 }
 
+// emitConnPoolSamples emits the metrics of the connection pool of the VU for
+// the finished request, tagged with the host of the request, and the number of
+// the connections that were closed since the previous request.
+func (t *transport) emitConnPoolSamples(unfReq *unfinishedRequest, trail *Trail) {
+	pool := unfReq.tracer.connPool
+	if pool == nil {
+		return
+	}
+	unfReq.tracer.releaseConn()
+
+	enabledTags := t.state.Options.SystemTags
+	ctm := t.tagsAndMeta.Clone()
+	for _, closed := range pool.ClosedSamples(trail.EndTime, ctm, enabledTags, t.state.BuiltinMetrics) {
+		metrics.PushIfNotDone(t.ctx, t.state.Samples, closed)
+	}
+	if unfReq.tracer.conn == nil {
+		return // a connection wasn't obtained
+	}
+
+	host := unfReq.tracer.connHost
+	ctm.SetSystemTagOrMetaIfEnabled(enabledTags, metrics.TagHost, host)
+	sample := func(metric *metrics.Metric, value float64) metrics.Sample {
+		return metrics.Sample{
+			TimeSeries: metrics.TimeSeries{Metric: metric, Tags: ctm.Tags},
+			Time:       trail.EndTime,
+			Metadata:   ctm.Metadata,
+			Value:      value,
+		}
+	}
+	var opened, reused float64 = 0, 1
+	if trail.ConnOpened {
+		opened, reused = 1, 0
+	}
+	samples := []metrics.Sample{
+		sample(t.state.BuiltinMetrics.HTTPConnsOpened, opened),
+		sample(t.state.BuiltinMetrics.HTTPReqConnReused, reused),
+		sample(t.state.BuiltinMetrics.HTTPIdleConns, float64(pool.IdleConns(host))),
+	}
+	if unfReq.response != nil && unfReq.response.ProtoMajor == 2 {
+		samples = append(samples, sample(t.state.BuiltinMetrics.HTTP2ConnStreams, float64(trail.ConnStreams)))
+	}
+	metrics.PushIfNotDone(t.ctx, t.state.Samples, metrics.ConnectedSamples{
+		Samples: samples,
+		Tags:    ctm.Tags,
+		Time:    trail.EndTime,
+	})
+}
+
 func (t *transport) saveCurrentRequest(currentRequest *unfinishedRequest) {
 	t.lastRequestLock.Lock()
 	unprocessedRequest := t.lastRequest
@@ -203,6 +257,9 @@ func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 	ctx := req.Context()
 	tracer := &Tracer{}
+	if dialer, ok := t.state.Dialer.(*netext.Dialer); ok {
+		tracer.connPool, tracer.connHost = &dialer.ConnPool, req.URL.Hostname()
+	}
 	reqWithTracer := req.WithContext(httptrace.WithClientTrace(ctx, tracer.Trace()))
 	resp, err := t.state.Transport.RoundTrip(reqWithTracer)
 
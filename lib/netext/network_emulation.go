@@ -0,0 +1,186 @@
+package netext
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"go.k6.io/k6/lib/types"
+)
+
+// minLossStall is the minimum time a read or write stalls when a packet loss
+// is emulated, roughly the minimum TCP retransmission timeout.
+const minLossStall = 200 * time.Millisecond
+
+type networkConditionsKey struct{}
+
+// WithNetworkConditions returns a new context with the given network
+// conditions, which are emulated for the connections dialed with it.
+func WithNetworkConditions(ctx context.Context, nc *types.NetworkConditions) context.Context {
+	return context.WithValue(ctx, networkConditionsKey{}, nc)
+}
+
+// GetNetworkConditions returns the network conditions of the given context, if
+// there are any.
+func GetNetworkConditions(ctx context.Context) *types.NetworkConditions {
+	nc, _ := ctx.Value(networkConditionsKey{}).(*types.NetworkConditions)
+	return nc
+}
+
+// SetNetworkConditions changes the network conditions emulated for the given
+// established connection, e.g. when it's reused by a request with different
+// conditions. A nil value disables the emulation. It doesn't do anything for
+// connections that weren't dialed by a Dialer.
+func SetNetworkConditions(conn net.Conn, nc *types.NetworkConditions) {
+	for {
+		switch c := conn.(type) {
+		case *Conn:
+			c.setNetworkConditions(nc)
+			return
+		case interface{ NetConn() net.Conn }: // e.g. *tls.Conn
+			conn = c.NetConn()
+		default:
+			return
+		}
+	}
+}
+
+// networkEmulator delays and throttles the reads and writes of a connection,
+// according to the network conditions.
+type networkEmulator struct {
+	conditions       *types.NetworkConditions
+	download, upload *rate.Limiter
+	randMx           sync.Mutex
+	rand             *rand.Rand
+}
+
+func newNetworkEmulator(nc *types.NetworkConditions) *networkEmulator {
+	return &networkEmulator{
+		conditions: nc,
+		download:   newBandwidthLimiter(nc.DownloadKbps),
+		upload:     newBandwidthLimiter(nc.UploadKbps),
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec
+	}
+}
+
+// newBandwidthLimiter returns a limiter of bytes per second, which allows
+// bursts of 50ms worth of data, or nil if the bandwidth isn't limited.
+func newBandwidthLimiter(kbps float64) *rate.Limiter {
+	if kbps <= 0 {
+		return nil
+	}
+	bytesPerSec := kbps * 1000 / 8
+	burst := int(bytesPerSec / 20)
+	if burst < 1024 {
+		burst = 1024
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSec), burst)
+}
+
+// latency returns the latency with a random jitter applied to it.
+func (e *networkEmulator) latency() time.Duration {
+	latency := time.Duration(e.conditions.Latency)
+	if jitter := int64(e.conditions.Jitter); jitter > 0 {
+		e.randMx.Lock()
+		latency += time.Duration(e.rand.Int63n(2*jitter+1) - jitter)
+		e.randMx.Unlock()
+	}
+	if latency < 0 {
+		return 0
+	}
+	return latency
+}
+
+// lossStall returns how long an operation should stall because of an
+// emulated packet loss, if there is one.
+func (e *networkEmulator) lossStall() time.Duration {
+	if e.conditions.Loss <= 0 {
+		return 0
+	}
+	e.randMx.Lock()
+	lost := e.rand.Float64() < e.conditions.Loss
+	e.randMx.Unlock()
+	if !lost {
+		return 0
+	}
+	if stall := 2 * time.Duration(e.conditions.Latency); stall > minLossStall {
+		return stall
+	}
+	return minLossStall
+}
+
+func (c *Conn) setNetworkConditions(nc *types.NetworkConditions) {
+	if nc == nil {
+		c.emulator.Store(nil)
+		return
+	}
+	if current := c.emulator.Load(); current != nil && current.conditions == nc {
+		return
+	}
+	c.emulator.Store(newNetworkEmulator(nc))
+}
+
+// sleep waits for the given duration, or until the connection is closed.
+func (c *Conn) sleep(d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-c.closed:
+		return net.ErrClosed
+	}
+}
+
+// waitBandwidth waits until n bytes can be transferred with the limiter.
+func (c *Conn) waitBandwidth(limiter *rate.Limiter, n int) error {
+	if limiter == nil {
+		return nil
+	}
+	return c.sleep(limiter.ReserveN(time.Now(), n).Delay())
+}
+
+func (c *Conn) emulatedRead(e *networkEmulator, b []byte) (int, error) {
+	if e.download != nil && len(b) > e.download.Burst() {
+		b = b[:e.download.Burst()]
+	}
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		if waitErr := c.sleep(e.lossStall()); waitErr != nil {
+			return n, waitErr
+		}
+		if waitErr := c.waitBandwidth(e.download, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+func (c *Conn) emulatedWrite(e *networkEmulator, b []byte) (int, error) {
+	if err := c.sleep(e.latency() + e.lossStall()); err != nil {
+		return 0, err
+	}
+	written := 0
+	for written < len(b) {
+		chunk := b[written:]
+		if e.upload != nil && len(chunk) > e.upload.Burst() {
+			chunk = chunk[:e.upload.Burst()]
+		}
+		if err := c.waitBandwidth(e.upload, len(chunk)); err != nil {
+			return written, err
+		}
+		n, err := c.Conn.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
@@ -0,0 +1,72 @@
+package netext
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.k6.io/k6/lib/types"
+)
+
+func TestDialerNetworkConditions(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	payload := make([]byte, 32*1024)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				buf := make([]byte, 1)
+				if _, err := io.ReadFull(conn, buf); err != nil {
+					return
+				}
+				_, _ = conn.Write(payload)
+				_, _ = io.ReadFull(conn, buf)
+			}()
+		}
+	}()
+
+	nc := &types.NetworkConditions{
+		Latency:      types.Duration(100 * time.Millisecond),
+		DownloadKbps: 1024, // 128KB/s, i.e. ~250ms for the payload
+	}
+	dialer := NewDialer(net.Dialer{}, newResolver())
+	ctx := WithNetworkConditions(context.Background(), nc)
+
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", listener.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	start = time.Now()
+	_, err = conn.Write([]byte{1})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	start = time.Now()
+	n, err := io.ReadFull(conn, make([]byte, len(payload)))
+	require.NoError(t, err)
+	assert.Equal(t, len(payload), n)
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+	// disabling the emulation doesn't delay the writes anymore
+	SetNetworkConditions(conn, nil)
+	start = time.Now()
+	_, err = conn.Write([]byte{1})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
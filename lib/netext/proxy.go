@@ -0,0 +1,28 @@
+package netext
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"go.k6.io/k6/lib/types"
+)
+
+type proxyKey struct{}
+
+// WithProxy returns a new context with the given proxy, which is used for the
+// requests made with it.
+func WithProxy(ctx context.Context, p *types.Proxy) context.Context {
+	return context.WithValue(ctx, proxyKey{}, p)
+}
+
+// ProxyFromContext is a proxy function for http.Transport and similar, which
+// returns the proxy for the request from its context. If there isn't one, the
+// proxy is determined by the environment variables, like with
+// http.ProxyFromEnvironment.
+func ProxyFromContext(req *http.Request) (*url.URL, error) {
+	if p, ok := req.Context().Value(proxyKey{}).(*types.Proxy); ok && p != nil {
+		return p.URLFor(req.URL), nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
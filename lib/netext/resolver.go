@@ -17,6 +17,12 @@ type Resolver interface {
 	LookupIP(host string) (net.IP, error)
 }
 
+// queryingResolver is implemented by the resolvers that can report whether a
+// lookup actually queried the DNS servers, or was answered from their cache.
+type queryingResolver interface {
+	lookupIPQueried(host string) (ip net.IP, queried bool, err error)
+}
+
 type resolver struct {
 	resolve     MultiResolver
 	selectIndex types.DNSSelect
@@ -67,13 +73,20 @@ func NewResolver(
 // LookupIP returns a single IP resolved for host, selected according to the
 // configured select and policy options.
 func (r *resolver) LookupIP(host string) (net.IP, error) {
+	ip, _, err := r.lookupIPQueried(host)
+	return ip, err
+}
+
+// lookupIPQueried is the same as LookupIP, and the DNS servers are always
+// queried, since there is no cache.
+func (r *resolver) lookupIPQueried(host string) (net.IP, bool, error) {
 	ips, err := r.resolve(host)
 	if err != nil {
-		return nil, err
+		return nil, true, err
 	}
 
 	ips = r.applyPolicy(ips)
-	return r.selectOne(host, ips), nil
+	return r.selectOne(host, ips), true, nil
 }
 
 // LookupIP returns a single IP resolved for host, selected according to the
@@ -81,9 +94,17 @@ func (r *resolver) LookupIP(host string) (net.IP, error) {
 // refreshed if the last lookup time exceeds the configured TTL (not the TTL
 // returned in the DNS record).
 func (r *cacheResolver) LookupIP(host string) (net.IP, error) {
+	ip, _, err := r.lookupIPQueried(host)
+	return ip, err
+}
+
+// lookupIPQueried is the same as LookupIP, and it also reports whether the DNS
+// servers were queried, i.e. whether the host wasn't in the cache.
+func (r *cacheResolver) lookupIPQueried(host string) (net.IP, bool, error) {
 	r.cm.Lock()
 
 	var ips []net.IP
+	queried := false
 	// TODO: Invalidate? When?
 	if cr, ok := r.cache[host]; ok && time.Now().Before(cr.lastLookup.Add(r.ttl)) {
 		ips = cr.ips
@@ -92,16 +113,17 @@ func (r *cacheResolver) LookupIP(host string) (net.IP, error) {
 		var err error
 		ips, err = r.resolve(host)
 		if err != nil {
-			return nil, err
+			return nil, true, err
 		}
 		ips = r.applyPolicy(ips)
+		queried = true
 		r.cm.Lock()
 		r.cache[host] = cacheRecord{ips: ips, lastLookup: time.Now()}
 	}
 
 	r.cm.Unlock()
 
-	return r.selectOne(host, ips), nil
+	return r.selectOne(host, ips), queried, nil
 }
 
 func (r *resolver) selectOne(host string, ips []net.IP) net.IP {
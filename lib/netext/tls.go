@@ -35,6 +35,13 @@ const (
 type TLSInfo struct {
 	Version     string
 	CipherSuite string
+	// Resumed is true if the session of a previous connection was resumed.
+	Resumed bool
+	// ALPN is the application protocol negotiated with ALPN, e.g. "h2".
+	ALPN string
+	// Curve is the key exchange mechanism, e.g. "X25519". It's empty if it
+	// isn't known, e.g. for resumed sessions or older Go versions.
+	Curve string
 }
 
 // OCSP keeps Online Certificate Status Protocol (OCSP) details
@@ -62,6 +69,9 @@ func ParseTLSConnState(tlsState *tls.ConnectionState) (TLSInfo, OCSP) {
 	}
 
 	tlsInfo.CipherSuite = lib.SupportedTLSCipherSuitesToString[tlsState.CipherSuite]
+	tlsInfo.Resumed = tlsState.DidResume
+	tlsInfo.ALPN = tlsState.NegotiatedProtocol
+	tlsInfo.Curve = tlsCurve(tlsState)
 	ocspStapledRes := OCSP{Status: OCSP_STATUS_UNKNOWN}
 
 	if ocspRes, err := ocsp.ParseResponse(tlsState.OCSPResponse, nil); err == nil {
@@ -0,0 +1,13 @@
+//go:build go1.25
+
+package netext
+
+import "crypto/tls"
+
+// tlsCurve returns the name of the key exchange mechanism of the connection.
+func tlsCurve(tlsState *tls.ConnectionState) string {
+	if tlsState.CurveID == 0 {
+		return ""
+	}
+	return tlsState.CurveID.String()
+}
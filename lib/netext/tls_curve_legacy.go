@@ -0,0 +1,11 @@
+//go:build !go1.25
+
+package netext
+
+import "crypto/tls"
+
+// tlsCurve returns an empty string, since the key exchange mechanism isn't
+// exposed by crypto/tls before Go 1.25.
+func tlsCurve(*tls.ConnectionState) string {
+	return ""
+}
@@ -0,0 +1,46 @@
+package netext
+
+import (
+	"crypto/tls"
+	"sync"
+)
+
+// SessionCache is a tls.ClientSessionCache which can be reset, e.g. to force
+// full handshakes for the new connections of a VU.
+type SessionCache struct {
+	mx       sync.Mutex
+	capacity int
+	cache    tls.ClientSessionCache
+}
+
+var _ tls.ClientSessionCache = &SessionCache{}
+
+// NewSessionCache returns a new SessionCache which keeps the sessions of, at
+// most, capacity servers. A capacity < 1 uses the default capacity of
+// tls.NewLRUClientSessionCache.
+func NewSessionCache(capacity int) *SessionCache {
+	return &SessionCache{capacity: capacity, cache: tls.NewLRUClientSessionCache(capacity)}
+}
+
+// Get returns the session for the given key, if there is one.
+func (c *SessionCache) Get(sessionKey string) (*tls.ClientSessionState, bool) {
+	c.mx.Lock()
+	cache := c.cache
+	c.mx.Unlock()
+	return cache.Get(sessionKey)
+}
+
+// Put adds the session with the given key to the cache.
+func (c *SessionCache) Put(sessionKey string, cs *tls.ClientSessionState) {
+	c.mx.Lock()
+	cache := c.cache
+	c.mx.Unlock()
+	cache.Put(sessionKey, cs)
+}
+
+// Reset forgets all the sessions in the cache.
+func (c *SessionCache) Reset() {
+	c.mx.Lock()
+	c.cache = tls.NewLRUClientSessionCache(c.capacity)
+	c.mx.Unlock()
+}
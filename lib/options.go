@@ -255,6 +255,10 @@ type Options struct {
 	// Limit HTTP requests per second.
 	RPS null.Int `json:"rps" envconfig:"K6_RPS"`
 
+	// Limit the rate and the concurrency of the HTTP requests to the hosts
+	// which match the given patterns.
+	HostLimits types.NullHostLimits `json:"hostLimits" envconfig:"K6_HOST_LIMITS"`
+
 	// DNS handling configuration.
 	DNS types.DNSConfig `json:"dns" envconfig:"K6_DNS"`
 
@@ -279,6 +283,14 @@ type Options struct {
 	TLSVersion      *TLSVersions     `json:"tlsVersion" ignored:"true"`
 	TLSAuth         []*TLSAuth       `json:"tlsAuth" envconfig:"K6_TLSAUTH"`
 
+	// Resume TLS sessions of previous connections of the same VU. It's
+	// disabled by default, so every connection makes a full handshake.
+	TLSSessionResumption null.Bool `json:"tlsSessionResumption" envconfig:"K6_TLS_SESSION_RESUMPTION"`
+
+	// Forget the TLS sessions and idle connections of the VU at the start of
+	// each iteration, so that every iteration does full handshakes.
+	TLSFullHandshakePerIteration null.Bool `json:"tlsFullHandshakePerIteration" envconfig:"K6_TLS_FULL_HANDSHAKE_PER_ITERATION"`
+
 	// Throw warnings (eg. failed HTTP requests) as errors instead of simply logging them.
 	Throw null.Bool `json:"throw" envconfig:"K6_THROW"`
 
@@ -296,6 +308,12 @@ type Options struct {
 	// Hosts overrides dns entries for given hosts
 	Hosts types.NullHosts `json:"hosts" envconfig:"K6_HOSTS"`
 
+	// Proxy used for the requests, instead of the one from the HTTP_PROXY and
+	// HTTPS_PROXY environment variables. Its credentials aren't marshaled, so
+	// they aren't included in archives. The connections of k6/net/grpc with
+	// the native gRPC protocol don't use it.
+	Proxy types.NullProxy `json:"proxy" envconfig:"K6_PROXY"`
+
 	// Disable keep-alive connections
 	NoConnectionReuse null.Bool `json:"noConnectionReuse" envconfig:"K6_NO_CONNECTION_REUSE"`
 
@@ -419,6 +437,9 @@ func (o Options) Apply(opts Options) Options {
 	if opts.RPS.Valid {
 		o.RPS = opts.RPS
 	}
+	if opts.HostLimits.Valid {
+		o.HostLimits = opts.HostLimits
+	}
 	if opts.MaxRedirects.Valid {
 		o.MaxRedirects = opts.MaxRedirects
 	}
@@ -446,6 +467,12 @@ func (o Options) Apply(opts Options) Options {
 	if opts.TLSAuth != nil {
 		o.TLSAuth = opts.TLSAuth
 	}
+	if opts.TLSSessionResumption.Valid {
+		o.TLSSessionResumption = opts.TLSSessionResumption
+	}
+	if opts.TLSFullHandshakePerIteration.Valid {
+		o.TLSFullHandshakePerIteration = opts.TLSFullHandshakePerIteration
+	}
 	if opts.Throw.Valid {
 		o.Throw = opts.Throw
 	}
@@ -461,6 +488,9 @@ func (o Options) Apply(opts Options) Options {
 	if opts.Hosts.Valid {
 		o.Hosts = opts.Hosts
 	}
+	if opts.Proxy.Valid {
+		o.Proxy = opts.Proxy
+	}
 	if opts.NoConnectionReuse.Valid {
 		o.NoConnectionReuse = opts.NoConnectionReuse
 	}
@@ -512,6 +542,12 @@ func (o Options) Apply(opts Options) Options {
 	if opts.DNS.Policy.Valid {
 		o.DNS.Policy = opts.DNS.Policy
 	}
+	if opts.DNS.Servers != nil {
+		o.DNS.Servers = opts.DNS.Servers
+	}
+	if opts.DNS.Overrides != nil {
+		o.DNS.Overrides = opts.DNS.Overrides
+	}
 
 	return o
 }
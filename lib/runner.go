@@ -2,9 +2,11 @@ package lib
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"time"
 
+	"go.k6.io/k6/lib/types"
 	"go.k6.io/k6/metrics"
 )
 
@@ -36,6 +38,29 @@ type VUActivationParams struct {
 	Env, Tags                map[string]string
 	Exec, Scenario           string
 	GetNextIterationCounters func() (uint64, uint64)
+
+	// MaxIterationDuration is the maximum time a single iteration is allowed
+	// to run before it's interrupted. A zero value means no limit.
+	MaxIterationDuration time.Duration
+
+	// NetworkConditions are emulated for the connections made by the VU
+	// while it's active. A nil value means no emulation.
+	NetworkConditions *types.NetworkConditions
+
+	// Proxy used for the requests of the VU while it's active, instead of the
+	// global one from the options. A nil value means the global one is used.
+	Proxy *types.Proxy
+}
+
+// IterationTimeoutError is returned by ActiveVU.RunOnce() when an iteration
+// was interrupted because it exceeded the maxIterationDuration of its scenario.
+type IterationTimeoutError struct {
+	Duration time.Duration
+}
+
+// Error returns a human-readable description of the timeout.
+func (e *IterationTimeoutError) Error() string {
+	return fmt.Sprintf("iteration interrupted after exceeding the maxIterationDuration of %s", e.Duration)
 }
 
 // A Runner is a factory for VUs. It should precompute as much as possible upon
@@ -91,6 +116,17 @@ type UIState struct {
 	IsStdErrTTY bool
 }
 
+// ScenarioOutcome contains the outcome of the thresholds that are scoped to a
+// single scenario.
+type ScenarioOutcome struct {
+	// Thresholds maps the names of the scenario-scoped (sub-)metrics to
+	// whether all of their thresholds have passed.
+	Thresholds map[string]bool
+	// Stopped is true if the scenario was stopped prematurely because one of
+	// its thresholds with abortOnFail was crossed.
+	Stopped bool
+}
+
 // Summary contains all of the data the summary handler gets.
 type Summary struct {
 	Metrics         map[string]*metrics.Metric
@@ -98,4 +134,5 @@ type Summary struct {
 	TestRunDuration time.Duration // TODO: use lib.ExecutionState-based interface instead?
 	NoColor         bool          // TODO: drop this when noColor is part of the (runtime) options
 	UIState         UIState
+	Scenarios       map[string]ScenarioOutcome
 }
@@ -44,6 +44,19 @@ type RuntimeOptions struct {
 	SummaryExport null.String `json:"summaryExport"`
 	KeyWriter     null.String `json:"-"`
 	TracesOutput  null.String `json:"tracesOutput"`
+
+	// File where the TLS connection keys are logged by k6 run, in the NSS key
+	// log format, so captured traffic can be decrypted. SSLKEYLOGFILE takes
+	// precedence. It's only set with the --tls-key-log flag or K6_TLS_KEY_LOG,
+	// so scripts and archives can't make k6 write to arbitrary files.
+	TLSKeyLog null.String `json:"-"`
+
+	// Local directory where k6/experimental/fs can write files, set with the
+	// --fs-output-dir flag of k6 run or K6_FS_OUTPUT_DIR. It's a path of the
+	// machine running the test, so it's neither included in archives nor
+	// used by cloud test runs, and it needs to be set again when an archive
+	// is run.
+	FSOutputDir null.String `json:"-"`
 }
 
 // ValidateCompatibilityMode checks if the provided val is a valid compatibility mode
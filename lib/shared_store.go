@@ -0,0 +1,23 @@
+package lib
+
+// SharedStore is a key-value store for mutable state that is shared between
+// all VUs and, in distributed test runs, between all k6 instances. Values are
+// opaque to the store, while counters are kept separately from them.
+//
+// All of the methods need to be atomic and safe for concurrent use.
+type SharedStore interface {
+	// GetValue returns the value for the given key and whether it was found.
+	GetValue(key string) (value []byte, found bool, err error)
+	// SetValue sets the value for the given key.
+	SetValue(key string, value []byte) error
+	// CompareAndSwapValue sets the value for the given key to newValue, only
+	// if its current value is equal to oldValue. A nil oldValue means that
+	// the key shouldn't exist. It returns whether the value was swapped.
+	CompareAndSwapValue(key string, oldValue, newValue []byte) (swapped bool, err error)
+	// DeleteValue deletes the given key and returns whether it existed.
+	DeleteValue(key string) (deleted bool, err error)
+
+	// AddToCounter atomically adds delta to the counter with the given key,
+	// which starts from 0, and returns its new value.
+	AddToCounter(key string, delta int64) (int64, error)
+}
@@ -114,6 +114,11 @@ func (gs *GroupSummary) handleSample(sample metrics.Sample) error {
 		}
 		if sample.Value == 0 {
 			atomic.AddInt64(&check.Fails, 1)
+			// Only the failures of expect() assertions carry their details,
+			// so the ones of plain check() calls aren't recorded.
+			if reason, ok := sample.Metadata[CheckFailureReasonMetadata]; ok {
+				check.AddFailure(sample.Time, reason, sample.Metadata[CheckActualValueMetadata])
+			}
 		} else {
 			atomic.AddInt64(&check.Passes, 1)
 		}
@@ -0,0 +1,56 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.k6.io/k6/metrics"
+)
+
+func TestGroupSummaryCheckFailures(t *testing.T) {
+	t.Parallel()
+
+	registry := metrics.NewRegistry()
+	checks := registry.MustNewMetric(metrics.ChecksName, metrics.Rate)
+	tags := registry.RootTagSet().With("group", "")
+	now := time.Now()
+
+	gs := NewGroupSummary(logrus.New())
+	samples := []metrics.Sample{
+		// a failing check()
+		{
+			TimeSeries: metrics.TimeSeries{Metric: checks, Tags: tags.With("check", "plain")},
+			Time:       now,
+		},
+		// a failing expect() assertion
+		{
+			TimeSeries: metrics.TimeSeries{Metric: checks, Tags: tags.With("check", "expected")},
+			Time:       now,
+			Metadata: map[string]string{
+				CheckFailureReasonMetadata: "expected 404 to be 200",
+				CheckActualValueMetadata:   "404",
+			},
+		},
+	}
+	for _, sample := range samples {
+		require.NoError(t, gs.handleSample(sample))
+	}
+
+	plain := gs.Group().Checks["plain"]
+	require.NotNil(t, plain)
+	assert.Equal(t, int64(1), plain.Fails)
+	assert.Equal(t, CheckFailures{}, plain.Failures())
+
+	expected := gs.Group().Checks["expected"]
+	require.NotNil(t, expected)
+	assert.Equal(t, int64(1), expected.Fails)
+	assert.Equal(t, CheckFailures{
+		First:        now,
+		Reasons:      []string{"expected 404 to be 200"},
+		ActualValues: []string{"404"},
+	}, expected.Failures())
+}
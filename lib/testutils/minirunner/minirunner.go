@@ -4,6 +4,7 @@ package minirunner
 
 import (
 	"context"
+	"errors"
 	"io"
 
 	"go.k6.io/k6/lib"
@@ -214,5 +215,15 @@ func (vu *ActiveVU) RunOnce() error {
 	}()
 
 	vu.incrIteration()
-	return vu.R.Fn(vu.RunContext, vu.State(), vu.Out)
+	if vu.MaxIterationDuration <= 0 {
+		return vu.R.Fn(vu.RunContext, vu.State(), vu.Out)
+	}
+
+	ctx, cancel := context.WithTimeout(vu.RunContext, vu.MaxIterationDuration)
+	defer cancel()
+	err := vu.R.Fn(ctx, vu.State(), vu.Out)
+	if vu.RunContext.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &lib.IterationTimeoutError{Duration: vu.MaxIterationDuration}
+	}
+	return err
 }
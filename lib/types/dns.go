@@ -17,6 +17,11 @@ type DNSConfig struct {
 	Select NullDNSSelect `json:"select"`
 	// Policy specifies how to handle returning of IPv4 or IPv6 addresses.
 	Policy NullDNSPolicy `json:"policy"`
+	// Servers are queried, in order, instead of the ones of the system.
+	Servers []DNSServer `json:"servers"`
+	// Overrides maps hostname patterns, with optional leading wildcards, to the
+	// servers that are queried for the matching hosts.
+	Overrides map[string][]DNSServer `json:"overrides"`
 	// FIXME: Valid is unused and is only added to satisfy some logic in
 	// lib.Options.ForEachSpecified(), otherwise it would panic with
 	// `reflect: call of reflect.Value.Bool on zero Value`.
@@ -169,8 +174,16 @@ func (d NullDNSSelect) MarshalJSON() ([]byte, error) {
 
 // String implements fmt.Stringer.
 func (c DNSConfig) String() string {
-	return fmt.Sprintf("ttl=%s,select=%s,policy=%s",
+	s := fmt.Sprintf("ttl=%s,select=%s,policy=%s",
 		c.TTL.String, c.Select.String(), c.Policy.String())
+	if len(c.Servers) > 0 {
+		servers := make([]string, len(c.Servers))
+		for i, server := range c.Servers {
+			servers[i] = server.String()
+		}
+		s += ",servers=" + strings.Join(servers, ";")
+	}
+	return s
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
@@ -179,16 +192,38 @@ func (c *DNSConfig) UnmarshalJSON(data []byte) error {
 		TTL    null.String   `json:"ttl"`
 		Select NullDNSSelect `json:"select"`
 		Policy NullDNSPolicy `json:"policy"`
+
+		Servers   []DNSServer            `json:"servers"`
+		Overrides map[string][]DNSServer `json:"overrides"`
 	}
 	if err := json.Unmarshal(data, &s); err != nil {
 		return err
 	}
+	if _, err := (DNSConfig{Overrides: s.Overrides}).OverridesTrie(); err != nil {
+		return err
+	}
 	c.TTL = s.TTL
 	c.Select = s.Select
 	c.Policy = s.Policy
+	c.Servers = s.Servers
+	c.Overrides = s.Overrides
 	return nil
 }
 
+// OverridesTrie returns a HostnameTrie with the hostname patterns of the
+// overrides, whose matches are the keys of the Overrides map.
+func (c DNSConfig) OverridesTrie() (*HostnameTrie, error) {
+	patterns := make([]string, 0, len(c.Overrides))
+	for pattern := range c.Overrides {
+		patterns = append(patterns, pattern)
+	}
+	trie, err := NewHostnameTrie(patterns)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DNS override: %w", err)
+	}
+	return trie, nil
+}
+
 // UnmarshalText implements encoding.TextUnmarshaler.
 func (c *DNSConfig) UnmarshalText(text []byte) error {
 	if string(text) == DefaultDNSConfig().String() {
@@ -226,6 +261,15 @@ func (c *DNSConfig) unmarshal(params map[string]string) error {
 			c.Select.Valid = true
 		case "ttl":
 			c.TTL = null.StringFrom(v)
+		case "servers":
+			c.Servers = nil
+			for _, s := range strings.Split(v, ";") {
+				server, err := ParseDNSServer(s)
+				if err != nil {
+					return err
+				}
+				c.Servers = append(c.Servers, server)
+			}
 		default:
 			return fmt.Errorf("unknown DNS configuration field: %s", k)
 		}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// DNSTransport is the transport used to query a DNS server.
+type DNSTransport string
+
+// The supported DNS transports.
+const (
+	DNSTransportUDP   DNSTransport = "udp"
+	DNSTransportTCP   DNSTransport = "tcp"
+	DNSTransportTLS   DNSTransport = "tls"   // DNS-over-TLS, RFC 7858
+	DNSTransportHTTPS DNSTransport = "https" // DNS-over-HTTPS, RFC 8484
+)
+
+// defaultDNSPorts are the default ports of the DNS transports.
+//
+//nolint:gochecknoglobals
+var defaultDNSPorts = map[DNSTransport]string{
+	DNSTransportUDP: "53",
+	DNSTransportTCP: "53",
+	DNSTransportTLS: "853",
+}
+
+// DNSServer is a DNS server and the transport used to query it. It's written
+// as [transport://]host[:port], e.g. 1.1.1.1, tcp://1.1.1.1:5353 or
+// tls://dns.google, and as an URL for DNS-over-HTTPS, e.g.
+// https://cloudflare-dns.com/dns-query.
+type DNSServer struct {
+	Transport DNSTransport
+	// Address is the host:port of the server for the UDP, TCP and TLS
+	// transports, and the URL of the server for the HTTPS one.
+	Address string
+}
+
+// ParseDNSServer parses the given DNS server.
+func ParseDNSServer(s string) (DNSServer, error) {
+	transport, address := DNSTransportUDP, s
+	if i := strings.Index(s, "://"); i >= 0 {
+		transport, address = DNSTransport(strings.ToLower(s[:i])), s[i+3:]
+	}
+
+	switch transport {
+	case DNSTransportUDP, DNSTransportTCP, DNSTransportTLS:
+		if address == "" || strings.ContainsAny(address, "/?#") {
+			return DNSServer{}, fmt.Errorf("invalid DNS server address '%s'", s)
+		}
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(strings.Trim(address, "[]"), defaultDNSPorts[transport])
+		}
+		return DNSServer{Transport: transport, Address: address}, nil
+	case DNSTransportHTTPS:
+		u, err := url.Parse(s)
+		if err != nil || u.Host == "" {
+			return DNSServer{}, fmt.Errorf("invalid DNS-over-HTTPS server URL '%s'", s)
+		}
+		return DNSServer{Transport: transport, Address: u.String()}, nil
+	default:
+		return DNSServer{}, fmt.Errorf("unsupported DNS transport '%s', valid ones are: udp, tcp, tls, https", transport)
+	}
+}
+
+// String returns the DNS server in the format accepted by ParseDNSServer.
+func (s DNSServer) String() string {
+	if s.Transport == DNSTransportHTTPS {
+		return s.Address
+	}
+	return string(s.Transport) + "://" + s.Address
+}
+
+// MarshalJSON returns the JSON representation of the server.
+func (s DNSServer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON converts JSON data to a valid DNSServer.
+func (s *DNSServer) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	v, err := ParseDNSServer(str)
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
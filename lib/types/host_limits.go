@@ -0,0 +1,106 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/guregu/null.v3"
+)
+
+// HostLimit is the limit of the requests to the hosts which match a pattern.
+type HostLimit struct {
+	// RPS is the maximum number of requests per second, across all VUs.
+	RPS null.Int `json:"rps"`
+	// MaxConcurrent is the maximum number of requests in flight at the same
+	// time, across all VUs.
+	MaxConcurrent null.Int `json:"maxConcurrent"`
+}
+
+// Validate checks that the set limits are positive.
+func (l HostLimit) Validate() error {
+	if l.RPS.Valid && l.RPS.Int64 <= 0 {
+		return errors.New("rps must be positive")
+	}
+	if l.MaxConcurrent.Valid && l.MaxConcurrent.Int64 <= 0 {
+		return errors.New("maxConcurrent must be positive")
+	}
+	return nil
+}
+
+// NullHostLimits is a nullable mapping of hostname patterns, with optional
+// leading wildcards, to the limits of the requests to the matching hosts.
+type NullHostLimits struct {
+	Limits map[string]HostLimit
+	Trie   *HostnameTrie
+	Valid  bool
+}
+
+// NewNullHostLimits returns a valid NullHostLimits for the given limits, or an
+// error if a pattern or a limit is invalid.
+func NewNullHostLimits(limits map[string]HostLimit) (NullHostLimits, error) {
+	patterns := make([]string, 0, len(limits))
+	lowered := make(map[string]HostLimit, len(limits))
+	for pattern, limit := range limits {
+		if err := limit.Validate(); err != nil {
+			return NullHostLimits{}, fmt.Errorf("invalid limit for host '%s': %w", pattern, err)
+		}
+		patterns = append(patterns, pattern)
+		lowered[strings.ToLower(pattern)] = limit
+	}
+	trie, err := NewHostnameTrie(patterns)
+	if err != nil {
+		return NullHostLimits{}, err
+	}
+	return NullHostLimits{Limits: lowered, Trie: trie, Valid: true}, nil
+}
+
+// Match returns the pattern which matches the host and its limit, if any.
+func (n NullHostLimits) Match(host string) (string, HostLimit, bool) {
+	if !n.Valid || n.Trie == nil {
+		return "", HostLimit{}, false
+	}
+	pattern, ok := n.Trie.Contains(host)
+	if !ok {
+		return "", HostLimit{}, false
+	}
+	return pattern, n.Limits[pattern], true
+}
+
+// UnmarshalJSON converts a JSON object of patterns to limits to NullHostLimits.
+func (n *NullHostLimits) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte(`null`)) {
+		*n = NullHostLimits{}
+		return nil
+	}
+	var limits map[string]HostLimit
+	if err := json.Unmarshal(data, &limits); err != nil {
+		return err
+	}
+	result, err := NewNullHostLimits(limits)
+	if err != nil {
+		return err
+	}
+	*n = result
+	return nil
+}
+
+// UnmarshalText converts the same JSON object as UnmarshalJSON, e.g. from an
+// environment variable, to NullHostLimits.
+func (n *NullHostLimits) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*n = NullHostLimits{}
+		return nil
+	}
+	return n.UnmarshalJSON(data)
+}
+
+// MarshalJSON converts NullHostLimits to a JSON object.
+func (n NullHostLimits) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte(`null`), nil
+	}
+	return json.Marshal(n.Limits)
+}
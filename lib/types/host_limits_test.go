@@ -0,0 +1,77 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v3"
+)
+
+func TestNullHostLimits(t *testing.T) {
+	t.Parallel()
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		var limits NullHostLimits
+		require.NoError(t, json.Unmarshal(
+			[]byte(`{"API.k6.io":{"rps":10},"*.internal":{"rps":5,"maxConcurrent":2}}`), &limits))
+		assert.True(t, limits.Valid)
+
+		pattern, limit, ok := limits.Match("api.k6.io")
+		assert.True(t, ok)
+		assert.Equal(t, "api.k6.io", pattern)
+		assert.Equal(t, HostLimit{RPS: null.IntFrom(10)}, limit)
+
+		pattern, limit, ok = limits.Match("db.internal")
+		assert.True(t, ok)
+		assert.Equal(t, "*.internal", pattern)
+		assert.Equal(t, HostLimit{RPS: null.IntFrom(5), MaxConcurrent: null.IntFrom(2)}, limit)
+
+		_, _, ok = limits.Match("k6.io")
+		assert.False(t, ok)
+
+		data, err := json.Marshal(limits)
+		require.NoError(t, err)
+		assert.JSONEq(t,
+			`{"api.k6.io":{"rps":10,"maxConcurrent":null},"*.internal":{"rps":5,"maxConcurrent":2}}`, string(data))
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		t.Parallel()
+		var limits NullHostLimits
+		require.NoError(t, limits.UnmarshalText([]byte(`{"k6.io":{"maxConcurrent":1}}`)))
+		_, limit, ok := limits.Match("k6.io")
+		assert.True(t, ok)
+		assert.Equal(t, HostLimit{MaxConcurrent: null.IntFrom(1)}, limit)
+
+		require.NoError(t, limits.UnmarshalText(nil))
+		assert.False(t, limits.Valid)
+		_, _, ok = limits.Match("k6.io")
+		assert.False(t, ok)
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		t.Parallel()
+		var limits NullHostLimits
+		require.NoError(t, json.Unmarshal([]byte(`null`), &limits))
+		assert.False(t, limits.Valid)
+		data, err := json.Marshal(limits)
+		require.NoError(t, err)
+		assert.Equal(t, "null", string(data))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		testCases := map[string]string{
+			`{"k6.io":{"rps":0}}`:            "invalid limit for host 'k6.io': rps must be positive",
+			`{"k6.io":{"maxConcurrent":-1}}`: "invalid limit for host 'k6.io': maxConcurrent must be positive",
+			`{"k6.*":{"rps":1}}`:             "invalid hostname pattern 'k6.*'",
+		}
+		for data, expErr := range testCases {
+			var limits NullHostLimits
+			assert.EqualError(t, json.Unmarshal([]byte(data), &limits), expErr, data)
+		}
+	})
+}
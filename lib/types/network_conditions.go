@@ -0,0 +1,144 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NetworkConditions describes the network conditions that are emulated for
+// the connections of the VUs, e.g. to test how a system behaves for mobile
+// clients.
+type NetworkConditions struct {
+	// Latency is added before every write, and once more when connecting.
+	Latency Duration `json:"latency"`
+	// Jitter is the maximum random variation of the latency, in both directions.
+	Jitter Duration `json:"jitter"`
+	// DownloadKbps and UploadKbps limit the bandwidth of each connection, in
+	// kilobits per second. Zero means no limit.
+	DownloadKbps float64 `json:"downloadKbps"`
+	UploadKbps   float64 `json:"uploadKbps"`
+	// Loss is the probability, between 0 and 1, of every read or write to
+	// stall, as if a packet was lost and had to be retransmitted.
+	Loss float64 `json:"loss"`
+}
+
+// networkConditionsPresets contains the conditions of typical mobile
+// networks. The 3g one is based on the "Fast 3G" throttling profile of the
+// browser developer tools, and the 4g one on common 4G/LTE conditions.
+//
+//nolint:gochecknoglobals
+var networkConditionsPresets = map[string]NetworkConditions{
+	"3g": {
+		Latency:      Duration(560 * time.Millisecond),
+		Jitter:       Duration(50 * time.Millisecond),
+		DownloadKbps: 1440,
+		UploadKbps:   675,
+	},
+	"4g": {
+		Latency:      Duration(60 * time.Millisecond),
+		Jitter:       Duration(10 * time.Millisecond),
+		DownloadKbps: 9000,
+		UploadKbps:   1500,
+	},
+}
+
+// GetNetworkConditionsPreset returns the network conditions of the given
+// preset, e.g. "3g" or "4g".
+func GetNetworkConditionsPreset(name string) (NetworkConditions, error) {
+	nc, ok := networkConditionsPresets[strings.ToLower(name)]
+	if !ok {
+		presets := make([]string, 0, len(networkConditionsPresets))
+		for preset := range networkConditionsPresets {
+			presets = append(presets, preset)
+		}
+		sort.Strings(presets)
+		return nc, fmt.Errorf("unknown network conditions preset '%s', valid ones are: %s",
+			name, strings.Join(presets, ", "))
+	}
+	return nc, nil
+}
+
+// UnmarshalJSON converts JSON data to NetworkConditions. It accepts either
+// the name of a preset or an object, which can contain a "preset" key whose
+// conditions are overridden by the rest of the keys.
+func (nc *NetworkConditions) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte(`null`)) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var preset string
+		if err := json.Unmarshal(data, &preset); err != nil {
+			return err
+		}
+		v, err := GetNetworkConditionsPreset(preset)
+		if err != nil {
+			return err
+		}
+		*nc = v
+		return nil
+	}
+
+	var base struct {
+		Preset string `json:"preset"`
+	}
+	if err := json.Unmarshal(data, &base); err != nil {
+		return err
+	}
+	result := NetworkConditions{}
+	if base.Preset != "" {
+		v, err := GetNetworkConditionsPreset(base.Preset)
+		if err != nil {
+			return err
+		}
+		result = v
+	}
+
+	// the plain type avoids the recursion into this method
+	type plainNetworkConditions NetworkConditions
+	if err := json.Unmarshal(data, (*plainNetworkConditions)(&result)); err != nil {
+		return err
+	}
+	if err := result.Validate(); err != nil {
+		return err
+	}
+	*nc = result
+	return nil
+}
+
+// Validate checks that the network conditions are valid.
+func (nc NetworkConditions) Validate() error {
+	var errs []error
+	if nc.Latency < 0 {
+		errs = append(errs, errors.New("the network latency can't be negative"))
+	}
+	if nc.Jitter < 0 {
+		errs = append(errs, errors.New("the network jitter can't be negative"))
+	}
+	if nc.DownloadKbps < 0 || nc.UploadKbps < 0 {
+		errs = append(errs, errors.New("the network bandwidth can't be negative"))
+	}
+	if nc.Loss < 0 || nc.Loss > 1 {
+		errs = append(errs, fmt.Errorf("the network loss needs to be between 0 and 1, but it's %g", nc.Loss))
+	}
+	return errors.Join(errs...)
+}
+
+// NetworkConditionsFromValue returns the network conditions described by the
+// given value, e.g. one exported from JS, which can be a preset name or an
+// object with the conditions.
+func NetworkConditionsFromValue(v interface{}) (*NetworkConditions, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	nc := &NetworkConditions{}
+	if err := json.Unmarshal(data, nc); err != nil {
+		return nil, fmt.Errorf("invalid network conditions: %w", err)
+	}
+	return nc, nil
+}
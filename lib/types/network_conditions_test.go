@@ -0,0 +1,70 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkConditionsUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		data   string
+		exp    NetworkConditions
+		expErr string
+	}{
+		{
+			data: `"3g"`,
+			exp: NetworkConditions{
+				Latency: Duration(560 * time.Millisecond), Jitter: Duration(50 * time.Millisecond),
+				DownloadKbps: 1440, UploadKbps: 675,
+			},
+		},
+		{
+			data: `{"preset": "4G", "latency": "300ms", "loss": 0.01}`,
+			exp: NetworkConditions{
+				Latency: Duration(300 * time.Millisecond), Jitter: Duration(10 * time.Millisecond),
+				DownloadKbps: 9000, UploadKbps: 1500, Loss: 0.01,
+			},
+		},
+		{
+			data: `{"latency": 100, "downloadKbps": 500}`,
+			exp:  NetworkConditions{Latency: Duration(100 * time.Millisecond), DownloadKbps: 500},
+		},
+		{data: `"5g"`, expErr: "unknown network conditions preset '5g', valid ones are: 3g, 4g"},
+		{data: `{"preset": "edge"}`, expErr: "unknown network conditions preset 'edge'"},
+		{data: `{"loss": 1.5}`, expErr: "the network loss needs to be between 0 and 1, but it's 1.5"},
+		{data: `{"latency": "-1s", "uploadKbps": -1}`, expErr: "the network bandwidth can't be negative"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.data, func(t *testing.T) {
+			t.Parallel()
+			var nc NetworkConditions
+			err := json.Unmarshal([]byte(tc.data), &nc)
+			if tc.expErr != "" {
+				assert.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.exp, nc)
+		})
+	}
+}
+
+func TestNetworkConditionsFromValue(t *testing.T) {
+	t.Parallel()
+
+	nc, err := NetworkConditionsFromValue(map[string]interface{}{"preset": "3g", "jitter": "0s"})
+	require.NoError(t, err)
+	assert.Equal(t, Duration(560*time.Millisecond), nc.Latency)
+	assert.Equal(t, Duration(0), nc.Jitter)
+
+	_, err = NetworkConditionsFromValue(42)
+	assert.ErrorContains(t, err, "invalid network conditions")
+}
@@ -0,0 +1,215 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Proxy describes the proxy used for the connections of the VUs. It supports
+// HTTP(S) proxies, which are used through HTTP CONNECT for HTTPS targets, and
+// SOCKS5 proxies. A Proxy without a URL disables proxying, including the one
+// configured with the HTTP_PROXY and HTTPS_PROXY environment variables.
+type Proxy struct {
+	URL *url.URL
+	// NoProxy contains the hostname patterns that are connected to directly.
+	NoProxy *HostnameTrie
+}
+
+// proxyJSON is the JSON representation of a Proxy.
+type proxyJSON struct {
+	URL      string   `json:"url"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	NoProxy  []string `json:"noProxy,omitempty"`
+}
+
+// NewProxy returns a new Proxy for the given URL and no-proxy hostname
+// patterns. The URL can omit the scheme, in which case it's an HTTP proxy,
+// and it can contain the credentials for the proxy. An empty URL disables
+// proxying.
+func NewProxy(rawURL string, noProxy []string) (*Proxy, error) {
+	p := &Proxy{}
+	if rawURL != "" {
+		if !strings.Contains(rawURL, "://") {
+			rawURL = "http://" + rawURL
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("unsupported proxy scheme '%s', valid ones are: http, https, socks5, socks5h", u.Scheme)
+		}
+		if u.Hostname() == "" {
+			return nil, fmt.Errorf("the proxy URL '%s' doesn't have a host", rawURL)
+		}
+		p.URL = u
+	}
+	if len(noProxy) > 0 {
+		trie, err := NewHostnameTrie(noProxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid noProxy pattern: %w", err)
+		}
+		p.NoProxy = trie
+	}
+	return p, nil
+}
+
+// URLFor returns the URL of the proxy that should be used to connect to the
+// given target, or nil if it should be connected to directly.
+func (p *Proxy) URLFor(target *url.URL) *url.URL {
+	if p == nil || p.URL == nil {
+		return nil
+	}
+	if p.NoProxy != nil && target != nil {
+		host := target.Hostname()
+		if _, found := p.NoProxy.Contains(host); found {
+			return nil
+		}
+	}
+	return p.URL
+}
+
+// HasCredentials returns whether the URL of the proxy contains credentials,
+// which are left out of its JSON representation.
+func (p *Proxy) HasCredentials() bool {
+	return p != nil && p.URL != nil && p.URL.User != nil
+}
+
+// String returns the URL of the proxy, with its password redacted.
+func (p *Proxy) String() string {
+	if p == nil || p.URL == nil {
+		return ""
+	}
+	return p.URL.Redacted()
+}
+
+// UnmarshalText converts text data, i.e. the URL of the proxy, to a Proxy.
+func (p *Proxy) UnmarshalText(data []byte) error {
+	v, err := NewProxy(string(data), nil)
+	if err != nil {
+		return err
+	}
+	*p = *v
+	return nil
+}
+
+// UnmarshalJSON converts JSON data to a Proxy. It accepts either the URL of the
+// proxy, or an object with the url, username, password and noProxy keys.
+func (p *Proxy) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var rawURL string
+		if err := json.Unmarshal(data, &rawURL); err != nil {
+			return err
+		}
+		return p.UnmarshalText([]byte(rawURL))
+	}
+
+	var pj proxyJSON
+	if err := json.Unmarshal(data, &pj); err != nil {
+		return err
+	}
+	v, err := NewProxy(pj.URL, pj.NoProxy)
+	if err != nil {
+		return err
+	}
+	if pj.Username != "" || pj.Password != "" {
+		if v.URL == nil {
+			return errors.New("the proxy credentials require a proxy url")
+		}
+		v.URL.User = url.UserPassword(pj.Username, pj.Password)
+	}
+	*p = *v
+	return nil
+}
+
+// MarshalJSON converts the Proxy to JSON. The credentials of the proxy are
+// left out, since the JSON ends up in places like archives, the test.options
+// of k6/execution and cloud uploads, so they need to be provided again, e.g.
+// with the K6_PROXY environment variable, when the test is run from them.
+func (p Proxy) MarshalJSON() ([]byte, error) {
+	pj := proxyJSON{}
+	if p.URL != nil {
+		u := *p.URL
+		u.User = nil
+		pj.URL = u.String()
+	}
+	if p.NoProxy != nil {
+		pj.NoProxy = p.NoProxy.source
+	}
+	return json.Marshal(pj)
+}
+
+// NullProxy is a nullable Proxy, in the same vein as the nullable types
+// provided by package gopkg.in/guregu/null.v3
+type NullProxy struct {
+	Proxy *Proxy
+	Valid bool
+}
+
+// NewNullProxy returns a valid NullProxy for the given URL and no-proxy
+// hostname patterns.
+func NewNullProxy(rawURL string, noProxy []string) (NullProxy, error) {
+	p, err := NewProxy(rawURL, noProxy)
+	if err != nil {
+		return NullProxy{}, err
+	}
+	return NullProxy{Proxy: p, Valid: true}, nil
+}
+
+// UnmarshalText converts text data to a valid NullProxy
+func (n *NullProxy) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*n = NullProxy{}
+		return nil
+	}
+	p := &Proxy{}
+	if err := p.UnmarshalText(data); err != nil {
+		return err
+	}
+	*n = NullProxy{Proxy: p, Valid: true}
+	return nil
+}
+
+// UnmarshalJSON converts JSON data to a valid NullProxy
+func (n *NullProxy) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte(nullJSON)) {
+		*n = NullProxy{}
+		return nil
+	}
+	p := &Proxy{}
+	if err := p.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*n = NullProxy{Proxy: p, Valid: true}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (n NullProxy) MarshalJSON() ([]byte, error) {
+	if !n.Valid || n.Proxy == nil {
+		return []byte(nullJSON), nil
+	}
+	return n.Proxy.MarshalJSON()
+}
+
+// ProxyFromValue returns the proxy described by the given value, e.g. one
+// exported from JS, which can be the URL of the proxy or an object with its
+// configuration.
+func ProxyFromValue(v interface{}) (*Proxy, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	p := &Proxy{}
+	if err := p.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("invalid proxy: %w", err)
+	}
+	return p, nil
+}
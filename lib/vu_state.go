@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/time/rate"
 
+	"go.k6.io/k6/lib/types"
 	"go.k6.io/k6/metrics"
 	"go.k6.io/k6/usage"
 )
@@ -54,6 +55,16 @@ type State struct {
 
 	// Rate limits.
 	RPSLimit *rate.Limiter
+	// Limits of the requests to specific hosts, shared by all VUs.
+	HostLimiter *HostLimiter
+
+	// Network conditions emulated for the connections of the VU. They are
+	// assigned on VU activation, from the scenario options.
+	NetworkConditions *types.NetworkConditions
+
+	// Proxy used for the requests of the VU, from the scenario or the global
+	// options. A nil value means the environment variables are used.
+	Proxy *types.Proxy
 
 	// Sample channel, possibly buffered
 	Samples chan<- metrics.SampleContainer
@@ -9,6 +9,8 @@ const (
 	IterationDurationName = "iteration_duration"
 	DroppedIterationsName = "dropped_iterations"
 
+	IterationsInterruptedName = "iterations_interrupted"
+
 	ChecksName        = "checks"
 	GroupDurationName = "group_duration"
 
@@ -21,6 +23,14 @@ const (
 	HTTPReqSendingName        = "http_req_sending"
 	HTTPReqWaitingName        = "http_req_waiting"
 	HTTPReqReceivingName      = "http_req_receiving"
+	HTTPReqConnReusedName     = "http_req_conn_reused"
+
+	HTTPReqLimitedDurationName = "http_req_limited_duration"
+
+	HTTPConnsOpenedName  = "http_conns_opened"
+	HTTPConnsClosedName  = "http_conns_closed"
+	HTTPIdleConnsName    = "http_idle_conns"
+	HTTP2ConnStreamsName = "http2_conn_streams"
 
 	WSSessionsName         = "ws_sessions"
 	WSMessagesSentName     = "ws_msgs_sent"
@@ -31,6 +41,17 @@ const (
 
 	GRPCReqDurationName = "grpc_req_duration"
 
+	SSEEventsName           = "sse_event"
+	SSETimeToFirstEventName = "sse_time_to_first_event"
+
+	TCPConnectDurationName = "tcp_connect_duration"
+	TCPRequestDurationName = "tcp_request_duration"
+
+	DNSLookupDurationName = "dns_lookup_duration"
+	DNSLookupFailedName   = "dns_lookup_failed"
+
+	JSONSchemaFailedName = "json_schema_failed"
+
 	DataSentName     = "data_sent"
 	DataReceivedName = "data_received"
 )
@@ -43,6 +64,9 @@ type BuiltinMetrics struct {
 	IterationDuration *Metric
 	DroppedIterations *Metric
 
+	// IterationsInterrupted is tagged with the cause of the interruption.
+	IterationsInterrupted *Metric
+
 	// Runner-emitted.
 	Checks        *Metric
 	GroupDuration *Metric
@@ -57,6 +81,16 @@ type BuiltinMetrics struct {
 	HTTPReqSending        *Metric
 	HTTPReqWaiting        *Metric
 	HTTPReqReceiving      *Metric
+	HTTPReqConnReused     *Metric
+
+	// HTTPReqLimitedDuration is the time requests waited for the hostLimits.
+	HTTPReqLimitedDuration *Metric
+
+	// HTTP connection pool, tagged with the host.
+	HTTPConnsOpened  *Metric
+	HTTPConnsClosed  *Metric
+	HTTPIdleConns    *Metric
+	HTTP2ConnStreams *Metric
 
 	// Websocket-related
 	WSSessions         *Metric
@@ -69,6 +103,21 @@ type BuiltinMetrics struct {
 	// gRPC-related
 	GRPCReqDuration *Metric
 
+	// Server-Sent Events-related
+	SSEEvents           *Metric
+	SSETimeToFirstEvent *Metric
+
+	// Raw TCP socket-related
+	TCPConnectDuration *Metric
+	TCPRequestDuration *Metric
+
+	// DNS-related, tagged with the looked up host.
+	DNSLookupDuration *Metric
+	DNSLookupFailed   *Metric
+
+	// JSONSchemaFailed is the rate of the failed JSON Schema validations.
+	JSONSchemaFailed *Metric
+
 	// Network-related; used for future protocols as well.
 	DataSent     *Metric
 	DataReceived *Metric
@@ -83,6 +132,8 @@ func RegisterBuiltinMetrics(registry *Registry) *BuiltinMetrics {
 		IterationDuration: registry.MustNewMetric(IterationDurationName, Trend, Time),
 		DroppedIterations: registry.MustNewMetric(DroppedIterationsName, Counter),
 
+		IterationsInterrupted: registry.MustNewMetric(IterationsInterruptedName, Counter),
+
 		Checks:        registry.MustNewMetric(ChecksName, Rate),
 		GroupDuration: registry.MustNewMetric(GroupDurationName, Trend, Time),
 
@@ -95,6 +146,14 @@ func RegisterBuiltinMetrics(registry *Registry) *BuiltinMetrics {
 		HTTPReqSending:        registry.MustNewMetric(HTTPReqSendingName, Trend, Time),
 		HTTPReqWaiting:        registry.MustNewMetric(HTTPReqWaitingName, Trend, Time),
 		HTTPReqReceiving:      registry.MustNewMetric(HTTPReqReceivingName, Trend, Time),
+		HTTPReqConnReused:     registry.MustNewMetric(HTTPReqConnReusedName, Rate),
+
+		HTTPReqLimitedDuration: registry.MustNewMetric(HTTPReqLimitedDurationName, Trend, Time),
+
+		HTTPConnsOpened:  registry.MustNewMetric(HTTPConnsOpenedName, Counter),
+		HTTPConnsClosed:  registry.MustNewMetric(HTTPConnsClosedName, Counter),
+		HTTPIdleConns:    registry.MustNewMetric(HTTPIdleConnsName, Gauge),
+		HTTP2ConnStreams: registry.MustNewMetric(HTTP2ConnStreamsName, Trend),
 
 		WSSessions:         registry.MustNewMetric(WSSessionsName, Counter),
 		WSMessagesSent:     registry.MustNewMetric(WSMessagesSentName, Counter),
@@ -105,6 +164,17 @@ func RegisterBuiltinMetrics(registry *Registry) *BuiltinMetrics {
 
 		GRPCReqDuration: registry.MustNewMetric(GRPCReqDurationName, Trend, Time),
 
+		SSEEvents:           registry.MustNewMetric(SSEEventsName, Counter),
+		SSETimeToFirstEvent: registry.MustNewMetric(SSETimeToFirstEventName, Trend, Time),
+
+		TCPConnectDuration: registry.MustNewMetric(TCPConnectDurationName, Trend, Time),
+		TCPRequestDuration: registry.MustNewMetric(TCPRequestDurationName, Trend, Time),
+
+		DNSLookupDuration: registry.MustNewMetric(DNSLookupDurationName, Trend, Time),
+		DNSLookupFailed:   registry.MustNewMetric(DNSLookupFailedName, Rate),
+
+		JSONSchemaFailed: registry.MustNewMetric(JSONSchemaFailedName, Rate),
+
 		DataSent:     registry.MustNewMetric(DataSentName, Counter, Data),
 		DataReceived: registry.MustNewMetric(DataReceivedName, Counter, Data),
 	}
@@ -3,6 +3,7 @@
 package engine
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -31,6 +32,12 @@ type MetricsEngine struct {
 	metricsWithThresholds   []*metrics.Metric
 	breachedThresholdsCount uint32
 
+	// The (sub-)metrics with thresholds that are scoped to a single scenario,
+	// mapped to the name of that scenario, and the scenarios that have been
+	// stopped early because of them. Both are protected by MetricsLock.
+	scenarioThresholdMetrics map[*metrics.Metric]string
+	stoppedScenarios         map[string]bool
+
 	// TODO: completely refactor:
 	//   - make these private, add a method to export the raw data
 	//   - do not use an unnecessary map for the observed metrics
@@ -43,9 +50,11 @@ type MetricsEngine struct {
 // NewMetricsEngine creates a new metrics Engine with the given parameters.
 func NewMetricsEngine(registry *metrics.Registry, logger logrus.FieldLogger) (*MetricsEngine, error) {
 	me := &MetricsEngine{
-		registry:        registry,
-		logger:          logger.WithField("component", "metrics-engine"),
-		ObservedMetrics: make(map[string]*metrics.Metric),
+		registry:                 registry,
+		logger:                   logger.WithField("component", "metrics-engine"),
+		ObservedMetrics:          make(map[string]*metrics.Metric),
+		scenarioThresholdMetrics: make(map[*metrics.Metric]string),
+		stoppedScenarios:         make(map[string]bool),
 	}
 
 	return me, nil
@@ -132,16 +141,11 @@ func (me *MetricsEngine) InitSubMetricsAndThresholds(options lib.Options, onlyLo
 			return fmt.Errorf("invalid metric '%s' in threshold definitions: %w", metricName, err)
 		}
 
-		metric.Thresholds = thresholds
-		me.metricsWithThresholds = append(me.metricsWithThresholds, metric)
+		me.addThresholds(metric, thresholds)
+	}
 
-		// Mark the metric (and the parent metric, if we're dealing with a
-		// submetric) as observed, so they are shown in the end-of-test summary,
-		// even if they don't have any metric samples during the test run
-		me.markObserved(metric)
-		if metric.Sub != nil {
-			me.markObserved(metric.Sub.Parent)
-		}
+	if err := me.initScenarioThresholds(options, onlyLogErrors); err != nil {
+		return err
 	}
 
 	// TODO: refactor out of here when https://github.com/grafana/k6/issues/1321
@@ -156,11 +160,76 @@ func (me *MetricsEngine) InitSubMetricsAndThresholds(options lib.Options, onlyLo
 	return nil
 }
 
+func (me *MetricsEngine) addThresholds(metric *metrics.Metric, thresholds metrics.Thresholds) {
+	metric.Thresholds = thresholds
+	me.metricsWithThresholds = append(me.metricsWithThresholds, metric)
+
+	// Mark the metric (and the parent metric, if we're dealing with a
+	// submetric) as observed, so they are shown in the end-of-test summary,
+	// even if they don't have any metric samples during the test run
+	me.markObserved(metric)
+	if metric.Sub != nil {
+		me.markObserved(metric.Sub.Parent)
+	}
+}
+
+// initScenarioThresholds initializes the thresholds defined in the scenarios.
+// Each of them is applied to a sub-metric of the referenced metric (or
+// sub-metric), additionally filtered by the scenario tag.
+func (me *MetricsEngine) initScenarioThresholds(options lib.Options, onlyLogErrors bool) error {
+	for scenarioName, scenarioConfig := range options.Scenarios {
+		for metricName, thresholds := range lib.GetScenarioThresholds(scenarioConfig) {
+			scopedName, err := scenarioScopedMetricName(metricName, scenarioName)
+			var metric *metrics.Metric
+			if err == nil {
+				metric, err = me.getThresholdMetricOrSubmetric(scopedName)
+			}
+			if err == nil && len(metric.Thresholds.Thresholds) > 0 {
+				err = fmt.Errorf("thresholds for '%s' are already defined globally", scopedName)
+			}
+
+			if onlyLogErrors {
+				if err != nil {
+					me.logger.WithError(err).Warnf(
+						"Invalid metric '%s' in the threshold definitions of scenario '%s'", metricName, scenarioName)
+				}
+				continue
+			}
+
+			if err != nil {
+				return fmt.Errorf(
+					"invalid metric '%s' in the threshold definitions of scenario '%s': %w", metricName, scenarioName, err)
+			}
+
+			me.addThresholds(metric, thresholds)
+			me.scenarioThresholdMetrics[metric] = scenarioName
+		}
+	}
+	return nil
+}
+
+// scenarioScopedMetricName returns the name of the sub-metric that's used for
+// a threshold defined on the given metric in the given scenario.
+func scenarioScopedMetricName(metricName, scenarioName string) (string, error) {
+	name, tags, hasTags := strings.Cut(metricName, "{")
+	if !hasTags {
+		return name + "{scenario:" + scenarioName + "}", nil
+	}
+	if strings.HasPrefix(tags, "scenario:") || strings.Contains(tags, ",scenario:") {
+		return "", errors.New("scenario thresholds are already filtered by scenario and can't use the 'scenario' tag")
+	}
+	return name + "{scenario:" + scenarioName + "," + tags, nil
+}
+
 // StartThresholdCalculations spins up a new goroutine to crunch thresholds and
 // returns a callback that will stop the goroutine and finalizes calculations.
+//
+// If a threshold with abortOnFail is crossed, abortRun is called, unless the
+// threshold is scoped to a scenario; then only stopScenario is called for it.
 func (me *MetricsEngine) StartThresholdCalculations(
 	ingester *OutputIngester,
 	abortRun func(error),
+	stopScenario func(name string, err error),
 	getCurrentTestRunDuration func() time.Duration,
 ) (finalize func() (breached []string)) {
 	if len(me.metricsWithThresholds) == 0 {
@@ -178,7 +247,16 @@ func (me *MetricsEngine) StartThresholdCalculations(
 		for {
 			select {
 			case <-ticker.C:
-				breached, shouldAbort := me.evaluateThresholds(true, getCurrentTestRunDuration)
+				breached, shouldAbort, scenariosToStop := me.evaluateThresholds(true, getCurrentTestRunDuration)
+				for scenarioName, scenarioBreached := range scenariosToStop {
+					err := fmt.Errorf(
+						"thresholds on metrics '%s' of scenario '%s' were crossed; at least one has abortOnFail enabled,"+
+							" stopping the scenario prematurely",
+						strings.Join(scenarioBreached, ", "), scenarioName,
+					)
+					me.logger.Warn(err.Error())
+					stopScenario(scenarioName, err)
+				}
 				if shouldAbort {
 					err := fmt.Errorf(
 						"thresholds on metrics '%s' were crossed; at least one has abortOnFail enabled, stopping test prematurely",
@@ -207,18 +285,20 @@ func (me *MetricsEngine) StartThresholdCalculations(
 		close(stop)
 		<-done
 
-		breached, _ := me.evaluateThresholds(false, getCurrentTestRunDuration)
+		breached, _, _ := me.evaluateThresholds(false, getCurrentTestRunDuration)
 		return breached
 	}
 }
 
-// evaluateThresholds processes all of the thresholds.
+// evaluateThresholds processes all of the thresholds. Besides the breached
+// thresholds and whether the test should be aborted, it returns the scenarios
+// that should be stopped, mapped to their breached thresholds.
 //
 // TODO: refactor, optimize
 func (me *MetricsEngine) evaluateThresholds(
 	ignoreEmptySinks bool,
 	getCurrentTestRunDuration func() time.Duration,
-) (breachedThresholds []string, shouldAbort bool) {
+) (breachedThresholds []string, shouldAbort bool, scenariosToStop map[string][]string) {
 	me.MetricsLock.Lock()
 	defer me.MetricsLock.Unlock()
 
@@ -243,16 +323,31 @@ func (me *MetricsEngine) evaluateThresholds(
 		}
 		breachedThresholds = append(breachedThresholds, m.Name)
 		m.Tainted = null.BoolFrom(true)
-		if m.Thresholds.Abort {
+		if !m.Thresholds.Abort {
+			continue
+		}
+		scenarioName, isScoped := me.scenarioThresholdMetrics[m]
+		if !isScoped {
 			shouldAbort = true
+			continue
+		}
+		if me.stoppedScenarios[scenarioName] {
+			continue
 		}
+		if scenariosToStop == nil {
+			scenariosToStop = make(map[string][]string)
+		}
+		scenariosToStop[scenarioName] = append(scenariosToStop[scenarioName], m.Name)
+	}
+	for scenarioName := range scenariosToStop {
+		me.stoppedScenarios[scenarioName] = true
 	}
 	if len(breachedThresholds) > 0 {
 		sort.Strings(breachedThresholds)
 		me.logger.Debugf("Thresholds on %d metrics crossed: %v", len(breachedThresholds), breachedThresholds)
 	}
 	atomic.StoreUint32(&me.breachedThresholdsCount, uint32(len(breachedThresholds)))
-	return breachedThresholds, shouldAbort
+	return breachedThresholds, shouldAbort, scenariosToStop
 }
 
 // GetMetricsWithBreachedThresholdsCount returns the number of metrics for which
@@ -261,3 +356,28 @@ func (me *MetricsEngine) evaluateThresholds(
 func (me *MetricsEngine) GetMetricsWithBreachedThresholdsCount() uint32 {
 	return atomic.LoadUint32(&me.breachedThresholdsCount)
 }
+
+// GetScenarioOutcomes returns the outcome of the scenario-scoped thresholds
+// from their last evaluation, for every scenario that has any. This API is
+// safe to use concurrently.
+func (me *MetricsEngine) GetScenarioOutcomes() map[string]lib.ScenarioOutcome {
+	me.MetricsLock.Lock()
+	defer me.MetricsLock.Unlock()
+
+	if len(me.scenarioThresholdMetrics) == 0 {
+		return nil
+	}
+	outcomes := make(map[string]lib.ScenarioOutcome)
+	for m, scenarioName := range me.scenarioThresholdMetrics {
+		outcome, ok := outcomes[scenarioName]
+		if !ok {
+			outcome = lib.ScenarioOutcome{
+				Thresholds: make(map[string]bool),
+				Stopped:    me.stoppedScenarios[scenarioName],
+			}
+			outcomes[scenarioName] = outcome
+		}
+		outcome.Thresholds[m.Name] = !m.Tainted.Bool
+	}
+	return outcomes
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.k6.io/k6/lib"
+	"go.k6.io/k6/lib/executor"
 	"go.k6.io/k6/lib/testutils"
 	"go.k6.io/k6/metrics"
 )
@@ -120,7 +121,7 @@ func TestMetricsEngineEvaluateThresholdNoAbort(t *testing.T) {
 			me.metricsWithThresholds = []*metrics.Metric{m1, m2}
 			m1.Sink.Add(metrics.Sample{Value: 6.0})
 
-			breached, abort := me.evaluateThresholds(false, zeroTestRunDuration)
+			breached, abort, _ := me.evaluateThresholds(false, zeroTestRunDuration)
 			require.Equal(t, tc.abortOnFail, abort)
 			assert.Equal(t, tc.expBreached, breached)
 		})
@@ -144,15 +145,97 @@ func TestMetricsEngineEvaluateIgnoreEmptySink(t *testing.T) {
 
 	me.metricsWithThresholds = []*metrics.Metric{m1, m2}
 
-	breached, abort := me.evaluateThresholds(false, zeroTestRunDuration)
+	breached, abort, _ := me.evaluateThresholds(false, zeroTestRunDuration)
 	require.True(t, abort)
 	require.Equal(t, []string{"m1"}, breached)
 
-	breached, abort = me.evaluateThresholds(true, zeroTestRunDuration)
+	breached, abort, _ = me.evaluateThresholds(true, zeroTestRunDuration)
 	require.False(t, abort)
 	assert.Empty(t, breached)
 }
 
+func TestMetricsEngineScenarioThresholds(t *testing.T) {
+	t.Parallel()
+
+	me := newTestMetricsEngine(t)
+	_, err := me.registry.NewMetric("m1", metrics.Counter)
+	require.NoError(t, err)
+
+	ths := metrics.NewThresholds([]string{"count<5"})
+	ths.Thresholds[0].AbortOnFail = true
+	require.NoError(t, ths.Parse())
+
+	scenario := executor.NewConstantVUsConfig("spike")
+	scenario.Thresholds = map[string]metrics.Thresholds{"m1{status:200}": ths}
+	options := lib.Options{Scenarios: lib.ScenarioConfigs{"spike": scenario}}
+	require.NoError(t, me.InitSubMetricsAndThresholds(options, false))
+	require.Len(t, me.metricsWithThresholds, 1)
+
+	scoped := me.metricsWithThresholds[0]
+	assert.Equal(t, "m1{scenario:spike,status:200}", scoped.Name)
+	scoped.Sink.Add(metrics.Sample{Value: 6.0})
+
+	breached, abort, toStop := me.evaluateThresholds(false, zeroTestRunDuration)
+	assert.False(t, abort)
+	assert.Equal(t, []string{"m1{scenario:spike,status:200}"}, breached)
+	assert.Equal(t, map[string][]string{"spike": {"m1{scenario:spike,status:200}"}}, toStop)
+
+	// the scenario should be stopped only once
+	_, _, toStop = me.evaluateThresholds(false, zeroTestRunDuration)
+	assert.Empty(t, toStop)
+
+	assert.Equal(t, map[string]lib.ScenarioOutcome{
+		"spike": {
+			Thresholds: map[string]bool{"m1{scenario:spike,status:200}": false},
+			Stopped:    true,
+		},
+	}, me.GetScenarioOutcomes())
+}
+
+func TestMetricsEngineScenarioThresholdsErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		global, scoped string
+		expErr         string
+	}{
+		"scenario tag":   {scoped: "m1{scenario:other}", expErr: "can't use the 'scenario' tag"},
+		"global clash":   {global: "m1{scenario:spike}", scoped: "m1", expErr: "already defined globally"},
+		"unknown metric": {scoped: "m2", expErr: "'m2' does not exist in the script"},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			me := newTestMetricsEngine(t)
+			_, err := me.registry.NewMetric("m1", metrics.Counter)
+			require.NoError(t, err)
+
+			scenario := executor.NewConstantVUsConfig("spike")
+			scenario.Thresholds = map[string]metrics.Thresholds{tc.scoped: metrics.NewThresholds([]string{"count<5"})}
+			options := lib.Options{Scenarios: lib.ScenarioConfigs{"spike": scenario}}
+			if tc.global != "" {
+				options.Thresholds = map[string]metrics.Thresholds{tc.global: metrics.NewThresholds([]string{"count<5"})}
+			}
+			assert.ErrorContains(t, me.InitSubMetricsAndThresholds(options, false), tc.expErr)
+		})
+	}
+}
+
+func TestMetricsEngineScenarioThresholdsUnsupported(t *testing.T) {
+	t.Parallel()
+
+	// executor configs of extensions don't have to support scenario thresholds
+	type extensionConfig struct{ lib.ExecutorConfig }
+
+	me := newTestMetricsEngine(t)
+	options := lib.Options{Scenarios: lib.ScenarioConfigs{"ext": extensionConfig{}}}
+	require.NoError(t, me.InitSubMetricsAndThresholds(options, false))
+	assert.Empty(t, me.metricsWithThresholds)
+}
+
 func newTestMetricsEngine(t *testing.T) *MetricsEngine {
 	m, err := NewMetricsEngine(metrics.NewRegistry(), testutils.NewLogger(t))
 	require.NoError(t, err)
@@ -38,6 +38,11 @@ const (
 	TagVU   // non-indexable
 	TagOCSPStatus
 	TagIP
+
+	// TagHost and TagCause are enabled by default, but they're appended here
+	// so the values of the tags above stay the same.
+	TagHost
+	TagCause
 )
 
 // DefaultSystemTagSet includes all of the system tags emitted with metrics by default.
@@ -46,7 +51,8 @@ const (
 //nolint:gochecknoglobals
 var DefaultSystemTagSet = SystemTagSet(
 	TagProto | TagSubproto | TagStatus | TagMethod | TagURL | TagName | TagGroup |
-		TagCheck | TagError | TagErrorCode | TagTLSVersion | TagScenario | TagService | TagExpectedResponse)
+		TagCheck | TagError | TagErrorCode | TagTLSVersion | TagScenario | TagService | TagExpectedResponse |
+		TagHost | TagCause)
 
 // NonIndexableSystemTags are high cardinality system tags (i.e. metadata).
 //